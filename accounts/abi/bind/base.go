@@ -39,6 +39,7 @@ type CallOpts struct {
 	Pending     bool            // Whether to operate on the pending state or the last known one
 	From        common.Address  // Optional the sender address, otherwise the first account is used
 	BlockNumber *big.Int        // Optional the block number on which the call should be performed
+	GasLimit    uint64          // Optional gas allowance for the call (0 = backend default), needed to bound in-process system calls
 	Context     context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 }
 
@@ -136,7 +137,7 @@ func (c *BoundContract) Call(opts *CallOpts, result interface{}, method string,
 		return err
 	}
 	var (
-		msg    = ethereum.CallMsg{From: opts.From, To: &c.address, Data: input}
+		msg    = ethereum.CallMsg{From: opts.From, To: &c.address, Gas: opts.GasLimit, Data: input}
 		ctx    = ensureContext(opts.Context)
 		code   []byte
 		output []byte
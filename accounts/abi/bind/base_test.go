@@ -36,6 +36,7 @@ import (
 type mockCaller struct {
 	codeAtBlockNumber       *big.Int
 	callContractBlockNumber *big.Int
+	callContractGas         uint64
 }
 
 func (mc *mockCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
@@ -45,6 +46,7 @@ func (mc *mockCaller) CodeAt(ctx context.Context, contract common.Address, block
 
 func (mc *mockCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
 	mc.callContractBlockNumber = blockNumber
+	mc.callContractGas = call.Gas
 	return nil, nil
 }
 func TestPassingBlockNumber(t *testing.T) {
@@ -84,6 +86,30 @@ func TestPassingBlockNumber(t *testing.T) {
 	}
 }
 
+func TestPassingGasLimit(t *testing.T) {
+	mc := &mockCaller{}
+
+	bc := bind.NewBoundContract(common.HexToAddress("0x0"), abi.ABI{
+		Methods: map[string]abi.Method{
+			"something": {
+				Name:    "something",
+				Outputs: abi.Arguments{},
+			},
+		},
+	}, mc, nil, nil)
+	var ret string
+
+	bc.Call(&bind.CallOpts{GasLimit: 100000}, &ret, "something")
+	if mc.callContractGas != 100000 {
+		t.Fatalf("CallContract() was passed gas %d, want 100000", mc.callContractGas)
+	}
+
+	bc.Call(&bind.CallOpts{}, &ret, "something")
+	if mc.callContractGas != 0 {
+		t.Fatalf("CallContract() was passed gas %d when no limit was set", mc.callContractGas)
+	}
+}
+
 const hexData = "0x000000000000000000000000376c47978271565f56deb45495afa69e59c16ab200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000060000000000000000000000000000000000000000000000000000000000000000158"
 
 func TestUnpackIndexedStringTyLogIntoMap(t *testing.T) {
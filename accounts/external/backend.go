@@ -18,12 +18,14 @@ package external
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -208,6 +210,20 @@ func (api *ExternalSigner) SignTx(account accounts.Account, tx *types.Transactio
 	return res.Tx, nil
 }
 
+// NewClefTransactor is a utility method to easily create a transaction signer
+// with a clef backend.
+func NewClefTransactor(clef *ExternalSigner, account accounts.Account) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(signer types.Signer, address common.Address, transaction *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, errors.New("not authorized to sign this account")
+			}
+			return clef.SignTx(account, transaction, nil) // Clef enforces its own chain id
+		},
+	}
+}
+
 func (api *ExternalSigner) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
 	return []byte{}, fmt.Errorf("password-operations not supported on external signers")
 }
@@ -224,7 +240,30 @@ func (api *ExternalSigner) Decrypt(account accounts.Account, c, s1, s2 []byte) (
 }
 
 func (api *ExternalSigner) SignBLS(account accounts.Account, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
-	return blscrypto.SerializedSignature{}, accounts.ErrNotSupported
+	return api.SignBLSInView(account, msg, extraData, useComposite, nil, nil)
+}
+
+// SignBLSInView requests a BLS signature of the message and extra data, signed in the given
+// consensus view. The signer uses the view to refuse signing conflicting messages for the same
+// view. The message isn't signed in a view if the sequence or round is nil.
+func (api *ExternalSigner) SignBLSInView(account accounts.Account, msg []byte, extraData []byte, useComposite bool, sequence, round *big.Int) (blscrypto.SerializedSignature, error) {
+	var (
+		res         hexutil.Bytes
+		signAddress = common.NewMixedcaseAddress(account.Address)
+		view        *core.BLSView
+	)
+	if sequence != nil && round != nil {
+		view = &core.BLSView{Sequence: (*hexutil.Big)(sequence), Round: (*hexutil.Big)(round)}
+	}
+	if err := api.client.Call(&res, "account_signBLS",
+		&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
+		hexutil.Bytes(msg),
+		hexutil.Bytes(extraData),
+		useComposite,
+		view); err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	return blscrypto.SerializedSignatureFromBytes(res)
 }
 
 func (api *ExternalSigner) GenerateProofOfPossession(account accounts.Account, address common.Address) ([]byte, []byte, error) {
@@ -232,7 +271,16 @@ func (api *ExternalSigner) GenerateProofOfPossession(account accounts.Account, a
 }
 
 func (api *ExternalSigner) GenerateProofOfPossessionBLS(account accounts.Account, address common.Address) ([]byte, []byte, error) {
-	return nil, nil, accounts.ErrNotSupported
+	var (
+		res         core.ProofOfPossessionBLS
+		signAddress = common.NewMixedcaseAddress(account.Address)
+	)
+	if err := api.client.Call(&res, "account_generateProofOfPossessionBLS",
+		&signAddress, // Need to use the pointer here, because of how MarshalJSON is defined
+		address); err != nil {
+		return nil, nil, err
+	}
+	return res.PublicKey, res.Signature, nil
 }
 
 func (api *ExternalSigner) GetPublicKey(account accounts.Account) (*ecdsa.PublicKey, error) {
@@ -299,8 +299,12 @@ func (ks *KeyStore) SignBLS(a accounts.Account, msg []byte, extraData []byte, us
 	if !found {
 		return blscrypto.SerializedSignature{}, ErrLocked
 	}
+	return signBLS(unlockedKey.PrivateKey, msg, extraData, useComposite)
+}
 
-	privateKeyBytes, err := blscrypto.ECDSAToBLS(unlockedKey.PrivateKey)
+// signBLS signs the message and extra data with the BLS key derived from the given ECDSA key.
+func signBLS(key *ecdsa.PrivateKey, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+	privateKeyBytes, err := blscrypto.ECDSAToBLS(key)
 	if err != nil {
 		return blscrypto.SerializedSignature{}, err
 	}
@@ -353,8 +357,13 @@ func (ks *KeyStore) GenerateProofOfPossessionBLS(a accounts.Account, address com
 	if !found {
 		return nil, nil, ErrLocked
 	}
+	return proofOfPossessionBLS(unlockedKey.PrivateKey, address)
+}
 
-	privateKeyBytes, err := blscrypto.ECDSAToBLS(unlockedKey.PrivateKey)
+// proofOfPossessionBLS returns the public key and the proof of possession of the BLS key derived
+// from the given ECDSA key, for the given address.
+func proofOfPossessionBLS(key *ecdsa.PrivateKey, address common.Address) ([]byte, []byte, error) {
+	privateKeyBytes, err := blscrypto.ECDSAToBLS(key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -445,6 +454,30 @@ func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string,
 	return types.SignTx(tx, types.HomesteadSigner{}, key.PrivateKey)
 }
 
+// SignBLSWithPassphrase signs the message and extra data with the BLS key of the
+// account if the private key matching the given address can be decrypted with the
+// given passphrase.
+func (ks *KeyStore) SignBLSWithPassphrase(a accounts.Account, passphrase string, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+	_, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	defer zeroKey(key.PrivateKey)
+	return signBLS(key.PrivateKey, msg, extraData, useComposite)
+}
+
+// GenerateProofOfPossessionBLSWithPassphrase generates the BLS proof of possession
+// of the account for the given address if the private key matching the account can
+// be decrypted with the given passphrase.
+func (ks *KeyStore) GenerateProofOfPossessionBLSWithPassphrase(a accounts.Account, passphrase string, address common.Address) ([]byte, []byte, error) {
+	_, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer zeroKey(key.PrivateKey)
+	return proofOfPossessionBLS(key.PrivateKey, address)
+}
+
 // Unlock unlocks the given account indefinitely.
 func (ks *KeyStore) Unlock(a accounts.Account, passphrase string) error {
 	return ks.TimedUnlock(a, passphrase, 0)
@@ -17,6 +17,7 @@
 package keystore
 
 import (
+	"bytes"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -113,6 +114,52 @@ func TestSignWithPassphrase(t *testing.T) {
 	}
 }
 
+func TestSignBLSWithPassphrase(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	pass := "passwd"
+	acc, err := ks.NewAccount(pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := ks.SignBLSWithPassphrase(acc, pass, testSigData, []byte{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, unlocked := ks.unlocked[acc.Address]; unlocked {
+		t.Fatal("expected account to be locked")
+	}
+
+	// The signature matches the one of the unlocked account
+	if err := ks.Unlock(acc, pass); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ks.SignBLS(acc, testSigData, []byte{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig != want {
+		t.Errorf("signature mismatch: have %x, want %x", sig, want)
+	}
+	pubKey, pop, err := ks.GenerateProofOfPossessionBLSWithPassphrase(acc, pass, acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPubKey, wantPop, err := ks.GenerateProofOfPossessionBLS(acc, acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pubKey, wantPubKey) || !bytes.Equal(pop, wantPop) {
+		t.Errorf("proof of possession mismatch: have %x %x, want %x %x", pubKey, pop, wantPubKey, wantPop)
+	}
+
+	if _, err = ks.SignBLSWithPassphrase(acc, "invalid passwd", testSigData, []byte{}, false); err == nil {
+		t.Fatal("expected SignBLSWithPassphrase to fail with invalid password")
+	}
+}
+
 func TestTimedUnlock(t *testing.T) {
 	dir, ks := tmpKeyStore(t, true)
 	defer os.RemoveAll(dir)
@@ -185,6 +185,30 @@ func (w *keystoreWallet) SignDataWithPassphrase(account accounts.Account, passph
 	return w.keystore.SignHashWithPassphrase(account, passphrase, crypto.Keccak256(data))
 }
 
+// SignBLSWithPassphrase signs the message and extra data with the BLS key of the
+// account, using passphrase as extra authentication.
+func (w *keystoreWallet) SignBLSWithPassphrase(account accounts.Account, passphrase string, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+	// Make sure the requested account is contained within
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return blscrypto.SerializedSignature{}, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.SignBLSWithPassphrase(account, passphrase, msg, extraData, useComposite)
+}
+
+// GenerateProofOfPossessionBLSWithPassphrase generates the BLS proof of possession of
+// the account for the given address, using passphrase as extra authentication.
+func (w *keystoreWallet) GenerateProofOfPossessionBLSWithPassphrase(account accounts.Account, passphrase string, address common.Address) ([]byte, []byte, error) {
+	// Make sure the requested account is contained within
+	if !w.Contains(account) {
+		log.Debug(accounts.ErrUnknownAccount.Error(), "account", account)
+		return nil, nil, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.GenerateProofOfPossessionBLSWithPassphrase(account, passphrase, address)
+}
+
 func (w *keystoreWallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
 	return w.signHash(account, accounts.TextHash(text))
 }
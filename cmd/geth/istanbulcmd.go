@@ -0,0 +1,168 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	istanbulCore "github.com/ethereum/go-ethereum/consensus/istanbul/core"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/slashing"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	istanbulCommand = cli.Command{
+		Name:     "istanbul",
+		Usage:    "Istanbul consensus utilities",
+		Category: "MISCELLANEOUS COMMANDS",
+		Description: `
+Utilities to inspect the Istanbul consensus engine of a validator.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replay a consensus write-ahead log",
+				Action:    utils.MigrateFlags(istanbulReplay),
+				ArgsUsage: "[<walDir>]",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+    geth istanbul replay [<walDir>]
+
+Feeds the consensus write-ahead log recorded with --istanbul.wal back through the
+Istanbul core, and checks that it goes through the same sequence of round states.
+Every replayed event is printed along with the round state it resulted in.
+
+The write-ahead log is read from <DATADIR>/geth/consensuswal unless a directory
+is given.`,
+			},
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export the slashing protection records to a file",
+				Action:    utils.MigrateFlags(exportSlashingProtection),
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+    geth istanbul export-slashing-protection <file>
+
+Exports the consensus messages signed by the validator, as recorded in the slashing
+protection database, to a JSON interchange file. The node must not be running.`,
+			},
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import slashing protection records from a file",
+				Action:    utils.MigrateFlags(importSlashingProtection),
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+    geth istanbul import-slashing-protection <file>
+
+Merges the records of a JSON interchange file exported from another node into the
+slashing protection database, so that a validator key migrated to this node refuses
+to sign messages conflicting with the ones it already signed. The node must not be
+running.`,
+			},
+		},
+	}
+)
+
+func istanbulReplay(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		utils.Fatalf("This command requires at most one argument.")
+	}
+	dir := ctx.Args().First()
+	if dir == "" {
+		stack, cfg := makeConfigNode(ctx)
+		stack.Close()
+		dir = cfg.Eth.Istanbul.WALPath
+	}
+
+	steps := 0
+	err := istanbulCore.ReplayWAL(dir, func(step *istanbulCore.ReplayStep) {
+		fmt.Printf("%6d %s %-25s calls=%-3d sequence=%v round=%v desiredRound=%v state=%v\n",
+			step.Index, step.Time.Format(time.RFC3339Nano), step.Event, step.Calls, step.Sequence, step.Round, step.DesiredRound, step.State)
+		steps++
+	})
+	if err != nil {
+		utils.Fatalf("Replay failed after %d events: %v", steps, err)
+	}
+	fmt.Printf("Replayed %d events from %s\n", steps, dir)
+	return nil
+}
+
+// openSlashingProtectionDB opens the slashing protection database of the node
+func openSlashingProtectionDB(ctx *cli.Context) *slashing.DB {
+	stack, cfg := makeConfigNode(ctx)
+	stack.Close()
+	db, err := slashing.Open(cfg.Eth.Istanbul.SlashingProtectionDBPath)
+	if err != nil {
+		utils.Fatalf("Failed to open slashing protection database: %v", err)
+	}
+	return db
+}
+
+func exportSlashingProtection(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	db := openSlashingProtectionDB(ctx)
+	defer db.Close()
+
+	interchange, err := db.Export()
+	if err != nil {
+		utils.Fatalf("Export failed: %v", err)
+	}
+	blob, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		utils.Fatalf("Export failed: %v", err)
+	}
+	if err := ioutil.WriteFile(ctx.Args().First(), blob, 0600); err != nil {
+		utils.Fatalf("Export failed: %v", err)
+	}
+	fmt.Printf("Exported the slashing protection records of %d signers\n", len(interchange.Signers))
+	return nil
+}
+
+func importSlashingProtection(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	blob, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Import failed: %v", err)
+	}
+	var interchange slashing.Interchange
+	if err := json.Unmarshal(blob, &interchange); err != nil {
+		utils.Fatalf("Import failed: %v", err)
+	}
+	db := openSlashingProtectionDB(ctx)
+	defer db.Close()
+
+	if err := db.Import(&interchange); err != nil {
+		utils.Fatalf("Import failed: %v", err)
+	}
+	fmt.Printf("Imported the slashing protection records of %d signers\n", len(interchange.Signers))
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/console"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -80,6 +81,7 @@ var (
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
+		utils.TxPoolCurrencySlotsFlag,
 		utils.TxPoolLifetimeFlag,
 		utils.SyncModeFlag,
 		utils.ExitWhenSyncedFlag,
@@ -90,6 +92,9 @@ var (
 		utils.LightMaxPeersFlag,
 		utils.LightKDFFlag,
 		utils.LightGatewayFeeFlag,
+		utils.LightGatewayFeeDeprioritizeFlag,
+		utils.LightMaxGatewayFeeFlag,
+		utils.LightGatewayFeeRecipientsFlag,
 		utils.UltraLightServersFlag,
 		utils.UltraLightFractionFlag,
 		utils.UltraLightOnlyAnnounceFlag,
@@ -101,6 +106,8 @@ var (
 		utils.CacheTrieFlag,
 		utils.CacheGCFlag,
 		utils.CacheNoPrefetchFlag,
+		utils.CacheSnapshotFlag,
+		utils.SnapshotFlag,
 		utils.ListenPortFlag,
 		utils.MaxPeersFlag,
 		utils.MaxPendingPeersFlag,
@@ -142,6 +149,9 @@ var (
 		utils.IstanbulBlockPeriodFlag,
 		utils.IstanbulProposerPolicyFlag,
 		utils.IstanbulLookbackWindowFlag,
+		utils.IstanbulSigningHistoryIndexFlag,
+		utils.IstanbulReplicaFlag,
+		utils.IstanbulWALFlag,
 		utils.AnnounceQueryEnodeGossipPeriodFlag,
 		utils.AnnounceAggressiveQueryEnodeGossipOnEnablementFlag,
 		utils.PingIPFromPacketFlag,
@@ -227,6 +237,8 @@ func init() {
 		dumpConfigCommand,
 		// See retesteth.go
 		retestethCommand,
+		// See istanbulcmd.go
+		istanbulCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -451,7 +463,17 @@ func startNode(ctx *cli.Context, stack *node.Node) {
 		}
 	}
 	if !ctx.GlobalBool(utils.VersionCheckFlag.Name) {
-		blockchain_parameters.SpawnCheck()
+		var (
+			ethService *eth.Ethereum
+			lesService *les.LightEthereum
+			caller     *contract_comm.SystemCaller
+		)
+		if err := stack.Service(&ethService); err == nil {
+			caller = ethService.BlockChain().SystemCaller()
+		} else if err := stack.Service(&lesService); err == nil {
+			caller = lesService.BlockChain().SystemCaller()
+		}
+		blockchain_parameters.SpawnCheck(caller)
 	}
 }
 
@@ -92,6 +92,9 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.LightEgressFlag,
 			utils.LightMaxPeersFlag,
 			utils.LightGatewayFeeFlag,
+			utils.LightGatewayFeeDeprioritizeFlag,
+			utils.LightMaxGatewayFeeFlag,
+			utils.LightGatewayFeeRecipientsFlag,
 			utils.UltraLightServersFlag,
 			utils.UltraLightFractionFlag,
 			utils.UltraLightOnlyAnnounceFlag,
@@ -117,6 +120,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.TxPoolGlobalSlotsFlag,
 			utils.TxPoolAccountQueueFlag,
 			utils.TxPoolGlobalQueueFlag,
+			utils.TxPoolCurrencySlotsFlag,
 			utils.TxPoolLifetimeFlag,
 		},
 	},
@@ -128,6 +132,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.CacheTrieFlag,
 			utils.CacheGCFlag,
 			utils.CacheNoPrefetchFlag,
+			utils.CacheSnapshotFlag,
+			utils.SnapshotFlag,
 		},
 	},
 	{
@@ -228,8 +234,10 @@ var AppHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			utils.IstanbulRequestTimeoutFlag,
 			utils.IstanbulBlockPeriodFlag,
-			utils.IstanbulProposerPolicyFlag,
 			utils.IstanbulLookbackWindowFlag,
+			utils.IstanbulSigningHistoryIndexFlag,
+			utils.IstanbulReplicaFlag,
+			utils.IstanbulWALFlag,
 		},
 	},
 	{
@@ -259,6 +267,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.MinerLegacyGasTargetFlag,
 			utils.MinerLegacyGasPriceFlag,
 			utils.MinerLegacyExtraDataFlag,
+			utils.IstanbulProposerPolicyFlag,
 		},
 	},
 	{
@@ -37,7 +37,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
 	mockEngine "github.com/ethereum/go-ethereum/consensus/consensustest"
-	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -252,6 +251,18 @@ var (
 		Usage: "Minimum value of gateway fee to serve a light client transaction",
 		Value: eth.DefaultConfig.GatewayFee,
 	}
+	LightGatewayFeeDeprioritizeFlag = cli.BoolFlag{
+		Name:  "light.gatewayfee.deprioritize",
+		Usage: "Relay light client transactions with an insufficient gateway fee at the lowest priority instead of rejecting them",
+	}
+	LightMaxGatewayFeeFlag = BigFlag{
+		Name:  "light.maxgatewayfee",
+		Usage: "Maximum gateway fee to pay a light server to relay transactions (default = unlimited)",
+	}
+	LightGatewayFeeRecipientsFlag = cli.StringFlag{
+		Name:  "light.gatewayfee.recipients",
+		Usage: "Comma separated gateway fee recipients to prefer relaying transactions through",
+	}
 	UltraLightServersFlag = cli.StringFlag{
 		Name:  "ulc.servers",
 		Usage: "List of trusted ultra-light servers",
@@ -315,6 +326,10 @@ var (
 		Usage: "Maximum number of non-executable transaction slots for all accounts",
 		Value: eth.DefaultConfig.TxPool.GlobalQueue,
 	}
+	TxPoolCurrencySlotsFlag = cli.StringFlag{
+		Name:  "txpool.currencyslots",
+		Usage: "Comma separated currency=slots pairs limiting the transaction slots for fees paid in each non-native currency",
+	}
 	TxPoolLifetimeFlag = cli.DurationFlag{
 		Name:  "txpool.lifetime",
 		Usage: "Maximum amount of time non-executable transaction are queued",
@@ -345,6 +360,15 @@ var (
 		Name:  "cache.noprefetch",
 		Usage: "Disable heuristic state prefetch during block import (less CPU and disk IO, more time waiting for data)",
 	}
+	CacheSnapshotFlag = cli.IntFlag{
+		Name:  "cache.snapshot",
+		Usage: "Percentage of cache memory allowance to use for snapshot caching (default = 10%)",
+		Value: 10,
+	}
+	SnapshotFlag = cli.BoolFlag{
+		Name:  "snapshot",
+		Usage: "Enables the flat state snapshot for faster state reads (experimental)",
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -720,7 +744,7 @@ var (
 	}
 	IstanbulProposerPolicyFlag = cli.Uint64Flag{
 		Name:  "istanbul.proposerpolicy",
-		Usage: "Default minimum difference between two consecutive block's timestamps in seconds",
+		Usage: "Deprecated: the proposer selection policy is set by the chain config (genesis istanbul.policy)",
 		Value: uint64(eth.DefaultConfig.Istanbul.ProposerPolicy),
 	}
 	IstanbulLookbackWindowFlag = cli.Uint64Flag{
@@ -728,6 +752,18 @@ var (
 		Usage: "A validator's signature must be absent for this many consecutive blocks to be considered down for the uptime score",
 		Value: eth.DefaultConfig.Istanbul.LookbackWindow,
 	}
+	IstanbulSigningHistoryIndexFlag = cli.BoolFlag{
+		Name:  "istanbul.signinghistoryindex",
+		Usage: "Index the validators that signed each imported block to speed up istanbul_getValidatorSigningHistory",
+	}
+	IstanbulReplicaFlag = cli.BoolFlag{
+		Name:  "istanbul.replica",
+		Usage: "Run the validator as a replica tracking consensus state without signing, until scheduled to start with istanbul.startAtBlock",
+	}
+	IstanbulWALFlag = cli.BoolFlag{
+		Name:  "istanbul.wal",
+		Usage: "Record the consensus events and state transitions in a write-ahead log, for replay with 'geth istanbul replay'",
+	}
 
 	// Announce settings
 	AnnounceQueryEnodeGossipPeriodFlag = cli.Uint64Flag{
@@ -1008,6 +1044,21 @@ func setLes(ctx *cli.Context, cfg *eth.Config) {
 	if ctx.GlobalIsSet(LightGatewayFeeFlag.Name) {
 		cfg.GatewayFee = GlobalBig(ctx, LightGatewayFeeFlag.Name)
 	}
+	if ctx.GlobalIsSet(LightGatewayFeeDeprioritizeFlag.Name) {
+		cfg.GatewayFeeDeprioritize = ctx.GlobalBool(LightGatewayFeeDeprioritizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(LightMaxGatewayFeeFlag.Name) {
+		cfg.MaxGatewayFee = GlobalBig(ctx, LightMaxGatewayFeeFlag.Name)
+	}
+	if ctx.GlobalIsSet(LightGatewayFeeRecipientsFlag.Name) {
+		for _, recipient := range strings.Split(ctx.GlobalString(LightGatewayFeeRecipientsFlag.Name), ",") {
+			if trimmed := strings.TrimSpace(recipient); !common.IsHexAddress(trimmed) {
+				Fatalf("Invalid address in --%s: %s", LightGatewayFeeRecipientsFlag.Name, trimmed)
+			} else {
+				cfg.GatewayFeeRecipients = append(cfg.GatewayFeeRecipients, common.HexToAddress(trimmed))
+			}
+		}
+	}
 	if ctx.GlobalIsSet(UltraLightServersFlag.Name) {
 		cfg.UltraLightServers = strings.Split(ctx.GlobalString(UltraLightServersFlag.Name), ",")
 	}
@@ -1346,6 +1397,20 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolGlobalQueueFlag.Name) {
 		cfg.GlobalQueue = ctx.GlobalUint64(TxPoolGlobalQueueFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolCurrencySlotsFlag.Name) {
+		cfg.CurrencySlots = make(map[common.Address]uint64)
+		for _, entry := range strings.Split(ctx.GlobalString(TxPoolCurrencySlotsFlag.Name), ",") {
+			parts := strings.Split(strings.TrimSpace(entry), "=")
+			if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+				Fatalf("Invalid entry in --txpool.currencyslots: %s", entry)
+			}
+			slots, err := strconv.ParseUint(parts[1], 10, 64)
+			if err != nil {
+				Fatalf("Invalid slots in --txpool.currencyslots: %s", entry)
+			}
+			cfg.CurrencySlots[common.HexToAddress(parts[0])] = slots
+		}
+	}
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
@@ -1418,11 +1483,23 @@ func setIstanbul(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 		cfg.Istanbul.LookbackWindow = ctx.GlobalUint64(IstanbulLookbackWindowFlag.Name)
 	}
 	if ctx.GlobalIsSet(IstanbulProposerPolicyFlag.Name) {
-		cfg.Istanbul.ProposerPolicy = istanbul.ProposerPolicy(ctx.GlobalUint64(IstanbulProposerPolicyFlag.Name))
+		log.Warn("The flag --istanbul.proposerpolicy is deprecated and ignored, the proposer policy is set by the chain config")
+	}
+	if ctx.GlobalIsSet(IstanbulSigningHistoryIndexFlag.Name) {
+		cfg.SigningHistoryIndex = ctx.GlobalBool(IstanbulSigningHistoryIndexFlag.Name)
+	}
+	if ctx.GlobalIsSet(IstanbulReplicaFlag.Name) {
+		cfg.Istanbul.Replica = ctx.GlobalBool(IstanbulReplicaFlag.Name)
+	}
+	if ctx.GlobalIsSet(IstanbulWALFlag.Name) {
+		cfg.Istanbul.WAL = ctx.GlobalBool(IstanbulWALFlag.Name)
 	}
 	cfg.Istanbul.ValidatorEnodeDBPath = stack.ResolvePath(cfg.Istanbul.ValidatorEnodeDBPath)
 	cfg.Istanbul.VersionCertificateDBPath = stack.ResolvePath(cfg.Istanbul.VersionCertificateDBPath)
 	cfg.Istanbul.RoundStateDBPath = stack.ResolvePath(cfg.Istanbul.RoundStateDBPath)
+	cfg.Istanbul.EquivocationDBPath = stack.ResolvePath(cfg.Istanbul.EquivocationDBPath)
+	cfg.Istanbul.SlashingProtectionDBPath = stack.ResolvePath(cfg.Istanbul.SlashingProtectionDBPath)
+	cfg.Istanbul.WALPath = stack.ResolvePath(cfg.Istanbul.WALPath)
 	cfg.Istanbul.Validator = ctx.GlobalIsSet(MiningEnabledFlag.Name)
 }
 
@@ -1628,6 +1705,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheGCFlag.Name) {
 		cfg.TrieDirtyCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheGCFlag.Name) / 100
 	}
+	if ctx.GlobalBool(SnapshotFlag.Name) {
+		cfg.SnapshotCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheSnapshotFlag.Name) / 100
+	}
 	if ctx.GlobalIsSet(DocRootFlag.Name) {
 		cfg.DocRoot = ctx.GlobalString(DocRootFlag.Name)
 	}
@@ -1852,10 +1932,14 @@ func MakeChain(ctx *cli.Context, stack *node.Node) (chain *core.BlockChain, chai
 		TrieDirtyLimit:      eth.DefaultConfig.TrieDirtyCache,
 		TrieDirtyDisabled:   ctx.GlobalString(GCModeFlag.Name) == "archive",
 		TrieTimeLimit:       eth.DefaultConfig.TrieTimeout,
+		SigningHistoryIndex: ctx.GlobalBool(IstanbulSigningHistoryIndexFlag.Name),
 	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cache.TrieCleanLimit = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
+	if ctx.GlobalBool(SnapshotFlag.Name) {
+		cache.SnapshotLimit = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheSnapshotFlag.Name) / 100
+	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheGCFlag.Name) {
 		cache.TrieDirtyLimit = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheGCFlag.Name) / 100
 	}
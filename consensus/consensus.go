@@ -197,4 +197,13 @@ type Istanbul interface {
 	// This is only implemented for Istanbul.
 	// It will check to see if the header is from the last block of an epoch
 	IsLastBlockOfEpoch(header *types.Header) bool
+
+	// VerifyEpochSnarkData checks that the epoch snark data of the last block of an epoch
+	// is sealed by a quorum of the epoch's validators over the next epoch's validator set.
+	// The parents may be non contiguous epoch headers, as fetched by a lightest sync.
+	VerifyEpochSnarkData(chain ChainReader, header *types.Header, parents []*types.Header, epochSnarkData *types.EpochSnarkData) error
+
+	// EpochRewards returns the breakdown of the epoch rewards distributed when finalizing the
+	// given last block of an epoch, or nil if the block wasn't finalized by this engine.
+	EpochRewards(header *types.Header) *istanbul.EpochRewards
 }
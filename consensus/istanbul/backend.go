@@ -41,13 +41,26 @@ type SignerFn func(accounts.Account, string, []byte) ([]byte, error)
 // backing account using BLS with a direct or composite hasher
 type BLSSignerFn func(accounts.Account, []byte, []byte, bool) (blscrypto.SerializedSignature, error)
 
+// BLSViewSignerFn is a BLS signer callback function which is also given the sequence and round of
+// the consensus view the message is signed in, so that remote signers can refuse to sign
+// conflicting messages in a view
+type BLSViewSignerFn func(accounts.Account, []byte, []byte, bool, *big.Int, *big.Int) (blscrypto.SerializedSignature, error)
+
+// BLSSignerInAnyView returns a BLSViewSignerFn signing messages with signFn, for signers that
+// don't keep track of the consensus views they sign in
+func BLSSignerInAnyView(signFn BLSSignerFn) BLSViewSignerFn {
+	return func(account accounts.Account, msg []byte, extraData []byte, useComposite bool, _, _ *big.Int) (blscrypto.SerializedSignature, error) {
+		return signFn(account, msg, extraData, useComposite)
+	}
+}
+
 // Backend provides application specific functions for Istanbul core
 type Backend interface {
 	// Address returns the owner's address
 	Address() common.Address
 
 	// Validators returns the validator set
-	Validators(proposal Proposal) ValidatorSet
+	Validators(proposal Proposal) (ValidatorSet, error)
 	NextBlockValidators(proposal Proposal) (ValidatorSet, error)
 
 	// EventMux returns the event mux in backend
@@ -72,8 +85,8 @@ type Backend interface {
 	// Sign signs input data with the backend's private key
 	Sign([]byte) ([]byte, error)
 
-	// Sign with the data with the BLS key, using either a direct or composite hasher
-	SignBLS([]byte, []byte, bool) (blscrypto.SerializedSignature, error)
+	// Sign with the data with the BLS key, using either a direct or composite hasher, in the given consensus view
+	SignBLS([]byte, []byte, bool, *View) (blscrypto.SerializedSignature, error)
 
 	// CheckSignature verifies the signature by checking if it's signed by
 	// the given validator
@@ -91,15 +104,25 @@ type Backend interface {
 	// HasBlock checks if the combination of the given hash and height matches any existing blocks
 	HasBlock(hash common.Hash, number *big.Int) bool
 
-	// AuthorForBlock returns the proposer of the given block height
-	AuthorForBlock(number uint64) common.Address
+	// IsPrimaryForSeq returns whether this validator signs consensus messages at the given
+	// sequence, or is a replica only tracking the consensus state
+	IsPrimaryForSeq(seq *big.Int) bool
+
+	// AuthorForBlock returns the proposer of the given block
+	AuthorForBlock(hash common.Hash, number uint64) common.Address
 
 	// ParentBlockValidators returns the validator set of the given proposal's parent block
-	ParentBlockValidators(proposal Proposal) ValidatorSet
+	ParentBlockValidators(proposal Proposal) (ValidatorSet, error)
 
 	// RefreshValPeers will connect with all the validators in the validator connection set and disconnect validator peers that are not in the set
 	RefreshValPeers() error
 
+	// PenalizeOverflowingValidator is called when the messages signed by a validator keep
+	// exceeding its quotas in the backlog of future messages. The backlog drops the validator's
+	// future messages until the next sequence, whichever peer relays them, and the backend
+	// disconnects the validator's peer.
+	PenalizeOverflowingValidator(addr common.Address)
+
 	// Authorize injects a private key into the consensus engine.
-	Authorize(address common.Address, publicKey *ecdsa.PublicKey, decryptFn DecryptFn, signFn SignerFn, signBLSFn BLSSignerFn)
+	Authorize(address common.Address, publicKey *ecdsa.PublicKey, decryptFn DecryptFn, signFn SignerFn, signBLSFn BLSViewSignerFn)
 }
@@ -109,62 +109,79 @@ func (sb *Backend) announceThread() {
 		announceVersion = version
 	}
 
-	for {
-		select {
-		case <-checkIfShouldAnnounceTicker.C:
-			logger.Trace("Checking if this node should announce it's enode")
+	// checkIfShouldAnnounce starts or stops announcing this node's enode, gossiping the
+	// first queryEnode message after waitPeriod
+	checkIfShouldAnnounce := func(waitPeriod time.Duration) {
+		logger.Trace("Checking if this node should announce it's enode")
 
-			shouldAnnounce, err = sb.shouldSaveAndPublishValEnodeURLs()
-			if err != nil {
-				logger.Warn("Error in checking if should announce", err)
-				break
-			}
+		shouldAnnounce, err = sb.shouldSaveAndPublishValEnodeURLs()
+		if err != nil {
+			logger.Warn("Error in checking if should announce", err)
+			return
+		}
 
-			if shouldAnnounce && !announcing {
-				updateAnnounceVersionFunc()
-				// Gossip the announce after a minute.
-				// The delay allows for all receivers of the announce message to
-				// have a more up-to-date cached registered/elected valset, and
-				// hence more likely that they will be aware that this node is
-				// within that set.
-				waitPeriod := 1 * time.Minute
-				if sb.config.Epoch <= 10 {
-					waitPeriod = 5 * time.Second
-				}
+		if shouldAnnounce && !announcing {
+			updateAnnounceVersionFunc()
+			if waitPeriod > 0 {
 				time.AfterFunc(waitPeriod, func() {
 					sb.startGossipQueryEnodeTask()
 				})
+			} else {
+				sb.startGossipQueryEnodeTask()
+			}
 
-				if sb.config.AnnounceAggressiveQueryEnodeGossipOnEnablement {
-					queryEnodeFrequencyState = HighFreqBeforeFirstPeerState
-					// Send an query enode message once a minute
-					currentQueryEnodeTickerDuration = 1 * time.Minute
-					numQueryEnodesInHighFreqAfterFirstPeerState = 0
-				} else {
-					queryEnodeFrequencyState = LowFreqState
-					currentQueryEnodeTickerDuration = time.Duration(sb.config.AnnounceQueryEnodeGossipPeriod) * time.Second
-				}
-
-				// Enable periodic gossiping by setting announceGossipTickerCh to non nil value
-				queryEnodeTicker = time.NewTicker(currentQueryEnodeTickerDuration)
-				queryEnodeTickerCh = queryEnodeTicker.C
-
-				updateAnnounceVersionTicker = time.NewTicker(5 * time.Minute)
-				updateAnnounceVersionTickerCh = updateAnnounceVersionTicker.C
+			if sb.config.AnnounceAggressiveQueryEnodeGossipOnEnablement {
+				queryEnodeFrequencyState = HighFreqBeforeFirstPeerState
+				// Send an query enode message once a minute
+				currentQueryEnodeTickerDuration = 1 * time.Minute
+				numQueryEnodesInHighFreqAfterFirstPeerState = 0
+			} else {
+				queryEnodeFrequencyState = LowFreqState
+				currentQueryEnodeTickerDuration = time.Duration(sb.config.AnnounceQueryEnodeGossipPeriod) * time.Second
+			}
 
-				announcing = true
-				logger.Trace("Enabled periodic gossiping of announce message")
-			} else if !shouldAnnounce && announcing {
-				// Disable periodic queryEnode msgs by setting queryEnodeTickerCh to nil
-				queryEnodeTicker.Stop()
-				queryEnodeTickerCh = nil
-				// Disable periodic updating of announce version
-				updateAnnounceVersionTicker.Stop()
-				updateAnnounceVersionTickerCh = nil
+			// Enable periodic gossiping by setting announceGossipTickerCh to non nil value
+			queryEnodeTicker = time.NewTicker(currentQueryEnodeTickerDuration)
+			queryEnodeTickerCh = queryEnodeTicker.C
+
+			updateAnnounceVersionTicker = time.NewTicker(5 * time.Minute)
+			updateAnnounceVersionTickerCh = updateAnnounceVersionTicker.C
+
+			announcing = true
+			logger.Trace("Enabled periodic gossiping of announce message")
+		} else if !shouldAnnounce && announcing {
+			// Disable periodic queryEnode msgs by setting queryEnodeTickerCh to nil
+			queryEnodeTicker.Stop()
+			queryEnodeTickerCh = nil
+			// Disable periodic updating of announce version
+			updateAnnounceVersionTicker.Stop()
+			updateAnnounceVersionTickerCh = nil
+
+			announcing = false
+			logger.Trace("Disabled periodic gossiping of announce message")
+		}
+	}
 
-				announcing = false
-				logger.Trace("Disabled periodic gossiping of announce message")
+	for {
+		select {
+		case <-checkIfShouldAnnounceTicker.C:
+			// Gossip the announce after a minute.
+			// The delay allows for all receivers of the announce message to
+			// have a more up-to-date cached registered/elected valset, and
+			// hence more likely that they will be aware that this node is
+			// within that set.
+			waitPeriod := 1 * time.Minute
+			if sb.config.Epoch <= 10 {
+				waitPeriod = 5 * time.Second
 			}
+			checkIfShouldAnnounce(waitPeriod)
+
+		case <-sb.replicaSwitchCh:
+			// A replica switching to primary announces its enode straight away, so that the other
+			// validators connect to it before its first consensus round. It was already elected, so
+			// there's no need to wait for the receivers to update their valset. A primary switching
+			// to replica stops announcing.
+			checkIfShouldAnnounce(0)
 
 		case <-shareVersionCertificatesTicker.C:
 			// Send all version certificates to every peer. Only the entries
@@ -241,6 +258,15 @@ func (sb *Backend) announceThread() {
 	}
 }
 
+// announceReplicaSwitch lets the announce thread know that this validator switched between
+// primary and replica
+func (sb *Backend) announceReplicaSwitch() {
+	select {
+	case sb.replicaSwitchCh <- struct{}{}:
+	default:
+	}
+}
+
 // startGossipQueryEnodeTask will schedule a task for the announceThread to
 // generate and gossip a queryEnode message
 func (sb *Backend) startGossipQueryEnodeTask() {
@@ -253,6 +279,10 @@ func (sb *Backend) startGossipQueryEnodeTask() {
 }
 
 func (sb *Backend) shouldSaveAndPublishValEnodeURLs() (bool, error) {
+	// Only the primary announces its enode, so that validators connect to the validator signing
+	if !sb.replicaState.isPrimary() {
+		return false, nil
+	}
 
 	// Check if this node is in the validator connection set
 	validatorConnSet, err := sb.retrieveValidatorConnSet()
@@ -1018,7 +1048,7 @@ func (sb *Backend) updateAnnounceVersion() {
 // and shares them with relevant nodes.
 // It will:
 //  1) Generate a new enode certificate
-//  2) Send the new enode certificate to this node's proxy if one exists
+//  2) Send each of this node's proxies an enode certificate with that proxy's external enode
 //  3) Send the new enode certificate to all peers in the validator conn set
 //  4) Generate a new version certificate
 //  5) Gossip the new version certificate to all peers
@@ -1029,17 +1059,33 @@ func (sb *Backend) setAndShareUpdatedAnnounceVersion(version uint) error {
 	if err != nil {
 		return err
 	}
-	enodeCertificateMsg, err := sb.generateEnodeCertificateMsg(version)
+	enodeCertificateMsgs, err := sb.generateEnodeCertificateMsgs(version)
 	if err != nil {
 		return err
 	}
+	var enodeCertificateMsg *istanbul.Message
+	if sb.config.Proxied {
+		if primaryProxy := sb.proxies.getPrimaryProxy(); primaryProxy != nil {
+			enodeCertificateMsg = enodeCertificateMsgs[primaryProxy.node.ID()]
+		}
+	} else {
+		enodeCertificateMsg = enodeCertificateMsgs[sb.p2pserver.Self().ID()]
+	}
+	if enodeCertificateMsg == nil {
+		return errNoProxyConnection
+	}
 	sb.setEnodeCertificateMsg(enodeCertificateMsg)
-	// Send the new versioned enode msg to the proxy peer
-	if sb.config.Proxied && sb.proxyNode != nil && sb.proxyNode.peer != nil {
-		err := sb.sendEnodeCertificateMsg(sb.proxyNode.peer, enodeCertificateMsg)
-		if err != nil {
-			logger.Error("Error in sending versioned enode msg to proxy", "err", err)
-			return err
+	// Send each connected proxy the new versioned enode msg containing its own external enode
+	if sb.config.Proxied {
+		sb.setProxyEnodeCertificateMsgs(enodeCertificateMsgs)
+		for _, proxy := range sb.proxies.getProxies() {
+			if proxy.peer == nil || enodeCertificateMsgs[proxy.node.ID()] == nil {
+				continue
+			}
+			if err := sb.sendEnodeCertificateMsg(proxy.peer, enodeCertificateMsgs[proxy.node.ID()]); err != nil {
+				logger.Error("Error in sending versioned enode msg to proxy", "proxy", proxy.node.ID(), "err", err)
+				return err
+			}
 		}
 	}
 	// Don't send any of the following messages if this node is not in the validator conn set
@@ -1074,8 +1120,8 @@ func (sb *Backend) setAndShareUpdatedAnnounceVersion(version uint) error {
 
 func (sb *Backend) getEnodeURL() (string, error) {
 	if sb.config.Proxied {
-		if sb.proxyNode != nil {
-			return sb.proxyNode.externalNode.URLv4(), nil
+		if primaryProxy := sb.proxies.getPrimaryProxy(); primaryProxy != nil {
+			return primaryProxy.externalNode.URLv4(), nil
 		}
 		return "", errNoProxyConnection
 	}
@@ -1127,22 +1173,41 @@ func (sb *Backend) retrieveEnodeCertificateMsg() (*istanbul.Message, error) {
 	return sb.enodeCertificateMsg.Copy(), nil
 }
 
-// generateEnodeCertificateMsg generates an enode certificate message with the enode
-// this node is publicly accessible at. If this node is proxied, the proxy's
-// public enode is used.
-func (sb *Backend) generateEnodeCertificateMsg(version uint) (*istanbul.Message, error) {
-	logger := sb.logger.New("func", "generateEnodeCertificateMsg")
-
-	var enodeURL string
+// generateEnodeCertificateMsgs generates a map of enode certificate messages.
+// One certificate message is generated for each external enode this node possesses.
+// An unproxied validator has a single enode, while a proxied validator has one
+// for each of its proxies and each proxy's certificate contains that proxy's
+// public enode.  The returned map is keyed by this node's enode ID if it is
+// unproxied, or by each proxy's internal enode ID if it is proxied.
+func (sb *Backend) generateEnodeCertificateMsgs(version uint) (map[enode.ID]*istanbul.Message, error) {
+	enodeCertificateMsgs := make(map[enode.ID]*istanbul.Message)
 	if sb.config.Proxied {
-		if sb.proxyNode != nil {
-			enodeURL = sb.proxyNode.externalNode.URLv4()
-		} else {
+		proxies := sb.proxies.getProxies()
+		if len(proxies) == 0 {
 			return nil, errNoProxyConnection
 		}
+		for _, proxy := range proxies {
+			msg, err := sb.generateEnodeCertificateMsg(proxy.externalNode.URLv4(), version)
+			if err != nil {
+				return nil, err
+			}
+			enodeCertificateMsgs[proxy.node.ID()] = msg
+		}
 	} else {
-		enodeURL = sb.p2pserver.Self().URLv4()
+		selfNode := sb.p2pserver.Self()
+		msg, err := sb.generateEnodeCertificateMsg(selfNode.URLv4(), version)
+		if err != nil {
+			return nil, err
+		}
+		enodeCertificateMsgs[selfNode.ID()] = msg
 	}
+	return enodeCertificateMsgs, nil
+}
+
+// generateEnodeCertificateMsg generates a signed enode certificate message
+// with the given enode URL.
+func (sb *Backend) generateEnodeCertificateMsg(enodeURL string, version uint) (*istanbul.Message, error) {
+	logger := sb.logger.New("func", "generateEnodeCertificateMsg")
 
 	enodeCertificate := &enodeCertificate{
 		EnodeURL: enodeURL,
@@ -1269,10 +1334,12 @@ func (sb *Backend) handleEnodeCertificateMsg(_ common.Address, peer consensus.Pe
 		return errUnauthorizedAnnounceMessage
 	}
 
-	// Send enode certificate to proxy
-	if sb.config.Proxied && sb.proxyNode != nil && sb.proxyNode.peer != nil {
-		if err := sb.sendEnodeCertificateMsg(sb.proxyNode.peer, &msg); err != nil {
-			logger.Warn("Error sending enodeCertificate back to proxy peer", "err", err)
+	// Send enode certificate to all the connected proxies
+	if sb.config.Proxied {
+		for _, proxyPeer := range sb.proxies.getConnectedProxyPeers() {
+			if err := sb.sendEnodeCertificateMsg(proxyPeer, &msg); err != nil {
+				logger.Warn("Error sending enodeCertificate back to proxy peer", "peer", proxyPeer.Node().ID(), "err", err)
+			}
 		}
 	}
 
@@ -1301,6 +1368,24 @@ func (sb *Backend) setEnodeCertificateMsg(msg *istanbul.Message) error {
 	return nil
 }
 
+func (sb *Backend) setProxyEnodeCertificateMsgs(msgs map[enode.ID]*istanbul.Message) {
+	sb.enodeCertificateMsgMu.Lock()
+	defer sb.enodeCertificateMsgMu.Unlock()
+	sb.proxyEnodeCertificateMsgs = msgs
+}
+
+// retrieveProxyEnodeCertificateMsg gets the most recent enode certificate message
+// generated for the proxy with the given internal enode ID.  May be nil if no
+// message has been generated for that proxy yet.
+func (sb *Backend) retrieveProxyEnodeCertificateMsg(proxyID enode.ID) *istanbul.Message {
+	sb.enodeCertificateMsgMu.RLock()
+	defer sb.enodeCertificateMsgMu.RUnlock()
+	if msg, ok := sb.proxyEnodeCertificateMsgs[proxyID]; ok {
+		return msg.Copy()
+	}
+	return nil
+}
+
 func (sb *Backend) getEnodeCertificateMsgVersion() uint {
 	sb.enodeCertificateMsgMu.RLock()
 	defer sb.enodeCertificateMsgMu.RUnlock()
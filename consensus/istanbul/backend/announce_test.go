@@ -2,11 +2,15 @@ package backend
 
 import (
 	"crypto/ecdsa"
+	"math/big"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
@@ -60,3 +64,40 @@ func TestHandleIstAnnounce(t *testing.T) {
 		t.Errorf("Failed to save enode entry")
 	}
 }
+
+func TestAnnounceOnReplicaSwitch(t *testing.T) {
+	chain, b := newBlockChain(1, true)
+	defer chain.Stop()
+
+	// Restart announcing, without any version certificate, as a replica scheduled to become the primary at block 2
+	b.StopAnnouncing()
+	if err := b.versionCertificateTable.Remove(b.Address()); err != nil {
+		t.Fatalf("failed to remove the version certificate: %v", err)
+	}
+	b.replicaState = newReplicaState(rawdb.NewMemoryDatabase(), true, b.logger)
+	if err := b.replicaState.setStartBlock(big.NewInt(2), big.NewInt(0)); err != nil {
+		t.Fatalf("failed to schedule start: %v", err)
+	}
+	if err := b.StartAnnouncing(); err != nil {
+		t.Fatalf("failed to start announcing: %v", err)
+	}
+
+	// The replica doesn't announce its enode
+	time.Sleep(100 * time.Millisecond)
+	if version, _ := b.versionCertificateTable.GetVersion(b.Address()); version != 0 {
+		t.Fatalf("replica announced version %d", version)
+	}
+
+	// The switch is announced without waiting for the announce ticker
+	b.NewChainHead(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}))
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		if version, _ := b.versionCertificateTable.GetVersion(b.Address()); version != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("enode not announced after switching to primary")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
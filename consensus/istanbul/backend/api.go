@@ -19,18 +19,26 @@ package backend
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	vet "github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/core"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// maxSigningHistoryBlocks is the maximum number of blocks GetValidatorSigningHistory reports on
+const maxSigningHistoryBlocks = 100000
+
 // API is a user facing RPC API to dump Istanbul state
 type API struct {
 	chain    consensus.ChainReader
@@ -96,8 +104,8 @@ func (api *API) GetProposer(sequence *rpc.BlockNumber, round *uint64) (common.Ad
 		return common.Address{}, err
 	}
 
-	valSet := api.istanbul.getOrderedValidators(header.Number.Uint64(), header.Hash())
-	if valSet == nil {
+	valSet, err := api.istanbul.getOrderedValidators(header.Number.Uint64(), header.Hash())
+	if err != nil {
 		return common.Address{}, err
 	}
 	previousProposer, err := api.istanbul.Author(header)
@@ -111,6 +119,269 @@ func (api *API) GetProposer(sequence *rpc.BlockNumber, round *uint64) (common.Ad
 	return proposer.Address(), nil
 }
 
+// ValidatorUptime is the accumulated uptime of a validator during an epoch
+type ValidatorUptime struct {
+	Address         common.Address `json:"address"`
+	ScoreTally      uint64         `json:"scoreTally"`
+	LastSignedBlock uint64         `json:"lastSignedBlock"`
+}
+
+// EpochUptime is the accumulated uptime of the validators of an epoch returned by GetUptime
+type EpochUptime struct {
+	Epoch       uint64 `json:"epoch"`
+	LatestBlock uint64 `json:"latestBlock"`
+	// TallyBlocks is the size of the epoch's score tally window, i.e. the maximum ScoreTally
+	TallyBlocks uint64             `json:"tallyBlocks"`
+	Validators  []*ValidatorUptime `json:"validators"`
+}
+
+// getEpochValidators retrieves the validators elected for the given epoch.
+func (api *API) getEpochValidators(epoch uint64) ([]istanbul.Validator, error) {
+	firstBlock, err := istanbul.GetEpochFirstBlockNumber(epoch, api.istanbul.EpochSize())
+	if err != nil {
+		return nil, err
+	}
+	header := api.chain.GetHeaderByNumber(firstBlock - 1)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return api.istanbul.GetValidators(header.Number, header.Hash()), nil
+}
+
+// GetUptime retrieves the uptime accumulated so far by the validators of the given epoch
+// (or the current epoch if unspecified).
+func (api *API) GetUptime(epoch *uint64) (*EpochUptime, error) {
+	epochSize := api.istanbul.EpochSize()
+	if epoch == nil {
+		current := istanbul.GetEpochNumber(api.chain.CurrentHeader().Number.Uint64(), epochSize)
+		epoch = &current
+	}
+
+	uptime := rawdb.ReadAccumulatedEpochUptime(api.istanbul.db, *epoch)
+	if uptime == nil {
+		return nil, fmt.Errorf("no uptime accumulated for epoch %d", *epoch)
+	}
+	validators, err := api.getEpochValidators(*epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &EpochUptime{
+		Epoch:       *epoch,
+		LatestBlock: uptime.LatestBlock,
+		TallyBlocks: istanbul.GetValScoreTallyLastBlockNumber(*epoch, epochSize) - istanbul.GetValScoreTallyFirstBlockNumber(*epoch, epochSize, api.istanbul.LookbackWindow()) + 1,
+		Validators:  make([]*ValidatorUptime, 0, len(validators)),
+	}
+	// The uptime entries are allocated with an upper bound of the validator set size
+	for i, val := range validators {
+		entry := &ValidatorUptime{Address: val.Address()}
+		if i < len(uptime.Entries) {
+			entry.ScoreTally = uptime.Entries[i].ScoreTally
+			entry.LastSignedBlock = uptime.Entries[i].LastSignedBlock
+		}
+		summary.Validators = append(summary.Validators, entry)
+	}
+	return summary, nil
+}
+
+// BlockSigning tells whether a validator signed a block, as recorded by the parent aggregated
+// seal of the block's child
+type BlockSigning struct {
+	Number  uint64 `json:"number"`
+	Elected bool   `json:"elected"` // Whether the validator was part of the validator set of the block
+	Signed  bool   `json:"signed"`
+}
+
+// ValidatorSigningHistory is the signing history of a validator returned by GetValidatorSigningHistory
+type ValidatorSigningHistory struct {
+	Address common.Address  `json:"address"`
+	Signed  uint64          `json:"signed"`
+	Missed  uint64          `json:"missed"`
+	Blocks  []*BlockSigning `json:"blocks"`
+}
+
+// resolveBlockNumber returns the number of the given block, where latest and pending refer to the head
+func resolveBlockNumber(number rpc.BlockNumber, head uint64) uint64 {
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return head
+	}
+	return uint64(number)
+}
+
+// getParentSealBitmap retrieves the bitmap of the validators that signed the parent of the
+// canonical block with the given number, from the signing history index if available.
+func (api *API) getParentSealBitmap(number uint64) (*big.Int, error) {
+	hash := rawdb.ReadCanonicalHash(api.istanbul.db, number)
+	if bitmap := rawdb.ReadParentSealBitmap(api.istanbul.db, hash, number); bitmap != nil {
+		return bitmap, nil
+	}
+
+	header := api.chain.GetHeader(hash, number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	return extra.ParentAggregatedSeal.Bitmap, nil
+}
+
+// GetValidatorSigningHistory retrieves whether the given validator signed each of the blocks in
+// the given range. As the signers of a block are recorded by its child, the head block is not
+// part of the history.
+func (api *API) GetValidatorSigningHistory(address common.Address, fromBlock, toBlock rpc.BlockNumber) (*ValidatorSigningHistory, error) {
+	head := api.chain.CurrentHeader().Number.Uint64()
+	from, to := resolveBlockNumber(fromBlock, head), resolveBlockNumber(toBlock, head)
+	// The genesis block is not signed
+	if from == 0 {
+		from = 1
+	}
+	if to >= head {
+		to = head - 1
+	}
+
+	history := &ValidatorSigningHistory{Address: address, Blocks: []*BlockSigning{}}
+	if head == 0 || from > to {
+		return history, nil
+	}
+	if to-from >= maxSigningHistoryBlocks {
+		return nil, fmt.Errorf("requested signing history of %d blocks exceeds the limit of %d", to-from+1, maxSigningHistoryBlocks)
+	}
+
+	epochSize := api.istanbul.EpochSize()
+	var epoch uint64
+	index := -1
+	for number := from; number <= to; number++ {
+		// The validator set, and the validator's index in the parent seal bitmap, only change on epochs
+		if blockEpoch := istanbul.GetEpochNumber(number, epochSize); blockEpoch != epoch {
+			validators, err := api.getEpochValidators(blockEpoch)
+			if err != nil {
+				return nil, err
+			}
+			epoch, index = blockEpoch, -1
+			for i, val := range validators {
+				if val.Address() == address {
+					index = i
+					break
+				}
+			}
+		}
+
+		entry := &BlockSigning{Number: number, Elected: index >= 0}
+		if entry.Elected {
+			bitmap, err := api.getParentSealBitmap(number + 1)
+			if err != nil {
+				return nil, err
+			}
+			entry.Signed = bitmap.Bit(index) == 1
+			if entry.Signed {
+				history.Signed++
+			} else {
+				history.Missed++
+			}
+		}
+		history.Blocks = append(history.Blocks, entry)
+	}
+	return history, nil
+}
+
+// ValidatorEpochRewardSummary is the payment of a validator in an EpochRewardsSummary
+type ValidatorEpochRewardSummary struct {
+	Address common.Address `json:"address"`
+	Group   common.Address `json:"group"`
+	Uptime  *hexutil.Big   `json:"uptime"`  // Fixidity fraction
+	Payment *hexutil.Big   `json:"payment"` // In cUSD
+}
+
+// GroupEpochRewardSummary is the reward of the voters of a group in an EpochRewardsSummary
+type GroupEpochRewardSummary struct {
+	Group  common.Address `json:"group"`
+	Reward *hexutil.Big   `json:"reward"`
+}
+
+// EpochRewardMintSummary is an amount of CELO minted in an EpochRewardsSummary
+type EpochRewardMintSummary struct {
+	Beneficiary common.Address `json:"beneficiary"`
+	Purpose     string         `json:"purpose"`
+	Amount      *hexutil.Big   `json:"amount"`
+}
+
+// EpochRewardsSummary is the breakdown of the rewards of an epoch returned by GetEpochRewards
+// and PreviewEpochRewards
+type EpochRewardsSummary struct {
+	Epoch       uint64                         `json:"epoch"`
+	BlockNumber uint64                         `json:"blockNumber"`
+	Frozen      bool                           `json:"frozen"`
+	Validators  []*ValidatorEpochRewardSummary `json:"validators"`
+	Groups      []*GroupEpochRewardSummary     `json:"groups"`
+	Mints       []*EpochRewardMintSummary      `json:"mints"`
+}
+
+func newEpochRewardsSummary(number uint64, rewards *istanbul.EpochRewards) *EpochRewardsSummary {
+	summary := &EpochRewardsSummary{
+		Epoch:       rewards.Epoch,
+		BlockNumber: number,
+		Frozen:      rewards.Frozen,
+		Validators:  make([]*ValidatorEpochRewardSummary, 0, len(rewards.Validators)),
+		Groups:      make([]*GroupEpochRewardSummary, 0, len(rewards.Groups)),
+		Mints:       make([]*EpochRewardMintSummary, 0, len(rewards.Mints)),
+	}
+	for _, val := range rewards.Validators {
+		summary.Validators = append(summary.Validators, &ValidatorEpochRewardSummary{
+			Address: val.Validator,
+			Group:   val.Group,
+			Uptime:  (*hexutil.Big)(val.Uptime),
+			Payment: (*hexutil.Big)(val.Payment),
+		})
+	}
+	for _, group := range rewards.Groups {
+		summary.Groups = append(summary.Groups, &GroupEpochRewardSummary{Group: group.Group, Reward: (*hexutil.Big)(group.Reward)})
+	}
+	for _, mint := range rewards.Mints {
+		summary.Mints = append(summary.Mints, &EpochRewardMintSummary{Beneficiary: mint.Beneficiary, Purpose: mint.Purpose, Amount: (*hexutil.Big)(mint.Amount)})
+	}
+	return summary
+}
+
+// GetEpochRewards retrieves the validator payments, voter rewards of each group and CELO minted
+// at the last block of the given epoch (or the last completed epoch if unspecified). The rewards
+// are only known for the epochs whose last block was processed by this node.
+func (api *API) GetEpochRewards(epoch *uint64) (*EpochRewardsSummary, error) {
+	epochSize := api.istanbul.EpochSize()
+	if epoch == nil {
+		head := api.chain.CurrentHeader().Number.Uint64()
+		last := istanbul.GetEpochNumber(head, epochSize)
+		if !istanbul.IsLastBlockOfEpoch(head, epochSize) {
+			last--
+		}
+		epoch = &last
+	}
+	if *epoch == 0 {
+		return nil, errors.New("no epoch rewards distributed yet")
+	}
+
+	header := api.chain.GetHeaderByNumber(istanbul.GetEpochLastBlockNumber(*epoch, epochSize))
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	rewards := rawdb.ReadEpochRewards(api.istanbul.db, header.Hash(), header.Number.Uint64())
+	if rewards == nil {
+		return nil, fmt.Errorf("no rewards recorded for epoch %d", *epoch)
+	}
+	return newEpochRewardsSummary(header.Number.Uint64(), rewards), nil
+}
+
+// PreviewEpochRewards simulates the distribution of the current epoch's rewards on top of the
+// current head, using the uptime accumulated so far
+func (api *API) PreviewEpochRewards() (*EpochRewardsSummary, error) {
+	rewards, err := api.istanbul.previewEpochRewards()
+	if err != nil {
+		return nil, err
+	}
+	return newEpochRewardsSummary(api.chain.CurrentHeader().Number.Uint64()+1, rewards), nil
+}
+
 // AddProxy peers with a remote node that acts as a proxy, even if slots are full
 func (api *API) AddProxy(url, externalUrl string) (bool, error) {
 	if !api.istanbul.config.Proxied {
@@ -143,6 +414,59 @@ func (api *API) RemoveProxy(url string) (bool, error) {
 	return true, nil
 }
 
+// ProxyInfo is the information about a proxy and its health returned by GetProxiesInfo
+type ProxyInfo struct {
+	InternalNode    string  `json:"internalEnodeUrl"`
+	ExternalNode    string  `json:"externalEnodeUrl"`
+	IsPeer          bool    `json:"isPeer"`
+	IsPrimary       bool    `json:"isPrimary"`
+	Healthy         bool    `json:"healthy"`
+	Scored          bool    `json:"scored"`
+	Score           float64 `json:"score"`
+	RelayLatencyMs  float64 `json:"relayLatencyMs"`
+	PingRTTMs       float64 `json:"pingRttMs"`
+	DroppedMsgRate  float64 `json:"droppedMsgRate"`
+	PeerCount       *uint64 `json:"peerCount"` // nil if the proxy never reported its peer count
+	RelayedMsgCount uint64  `json:"relayedMsgCount"`
+	DroppedMsgCount uint64  `json:"droppedMsgCount"`
+}
+
+// GetProxiesInfo retrieves all the proxies of this proxied validator along with
+// their health scores, and which one is currently the primary proxy
+func (api *API) GetProxiesInfo() ([]*ProxyInfo, error) {
+	if !api.istanbul.IsProxiedValidator() {
+		return nil, errors.New("Can't get proxies info for node that is not a proxied validator")
+	}
+
+	proxies := api.istanbul.proxies.getProxies()
+	proxiesInfo := make([]*ProxyInfo, 0, len(proxies))
+	for _, proxy := range proxies {
+		info := &ProxyInfo{
+			InternalNode: proxy.node.URLv4(),
+			ExternalNode: proxy.externalNode.URLv4(),
+			IsPeer:       proxy.peer != nil,
+			IsPrimary:    api.istanbul.proxies.isPrimary(proxy.node.ID()),
+			Healthy:      true,
+		}
+		if ph := api.istanbul.proxyHealth.getHealth(proxy.node.ID()); ph != nil {
+			info.Healthy = ph.healthy()
+			info.Scored = ph.scored()
+			info.Score = ph.score()
+			info.RelayLatencyMs = float64(ph.relayLatency) / float64(time.Millisecond)
+			info.PingRTTMs = float64(ph.pingRTT) / float64(time.Millisecond)
+			info.DroppedMsgRate = ph.dropRate
+			info.RelayedMsgCount = ph.relayedMsgs
+			info.DroppedMsgCount = ph.droppedMsgs
+			if ph.hasStatus {
+				peerCount := ph.peerCount
+				info.PeerCount = &peerCount
+			}
+		}
+		proxiesInfo = append(proxiesInfo, info)
+	}
+	return proxiesInfo, nil
+}
+
 // Retrieve the Validator Enode Table
 func (api *API) GetValEnodeTable() (map[string]*vet.ValEnodeEntryInfo, error) {
 	return api.istanbul.valEnodeTable.ValEnodeTableInfo()
@@ -160,6 +484,32 @@ func (api *API) GetCurrentRoundState() (*core.RoundStateSummary, error) {
 	return api.istanbul.core.CurrentRoundState().Summary(), nil
 }
 
+// GetEquivocationEvidence retrieves the evidence of validators signing conflicting PREPARE or
+// COMMIT messages in the given range of sequences (all sequences if unspecified).
+func (api *API) GetEquivocationEvidence(from, to *rpc.BlockNumber) ([]*core.EquivocationEvidenceSummary, error) {
+	fromSequence, toSequence := uint64(0), uint64(math.MaxUint64)
+	if from != nil && *from > 0 {
+		fromSequence = uint64(*from)
+	}
+	if to != nil && *to >= 0 {
+		toSequence = uint64(*to)
+	}
+
+	evidence, err := api.istanbul.core.EquivocationEvidence(fromSequence, toSequence)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]*core.EquivocationEvidenceSummary, 0, len(evidence))
+	for _, ev := range evidence {
+		summary, err := ev.Summary()
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
 // GetCurrentRoundState retrieves the current IBFT RoundState
 func (api *API) ForceRoundChange() (bool, error) {
 	if !api.istanbul.coreStarted {
@@ -169,12 +519,19 @@ func (api *API) ForceRoundChange() (bool, error) {
 	return true, nil
 }
 
-// TODO(kevjue) - implement this
-// ProxyInfo retrieves all the information we know about each individual proxy node
-/* func (api *PublicAdminAPI) ProxyInfo() ([]*p2p.PeerInfo, error) {
-	server := api.node.Server()
-	if server == nil {
-		return nil, ErrNodeStopped
-	}
-	return server.ProxyInfo(), nil
-} */
+// StartAtBlock schedules this validator to start signing consensus messages and proposing blocks
+// at the given block, as the primary taking over from a replica stopping at the same block
+func (api *API) StartAtBlock(blockNumber int64) error {
+	return api.istanbul.replicaState.setStartBlock(big.NewInt(blockNumber), api.chain.CurrentHeader().Number)
+}
+
+// StopAtBlock schedules this validator to stop signing consensus messages and proposing blocks
+// at the given block, and to only track the consensus state as a replica from then on
+func (api *API) StopAtBlock(blockNumber int64) error {
+	return api.istanbul.replicaState.setStopBlock(big.NewInt(blockNumber), api.chain.CurrentHeader().Number)
+}
+
+// GetReplicaState retrieves whether this validator is the primary, and its scheduled role switches
+func (api *API) GetReplicaState() *ReplicaStateSummary {
+	return api.istanbul.replicaState.summary()
+}
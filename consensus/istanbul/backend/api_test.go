@@ -0,0 +1,109 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// newEpochTestChain creates a chain with a single validator, whose first epoch has ended
+func newEpochTestChain(t *testing.T) (*core.BlockChain, *Backend) {
+	genesisCfg, nodeKeys := getGenesisAndKeys(1, true)
+	chain, engine := newBlockChainWithKeys(genesisCfg, nodeKeys)
+	engine.config.Epoch = genesisCfg.Config.Istanbul.Epoch
+	engine.config.BlockPeriod = 0
+
+	block := chain.Genesis()
+	for i := uint64(0); i < engine.EpochSize(); i++ {
+		var err error
+		if block, err = makeBlock(nodeKeys, chain, engine, block); err != nil {
+			t.Fatalf("failed to make block %d: %v", i+1, err)
+		}
+	}
+	return chain, engine
+}
+
+func TestGetEpochRewards(t *testing.T) {
+	chain, engine := newEpochTestChain(t)
+	api := &API{chain: chain, istanbul: engine}
+	last := chain.CurrentBlock()
+
+	// The test chain has no core contracts, so the distribution failed and nothing was recorded
+	if rewards := rawdb.ReadEpochRewards(engine.db, last.Hash(), last.NumberU64()); rewards != nil {
+		t.Fatalf("epoch rewards recorded for a failed distribution: %v", rewards)
+	}
+	if _, err := api.GetEpochRewards(nil); err == nil {
+		t.Fatalf("expected an error for an epoch without recorded rewards")
+	}
+
+	rewards := &istanbul.EpochRewards{
+		Epoch: 1,
+		Validators: []istanbul.ValidatorEpochReward{
+			{Validator: engine.Address(), Group: common.HexToAddress("0x1"), Uptime: big.NewInt(99), Payment: big.NewInt(1000)},
+		},
+		Groups: []istanbul.GroupEpochReward{{Group: common.HexToAddress("0x1"), Reward: big.NewInt(500)}},
+		Mints:  []istanbul.EpochRewardMint{{Beneficiary: common.HexToAddress("0x2"), Purpose: istanbul.VoterRewardsMint, Amount: big.NewInt(500)}},
+	}
+	rawdb.WriteEpochRewards(engine.db, last.Hash(), last.NumberU64(), rewards)
+
+	// The last completed epoch is returned by default
+	epoch := uint64(1)
+	for _, arg := range []*uint64{nil, &epoch} {
+		summary, err := api.GetEpochRewards(arg)
+		if err != nil {
+			t.Fatalf("failed to get epoch rewards: %v", err)
+		}
+		if summary.Epoch != 1 || summary.BlockNumber != last.NumberU64() || summary.Frozen {
+			t.Errorf("summary mismatch: have epoch %d, number %d, frozen %v", summary.Epoch, summary.BlockNumber, summary.Frozen)
+		}
+		if len(summary.Validators) != 1 || summary.Validators[0].Address != engine.Address() || summary.Validators[0].Payment.ToInt().Cmp(big.NewInt(1000)) != 0 {
+			t.Errorf("validator rewards mismatch: have %v", summary.Validators)
+		}
+		if len(summary.Groups) != 1 || summary.Groups[0].Reward.ToInt().Cmp(big.NewInt(500)) != 0 {
+			t.Errorf("group rewards mismatch: have %v", summary.Groups)
+		}
+		if len(summary.Mints) != 1 || summary.Mints[0].Purpose != istanbul.VoterRewardsMint {
+			t.Errorf("mints mismatch: have %v", summary.Mints)
+		}
+	}
+
+	// No rewards are distributed at the genesis block, nor for epochs that haven't ended
+	for _, epoch := range []uint64{0, 2} {
+		if _, err := api.GetEpochRewards(&epoch); err == nil {
+			t.Errorf("expected an error for epoch %d", epoch)
+		}
+	}
+}
+
+func TestPreviewEpochRewards(t *testing.T) {
+	chain, engine := newEpochTestChain(t)
+	api := &API{chain: chain, istanbul: engine}
+	if chain.CurrentBlock().NumberU64() != engine.EpochSize() {
+		t.Fatalf("head mismatch: have %d, want %d", chain.CurrentBlock().NumberU64(), engine.EpochSize())
+	}
+
+	// Without the core contracts there is nothing to distribute
+	if _, err := api.PreviewEpochRewards(); err == nil {
+		t.Fatalf("expected an error without the core contracts")
+	}
+}
@@ -32,7 +32,9 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
 	istanbulCore "github.com/ethereum/go-ethereum/consensus/istanbul/core"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/slashing"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/contract_comm/election"
 	comm_errors "github.com/ethereum/go-ethereum/contract_comm/errors"
@@ -61,8 +63,7 @@ var (
 	// errInvalidSigningFn is returned when the consensus signing function is invalid.
 	errInvalidSigningFn = errors.New("invalid signing function for istanbul messages")
 
-	// errProxyAlreadySet is returned if a user tries to add a proxy that is already set.
-	// TODO - When we support multiple sentries per validator, this error will become irrelevant.
+	// errProxyAlreadySet is returned if a user tries to add a proxy that is already in the proxy set.
 	errProxyAlreadySet = errors.New("proxy already set")
 
 	// errNoProxyConnection is returned when a proxied validator is not connected to a proxy
@@ -72,13 +73,6 @@ var (
 	errNoBlockHeader = errors.New("failed to retrieve block header")
 )
 
-// Information about the proxy for a proxied validator
-type proxyInfo struct {
-	node         *enode.Node    // Enode for the internal network interface
-	externalNode *enode.Node    // Enode for the external network interface
-	peer         consensus.Peer // Connected proxy peer.  Is nil if this node is not connected to the proxy
-}
-
 // New creates an Ethereum backend for Istanbul core engine.
 func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 	// Allocate the snapshot caches and create the engine
@@ -95,6 +89,14 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 	if err != nil {
 		logger.Crit("Failed to create known messages cache", "err", err)
 	}
+	recentProposerWeights, err := lru.NewARC(inmemoryProposerWeights)
+	if err != nil {
+		logger.Crit("Failed to create recent proposer weights cache", "err", err)
+	}
+	pendingEpochRewards, err := lru.NewARC(inmemoryEpochRewards)
+	if err != nil {
+		logger.Crit("Failed to create pending epoch rewards cache", "err", err)
+	}
 	backend := &Backend{
 		config:                             config,
 		istanbulEventMux:                   new(event.TypeMux),
@@ -102,6 +104,8 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		db:                                 db,
 		commitCh:                           make(chan *types.Block, 1),
 		recentSnapshots:                    recentSnapshots,
+		recentProposerWeights:              recentProposerWeights,
+		pendingEpochRewards:                pendingEpochRewards,
 		coreStarted:                        false,
 		announceRunning:                    false,
 		peerRecentMessages:                 peerRecentMessages,
@@ -109,6 +113,7 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		announceThreadWg:                   new(sync.WaitGroup),
 		announceThreadQuit:                 make(chan struct{}),
 		generateAndGossipQueryEnodeCh:      make(chan struct{}, 1),
+		replicaSwitchCh:                    make(chan struct{}, 1),
 		updateAnnounceVersionCh:            make(chan struct{}),
 		updateAnnounceVersionCompleteCh:    make(chan struct{}),
 		lastQueryEnodeGossiped:             make(map[common.Address]time.Time),
@@ -116,6 +121,10 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		valEnodesShareThreadWg:             new(sync.WaitGroup),
 		valEnodesShareThreadQuit:           make(chan struct{}),
 		updatingCachedValidatorConnSetCond: sync.NewCond(&sync.Mutex{}),
+		proxies:                            newProxySet(),
+		proxyHealth:                        newProxyHealthTracker(),
+		proxyHealthThreadWg:                new(sync.WaitGroup),
+		proxyHealthThreadQuit:              make(chan struct{}),
 		finalizationTimer:                  metrics.NewRegisteredTimer("consensus/istanbul/backend/finalize", nil),
 		rewardDistributionTimer:            metrics.NewRegisteredTimer("consensus/istanbul/backend/rewards", nil),
 		blocksElectedMeter:                 metrics.NewRegisteredMeter("consensus/istanbul/blocks/elected", nil),
@@ -125,6 +134,7 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		blocksTotalSigsGauge:               metrics.NewRegisteredGauge("consensus/istanbul/blocks/totalsigs", nil),
 		blocksValSetSizeGauge:              metrics.NewRegisteredGauge("consensus/istanbul/blocks/validators", nil),
 		blocksTotalMissedRoundsMeter:       metrics.NewRegisteredMeter("consensus/istanbul/blocks/missedrounds", nil),
+		backlogPenaltiesMeter:              metrics.NewRegisteredMeter("consensus/istanbul/backend/backlog/penalties", nil),
 	}
 	backend.core = istanbulCore.New(backend, backend.config)
 
@@ -150,6 +160,8 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 	}
 	backend.versionCertificateTable = versionCertificateTable
 
+	backend.replicaState = newReplicaState(db, config.Replica, logger)
+
 	// Set the handler functions for each istanbul message type
 	backend.istanbulAnnounceMsgHandlers = make(map[uint64]announceMsgHandler)
 	backend.istanbulAnnounceMsgHandlers[istanbul.QueryEnodeMsg] = backend.handleQueryEnodeMsg
@@ -166,12 +178,12 @@ type Backend struct {
 	config           *istanbul.Config
 	istanbulEventMux *event.TypeMux
 
-	address   common.Address       // Ethereum address of the signing key
-	publicKey *ecdsa.PublicKey     // The signer public key
-	decryptFn istanbul.DecryptFn   // Decrypt function to decrypt ECIES ciphertext
-	signFn    istanbul.SignerFn    // Signer function to authorize hashes with
-	signBLSFn istanbul.BLSSignerFn // Signer function to authorize BLS messages
-	signFnMu  sync.RWMutex         // Protects the signer fields
+	address   common.Address           // Ethereum address of the signing key
+	publicKey *ecdsa.PublicKey         // The signer public key
+	decryptFn istanbul.DecryptFn       // Decrypt function to decrypt ECIES ciphertext
+	signFn    istanbul.SignerFn        // Signer function to authorize hashes with
+	signBLSFn istanbul.BLSViewSignerFn // Signer function to authorize BLS messages
+	signFnMu  sync.RWMutex             // Protects the signer fields
 
 	core         istanbulCore.Engine
 	logger       log.Logger
@@ -180,6 +192,7 @@ type Backend struct {
 	currentBlock func() *types.Block
 	hasBadBlock  func(hash common.Hash) bool
 	stateAt      func(hash common.Hash) (*state.StateDB, error)
+	systemCaller *contract_comm.SystemCaller
 
 	processBlock  func(block *types.Block, statedb *state.StateDB) (types.Receipts, []*types.Log, uint64, error)
 	validateState func(block *types.Block, statedb *state.StateDB, receipts types.Receipts, usedGas uint64) error
@@ -194,6 +207,12 @@ type Backend struct {
 	// Snapshots for recent blocks to speed up reorgs
 	recentSnapshots *lru.ARCCache
 
+	// Stake weighted proposer weights of recent epochs, keyed by the hash of the epoch block
+	recentProposerWeights *lru.ARCCache
+
+	// Epoch rewards distributed by finalized blocks, keyed by state root, until the blocks are written
+	pendingEpochRewards *lru.ARCCache
+
 	// event subscription for ChainHeadEvent event
 	broadcaster consensus.Broadcaster
 
@@ -208,6 +227,13 @@ type Backend struct {
 
 	valEnodeTable *enodes.ValidatorEnodeDB
 
+	// Slashing protection DB consulted before signing consensus messages, only open while validating
+	slashingProtection   *slashing.DB
+	slashingProtectionMu sync.RWMutex
+
+	// Whether this validator is the primary or a replica of it, and the scheduled role switches
+	replicaState *replicaState
+
 	versionCertificateTable           *enodes.VersionCertificateDB
 	lastVersionCertificatesGossiped   map[common.Address]time.Time
 	lastVersionCertificatesGossipedMu sync.RWMutex
@@ -218,6 +244,9 @@ type Backend struct {
 	announceThreadQuit            chan struct{}
 	generateAndGossipQueryEnodeCh chan struct{}
 
+	// Signals the announce thread when this validator switches between primary and replica
+	replicaSwitchCh chan struct{}
+
 	updateAnnounceVersionCh         chan struct{}
 	updateAnnounceVersionCompleteCh chan struct{}
 
@@ -229,11 +258,21 @@ type Backend struct {
 	enodeCertificateMsgVersion uint
 	enodeCertificateMsgMu      sync.RWMutex
 
+	// The enode certificate messages most recently generated for each proxy
+	// if this is a proxied validator, keyed by the proxy's internal enode ID.
+	// Each one contains the external enode of the proxy it was generated for.
+	proxyEnodeCertificateMsgs map[enode.ID]*istanbul.Message
+
 	valEnodesShareThreadWg   *sync.WaitGroup
 	valEnodesShareThreadQuit chan struct{}
 
-	// Validator's proxy
-	proxyNode *proxyInfo
+	// Validator's proxies
+	proxies *proxySet
+
+	// Health measurements of the validator's proxies, used to rotate the primary proxy
+	proxyHealth           *proxyHealthTracker
+	proxyHealthThreadWg   *sync.WaitGroup
+	proxyHealthThreadQuit chan struct{}
 
 	// Right now, we assume that there is at most one proxied peer for a proxy
 	// Proxy's validator
@@ -263,6 +302,9 @@ type Backend struct {
 	// Meter counting cumulative number of round changes that had to happen to get blocks agreed.
 	blocksTotalMissedRoundsMeter metrics.Meter
 
+	// Meter counting the validator peers disconnected for overflowing the backlog of future messages.
+	backlogPenaltiesMeter metrics.Meter
+
 	istanbulAnnounceMsgHandlers map[uint64]announceMsgHandler
 
 	// Cache for the return values of the method retrieveValidatorConnSet
@@ -297,15 +339,21 @@ func (sb *Backend) IsValidator() bool {
 	return sb.config.Validator
 }
 
-// SendDelegateSignMsgToProxy sends an istanbulDelegateSign message to a proxy
-// if one exists
+// SendDelegateSignMsgToProxy sends an istanbulDelegateSign message to the
+// primary proxy if one is connected
 func (sb *Backend) SendDelegateSignMsgToProxy(msg []byte) error {
-	if !sb.IsProxiedValidator() || sb.proxyNode == nil || sb.proxyNode.peer == nil {
+	if !sb.IsProxiedValidator() {
+		err := errors.New("No Proxy found")
+		sb.logger.Error("SendDelegateSignMsgToProxy failed", "err", err)
+		return err
+	}
+	primaryProxy := sb.proxies.getPrimaryProxy()
+	if primaryProxy == nil || primaryProxy.peer == nil {
 		err := errors.New("No Proxy found")
 		sb.logger.Error("SendDelegateSignMsgToProxy failed", "err", err)
 		return err
 	}
-	return sb.proxyNode.peer.Send(istanbul.DelegateSignMsg, msg)
+	return primaryProxy.peer.Send(istanbul.DelegateSignMsg, msg)
 }
 
 // SendDelegateSignMsgToProxiedValidator sends an istanbulDelegateSign message to a
@@ -320,7 +368,7 @@ func (sb *Backend) SendDelegateSignMsgToProxiedValidator(msg []byte) error {
 }
 
 // Authorize implements istanbul.Backend.Authorize
-func (sb *Backend) Authorize(address common.Address, publicKey *ecdsa.PublicKey, decryptFn istanbul.DecryptFn, signFn istanbul.SignerFn, signBLSFn istanbul.BLSSignerFn) {
+func (sb *Backend) Authorize(address common.Address, publicKey *ecdsa.PublicKey, decryptFn istanbul.DecryptFn, signFn istanbul.SignerFn, signBLSFn istanbul.BLSViewSignerFn) {
 	sb.signFnMu.Lock()
 	defer sb.signFnMu.Unlock()
 
@@ -347,6 +395,9 @@ func (sb *Backend) Close() error {
 	if err := sb.versionCertificateTable.Close(); err != nil {
 		errs = append(errs, err)
 	}
+	if err := sb.closeSlashingProtection(); err != nil {
+		errs = append(errs, err)
+	}
 	var concatenatedErrs error
 	for i, err := range errs {
 		if i == 0 {
@@ -359,12 +410,12 @@ func (sb *Backend) Close() error {
 }
 
 // Validators implements istanbul.Backend.Validators
-func (sb *Backend) Validators(proposal istanbul.Proposal) istanbul.ValidatorSet {
+func (sb *Backend) Validators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
 	return sb.getOrderedValidators(proposal.Number().Uint64(), proposal.Hash())
 }
 
 // ParentBlockValidators implements istanbul.Backend.ParentBlockValidators
-func (sb *Backend) ParentBlockValidators(proposal istanbul.Proposal) istanbul.ValidatorSet {
+func (sb *Backend) ParentBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
 	return sb.getOrderedValidators(proposal.Number().Uint64()-1, proposal.ParentHash())
 }
 
@@ -376,7 +427,7 @@ func (sb *Backend) NextBlockValidators(proposal istanbul.Proposal) (istanbul.Val
 
 	// There was no change
 	if len(istExtra.AddedValidators) == 0 && istExtra.RemovedValidators.BitLen() == 0 {
-		return sb.ParentBlockValidators(proposal), nil
+		return sb.ParentBlockValidators(proposal)
 	}
 
 	snap, err := sb.snapshot(sb.chain, proposal.Number().Uint64()-1, common.Hash{}, nil)
@@ -472,7 +523,7 @@ func (sb *Backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 	}
 
 	// Introduced support for setting `header.Coinbase` != `Author(header)` in `1.1.0`
-	isSupported, isOk, err := blockchain_parameters.IsMinimumVersionAtLeast(1, 1, 0)
+	isSupported, isOk, err := blockchain_parameters.IsMinimumVersionAtLeast(sb.systemCaller, 1, 1, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -537,11 +588,11 @@ func (sb *Backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 }
 
 func (sb *Backend) getNewValidatorSet(header *types.Header, state *state.StateDB) ([]istanbul.ValidatorData, error) {
-	newValSetAddresses, err := election.GetElectedValidators(header, state)
+	newValSetAddresses, err := election.GetElectedValidators(sb.systemCaller, header, state)
 	if err != nil {
 		return nil, err
 	}
-	newValSet, err := validators.GetValidatorData(header, state, newValSetAddresses)
+	newValSet, err := validators.GetValidatorData(sb.systemCaller, header, state, newValSetAddresses)
 	return newValSet, err
 }
 
@@ -561,7 +612,10 @@ func (sb *Backend) verifyValSetDiff(proposal istanbul.Proposal, block *types.Blo
 			return errInvalidValidatorSetDiff
 		}
 	} else {
-		parentValidators := sb.ParentBlockValidators(proposal)
+		parentValidators, err := sb.ParentBlockValidators(proposal)
+		if err != nil {
+			return err
+		}
 		oldValSet := make([]istanbul.ValidatorData, 0, parentValidators.Size())
 
 		for _, val := range parentValidators.List() {
@@ -596,17 +650,27 @@ func (sb *Backend) Sign(data []byte) ([]byte, error) {
 	}
 	sb.signFnMu.RLock()
 	defer sb.signFnMu.RUnlock()
+	if err := sb.checkSlashingProtection(data); err != nil {
+		return nil, err
+	}
 	return sb.signFn(accounts.Account{Address: sb.address}, accounts.MimetypeIstanbul, data)
 }
 
 // Sign implements istanbul.Backend.SignBLS
-func (sb *Backend) SignBLS(data []byte, extra []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+func (sb *Backend) SignBLS(data []byte, extra []byte, useComposite bool, view *istanbul.View) (blscrypto.SerializedSignature, error) {
 	if sb.signBLSFn == nil {
 		return blscrypto.SerializedSignature{}, errInvalidSigningFn
 	}
 	sb.signFnMu.RLock()
 	defer sb.signFnMu.RUnlock()
-	return sb.signBLSFn(accounts.Account{Address: sb.address}, data, extra, useComposite)
+	if err := sb.checkBLSSlashingProtection(data, extra, useComposite, view); err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	var sequence, round *big.Int
+	if view != nil {
+		sequence, round = view.Sequence, view.Round
+	}
+	return sb.signBLSFn(accounts.Account{Address: sb.address}, data, extra, useComposite, sequence, round)
 }
 
 // CheckSignature implements istanbul.Backend.CheckSignature
@@ -628,9 +692,29 @@ func (sb *Backend) HasBlock(hash common.Hash, number *big.Int) bool {
 	return sb.chain.GetHeader(hash, number.Uint64()) != nil
 }
 
+// IsPrimaryForSeq implements istanbul.Backend.IsPrimaryForSeq
+func (sb *Backend) IsPrimaryForSeq(seq *big.Int) bool {
+	return sb.replicaState.isPrimaryForSeq(seq)
+}
+
+// PenalizeOverflowingValidator implements istanbul.Backend.PenalizeOverflowingValidator
+func (sb *Backend) PenalizeOverflowingValidator(addr common.Address) {
+	sb.backlogPenaltiesMeter.Mark(1)
+
+	// The messages may have been relayed by any peer, so the connection they were received
+	// from is left alone and the validator's own connection is dropped instead
+	node, err := sb.valEnodeTable.GetNodeFromAddress(addr)
+	if err != nil || node == nil {
+		sb.logger.Warn("Penalized validator overflowing the backlog, no validator peer to disconnect", "address", addr, "err", err)
+		return
+	}
+	sb.logger.Warn("Disconnecting validator peer overflowing the backlog", "address", addr, "node", node)
+	sb.vph.RemoveValidatorPeer(node)
+}
+
 // AuthorForBlock implements istanbul.Backend.AuthorForBlock
-func (sb *Backend) AuthorForBlock(number uint64) common.Address {
-	if h := sb.chain.GetHeaderByNumber(number); h != nil {
+func (sb *Backend) AuthorForBlock(hash common.Hash, number uint64) common.Address {
+	if h := sb.chain.GetHeader(hash, number); h != nil {
 		a, _ := sb.Author(h)
 		return a
 	}
@@ -660,16 +744,118 @@ func (sb *Backend) validatorRandomnessAtBlockNumber(number uint64, hash common.H
 	if err != nil {
 		return common.Hash{}, err
 	}
-	return random.BlockRandomness(header, state, lastBlockInPreviousEpoch)
+	return random.BlockRandomness(sb.systemCaller, header, state, lastBlockInPreviousEpoch)
+}
+
+// epochBlockHeader returns the header of the last epoch block at or before the given block, on the chain of
+// the given block. The headers are walked back from the block until its chain joins the canonical one.
+func (sb *Backend) epochBlockHeader(number uint64, hash common.Hash) *types.Header {
+	epochNumber := number - number%sb.config.Epoch
+	for number > epochNumber {
+		if canonical := sb.chain.GetHeaderByNumber(number); canonical != nil && canonical.Hash() == hash {
+			header := sb.chain.GetHeaderByNumber(epochNumber)
+			// Make sure the chain wasn't reorged while reading the epoch block
+			if canonical = sb.chain.GetHeaderByNumber(number); canonical != nil && canonical.Hash() == hash {
+				return header
+			}
+		}
+		header := sb.chain.GetHeader(hash, number)
+		if header == nil {
+			return nil
+		}
+		hash, number = header.ParentHash, number-1
+	}
+	return sb.chain.GetHeader(hash, number)
+}
+
+// proposerWeightsAtEpochBlock calls into the EVM to get the votes backing each validator elected at a given
+// epoch block, in the order of the validator set. Since votes are cast for groups, a validator's weight is the
+// total votes for its group split evenly between the group's elected validators. The validator set only changes
+// on epoch blocks, so the weights are computed once per epoch.
+func (sb *Backend) proposerWeightsAtEpochBlock(header *types.Header) ([]*big.Int, error) {
+	if cached, ok := sb.recentProposerWeights.Get(header.Hash()); ok {
+		return cached.([]*big.Int), nil
+	}
+	state, err := sb.stateAt(header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	groupVotes, err := election.GetGroupVoteTotals(sb.systemCaller, header, state)
+	if err != nil {
+		return nil, err
+	}
+
+	valSet := sb.getValidators(header.Number.Uint64(), header.Hash())
+	groups := make([]common.Address, valSet.Size())
+	members := make(map[common.Address]int64)
+	for i, val := range valSet.List() {
+		group, err := validators.GetMembershipInLastEpoch(sb.systemCaller, header, state, val.Address())
+		if err != nil {
+			return nil, err
+		}
+		groups[i] = group
+		members[group]++
+	}
+
+	weights := make([]*big.Int, len(groups))
+	for i, group := range groups {
+		weights[i] = new(big.Int)
+		if votes, ok := groupVotes[group]; ok {
+			weights[i].Div(votes, big.NewInt(members[group]))
+		}
+	}
+	sb.recentProposerWeights.Add(header.Hash(), weights)
+	return weights, nil
 }
 
-func (sb *Backend) getOrderedValidators(number uint64, hash common.Hash) istanbul.ValidatorSet {
+// isStakeWeightedProposer returns whether the proposer of the given block is selected by stake, which is
+// set per chain by the proposer policy and fork block of the chain config.
+func (sb *Backend) isStakeWeightedProposer(number uint64) bool {
+	config := sb.chain.Config()
+	return config.Istanbul != nil && istanbul.ProposerPolicy(config.Istanbul.ProposerPolicy) == istanbul.StakeWeighted &&
+		config.IsStakeWeightedProposer(new(big.Int).SetUint64(number))
+}
+
+func (sb *Backend) getOrderedValidators(number uint64, hash common.Hash) (istanbul.ValidatorSet, error) {
 	valSet := sb.getValidators(number, hash)
 	if valSet.Size() == 0 {
-		return valSet
+		return valSet, nil
+	}
+
+	// The proposer of the next block is selected by stake from the fork block
+	if sb.isStakeWeightedProposer(number + 1) {
+		epochHeader := sb.epochBlockHeader(number, hash)
+		if epochHeader == nil {
+			return nil, errNoBlockHeader
+		}
+		weights, err := sb.proposerWeightsAtEpochBlock(epochHeader)
+		if err == comm_errors.ErrRegistryContractNotDeployed {
+			// Without the core contracts there are no votes, and every node falls back to ShuffledRoundRobinProposer
+			sb.logger.Debug("Failed to set weights for proposer selection", "block_number", number, "hash", hash, "error", err)
+			return valSet, nil
+		} else if err != nil {
+			return nil, err
+		}
+		header := sb.chain.GetHeader(hash, number)
+		if header == nil {
+			return nil, errNoBlockHeader
+		}
+		state, err := sb.stateAt(hash)
+		if err != nil {
+			return nil, err
+		}
+		seed, err := random.BlockRandomness(sb.systemCaller, header, state, number)
+		if err != nil {
+			return nil, err
+		}
+		// The validator set is shared by the blocks of the epoch, so the block's seed and weights are set on a copy
+		valSet = valSet.Copy()
+		valSet.SetRandomness(seed)
+		valSet.SetWeights(weights)
+		return valSet, nil
 	}
 
-	if sb.config.ProposerPolicy == istanbul.ShuffledRoundRobin {
+	if sb.config.ProposerPolicy == istanbul.ShuffledRoundRobin || sb.config.ProposerPolicy == istanbul.StakeWeighted {
 		seed, err := sb.validatorRandomnessAtBlockNumber(number, hash)
 		if err != nil {
 			if err == comm_errors.ErrRegistryContractNotDeployed {
@@ -681,7 +867,7 @@ func (sb *Backend) getOrderedValidators(number uint64, hash common.Hash) istanbu
 		valSet.SetRandomness(seed)
 	}
 
-	return valSet
+	return valSet, nil
 }
 
 // GetCurrentHeadBlock retrieves the last block
@@ -726,19 +912,44 @@ func (sb *Backend) hasBadProposal(hash common.Hash) bool {
 }
 
 func (sb *Backend) addProxy(node, externalNode *enode.Node) error {
-	if sb.proxyNode != nil {
-		return errProxyAlreadySet
+	if err := sb.proxies.addProxy(node, externalNode); err != nil {
+		return err
 	}
-	sb.proxyNode = &proxyInfo{node: node, externalNode: externalNode}
 	sb.updateAnnounceVersion()
 	sb.p2pserver.AddPeer(node, p2p.ProxyPurpose)
 	return nil
 }
 
 func (sb *Backend) removeProxy(node *enode.Node) {
-	if sb.proxyNode != nil && sb.proxyNode.node.ID() == node.ID() {
-		sb.p2pserver.RemovePeer(node, p2p.ProxyPurpose)
-		sb.proxyNode = nil
+	proxy, primaryChanged := sb.proxies.removeProxy(node.ID())
+	if proxy == nil {
+		return
+	}
+	sb.p2pserver.RemovePeer(proxy.node, p2p.ProxyPurpose)
+	sb.proxyHealth.remove(proxy.node.ID())
+	if primaryChanged {
+		sb.onPrimaryProxyChanged()
+	}
+}
+
+// onPrimaryProxyChanged is called whenever a different proxy is promoted to
+// be the primary proxy because of a proxy being removed, or a proxy peer
+// connecting or disconnecting.
+// It updates the announce version so that the new primary proxy's external
+// enode is advertised to the other validators.
+func (sb *Backend) onPrimaryProxyChanged() {
+	primaryProxy := sb.proxies.getPrimaryProxy()
+	if primaryProxy == nil {
+		return
+	}
+	sb.logger.Info("Failing over to a new primary proxy", "proxy", primaryProxy.node.ID(), "externalNode", primaryProxy.externalNode.URLv4())
+
+	sb.announceMu.RLock()
+	announceRunning := sb.announceRunning
+	sb.announceMu.RUnlock()
+	if announceRunning {
+		// updateAnnounceVersion must be called from a goroutine other than the announce thread
+		go sb.updateAnnounceVersion()
 	}
 }
 
@@ -861,7 +1072,7 @@ func (sb *Backend) retrieveUncachedValidatorConnSet() (map[common.Address]bool,
 	if err != nil {
 		return nil, err
 	}
-	electNValidators, err := election.ElectNValidatorSigners(currentBlock.Header(), currentState, sb.config.AnnounceAdditionalValidatorsToGossip)
+	electNValidators, err := election.ElectNValidatorSigners(sb.systemCaller, currentBlock.Header(), currentState, sb.config.AnnounceAdditionalValidatorsToGossip)
 
 	// The validator contract may not be deployed yet.
 	// Even if it is deployed, it may not have any registered validators yet.
@@ -885,9 +1096,13 @@ func (sb *Backend) retrieveUncachedValidatorConnSet() (map[common.Address]bool,
 	return validatorsSet, nil
 }
 
+// sendForwardMsgToProxy will send a forward message to all the connected healthy proxies,
+// so that the message can still reach its destination if one of the proxies
+// is unavailable.
 func (sb *Backend) sendForwardMsgToProxy(finalDestAddresses []common.Address, ethMsgCode uint64, payload []byte) error {
 	logger := sb.logger.New("func", "sendForwardMsgToProxy")
-	if sb.proxyNode.peer == nil {
+	proxyPeers := sb.getForwardingProxyPeers()
+	if len(proxyPeers) == 0 {
 		logger.Warn("No connected proxy for sending a fwd message", "ethMsgCode", ethMsgCode, "finalDestAddreses", common.ConvertToStringSlice(finalDestAddresses))
 		return errNoProxyConnection
 	}
@@ -911,7 +1126,9 @@ func (sb *Backend) sendForwardMsgToProxy(finalDestAddresses []common.Address, et
 		return err
 	}
 
-	go sb.proxyNode.peer.Send(istanbul.FwdMsg, fwdMsgPayload)
+	for _, proxyPeer := range proxyPeers {
+		go proxyPeer.Send(istanbul.FwdMsg, fwdMsgPayload)
+	}
 
 	return nil
 }
@@ -19,13 +19,23 @@ package backend
 import (
 	"fmt"
 	"math/big"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/consensustest"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	vet "github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/slashing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestSign(t *testing.T) {
@@ -45,6 +55,76 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignSlashingProtection(t *testing.T) {
+	b := newBackend()
+	view := &istanbul.View{Sequence: big.NewInt(10), Round: big.NewInt(1)}
+	prepare := func(digest common.Hash) []byte {
+		encoded, _ := rlp.EncodeToBytes(&istanbul.Subject{View: view, Digest: digest})
+		msg := &istanbul.Message{Code: istanbul.MsgPrepare, Msg: encoded, Address: b.Address()}
+		payload, _ := msg.PayloadNoSig()
+		return payload
+	}
+	if _, err := b.Sign(prepare(common.HexToHash("0x01"))); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	// Signing the same PREPARE again is allowed, a conflicting one isn't
+	if _, err := b.Sign(prepare(common.HexToHash("0x01"))); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+	if _, err := b.Sign(prepare(common.HexToHash("0x02"))); err != slashing.ErrConflictingSignature {
+		t.Errorf("error mismatch: have %v, want %v", err, slashing.ErrConflictingSignature)
+	}
+	// Other data isn't checked
+	data := []byte("Here is a string....")
+	for i := 0; i < 2; i++ {
+		if _, err := b.Sign(data); err != nil {
+			t.Errorf("error mismatch: have %v, want nil", err)
+		}
+	}
+	// Committed seals are checked in the view of the sealed subject
+	if _, err := b.SignBLS([]byte("seal 1"), []byte{}, false, view); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+	if _, err := b.SignBLS([]byte("seal 2"), []byte{}, false, view); err != slashing.ErrConflictingSignature {
+		t.Errorf("error mismatch: have %v, want %v", err, slashing.ErrConflictingSignature)
+	}
+	otherView := &istanbul.View{Sequence: big.NewInt(10), Round: big.NewInt(2)}
+	if _, err := b.SignBLS([]byte("seal 2"), []byte{}, false, otherView); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+
+	// The slashing protection DB is only open while validating
+	if err := b.StopValidating(); err != nil {
+		t.Fatalf("failed to stop validating: %v", err)
+	}
+	if _, err := b.Sign(prepare(common.HexToHash("0x03"))); err != istanbul.ErrStoppedEngine {
+		t.Errorf("error mismatch: have %v, want %v", err, istanbul.ErrStoppedEngine)
+	}
+	if _, err := b.Sign(data); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+}
+
+func TestSignBLSInView(t *testing.T) {
+	b := newBackend()
+	key, _ := generatePrivateKey()
+	var sequence, round *big.Int
+	signBLSFn := func(account accounts.Account, data []byte, extra []byte, useComposite bool, seq, r *big.Int) (blscrypto.SerializedSignature, error) {
+		sequence, round = seq, r
+		return SignBLSFn(key)(account, data, extra, useComposite, seq, r)
+	}
+	b.Authorize(crypto.PubkeyToAddress(key.PublicKey), &key.PublicKey, decryptFn, SignFn(key), signBLSFn)
+
+	// The signer is given the view of the signed message, not the current view of the validator
+	view := &istanbul.View{Sequence: big.NewInt(10), Round: big.NewInt(1)}
+	if _, err := b.SignBLS([]byte("Here is a string...."), []byte{}, false, view); err != nil {
+		t.Fatalf("error mismatch: have %v, want nil", err)
+	}
+	if sequence == nil || sequence.Cmp(view.Sequence) != 0 || round == nil || round.Cmp(view.Round) != 0 {
+		t.Errorf("view mismatch: have sequence %v round %v, want %v", sequence, round, view)
+	}
+}
+
 func TestCheckSignature(t *testing.T) {
 	key, _ := generatePrivateKey()
 	data := []byte("Here is a string....")
@@ -179,9 +259,110 @@ func TestGetProposer(t *testing.T) {
 
 	block, _ := makeBlock(nodeKeys, chain, engine, chain.Genesis())
 	chain.InsertChain(types.Blocks{block})
-	expected := engine.AuthorForBlock(1)
+	expected := engine.AuthorForBlock(block.Hash(), 1)
 	actual := engine.Address()
 	if actual != expected {
 		t.Errorf("proposer mismatch: have %v, want %v, currentblock: %v", actual.Hex(), expected.Hex(), chain.CurrentBlock().Number())
 	}
 }
+
+// removalRecordingP2PServer is a mock P2P server recording the removed peers.
+type removalRecordingP2PServer struct {
+	consensustest.MockP2PServer
+	removed []*enode.Node
+}
+
+func (serv *removalRecordingP2PServer) RemovePeer(node *enode.Node, purpose p2p.PurposeFlag) {
+	serv.removed = append(serv.removed, node)
+}
+
+func TestPenalizeOverflowingValidator(t *testing.T) {
+	_, b := newBlockChain(1, true)
+
+	p2pserver := &removalRecordingP2PServer{MockP2PServer: *consensustest.NewMockP2PServer()}
+	b.SetP2PServer(p2pserver)
+
+	// A validator without a known enode has no peer to disconnect
+	b.PenalizeOverflowingValidator(getAddress())
+	if len(p2pserver.removed) != 0 {
+		t.Fatalf("removed peers mismatch: have %d, want %d", len(p2pserver.removed), 0)
+	}
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	node := enode.NewV4(&key.PublicKey, net.ParseIP("1.2.3.4"), 0, 0)
+	if err := b.valEnodeTable.UpsertVersionAndEnode([]*vet.AddressEntry{{Address: addr, Node: node, Version: 1}}); err != nil {
+		t.Fatalf("failed to add validator enode: %v", err)
+	}
+	b.PenalizeOverflowingValidator(addr)
+	if len(p2pserver.removed) != 1 || p2pserver.removed[0].ID() != node.ID() {
+		t.Fatalf("removed peers mismatch: have %v, want [%v]", p2pserver.removed, node)
+	}
+}
+
+// forkedChainReader is a chain reader over a canonical chain and the headers of its side chains.
+type forkedChainReader struct {
+	canonical map[uint64]*types.Header
+	headers   map[common.Hash]*types.Header
+}
+
+func (c *forkedChainReader) add(header *types.Header, canonical bool) {
+	c.headers[header.Hash()] = header
+	if canonical {
+		c.canonical[header.Number.Uint64()] = header
+	}
+}
+
+func (c *forkedChainReader) Config() *params.ChainConfig                           { return params.TestChainConfig }
+func (c *forkedChainReader) CurrentHeader() *types.Header                          { return nil }
+func (c *forkedChainReader) GetHeaderByHash(hash common.Hash) *types.Header        { return c.headers[hash] }
+func (c *forkedChainReader) GetBlock(hash common.Hash, number uint64) *types.Block { return nil }
+
+func (c *forkedChainReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *forkedChainReader) GetHeaderByNumber(number uint64) *types.Header {
+	return c.canonical[number]
+}
+
+func TestEpochBlockHeader(t *testing.T) {
+	chain := &forkedChainReader{canonical: make(map[uint64]*types.Header), headers: make(map[common.Hash]*types.Header)}
+	sb := &Backend{chain: chain, config: &istanbul.Config{Epoch: 4}}
+
+	// Create a canonical chain and a side chain forking off it before the epoch block 4
+	canonical := []*types.Header{{Number: big.NewInt(0)}}
+	for i := 1; i <= 6; i++ {
+		canonical = append(canonical, &types.Header{ParentHash: canonical[i-1].Hash(), Number: big.NewInt(int64(i))})
+		chain.add(canonical[i], true)
+	}
+	chain.add(canonical[0], true)
+	side := []*types.Header{canonical[2]}
+	for i := 3; i <= 6; i++ {
+		side = append(side, &types.Header{ParentHash: side[len(side)-1].Hash(), Number: big.NewInt(int64(i)), Extra: []byte{1}})
+		chain.add(side[len(side)-1], false)
+	}
+
+	tests := []struct {
+		header *types.Header
+		want   *types.Header
+	}{
+		{canonical[6], canonical[4]},
+		{canonical[4], canonical[4]},
+		{canonical[3], canonical[0]},
+		{side[4], side[2]},
+		{side[2], side[2]},
+		{side[1], canonical[0]},
+	}
+	for i, tt := range tests {
+		if have := sb.epochBlockHeader(tt.header.Number.Uint64(), tt.header.Hash()); have == nil || have.Hash() != tt.want.Hash() {
+			t.Errorf("test %d: epoch block mismatch: have %v, want %v", i, have, tt.want.Hash())
+		}
+	}
+	if have := sb.epochBlockHeader(6, common.HexToHash("0x01")); have != nil {
+		t.Errorf("epoch block of unknown block mismatch: have %v, want nil", have.Hash())
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	istanbulCore "github.com/ethereum/go-ethereum/consensus/istanbul/core"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	gpm "github.com/ethereum/go-ethereum/contract_comm/gasprice_minimum"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -40,10 +41,12 @@ import (
 )
 
 const (
-	inmemorySnapshots             = 128 // Number of recent vote snapshots to keep in memory
-	inmemoryPeers                 = 40
-	inmemoryMessages              = 1024
-	mobileAllowedClockSkew uint64 = 5
+	inmemorySnapshots              = 128 // Number of recent vote snapshots to keep in memory
+	inmemoryProposerWeights        = 8   // Number of recent epochs' stake weighted proposer weights to keep in memory
+	inmemoryEpochRewards           = 16  // Number of finalized epoch reward breakdowns to keep in memory until their block is written
+	inmemoryPeers                  = 40
+	inmemoryMessages               = 1024
+	mobileAllowedClockSkew  uint64 = 5
 )
 
 var (
@@ -73,6 +76,12 @@ var (
 	errInvalidAggregatedSeal = errors.New("invalid aggregated seal")
 	// errInvalidAggregatedSeal is returned if the aggregated seal is missing.
 	errEmptyAggregatedSeal = errors.New("empty aggregated seal")
+	// errInvalidEpochSnarkData is returned if the epoch validator set seal of a block doesn't
+	// verify against the validator sets of the epochs it ends and starts.
+	errInvalidEpochSnarkData = errors.New("invalid epoch snark data")
+	// errNotLastBlockOfEpoch is returned if epoch snark data is verified for a block which
+	// doesn't end an epoch.
+	errNotLastBlockOfEpoch = errors.New("not the last block of an epoch")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transactions hashes")
 	// errInvalidValidatorSetDiff is returned if the header contains invalid validator set diff
@@ -308,6 +317,65 @@ func (sb *Backend) verifyAggregatedSeal(headerHash common.Hash, validators istan
 	return nil
 }
 
+// VerifyEpochSnarkData checks that the epoch snark data of the last block of an epoch carries an
+// aggregated epoch validator set seal from a quorum of the validators of the epoch, over the
+// validator set of the next epoch. The parents are used the same way as in VerifyHeader, so
+// that the non contiguous epoch headers of a lightest sync can be verified before being inserted.
+func (sb *Backend) VerifyEpochSnarkData(chain consensus.ChainReader, header *types.Header, parents []*types.Header, epochSnarkData *types.EpochSnarkData) error {
+	number := header.Number.Uint64()
+	if number == 0 || !istanbul.IsLastBlockOfEpoch(number, sb.config.Epoch) {
+		return errNotLastBlockOfEpoch
+	}
+	if epochSnarkData == nil || epochSnarkData.Bitmap == nil || len(epochSnarkData.Signature) != types.IstanbulExtraBlsSignature {
+		return errInvalidEpochSnarkData
+	}
+
+	// The seal is made by the validators of the epoch ending with the header
+	snap, err := sb.snapshot(chain, number-1, header.ParentHash, parents)
+	if err != nil {
+		return err
+	}
+	validators := snap.ValSet
+
+	// The seal signs the epoch snark data of the next epoch, which is computed over the validator
+	// set resulting from the validator set diff of the header. The diff is applied directly rather
+	// than through the snapshots cached by number, which may have been computed from another header
+	// at the same height. The snapshot may be cached, so it's copied before being moved to the
+	// previous epoch block.
+	prevSnap := snap.copy()
+	prevSnap.Number = number - sb.config.Epoch
+	newSnap, err := prevSnap.apply([]*types.Header{header}, sb.db)
+	if err != nil {
+		return err
+	}
+	newValSet := newSnap.ValSet
+	blsPubKeys := make([]blscrypto.SerializedPublicKey, 0, newValSet.Size())
+	for _, v := range newValSet.List() {
+		blsPubKeys = append(blsPubKeys, v.BLSPublicKey())
+	}
+	maxNonSigners := uint32(newValSet.Size() - newValSet.MinQuorumSize())
+	epochData, err := blscrypto.EncodeEpochSnarkData(blsPubKeys, maxNonSigners, uint16(istanbul.GetEpochNumber(number, sb.config.Epoch)))
+	if err != nil {
+		return err
+	}
+
+	publicKeys := []blscrypto.SerializedPublicKey{}
+	for i := 0; i < validators.Size(); i++ {
+		if epochSnarkData.Bitmap.Bit(i) == 1 {
+			publicKeys = append(publicKeys, validators.GetByIndex(uint64(i)).BLSPublicKey())
+		}
+	}
+	if len(publicKeys) < validators.MinQuorumSize() {
+		sb.logger.Warn("Epoch snark data does not aggregate enough seals", "number", number, "numSeals", len(publicKeys), "minimum quorum size", validators.MinQuorumSize())
+		return errInsufficientSeals
+	}
+	if err := blscrypto.VerifyAggregatedSignature(publicKeys, epochData, []byte{}, epochSnarkData.Signature, true); err != nil {
+		sb.logger.Warn("Unable to verify epoch validator set seal", "number", number, "err", err)
+		return errInvalidEpochSnarkData
+	}
+	return nil
+}
+
 // VerifySeal checks whether the crypto seal on a header is valid according to
 // the consensus rules of the given engine.
 func (sb *Backend) VerifySeal(chain consensus.ChainReader, header *types.Header) error {
@@ -420,15 +488,19 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 
 	// Trigger an update to the gas price minimum in the GasPriceMinimum contract based on block congestion
 	snapshot = state.Snapshot()
-	_, err = gpm.UpdateGasPriceMinimum(header, state)
+	_, err = gpm.UpdateGasPriceMinimum(sb.systemCaller, header, state)
 	if err != nil {
 		state.RevertToSnapshot(snapshot)
 	}
 
 	lastBlockOfEpoch := istanbul.IsLastBlockOfEpoch(header.Number.Uint64(), sb.config.Epoch)
+	var rewards *istanbul.EpochRewards
 	if lastBlockOfEpoch {
 		snapshot = state.Snapshot()
-		err = sb.distributeEpochRewards(header, state)
+		distributionStart := time.Now()
+		epoch := istanbul.GetEpochNumber(header.Number.Uint64(), sb.config.Epoch)
+		rewards, err = sb.distributeEpochRewards(header, state, istanbul.GetValScoreTallyLastBlockNumber(epoch, sb.config.Epoch))
+		sb.rewardDistributionTimer.UpdateSince(distributionStart)
 		if err != nil {
 			sb.logger.Error("Failed to distribute epoch rewards", "blockNumber", header.Number, "err", err)
 			state.RevertToSnapshot(snapshot)
@@ -436,9 +508,22 @@ func (sb *Backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 	}
 
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	if rewards != nil {
+		// The block hash isn't known yet, so the rewards are kept until the block is written to the chain
+		sb.pendingEpochRewards.Add(header.Root, rewards)
+	}
 	logger.Debug("Finalized", "duration", now().Sub(start), "lastInEpoch", lastBlockOfEpoch)
 }
 
+// EpochRewards returns the breakdown of the epoch rewards distributed when finalizing the given last
+// block of an epoch, or nil if the block wasn't finalized by this engine.
+func (sb *Backend) EpochRewards(header *types.Header) *istanbul.EpochRewards {
+	if rewards, ok := sb.pendingEpochRewards.Get(header.Root); ok {
+		return rewards.(*istanbul.EpochRewards)
+	}
+	return nil
+}
+
 // FinalizeAndAssemble runs any post-transaction state modifications (e.g. block
 // rewards) and assembles the final block.
 //
@@ -477,6 +562,12 @@ func (sb *Backend) Seal(chain consensus.ChainReader, block *types.Block, results
 		return errUnauthorized
 	}
 
+	// Replicas leave proposing blocks to the primary
+	if !sb.IsPrimaryForSeq(header.Number) {
+		sb.logger.Trace("Not proposing block as a replica", "number", number)
+		return nil
+	}
+
 	parent := chain.GetHeader(header.ParentHash, number-1)
 	if parent == nil {
 		return consensus.ErrUnknownAncestor
@@ -557,6 +648,12 @@ func (sb *Backend) SetChain(chain consensus.ChainReader, currentBlock func() *ty
 	sb.stateAt = stateAt
 }
 
+// SetSystemCaller sets the caller used to make calls to the system contracts,
+// e.g. to elect validators and distribute epoch rewards.
+func (sb *Backend) SetSystemCaller(caller *contract_comm.SystemCaller) {
+	sb.systemCaller = caller
+}
+
 // StartValidating implements consensus.Istanbul.StartValidating
 func (sb *Backend) StartValidating(hasBadBlock func(common.Hash) bool,
 	processBlock func(*types.Block, *state.StateDB) (types.Receipts, []*types.Log, uint64, error),
@@ -579,7 +676,11 @@ func (sb *Backend) StartValidating(hasBadBlock func(common.Hash) bool,
 	sb.validateState = validateState
 
 	sb.logger.Info("Starting istanbul.Engine validating")
+	if err := sb.openSlashingProtection(); err != nil {
+		return err
+	}
 	if err := sb.core.Start(); err != nil {
+		sb.closeSlashingProtection()
 		return err
 	}
 
@@ -613,6 +714,9 @@ func (sb *Backend) StopValidating() error {
 	if err := sb.core.Stop(); err != nil {
 		return err
 	}
+	if err := sb.closeSlashingProtection(); err != nil {
+		sb.logger.Warn("Failed to close the slashing protection DB", "err", err)
+	}
 	sb.coreStarted = false
 
 	return nil
@@ -676,6 +780,7 @@ func (sb *Backend) StartProxyHandler() error {
 	}
 
 	go sb.valEnodesShareThread()
+	go sb.proxyHealthThread()
 
 	sb.proxyHandlerRunning = true
 
@@ -695,8 +800,11 @@ func (sb *Backend) StopProxyHandler() error {
 		sb.valEnodesShareThreadQuit <- struct{}{}
 		sb.valEnodesShareThreadWg.Wait()
 
-		if sb.proxyNode != nil {
-			sb.removeProxy(sb.proxyNode.node)
+		sb.proxyHealthThreadQuit <- struct{}{}
+		sb.proxyHealthThreadWg.Wait()
+
+		for _, proxy := range sb.proxies.getProxies() {
+			sb.removeProxy(proxy.node)
 		}
 	}
 
@@ -18,16 +18,20 @@ package backend
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"math/big"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -567,3 +571,90 @@ func TestWriteAggregatedSeal(t *testing.T) {
 		t.Errorf("error mismatch: have %v, want %v", err, errInvalidAggregatedSeal)
 	}
 }
+
+func TestVerifyEpochSnarkData(t *testing.T) {
+	numValidators := 4
+	genesisCfg, nodeKeys := getGenesisAndKeys(numValidators, true)
+	chain, engine := newBlockChainWithKeys(genesisCfg, nodeKeys)
+	genesis := chain.Genesis()
+
+	valSet := engine.getValidators(0, genesis.Hash())
+	valData := validator.MapValidatorsToData(valSet.List())
+	keys := make([]*ecdsa.PrivateKey, numValidators)
+	for i, v := range valSet.List() {
+		for _, key := range nodeKeys {
+			if crypto.PubkeyToAddress(key.PublicKey) == v.Address() {
+				keys[i] = key
+			}
+		}
+	}
+
+	// makeEpochHeader creates the last header of the first epoch, leaving the given validators
+	makeEpochHeader := func(newValData []istanbul.ValidatorData) *types.Header {
+		header := &types.Header{
+			ParentHash: common.HexToHash("0x01"),
+			Number:     new(big.Int).SetUint64(engine.EpochSize()),
+			Extra:      genesis.Extra(),
+		}
+		if err := writeValidatorSetDiff(header, valData, newValData); err != nil {
+			t.Fatalf("failed to write validator set diff: %v", err)
+		}
+		block, err := engine.updateBlock(nil, types.NewBlockWithHeader(header))
+		if err != nil {
+			t.Fatalf("failed to seal header: %v", err)
+		}
+		return block.Header()
+	}
+	// sealEpoch aggregates the epoch validator set seals of the given validators
+	sealEpoch := func(newValData []istanbul.ValidatorData, signers ...int) *types.EpochSnarkData {
+		blsPubKeys := []blscrypto.SerializedPublicKey{}
+		for _, v := range newValData {
+			blsPubKeys = append(blsPubKeys, v.BLSPublicKey)
+		}
+		newValSet := validator.NewSet(newValData)
+		maxNonSigners := uint32(newValSet.Size() - newValSet.MinQuorumSize())
+		data, err := blscrypto.EncodeEpochSnarkData(blsPubKeys, maxNonSigners, 1)
+		if err != nil {
+			t.Fatalf("failed to encode epoch snark data: %v", err)
+		}
+		bitmap, signatures := big.NewInt(0), [][]byte{}
+		for _, i := range signers {
+			sig, err := SignBLSFn(keys[i])(accounts.Account{}, data, []byte{}, true, nil, nil)
+			if err != nil {
+				t.Fatalf("failed to sign epoch snark data: %v", err)
+			}
+			bitmap.SetBit(bitmap, i, 1)
+			signatures = append(signatures, sig[:])
+		}
+		signature, err := blscrypto.AggregateSignatures(signatures)
+		if err != nil {
+			t.Fatalf("failed to aggregate signatures: %v", err)
+		}
+		return &types.EpochSnarkData{Bitmap: bitmap, Signature: signature}
+	}
+
+	header := makeEpochHeader(valData)
+	if err := engine.VerifyEpochSnarkData(chain, header, nil, sealEpoch(valData, 0, 1, 2)); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+	if err := engine.VerifyEpochSnarkData(chain, header, nil, sealEpoch(valData, 0, 1)); err != errInsufficientSeals {
+		t.Errorf("error mismatch: have %v, want %v", err, errInsufficientSeals)
+	}
+	if err := engine.VerifyEpochSnarkData(chain, header, nil, nil); err != errInvalidEpochSnarkData {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidEpochSnarkData)
+	}
+
+	// a forged validator set transition isn't sealed by the validators of the epoch
+	forged := makeEpochHeader(valData[:numValidators-1])
+	if err := engine.VerifyEpochSnarkData(chain, forged, nil, sealEpoch(valData, 0, 1, 2)); err != errInvalidEpochSnarkData {
+		t.Errorf("error mismatch: have %v, want %v", err, errInvalidEpochSnarkData)
+	}
+	if err := engine.VerifyEpochSnarkData(chain, forged, nil, sealEpoch(valData[:numValidators-1], 0, 1, 2)); err != nil {
+		t.Errorf("error mismatch: have %v, want nil", err)
+	}
+
+	// only the last block of an epoch carries epoch snark data
+	if err := engine.VerifyEpochSnarkData(chain, genesis.Header(), nil, sealEpoch(valData, 0, 1, 2)); err != errNotLastBlockOfEpoch {
+		t.Errorf("error mismatch: have %v, want %v", err, errNotLastBlockOfEpoch)
+	}
+}
@@ -54,16 +54,23 @@ func (sb *Backend) HandleMsg(addr common.Address, msg p2p.Msg, peer consensus.Pe
 	defer sb.coreMu.Unlock()
 
 	if istanbul.IsIstanbulMsg(msg) {
-		if (!sb.coreStarted && !sb.IsProxy()) && (msg.Code == istanbul.ConsensusMsg) {
-			return true, istanbul.ErrStoppedEngine
-		}
-
 		var data []byte
 		if err := msg.Decode(&data); err != nil {
 			logger.Error("Failed to decode message payload", "err", err, "from", addr)
 			return true, errDecodeFailed
 		}
 
+		// Proxy status messages are carried in consensus messages, and are exchanged
+		// between a proxied validator and its proxies whether or not the core is started
+		if msg.Code == istanbul.ConsensusMsg {
+			if handled, err := sb.handleProxyStatusMsg(peer, data); handled {
+				return true, err
+			}
+			if !sb.coreStarted && !sb.IsProxy() {
+				return true, istanbul.ErrStoppedEngine
+			}
+		}
+
 		if msg.Code == istanbul.DelegateSignMsg {
 			if sb.shouldHandleDelegateSign() {
 				go sb.delegateSignFeed.Send(istanbul.MessageEvent{Payload: data})
@@ -123,6 +130,18 @@ func (sb *Backend) handleConsensusMsg(peer consensus.Peer, payload []byte) error
 			go sb.proxiedPeer.Send(istanbul.ConsensusMsg, payload)
 		}
 	} else { // The case when this node is a validator
+		// A proxied validator can receive the same message through each of its proxies,
+		// so only post the first copy.
+		if sb.IsProxiedValidator() {
+			if sb.proxies.getProxy(peer.Node().ID()) != nil {
+				sb.proxyHealth.recordRelayedMsg(peer.Node().ID(), istanbul.RLPHash(payload), time.Now())
+			}
+			if sb.checkIfMessageProcessedBySelf(payload) {
+				return nil
+			}
+			sb.markMessageProcessedBySelf(payload)
+		}
+
 		go sb.istanbulEventMux.Post(istanbul.MessageEvent{
 			Payload: payload,
 		})
@@ -280,6 +299,11 @@ func (sb *Backend) NewChainHead(newBlock *types.Block) {
 	// Update metrics for whether we were elected and signed the parent of this block.
 	sb.UpdateMetricsForParentOfBlock(newBlock)
 
+	// Apply the role switches of a primary or replica scheduled for the next block
+	if sb.replicaState.newChainHead(newBlock.Number()) {
+		sb.announceReplicaSwitch()
+	}
+
 	// If this is the last block of the epoch:
 	// * Print an easy to find log message giving our address and whether we're elected in next epoch.
 	// * if this is a proxy or a non proxied validator, refresh the validator enode table.
@@ -316,21 +340,20 @@ func (sb *Backend) RegisterPeer(peer consensus.Peer, isProxiedPeer bool) {
 	if sb.config.Proxy && isProxiedPeer {
 		sb.proxiedPeer = peer
 	} else if sb.config.Proxied {
-		if sb.proxyNode != nil && peer.Node().ID() == sb.proxyNode.node.ID() {
-			sb.proxyNode.peer = peer
-			// Share this node's enodeCertificate for the proxy to use for handshakes
-			enodeCertificateMsg, err := sb.retrieveEnodeCertificateMsg()
-			if err != nil {
-				logger.Warn("Error getting enode certificate message", "err", err)
-			} else if enodeCertificateMsg != nil {
+		if isProxy, primaryChanged := sb.proxies.setProxyPeer(peer.Node().ID(), peer); isProxy {
+			// Share the enodeCertificate generated for this proxy for it to use for handshakes
+			if enodeCertificateMsg := sb.retrieveProxyEnodeCertificateMsg(peer.Node().ID()); enodeCertificateMsg != nil {
 				if err := sb.sendEnodeCertificateMsg(peer, enodeCertificateMsg); err != nil {
 					logger.Warn("Error sending enode certificate message to proxy peer", "err", err)
 				}
 			}
 			// Share the whole val enode table
-			if err := sb.sendValEnodesShareMsg(); err != nil {
+			if err := sb.sendValEnodesShareMsg(peer); err != nil {
 				logger.Warn("Error sending val enodes share message to proxy peer", "err", err)
 			}
+			if primaryChanged {
+				sb.onPrimaryProxyChanged()
+			}
 		} else {
 			logger.Error("Unauthorized connected peer to the proxied validator", "peer", peer.Node().ID())
 		}
@@ -345,8 +368,8 @@ func (sb *Backend) UnregisterPeer(peer consensus.Peer, isProxiedPeer bool) {
 	if sb.config.Proxy && isProxiedPeer && reflect.DeepEqual(sb.proxiedPeer, peer) {
 		sb.proxiedPeer = nil
 	} else if sb.config.Proxied {
-		if sb.proxyNode != nil && peer.Node().ID() == sb.proxyNode.node.ID() {
-			sb.proxyNode.peer = nil
+		if primaryChanged := sb.proxies.removeProxyPeer(peer.Node().ID()); primaryChanged {
+			sb.onPrimaryProxyChanged()
 		}
 	}
 }
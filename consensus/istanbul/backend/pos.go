@@ -20,11 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
-	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/currency"
 	"github.com/ethereum/go-ethereum/contract_comm/election"
 	"github.com/ethereum/go-ethereum/contract_comm/epoch_rewards"
@@ -39,42 +37,45 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.StateDB) error {
-	start := time.Now()
-	defer sb.rewardDistributionTimer.UpdateSince(start)
+// distributeEpochRewards distributes the validator, voter, community and carbon offsetting rewards
+// of the epoch ending with the given header, and returns the breakdown of what was minted.
+// The uptime scores of the validators are tallied up to tallyLastBlock.
+func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.StateDB, tallyLastBlock uint64) (*istanbul.EpochRewards, error) {
 	logger := sb.logger.New("func", "Backend.distributeEpochPaymentsAndRewards", "blocknum", header.Number.Uint64())
+	rewards := &istanbul.EpochRewards{Epoch: istanbul.GetEpochNumber(header.Number.Uint64(), sb.EpochSize())}
 
 	// Check if reward distribution has been frozen and return early without error if it is.
-	if frozen, err := freezer.IsFrozen(params.EpochRewardsRegistryId, header, state); err != nil {
+	if frozen, err := freezer.IsFrozen(sb.systemCaller, params.EpochRewardsRegistryId, header, state); err != nil {
 		logger.Warn("Failed to determine if epoch rewards are frozen", "err", err)
 	} else if frozen {
 		logger.Debug("Epoch rewards are frozen, skipping distribution")
-		return nil
+		rewards.Frozen = true
+		return rewards, nil
 	}
 
 	// Get necessary Addresses First
-	reserveAddress, err := contract_comm.GetRegisteredAddress(params.ReserveRegistryId, header, state)
+	reserveAddress, err := sb.systemCaller.GetRegisteredAddress(params.ReserveRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	stableTokenAddress, err := contract_comm.GetRegisteredAddress(params.StableTokenRegistryId, header, state)
+	stableTokenAddress, err := sb.systemCaller.GetRegisteredAddress(params.StableTokenRegistryId, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	carbonOffsettingPartnerAddress, err := epoch_rewards.GetCarbonOffsettingPartnerAddress(header, state)
+	carbonOffsettingPartnerAddress, err := epoch_rewards.GetCarbonOffsettingPartnerAddress(sb.systemCaller, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = epoch_rewards.UpdateTargetVotingYield(header, state)
+	err = epoch_rewards.UpdateTargetVotingYield(sb.systemCaller, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	validatorReward, totalVoterRewards, communityReward, carbonOffsettingPartnerReward, err := epoch_rewards.CalculateTargetEpochRewards(header, state)
+	validatorReward, totalVoterRewards, communityReward, carbonOffsettingPartnerReward, err := epoch_rewards.CalculateTargetEpochRewards(sb.systemCaller, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if carbonOffsettingPartnerAddress == common.ZeroAddress {
@@ -90,53 +91,88 @@ func (sb *Backend) distributeEpochRewards(header *types.Header, state *state.Sta
 
 		err := errors.New("Unable to fetch validator set to update scores and distribute rewards")
 		logger.Error(err.Error())
-		return err
+		return nil, err
 	}
 
-	uptimes, err := sb.updateValidatorScores(header, state, valSet)
+	uptimes, err := sb.updateValidatorScores(header, state, valSet, tallyLastBlock)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	totalValidatorRewards, err := sb.distributeValidatorRewards(header, state, valSet, validatorReward)
+	totalValidatorRewards, err := sb.distributeValidatorRewards(header, state, valSet, validatorReward, uptimes, rewards)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validator rewards were paid in cUSD, convert that amount to cGLD and add it to the Reserve
-	totalValidatorRewardsConvertedToGold, err := currency.Convert(totalValidatorRewards, stableTokenAddress, nil)
+	totalValidatorRewardsConvertedToGold, err := currency.Convert(sb.systemCaller, totalValidatorRewards, stableTokenAddress, nil, header, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err = gold_token.Mint(header, state, *reserveAddress, totalValidatorRewardsConvertedToGold); err != nil {
-		return err
+	if err = gold_token.Mint(sb.systemCaller, header, state, *reserveAddress, totalValidatorRewardsConvertedToGold); err != nil {
+		return nil, err
 	}
+	rewards.Mints = append(rewards.Mints, istanbul.EpochRewardMint{Beneficiary: *reserveAddress, Purpose: istanbul.ValidatorRewardsMint, Amount: totalValidatorRewardsConvertedToGold})
 
-	if err := sb.distributeCommunityRewards(header, state, communityReward); err != nil {
-		return err
+	if err := sb.distributeCommunityRewards(header, state, communityReward, rewards); err != nil {
+		return nil, err
 	}
 
-	if err := sb.distributeVoterRewards(header, state, valSet, totalVoterRewards, uptimes); err != nil {
-		return err
+	if err := sb.distributeVoterRewards(header, state, valSet, totalVoterRewards, uptimes, rewards); err != nil {
+		return nil, err
 	}
 
 	if carbonOffsettingPartnerReward.Cmp(new(big.Int)) != 0 {
-		if err = gold_token.Mint(header, state, carbonOffsettingPartnerAddress, carbonOffsettingPartnerReward); err != nil {
-			return err
+		if err = gold_token.Mint(sb.systemCaller, header, state, carbonOffsettingPartnerAddress, carbonOffsettingPartnerReward); err != nil {
+			return nil, err
 		}
+		rewards.Mints = append(rewards.Mints, istanbul.EpochRewardMint{Beneficiary: carbonOffsettingPartnerAddress, Purpose: istanbul.CarbonOffsettingMint, Amount: carbonOffsettingPartnerReward})
 	}
 
-	return nil
+	return rewards, nil
+}
+
+// previewEpochRewards simulates the distribution of the current epoch's rewards on top of the current
+// head, as if the pending block was the last block of the epoch, with the uptime accumulated so far.
+func (sb *Backend) previewEpochRewards() (*istanbul.EpochRewards, error) {
+	head := sb.currentBlock()
+	if head == nil {
+		return nil, errNoBlockHeader
+	}
+	state, err := sb.stateAt(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number(), common.Big1),
+		Time:       uint64(now().Unix()),
+		Coinbase:   sb.address,
+	}
+
+	// Uptime scores are tallied up to the current head
+	epoch := istanbul.GetEpochNumber(header.Number.Uint64(), sb.EpochSize())
+	tallyLastBlock := istanbul.GetValScoreTallyLastBlockNumber(epoch, sb.EpochSize())
+	if head.NumberU64() < tallyLastBlock {
+		tallyLastBlock = head.NumberU64()
+	}
+	return sb.distributeEpochRewards(header, state, tallyLastBlock)
 }
 
-func (sb *Backend) updateValidatorScores(header *types.Header, state *state.StateDB, valSet []istanbul.Validator) ([]*big.Int, error) {
+func (sb *Backend) updateValidatorScores(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, tallyLastBlock uint64) ([]*big.Int, error) {
 	epoch := istanbul.GetEpochNumber(header.Number.Uint64(), sb.EpochSize())
 	logger := sb.logger.New("func", "Backend.updateValidatorScores", "blocknum", header.Number.Uint64(), "epoch", epoch, "epochsize", sb.EpochSize(), "window", sb.LookbackWindow())
 	logger.Trace("Updating validator scores")
 
 	// The denominator is the (last block - first block + 1) of the val score tally window
-	denominator := istanbul.GetValScoreTallyLastBlockNumber(epoch, sb.EpochSize()) - istanbul.GetValScoreTallyFirstBlockNumber(epoch, sb.EpochSize(), sb.LookbackWindow()) + 1
+	tallyFirstBlock := istanbul.GetValScoreTallyFirstBlockNumber(epoch, sb.EpochSize(), sb.LookbackWindow())
+	if tallyLastBlock < tallyFirstBlock {
+		err := fmt.Errorf("Val score tally window of epoch %d starts at block %d", epoch, tallyFirstBlock)
+		logger.Error(err.Error())
+		return nil, err
+	}
+	denominator := tallyLastBlock - tallyFirstBlock + 1
 
 	uptimes := make([]*big.Int, 0, len(valSet))
 	accumulated := rawdb.ReadAccumulatedEpochUptime(sb.db, epoch)
@@ -171,7 +207,7 @@ func (sb *Backend) updateValidatorScores(header *types.Header, state *state.Stat
 	for i, val := range valSet {
 		val_logger := logger.New("uptime", uptimes[i], "address", val.Address())
 		val_logger.Trace("Updating validator score")
-		err := validators.UpdateValidatorScore(header, state, val.Address(), uptimes[i])
+		err := validators.UpdateValidatorScore(sb.systemCaller, header, state, val.Address(), uptimes[i])
 		if err != nil {
 			return nil, err
 		}
@@ -179,46 +215,54 @@ func (sb *Backend) updateValidatorScores(header *types.Header, state *state.Stat
 	return uptimes, nil
 }
 
-func (sb *Backend) distributeValidatorRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxReward *big.Int) (*big.Int, error) {
+func (sb *Backend) distributeValidatorRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxReward *big.Int, uptimes []*big.Int, rewards *istanbul.EpochRewards) (*big.Int, error) {
 	totalValidatorRewards := big.NewInt(0)
-	for _, val := range valSet {
+	for i, val := range valSet {
 		sb.logger.Debug("Distributing epoch reward for validator", "address", val.Address())
-		validatorReward, err := validators.DistributeEpochReward(header, state, val.Address(), maxReward)
+		validatorReward, err := validators.DistributeEpochReward(sb.systemCaller, header, state, val.Address(), maxReward)
 		if err != nil {
 			sb.logger.Error("Error in distributing rewards to validator", "address", val.Address(), "err", err)
 			continue
 		}
 		totalValidatorRewards.Add(totalValidatorRewards, validatorReward)
+		rewards.Validators = append(rewards.Validators, istanbul.ValidatorEpochReward{Validator: val.Address(), Uptime: uptimes[i], Payment: validatorReward})
 	}
 	return totalValidatorRewards, nil
 }
 
-func (sb *Backend) distributeCommunityRewards(header *types.Header, state *state.StateDB, communityReward *big.Int) error {
-	governanceAddress, err := contract_comm.GetRegisteredAddress(params.GovernanceRegistryId, header, state)
+func (sb *Backend) distributeCommunityRewards(header *types.Header, state *state.StateDB, communityReward *big.Int, rewards *istanbul.EpochRewards) error {
+	governanceAddress, err := sb.systemCaller.GetRegisteredAddress(params.GovernanceRegistryId, header, state)
 	if err != nil {
 		return err
 	}
-	reserveAddress, err := contract_comm.GetRegisteredAddress(params.ReserveRegistryId, header, state)
+	reserveAddress, err := sb.systemCaller.GetRegisteredAddress(params.ReserveRegistryId, header, state)
 	if err != nil {
 		return err
 	}
-	lowReserve, err := epoch_rewards.IsReserveLow(header, state)
+	lowReserve, err := epoch_rewards.IsReserveLow(sb.systemCaller, header, state)
 	if err != nil {
 		return err
 	}
 
+	var beneficiary *common.Address
 	if lowReserve && reserveAddress != nil {
-		return gold_token.Mint(header, state, *reserveAddress, communityReward)
+		beneficiary = reserveAddress
 	} else if governanceAddress != nil {
 		// TODO: How to split eco fund here
-		return gold_token.Mint(header, state, *governanceAddress, communityReward)
+		beneficiary = governanceAddress
+	} else {
+		return nil
+	}
+	if err := gold_token.Mint(sb.systemCaller, header, state, *beneficiary, communityReward); err != nil {
+		return err
 	}
+	rewards.Mints = append(rewards.Mints, istanbul.EpochRewardMint{Beneficiary: *beneficiary, Purpose: istanbul.CommunityRewardMint, Amount: communityReward})
 	return nil
 }
 
-func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxTotalRewards *big.Int, uptimes []*big.Int) error {
+func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.StateDB, valSet []istanbul.Validator, maxTotalRewards *big.Int, uptimes []*big.Int, rewards *istanbul.EpochRewards) error {
 
-	lockedGoldAddress, err := contract_comm.GetRegisteredAddress(params.LockedGoldRegistryId, header, state)
+	lockedGoldAddress, err := sb.systemCaller.GetRegisteredAddress(params.LockedGoldRegistryId, header, state)
 	if err != nil {
 		return err
 	} else if lockedGoldAddress == nil {
@@ -229,11 +273,13 @@ func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.Sta
 	var groups []common.Address
 	groupUptimes := make(map[common.Address][]*big.Int)
 	groupElectedValidator := make(map[common.Address]bool)
+	validatorGroups := make(map[common.Address]common.Address)
 	for i, val := range valSet {
-		group, err := validators.GetMembershipInLastEpoch(header, state, val.Address())
+		group, err := validators.GetMembershipInLastEpoch(sb.systemCaller, header, state, val.Address())
 		if err != nil {
 			return err
 		}
+		validatorGroups[val.Address()] = group
 		if _, ok := groupElectedValidator[group]; !ok {
 			groups = append(groups, group)
 			sb.logger.Debug("Group elected validator", "group", group.String())
@@ -242,16 +288,26 @@ func (sb *Backend) distributeVoterRewards(header *types.Header, state *state.Sta
 		groupUptimes[group] = append(groupUptimes[group], uptimes[i])
 	}
 
-	electionRewards, err := election.DistributeEpochRewards(header, state, groups, maxTotalRewards, groupUptimes)
+	groupRewards, electionRewards, err := election.DistributeEpochRewards(sb.systemCaller, header, state, groups, maxTotalRewards, groupUptimes)
 	if err != nil {
 		return err
 	}
+	for i := range rewards.Validators {
+		rewards.Validators[i].Group = validatorGroups[rewards.Validators[i].Validator]
+	}
+	for i, group := range groups {
+		rewards.Groups = append(rewards.Groups, istanbul.GroupEpochReward{Group: group, Reward: groupRewards[i]})
+	}
 
-	return gold_token.Mint(header, state, *lockedGoldAddress, electionRewards)
+	if err := gold_token.Mint(sb.systemCaller, header, state, *lockedGoldAddress, electionRewards); err != nil {
+		return err
+	}
+	rewards.Mints = append(rewards.Mints, istanbul.EpochRewardMint{Beneficiary: *lockedGoldAddress, Purpose: istanbul.VoterRewardsMint, Amount: electionRewards})
+	return nil
 }
 
 func (sb *Backend) setInitialGoldTokenTotalSupplyIfUnset(header *types.Header, state *state.StateDB) error {
-	totalSupply, err := gold_token.GetTotalSupply(header, state)
+	totalSupply, err := gold_token.GetTotalSupply(sb.systemCaller, header, state)
 	if err != nil {
 		return err
 	}
@@ -265,7 +321,7 @@ func (sb *Backend) setInitialGoldTokenTotalSupplyIfUnset(header *types.Header, s
 		genesisSupply := new(big.Int)
 		genesisSupply.SetBytes(data)
 
-		err = gold_token.IncreaseSupply(header, state, genesisSupply)
+		err = gold_token.IncreaseSupply(sb.systemCaller, header, state, genesisSupply)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,422 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// proxyHealthCheckInterval is how often a proxied validator pings its proxies
+	// and re-evaluates which proxy should be its primary proxy
+	proxyHealthCheckInterval = 15 * time.Second
+
+	// relayedMsgWindow is how long after a consensus message is first received
+	// through any proxy that every other connected proxy has to relay it too
+	// before it is counted as dropped by that proxy
+	relayedMsgWindow = 5 * time.Second
+
+	// maxPendingRelayedMsgs bounds the number of consensus messages tracked
+	// at any time for computing relay latencies and drop rates
+	maxPendingRelayedMsgs = 2048
+
+	// proxyHealthEWMAWeight is the weight of the newest sample in all of the
+	// exponentially weighted moving averages kept for each proxy
+	proxyHealthEWMAWeight = 0.2
+
+	// minScoredRelayedMsgs is the minimum number of relayed or dropped messages
+	// a proxy needs to be accounted for before its score is used to promote or
+	// demote it
+	minScoredRelayedMsgs = 10
+
+	// minHealthyProxyScore is the score below which a scored proxy is considered
+	// unhealthy. Unhealthy proxies are not sent forward messages (unless all
+	// connected proxies are unhealthy) and can't be the primary proxy.
+	minHealthyProxyScore = 0.5
+
+	// primaryProxyScoreMargin is how much higher a proxy's score must be than
+	// the healthy primary proxy's score for it to replace the primary proxy.
+	// Prevents flapping between proxies with similar scores.
+	primaryProxyScoreMargin = 0.1
+
+	// proxyLatencyScoreScale is the relay latency at which a proxy's score is halved
+	proxyLatencyScoreScale = 250 * time.Millisecond
+
+	// proxyTargetPeerCount is the peer count at or above which a proxy's peer
+	// count no longer lowers its score
+	proxyTargetPeerCount = 10
+)
+
+// proxyStatus is the content of an istanbul.ProxyStatusMsg message.  A proxied validator
+// sends a status request to each of its proxies, and the proxies reply with
+// the request's timestamp and their own peer count.
+type proxyStatus struct {
+	Timestamp  uint64 // Unix time in nanoseconds the request was sent at
+	PeerCount  uint64
+	IsResponse bool
+}
+
+// EncodeRLP serializes ps into the Ethereum RLP format.
+func (ps *proxyStatus) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{ps.Timestamp, ps.PeerCount, ps.IsResponse})
+}
+
+// DecodeRLP implements rlp.Decoder, and load the ps fields from a RLP stream.
+func (ps *proxyStatus) DecodeRLP(s *rlp.Stream) error {
+	var msg struct {
+		Timestamp  uint64
+		PeerCount  uint64
+		IsResponse bool
+	}
+
+	if err := s.Decode(&msg); err != nil {
+		return err
+	}
+	ps.Timestamp, ps.PeerCount, ps.IsResponse = msg.Timestamp, msg.PeerCount, msg.IsResponse
+	return nil
+}
+
+// proxyHealth holds the health measurements of a single proxy
+type proxyHealth struct {
+	pingRTT      time.Duration // Moving average of the proxy status round trip time
+	relayLatency time.Duration // Moving average of the delay of relayed messages relative to the fastest proxy
+	dropRate     float64       // Moving average of the fraction of messages not relayed by this proxy
+	peerCount    uint64        // Peer count reported in the last proxy status response
+	hasStatus    bool          // Whether a proxy status response was ever received
+
+	relayedMsgs uint64 // Total number of consensus messages relayed by this proxy
+	droppedMsgs uint64 // Total number of consensus messages relayed by another proxy but not this one
+}
+
+// scored returns whether enough messages were accounted for this proxy for its score to be meaningful
+func (ph *proxyHealth) scored() bool {
+	return ph.relayedMsgs+ph.droppedMsgs >= minScoredRelayedMsgs
+}
+
+// score returns a value in [0, 1] that summarizes the health of a proxy.  It is
+// the product of the fraction of messages it relays, a latency factor which halves
+// with every proxyLatencyScoreScale of relay latency, and a peer count factor.
+func (ph *proxyHealth) score() float64 {
+	latency := ph.relayLatency + ph.pingRTT/2
+	latencyFactor := 1 / (1 + float64(latency)/float64(proxyLatencyScoreScale))
+	peerCountFactor := 1.0
+	if ph.hasStatus {
+		peerCountFactor = math.Min(float64(ph.peerCount), proxyTargetPeerCount) / proxyTargetPeerCount
+	}
+	return (1 - ph.dropRate) * latencyFactor * peerCountFactor
+}
+
+// healthy returns false if the proxy is scored and its score is too low
+func (ph *proxyHealth) healthy() bool {
+	return !ph.scored() || ph.score() >= minHealthyProxyScore
+}
+
+type relayedMsg struct {
+	firstSeen   time.Time
+	deliveredBy map[enode.ID]bool
+}
+
+// proxyHealthTracker measures the health of each proxy of a proxied validator
+type proxyHealthTracker struct {
+	health  map[enode.ID]*proxyHealth
+	pending map[common.Hash]*relayedMsg
+	mu      sync.Mutex
+}
+
+func newProxyHealthTracker() *proxyHealthTracker {
+	return &proxyHealthTracker{
+		health:  make(map[enode.ID]*proxyHealth),
+		pending: make(map[common.Hash]*relayedMsg),
+	}
+}
+
+func ewmaDuration(avg, sample time.Duration) time.Duration {
+	return time.Duration(proxyHealthEWMAWeight*float64(sample) + (1-proxyHealthEWMAWeight)*float64(avg))
+}
+
+// getOrCreate returns the health of a proxy.  Must be called with pht.mu held.
+func (pht *proxyHealthTracker) getOrCreate(proxyID enode.ID) *proxyHealth {
+	ph, ok := pht.health[proxyID]
+	if !ok {
+		ph = &proxyHealth{}
+		pht.health[proxyID] = ph
+	}
+	return ph
+}
+
+// recordRelayedMsg records that a consensus message was received through a proxy
+func (pht *proxyHealthTracker) recordRelayedMsg(proxyID enode.ID, msgHash common.Hash, now time.Time) {
+	pht.mu.Lock()
+	defer pht.mu.Unlock()
+
+	ph := pht.getOrCreate(proxyID)
+	if msg, ok := pht.pending[msgHash]; ok {
+		if msg.deliveredBy[proxyID] {
+			return
+		}
+		msg.deliveredBy[proxyID] = true
+		ph.relayLatency = ewmaDuration(ph.relayLatency, now.Sub(msg.firstSeen))
+		return
+	}
+	if len(pht.pending) >= maxPendingRelayedMsgs {
+		return
+	}
+	pht.pending[msgHash] = &relayedMsg{
+		firstSeen:   now,
+		deliveredBy: map[enode.ID]bool{proxyID: true},
+	}
+	ph.relayLatency = ewmaDuration(ph.relayLatency, 0)
+}
+
+// recordStatus records a proxy status response
+func (pht *proxyHealthTracker) recordStatus(proxyID enode.ID, rtt time.Duration, peerCount uint64) {
+	pht.mu.Lock()
+	defer pht.mu.Unlock()
+
+	ph := pht.getOrCreate(proxyID)
+	if ph.hasStatus {
+		ph.pingRTT = ewmaDuration(ph.pingRTT, rtt)
+	} else {
+		ph.pingRTT = rtt
+	}
+	ph.peerCount = peerCount
+	ph.hasStatus = true
+}
+
+// evaluate accounts every pending message that is older than relayedMsgWindow
+// as either relayed or dropped by each of the given connected proxies, and
+// updates their drop rates.
+func (pht *proxyHealthTracker) evaluate(connectedProxyIDs []enode.ID, now time.Time) {
+	pht.mu.Lock()
+	defer pht.mu.Unlock()
+
+	relayed := make(map[enode.ID]uint64)
+	dropped := make(map[enode.ID]uint64)
+	for hash, msg := range pht.pending {
+		if now.Sub(msg.firstSeen) < relayedMsgWindow {
+			continue
+		}
+		for _, id := range connectedProxyIDs {
+			if msg.deliveredBy[id] {
+				relayed[id]++
+			} else {
+				dropped[id]++
+			}
+		}
+		delete(pht.pending, hash)
+	}
+	for _, id := range connectedProxyIDs {
+		total := relayed[id] + dropped[id]
+		if total == 0 {
+			continue
+		}
+		ph := pht.getOrCreate(id)
+		ph.relayedMsgs += relayed[id]
+		ph.droppedMsgs += dropped[id]
+		ph.dropRate = proxyHealthEWMAWeight*float64(dropped[id])/float64(total) + (1-proxyHealthEWMAWeight)*ph.dropRate
+	}
+}
+
+// getHealth returns a copy of the health of a proxy, or nil if nothing was measured for it yet
+func (pht *proxyHealthTracker) getHealth(proxyID enode.ID) *proxyHealth {
+	pht.mu.Lock()
+	defer pht.mu.Unlock()
+
+	if ph, ok := pht.health[proxyID]; ok {
+		phCopy := *ph
+		return &phCopy
+	}
+	return nil
+}
+
+// remove discards all measurements for a proxy
+func (pht *proxyHealthTracker) remove(proxyID enode.ID) {
+	pht.mu.Lock()
+	defer pht.mu.Unlock()
+
+	delete(pht.health, proxyID)
+}
+
+// This function is meant to be run as a goroutine.  It will periodically send proxy status
+// requests to this node's proxies, evaluate their health, and rotate the primary proxy
+// when a healthier one is available.
+func (sb *Backend) proxyHealthThread() {
+	sb.proxyHealthThreadWg.Add(1)
+	defer sb.proxyHealthThreadWg.Done()
+
+	ticker := time.NewTicker(proxyHealthCheckInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+			sb.sendProxyStatusRequests()
+			sb.evaluateProxyHealth()
+
+		case <-sb.proxyHealthThreadQuit:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// sendProxyStatusRequests sends a proxy status request to every connected proxy.
+func (sb *Backend) sendProxyStatusRequests() {
+	logger := sb.logger.New("func", "sendProxyStatusRequests")
+	payload, err := sb.encodeProxyStatusMsg(&proxyStatus{Timestamp: uint64(time.Now().UnixNano())})
+	if err != nil {
+		logger.Error("Error encoding proxy status request", "err", err)
+		return
+	}
+	for _, proxyPeer := range sb.proxies.getConnectedProxyPeers() {
+		go proxyPeer.Send(istanbul.ConsensusMsg, payload)
+	}
+}
+
+// encodeProxyStatusMsg wraps a proxy status in an istanbul.Message to be sent as a
+// consensus message.  Like forward messages, it is not signed since it's only
+// exchanged between a proxied validator and its proxies.
+func (sb *Backend) encodeProxyStatusMsg(status *proxyStatus) ([]byte, error) {
+	statusBytes, err := rlp.EncodeToBytes(status)
+	if err != nil {
+		return nil, err
+	}
+	msg := &istanbul.Message{
+		Code:      istanbul.ProxyStatusMsg,
+		Msg:       statusBytes,
+		Address:   sb.Address(),
+		Signature: []byte{},
+	}
+	return msg.Payload()
+}
+
+// evaluateProxyHealth updates the health of each connected proxy and promotes
+// the healthiest one to be the primary proxy if the current primary proxy is
+// disconnected, unhealthy, or scores significantly lower.
+func (sb *Backend) evaluateProxyHealth() {
+	proxies := sb.proxies.getProxies()
+	connectedProxyIDs := make([]enode.ID, 0, len(proxies))
+	for _, proxy := range proxies {
+		if proxy.peer != nil {
+			connectedProxyIDs = append(connectedProxyIDs, proxy.node.ID())
+		}
+	}
+	sb.proxyHealth.evaluate(connectedProxyIDs, time.Now())
+
+	// A primary proxy that is disconnected, unhealthy or not scored yet is replaced by
+	// the best scored and healthy proxy.  A healthy one is only replaced by a proxy
+	// that scores significantly higher.
+	primaryScore := -1.0
+	if primaryProxy := sb.proxies.getPrimaryProxy(); primaryProxy != nil && primaryProxy.peer != nil {
+		if ph := sb.proxyHealth.getHealth(primaryProxy.node.ID()); ph != nil && ph.scored() && ph.healthy() {
+			primaryScore = ph.score() + primaryProxyScoreMargin
+		}
+	}
+
+	var bestID *enode.ID
+	bestScore := primaryScore
+	for i := range connectedProxyIDs {
+		ph := sb.proxyHealth.getHealth(connectedProxyIDs[i])
+		if ph == nil || !ph.scored() || !ph.healthy() {
+			continue
+		}
+		if score := ph.score(); score > bestScore {
+			bestID, bestScore = &connectedProxyIDs[i], score
+		}
+	}
+	if bestID != nil && sb.proxies.setPrimary(*bestID) {
+		sb.onPrimaryProxyChanged()
+	}
+}
+
+// getForwardingProxyPeers returns the connected proxy peers that forward messages
+// should be sent to.  Unhealthy proxies are left out, unless every connected
+// proxy is unhealthy.
+func (sb *Backend) getForwardingProxyPeers() []consensus.Peer {
+	proxyPeers := sb.proxies.getConnectedProxyPeers()
+	healthyProxyPeers := make([]consensus.Peer, 0, len(proxyPeers))
+	for _, proxyPeer := range proxyPeers {
+		if ph := sb.proxyHealth.getHealth(proxyPeer.Node().ID()); ph == nil || ph.healthy() {
+			healthyProxyPeers = append(healthyProxyPeers, proxyPeer)
+		}
+	}
+	if len(healthyProxyPeers) == 0 {
+		return proxyPeers
+	}
+	return healthyProxyPeers
+}
+
+// handleProxyStatusMsg handles a consensus message if it is a proxy status message
+// exchanged between this node and its proxied validator or one of its proxies, and
+// returns whether it did.  A proxy answers a request from its proxied validator
+// with its own peer count, and a proxied validator records the response from one
+// of its proxies.
+func (sb *Backend) handleProxyStatusMsg(peer consensus.Peer, payload []byte) (bool, error) {
+	fromProxiedPeer := sb.config.Proxy && sb.proxiedPeer != nil && sb.proxiedPeer.Node().ID() == peer.Node().ID()
+	fromProxy := sb.IsProxiedValidator() && sb.proxies.getProxy(peer.Node().ID()) != nil
+	if !fromProxiedPeer && !fromProxy {
+		return false, nil
+	}
+
+	// Leave messages that aren't proxy status messages to the consensus message handling
+	msg := new(istanbul.Message)
+	if err := msg.FromPayload(payload, nil); err != nil || msg.Code != istanbul.ProxyStatusMsg {
+		return false, nil
+	}
+
+	logger := sb.logger.New("func", "handleProxyStatusMsg")
+	var status proxyStatus
+	if err := msg.Decode(&status); err != nil {
+		logger.Warn("Error in decoding proxy status message", "err", err)
+		return true, err
+	}
+
+	if fromProxiedPeer && !status.IsResponse {
+		response, err := sb.encodeProxyStatusMsg(&proxyStatus{
+			Timestamp:  status.Timestamp,
+			PeerCount:  uint64(len(sb.broadcaster.FindPeers(nil, p2p.AnyPurpose))),
+			IsResponse: true,
+		})
+		if err != nil {
+			return true, err
+		}
+		go peer.Send(istanbul.ConsensusMsg, response)
+		return true, nil
+	}
+
+	if fromProxy && status.IsResponse {
+		rtt := time.Since(time.Unix(0, int64(status.Timestamp)))
+		if rtt < 0 || rtt > proxyHealthCheckInterval {
+			logger.Debug("Ignoring proxy status response with an invalid timestamp", "from", peer.Node().ID(), "rtt", rtt)
+			return true, nil
+		}
+		sb.proxyHealth.recordStatus(peer.Node().ID(), rtt, status.PeerCount)
+		return true, nil
+	}
+
+	logger.Debug("Ignoring unexpected proxy status message", "from", peer.Node().ID(), "isResponse", status.IsResponse)
+	return true, nil
+}
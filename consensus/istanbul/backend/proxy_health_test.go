@@ -0,0 +1,240 @@
+package backend
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// sendRecordingPeer is a MockPeer that records the payloads of the consensus messages sent to it
+type sendRecordingPeer struct {
+	MockPeer
+	sent chan []byte
+}
+
+func newSendRecordingPeer(node *enode.Node) *sendRecordingPeer {
+	return &sendRecordingPeer{MockPeer: MockPeer{NodeOverride: node}, sent: make(chan []byte, 1)}
+}
+
+func (p *sendRecordingPeer) Send(msgcode uint64, data interface{}) error {
+	if msgcode == istanbul.ConsensusMsg {
+		p.sent <- data.([]byte)
+	}
+	return nil
+}
+
+func (p *sendRecordingPeer) waitSent(t *testing.T) []byte {
+	select {
+	case payload := <-p.sent:
+		return payload
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for a consensus message to be sent to %v", p.Node().ID())
+		return nil
+	}
+}
+
+// newProxyHealthTestBackend creates a backend that is either a proxied validator or a proxy
+func newProxyHealthTestBackend(proxy bool) *Backend {
+	config := *istanbul.DefaultConfig
+	config.Proxy = proxy
+	config.Proxied = !proxy
+	config.Validator = !proxy
+	return &Backend{
+		config:      &config,
+		logger:      log.New(),
+		broadcaster: &consensustest.MockBroadcaster{},
+		proxies:     newProxySet(),
+		proxyHealth: newProxyHealthTracker(),
+	}
+}
+
+// setScore makes a proxy scored with the given score
+func setScore(sb *Backend, id enode.ID, score float64) {
+	sb.proxyHealth.mu.Lock()
+	defer sb.proxyHealth.mu.Unlock()
+
+	sb.proxyHealth.health[id] = &proxyHealth{relayedMsgs: minScoredRelayedMsgs, dropRate: 1 - score}
+}
+
+func TestProxyHealthTracker(t *testing.T) {
+	pht := newProxyHealthTracker()
+	fastProxy, _ := newTestProxyNodes(t)
+	slowProxy, _ := newTestProxyNodes(t)
+	lossyProxy, _ := newTestProxyNodes(t)
+	connected := []enode.ID{fastProxy.ID(), slowProxy.ID(), lossyProxy.ID()}
+
+	start := time.Now()
+	for i := 0; i < 2*minScoredRelayedMsgs; i++ {
+		msgHash := common.BigToHash(big.NewInt(int64(i)))
+		pht.recordRelayedMsg(fastProxy.ID(), msgHash, start)
+		pht.recordRelayedMsg(slowProxy.ID(), msgHash, start.Add(time.Second))
+		if i%2 == 0 {
+			pht.recordRelayedMsg(lossyProxy.ID(), msgHash, start)
+		}
+	}
+
+	// Nothing should be evaluated before the relay window has passed
+	pht.evaluate(connected, start.Add(relayedMsgWindow/2))
+	if ph := pht.getHealth(fastProxy.ID()); ph.scored() {
+		t.Errorf("expected proxy to not be scored before the relay window passed")
+	}
+
+	pht.evaluate(connected, start.Add(2*relayedMsgWindow))
+	fast, slow, lossy := pht.getHealth(fastProxy.ID()), pht.getHealth(slowProxy.ID()), pht.getHealth(lossyProxy.ID())
+	if !fast.scored() || !slow.scored() || !lossy.scored() {
+		t.Fatalf("expected all proxies to be scored")
+	}
+	if lossy.droppedMsgs != minScoredRelayedMsgs || lossy.relayedMsgs != minScoredRelayedMsgs {
+		t.Errorf("unexpected lossy proxy message counts: relayed %d dropped %d", lossy.relayedMsgs, lossy.droppedMsgs)
+	}
+	if fast.droppedMsgs != 0 || fast.dropRate != 0 {
+		t.Errorf("expected fast proxy to not drop messages")
+	}
+	if slow.relayLatency <= fast.relayLatency {
+		t.Errorf("expected slow proxy relay latency %v to be greater than fast proxy's %v", slow.relayLatency, fast.relayLatency)
+	}
+	if fast.score() <= slow.score() || fast.score() <= lossy.score() {
+		t.Errorf("expected fast proxy to have the highest score: fast %v slow %v lossy %v", fast.score(), slow.score(), lossy.score())
+	}
+	if !fast.healthy() {
+		t.Errorf("expected fast proxy to be healthy")
+	}
+
+	// A low peer count lowers the score
+	pht.recordStatus(fastProxy.ID(), 10*time.Millisecond, 1)
+	if lowPeers := pht.getHealth(fastProxy.ID()); lowPeers.score() >= fast.score() || lowPeers.healthy() {
+		t.Errorf("expected a proxy with few peers to have a lower score and be unhealthy, score %v", lowPeers.score())
+	}
+
+	pht.remove(fastProxy.ID())
+	if pht.getHealth(fastProxy.ID()) != nil {
+		t.Errorf("expected health of removed proxy to be discarded")
+	}
+}
+
+func TestProxyStatusRLP(t *testing.T) {
+	status := &proxyStatus{Timestamp: 12345, PeerCount: 7, IsResponse: true}
+	payload, err := rlp.EncodeToBytes(status)
+	if err != nil {
+		t.Fatalf("error encoding proxy status: %v", err)
+	}
+	var decoded proxyStatus
+	if err := rlp.DecodeBytes(payload, &decoded); err != nil {
+		t.Fatalf("error decoding proxy status: %v", err)
+	}
+	if decoded != *status {
+		t.Errorf("expected %v, got %v", *status, decoded)
+	}
+}
+
+func TestEvaluateProxyHealth(t *testing.T) {
+	sb := newProxyHealthTestBackend(false)
+	proxy1, proxy1External := newTestProxyNodes(t)
+	proxy2, proxy2External := newTestProxyNodes(t)
+	sb.proxies.addProxy(proxy1, proxy1External)
+	sb.proxies.addProxy(proxy2, proxy2External)
+	sb.proxies.setProxyPeer(proxy1.ID(), &MockPeer{NodeOverride: proxy1})
+	sb.proxies.setProxyPeer(proxy2.ID(), &MockPeer{NodeOverride: proxy2})
+
+	// Nothing changes while no proxy is scored
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy1.ID()) {
+		t.Fatalf("expected the primary not to change without scores")
+	}
+
+	// A primary that isn't scored yet is replaced by a scored healthy proxy
+	setScore(sb, proxy2.ID(), 0.9)
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy2.ID()) {
+		t.Fatalf("expected the unscored primary to be replaced by the scored healthy proxy")
+	}
+
+	// A healthy primary is only replaced by a proxy scoring significantly higher
+	setScore(sb, proxy1.ID(), 0.9+primaryProxyScoreMargin/2)
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy2.ID()) {
+		t.Fatalf("expected the primary not to change for a marginally better proxy")
+	}
+	setScore(sb, proxy1.ID(), 1)
+	setScore(sb, proxy2.ID(), 0.8)
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy1.ID()) {
+		t.Fatalf("expected the primary to change for a significantly better proxy")
+	}
+
+	// An unhealthy primary is kept if no other proxy is healthy
+	setScore(sb, proxy1.ID(), minHealthyProxyScore/2)
+	setScore(sb, proxy2.ID(), minHealthyProxyScore/2)
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy1.ID()) {
+		t.Fatalf("expected the primary not to change when no proxy is healthy")
+	}
+
+	// An unhealthy primary is replaced by any healthy proxy
+	setScore(sb, proxy2.ID(), minHealthyProxyScore)
+	sb.evaluateProxyHealth()
+	if !sb.proxies.isPrimary(proxy2.ID()) {
+		t.Fatalf("expected the unhealthy primary to be replaced by the healthy proxy")
+	}
+}
+
+func TestProxyStatusExchange(t *testing.T) {
+	validator := newProxyHealthTestBackend(false)
+	proxy := newProxyHealthTestBackend(true)
+
+	proxyNode, proxyExternalNode := newTestProxyNodes(t)
+	validatorNode, _ := newTestProxyNodes(t)
+	proxyPeer := newSendRecordingPeer(proxyNode)
+	validatorPeer := newSendRecordingPeer(validatorNode)
+	validator.proxies.addProxy(proxyNode, proxyExternalNode)
+	validator.proxies.setProxyPeer(proxyNode.ID(), proxyPeer)
+	proxy.proxiedPeer = validatorPeer
+
+	// The validator's request is answered by the proxy
+	validator.sendProxyStatusRequests()
+	request := proxyPeer.waitSent(t)
+	if handled, err := proxy.handleProxyStatusMsg(validatorPeer, request); !handled || err != nil {
+		t.Fatalf("expected the proxy to handle the request, handled %v err %v", handled, err)
+	}
+	response := validatorPeer.waitSent(t)
+
+	// Only the proxied validator of a proxy may request its status
+	otherNode, _ := newTestProxyNodes(t)
+	if handled, _ := proxy.handleProxyStatusMsg(newSendRecordingPeer(otherNode), request); handled {
+		t.Errorf("expected a request from another peer not to be handled")
+	}
+
+	// The response is recorded by the validator
+	if handled, err := validator.handleProxyStatusMsg(proxyPeer, response); !handled || err != nil {
+		t.Fatalf("expected the validator to handle the response, handled %v err %v", handled, err)
+	}
+	if ph := validator.proxyHealth.getHealth(proxyNode.ID()); ph == nil || !ph.hasStatus {
+		t.Fatalf("expected the proxy status to be recorded")
+	}
+
+	// Other consensus messages are left to the consensus message handling
+	payload, _ := (&istanbul.Message{Code: istanbul.MsgPrepare, Msg: []byte{}, Signature: []byte{}}).Payload()
+	if handled, _ := validator.handleProxyStatusMsg(proxyPeer, payload); handled {
+		t.Errorf("expected a prepare message not to be handled as a proxy status message")
+	}
+
+	// The recorded status is reported through the API
+	api := &API{istanbul: validator}
+	infos, err := api.GetProxiesInfo()
+	if err != nil {
+		t.Fatalf("error getting proxies info: %v", err)
+	}
+	if len(infos) != 1 || !infos[0].IsPrimary || !infos[0].IsPeer || infos[0].PeerCount == nil || *infos[0].PeerCount != 0 {
+		t.Errorf("unexpected proxies info: %+v", infos)
+	}
+	if _, err := (&API{istanbul: proxy}).GetProxiesInfo(); err == nil {
+		t.Errorf("expected an error getting proxies info from a proxy")
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Information about a proxy for a proxied validator
+type proxyInfo struct {
+	node         *enode.Node    // Enode for the internal network interface
+	externalNode *enode.Node    // Enode for the external network interface
+	peer         consensus.Peer // Connected proxy peer.  Is nil if this node is not connected to the proxy
+}
+
+// proxySet keeps track of all the proxies of a proxied validator.
+// One of the proxies is designated as the primary proxy. The primary proxy's
+// external enode is the one advertised to other validators in queryEnode
+// messages and in the validator's own enode certificate. Whenever the primary
+// proxy disconnects, another connected proxy (if any) is promoted.
+type proxySet struct {
+	proxies   map[enode.ID]*proxyInfo
+	primaryID *enode.ID // nil if there are no proxies
+	mu        sync.RWMutex
+}
+
+func newProxySet() *proxySet {
+	return &proxySet{
+		proxies: make(map[enode.ID]*proxyInfo),
+	}
+}
+
+// addProxy adds a proxy to the set.  The first proxy that is added becomes the primary proxy.
+func (ps *proxySet) addProxy(node, externalNode *enode.Node) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	id := node.ID()
+	if _, ok := ps.proxies[id]; ok {
+		return errProxyAlreadySet
+	}
+	ps.proxies[id] = &proxyInfo{node: node, externalNode: externalNode}
+	if ps.primaryID == nil {
+		ps.primaryID = &id
+	}
+	return nil
+}
+
+// removeProxy removes a proxy from the set.  Returns the removed proxy (nil if
+// it's not in the set), and whether the primary proxy changed as a result.
+func (ps *proxySet) removeProxy(id enode.ID) (*proxyInfo, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	proxy, ok := ps.proxies[id]
+	if !ok {
+		return nil, false
+	}
+	delete(ps.proxies, id)
+	if ps.primaryID != nil && *ps.primaryID == id {
+		ps.primaryID = ps.selectPrimary()
+		return proxy, true
+	}
+	return proxy, false
+}
+
+// setProxyPeer sets the connected peer of a proxy.  Returns whether the peer is a
+// proxy in the set, and whether the primary proxy changed as a result, which happens
+// when the existing primary proxy is not connected.
+func (ps *proxySet) setProxyPeer(id enode.ID, peer consensus.Peer) (bool, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	proxy, ok := ps.proxies[id]
+	if !ok {
+		return false, false
+	}
+	proxy.peer = peer
+	if ps.primaryID == nil || ps.proxies[*ps.primaryID].peer == nil {
+		primaryChanged := ps.primaryID == nil || *ps.primaryID != id
+		ps.primaryID = &id
+		return true, primaryChanged
+	}
+	return true, false
+}
+
+// removeProxyPeer unsets the connected peer of a proxy.  Returns whether the
+// primary proxy changed as a result, which happens when the disconnected proxy
+// was the primary and another proxy is still connected.
+func (ps *proxySet) removeProxyPeer(id enode.ID) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	proxy, ok := ps.proxies[id]
+	if !ok {
+		return false
+	}
+	proxy.peer = nil
+	if ps.primaryID != nil && *ps.primaryID == id {
+		if newPrimaryID := ps.selectConnected(); newPrimaryID != nil {
+			ps.primaryID = newPrimaryID
+			return true
+		}
+	}
+	return false
+}
+
+// setPrimary makes the connected proxy with the given ID the primary proxy.
+// Returns whether the primary proxy changed.
+func (ps *proxySet) setPrimary(id enode.ID) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	proxy, ok := ps.proxies[id]
+	if !ok || proxy.peer == nil || (ps.primaryID != nil && *ps.primaryID == id) {
+		return false
+	}
+	ps.primaryID = &id
+	return true
+}
+
+// selectPrimary returns the ID of the proxy that should be the primary proxy.
+// Connected proxies are preferred.  Ties are broken by the lowest enode ID so
+// the selection is deterministic.  Must be called with ps.mu held.
+func (ps *proxySet) selectPrimary() *enode.ID {
+	if id := ps.selectConnected(); id != nil {
+		return id
+	}
+	ids := ps.sortedIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	return &ids[0]
+}
+
+// selectConnected returns the ID of the connected proxy with the lowest enode ID,
+// or nil if no proxy is connected.  Must be called with ps.mu held.
+func (ps *proxySet) selectConnected() *enode.ID {
+	for _, id := range ps.sortedIDs() {
+		if ps.proxies[id].peer != nil {
+			return &id
+		}
+	}
+	return nil
+}
+
+// sortedIDs returns the IDs of all the proxies sorted by enode ID.  Must be
+// called with ps.mu held.
+func (ps *proxySet) sortedIDs() []enode.ID {
+	ids := make([]enode.ID, 0, len(ps.proxies))
+	for id := range ps.proxies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	return ids
+}
+
+// getProxy returns a copy of the proxy with the given ID, or nil if it's not in the set.
+func (ps *proxySet) getProxy(id enode.ID) *proxyInfo {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	if proxy, ok := ps.proxies[id]; ok {
+		proxyCopy := *proxy
+		return &proxyCopy
+	}
+	return nil
+}
+
+// getPrimaryProxy returns a copy of the primary proxy, or nil if there are no proxies.
+func (ps *proxySet) getPrimaryProxy() *proxyInfo {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	if ps.primaryID == nil {
+		return nil
+	}
+	proxyCopy := *ps.proxies[*ps.primaryID]
+	return &proxyCopy
+}
+
+// isPrimary returns whether the proxy with the given ID is the primary proxy.
+func (ps *proxySet) isPrimary(id enode.ID) bool {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return ps.primaryID != nil && *ps.primaryID == id
+}
+
+// getProxies returns copies of all the proxies in the set.
+func (ps *proxySet) getProxies() []*proxyInfo {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	proxies := make([]*proxyInfo, 0, len(ps.proxies))
+	for _, proxy := range ps.proxies {
+		proxyCopy := *proxy
+		proxies = append(proxies, &proxyCopy)
+	}
+	return proxies
+}
+
+// getConnectedProxyPeers returns the peers of all the connected proxies.
+func (ps *proxySet) getConnectedProxyPeers() []consensus.Peer {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	var peers []consensus.Peer
+	for _, proxy := range ps.proxies {
+		if proxy.peer != nil {
+			peers = append(peers, proxy.peer)
+		}
+	}
+	return peers
+}
+
+// size returns the number of proxies in the set.
+func (ps *proxySet) size() int {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	return len(ps.proxies)
+}
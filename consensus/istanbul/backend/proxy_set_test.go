@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func newTestProxyNodes(t *testing.T) (*enode.Node, *enode.Node) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	internalNode := enode.NewV4(&privateKey.PublicKey, net.ParseIP("10.0.0.1"), 30503, 0)
+	externalNode := enode.NewV4(&privateKey.PublicKey, net.ParseIP("1.2.3.4"), 30303, 0)
+	return internalNode, externalNode
+}
+
+func TestProxySetFailover(t *testing.T) {
+	ps := newProxySet()
+
+	proxy1, proxy1External := newTestProxyNodes(t)
+	proxy2, proxy2External := newTestProxyNodes(t)
+
+	if err := ps.addProxy(proxy1, proxy1External); err != nil {
+		t.Fatalf("error adding proxy: %v", err)
+	}
+	if err := ps.addProxy(proxy1, proxy1External); err != errProxyAlreadySet {
+		t.Errorf("expected errProxyAlreadySet, got %v", err)
+	}
+	if err := ps.addProxy(proxy2, proxy2External); err != nil {
+		t.Fatalf("error adding proxy: %v", err)
+	}
+	if ps.size() != 2 {
+		t.Errorf("expected 2 proxies, got %d", ps.size())
+	}
+
+	// The first added proxy is the primary until another proxy connects while it is disconnected
+	if !ps.isPrimary(proxy1.ID()) {
+		t.Errorf("expected the first added proxy to be the primary")
+	}
+	if isProxy, primaryChanged := ps.setProxyPeer(proxy2.ID(), &MockPeer{NodeOverride: proxy2}); !isProxy || !primaryChanged {
+		t.Errorf("expected the connected proxy to be promoted, isProxy %v primaryChanged %v", isProxy, primaryChanged)
+	}
+	if primary := ps.getPrimaryProxy(); primary.externalNode.ID() != proxy2External.ID() {
+		t.Errorf("expected primary proxy external node %v, got %v", proxy2External, primary.externalNode)
+	}
+	if isProxy, primaryChanged := ps.setProxyPeer(proxy1.ID(), &MockPeer{NodeOverride: proxy1}); !isProxy || primaryChanged {
+		t.Errorf("expected the connected primary to be kept, isProxy %v primaryChanged %v", isProxy, primaryChanged)
+	}
+	if len(ps.getConnectedProxyPeers()) != 2 {
+		t.Errorf("expected 2 connected proxy peers, got %d", len(ps.getConnectedProxyPeers()))
+	}
+
+	// Disconnecting the primary proxy fails over to the other connected proxy
+	if primaryChanged := ps.removeProxyPeer(proxy2.ID()); !primaryChanged {
+		t.Errorf("expected the primary to change after disconnecting it")
+	}
+	if !ps.isPrimary(proxy1.ID()) {
+		t.Errorf("expected the remaining connected proxy to be the primary")
+	}
+
+	// Disconnecting the last connected proxy keeps it as the primary
+	if primaryChanged := ps.removeProxyPeer(proxy1.ID()); primaryChanged {
+		t.Errorf("expected the primary not to change when no other proxy is connected")
+	}
+	if !ps.isPrimary(proxy1.ID()) {
+		t.Errorf("expected a disconnected proxy not to be promoted")
+	}
+
+	// Removing the primary promotes the remaining proxy
+	if proxy, primaryChanged := ps.removeProxy(proxy1.ID()); proxy == nil || !primaryChanged {
+		t.Errorf("expected the primary to change after removing it, proxy %v primaryChanged %v", proxy, primaryChanged)
+	}
+	if !ps.isPrimary(proxy2.ID()) {
+		t.Errorf("expected the remaining proxy to be the primary")
+	}
+	if proxy, _ := ps.removeProxy(proxy2.ID()); proxy == nil {
+		t.Errorf("expected the proxy to be removed")
+	}
+	if ps.getPrimaryProxy() != nil {
+		t.Errorf("expected no primary proxy in an empty set")
+	}
+}
+
+func TestProxySetUnknownPeer(t *testing.T) {
+	ps := newProxySet()
+	node, _ := newTestProxyNodes(t)
+	if isProxy, _ := ps.setProxyPeer(node.ID(), &MockPeer{NodeOverride: node}); isProxy {
+		t.Errorf("expected a peer not in the proxy set to not be a proxy")
+	}
+	if proxy, _ := ps.removeProxy(node.ID()); proxy != nil {
+		t.Errorf("expected no proxy to be removed")
+	}
+}
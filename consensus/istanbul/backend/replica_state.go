@@ -0,0 +1,214 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// errReplicaBlockTooLow is returned when a role switch is scheduled at a block whose
+	// consensus round may already be under way.
+	errReplicaBlockTooLow = errors.New("role switch must be scheduled after the next block")
+
+	// errReplicaBlockConflict is returned when the start and stop of the primary role are
+	// scheduled at the same block.
+	errReplicaBlockConflict = errors.New("start and stop scheduled at the same block")
+)
+
+// replicaStateKey is the database key the replica state is persisted under
+var replicaStateKey = []byte("istanbul-replica-state")
+
+// replicaState tracks whether a validator is the primary, signing consensus messages and
+// proposing blocks, or a replica only tracking the consensus state. Role switches are
+// scheduled at a block, so that a primary stopping at block n and a replica starting at
+// block n never sign for the same sequence.
+type replicaState struct {
+	mu     sync.RWMutex
+	db     ethdb.KeyValueStore
+	logger log.Logger
+
+	primary    bool     // Whether the validator is the primary for the sequence after the head
+	startBlock *big.Int // Block to become the primary at, if scheduled
+	stopBlock  *big.Int // Block to become a replica at, if scheduled
+}
+
+// storedReplicaState is the RLP encoding of the persisted replica state
+type storedReplicaState struct {
+	Primary    bool
+	StartBlock *big.Int `rlp:"nil"`
+	StopBlock  *big.Int `rlp:"nil"`
+}
+
+// newReplicaState loads the replica state persisted in the database. A validator started
+// as a replica, or stopped before it was restarted, stays a replica until it is scheduled
+// to start.
+func newReplicaState(db ethdb.KeyValueStore, replica bool, logger log.Logger) *replicaState {
+	rs := &replicaState{db: db, logger: logger, primary: true}
+	if blob, err := db.Get(replicaStateKey); err == nil {
+		var stored storedReplicaState
+		if err := rlp.DecodeBytes(blob, &stored); err != nil {
+			logger.Warn("Failed to decode replica state", "err", err)
+		} else {
+			rs.primary, rs.startBlock, rs.stopBlock = stored.Primary, stored.StartBlock, stored.StopBlock
+		}
+	}
+	if replica && rs.primary {
+		rs.primary = false
+		if err := rs.store(); err != nil {
+			logger.Error("Failed to store replica state", "err", err)
+		}
+	}
+	return rs
+}
+
+// isPrimary returns whether the validator is the primary for the sequence after the head
+func (rs *replicaState) isPrimary() bool {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.primary
+}
+
+// isPrimaryForSeq returns whether the validator is the primary for the given sequence
+func (rs *replicaState) isPrimaryForSeq(seq *big.Int) bool {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.primaryForSeq(seq)
+}
+
+// primaryForSeq applies the scheduled role switches due at the given sequence, in block order
+func (rs *replicaState) primaryForSeq(seq *big.Int) bool {
+	primary := rs.primary
+	start, stop := rs.startBlock != nil && seq.Cmp(rs.startBlock) >= 0, rs.stopBlock != nil && seq.Cmp(rs.stopBlock) >= 0
+	switch {
+	case start && stop:
+		primary = rs.startBlock.Cmp(rs.stopBlock) > 0
+	case start:
+		primary = true
+	case stop:
+		primary = false
+	}
+	return primary
+}
+
+// setStartBlock schedules the validator to become the primary at the given block, which
+// must come after the next sequence to be agreed on.
+func (rs *replicaState) setStartBlock(block, head *big.Int) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := rs.checkScheduledBlock(block, head, rs.stopBlock); err != nil {
+		return err
+	}
+	rs.startBlock = new(big.Int).Set(block)
+	return rs.store()
+}
+
+// setStopBlock schedules the validator to become a replica at the given block, which must
+// come after the next sequence to be agreed on.
+func (rs *replicaState) setStopBlock(block, head *big.Int) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := rs.checkScheduledBlock(block, head, rs.startBlock); err != nil {
+		return err
+	}
+	rs.stopBlock = new(big.Int).Set(block)
+	return rs.store()
+}
+
+func (rs *replicaState) checkScheduledBlock(block, head, other *big.Int) error {
+	if block.Cmp(new(big.Int).Add(head, big.NewInt(2))) < 0 {
+		return errReplicaBlockTooLow
+	}
+	if other != nil && block.Cmp(other) == 0 {
+		return errReplicaBlockConflict
+	}
+	return nil
+}
+
+// newChainHead applies the role switches due at the sequence after the new head, and returns
+// whether the validator switched between primary and replica
+func (rs *replicaState) newChainHead(head *big.Int) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	seq := new(big.Int).Add(head, big.NewInt(1))
+	primary, changed := rs.primaryForSeq(seq), false
+	if rs.startBlock != nil && seq.Cmp(rs.startBlock) >= 0 {
+		rs.startBlock, changed = nil, true
+	}
+	if rs.stopBlock != nil && seq.Cmp(rs.stopBlock) >= 0 {
+		rs.stopBlock, changed = nil, true
+	}
+	if !changed {
+		return false
+	}
+	switched := primary != rs.primary
+	if switched {
+		if primary {
+			rs.logger.Info("Validator switched to primary", "sequence", seq)
+		} else {
+			rs.logger.Info("Validator switched to replica", "sequence", seq)
+		}
+	}
+	rs.primary = primary
+	if err := rs.store(); err != nil {
+		rs.logger.Error("Failed to store replica state", "err", err)
+	}
+	return switched
+}
+
+// store persists the replica state, so that a restarted validator keeps its role
+func (rs *replicaState) store() error {
+	blob, err := rlp.EncodeToBytes(&storedReplicaState{
+		Primary:    rs.primary,
+		StartBlock: rs.startBlock,
+		StopBlock:  rs.stopBlock,
+	})
+	if err != nil {
+		return err
+	}
+	return rs.db.Put(replicaStateKey, blob)
+}
+
+// ReplicaStateSummary is the replica state reported by istanbul_getReplicaState
+type ReplicaStateSummary struct {
+	IsPrimary  bool     `json:"isPrimary"`
+	StartBlock *big.Int `json:"startBlock"`
+	StopBlock  *big.Int `json:"stopBlock"`
+}
+
+func (rs *replicaState) summary() *ReplicaStateSummary {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	summary := &ReplicaStateSummary{IsPrimary: rs.primary}
+	if rs.startBlock != nil {
+		summary.StartBlock = new(big.Int).Set(rs.startBlock)
+	}
+	if rs.stopBlock != nil {
+		summary.StopBlock = new(big.Int).Set(rs.stopBlock)
+	}
+	return summary
+}
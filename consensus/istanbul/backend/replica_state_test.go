@@ -0,0 +1,90 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func TestReplicaStateSwitch(t *testing.T) {
+	primaryDB, replicaDB := rawdb.NewMemoryDatabase(), rawdb.NewMemoryDatabase()
+	primary := newReplicaState(primaryDB, false, log.New())
+	replica := newReplicaState(replicaDB, true, log.New())
+
+	head := big.NewInt(10)
+	if err := primary.setStopBlock(big.NewInt(11), head); err != errReplicaBlockTooLow {
+		t.Errorf("error mismatch: have %v, want %v", err, errReplicaBlockTooLow)
+	}
+	if err := primary.setStopBlock(big.NewInt(15), head); err != nil {
+		t.Fatalf("failed to schedule stop: %v", err)
+	}
+	if err := replica.setStartBlock(big.NewInt(15), head); err != nil {
+		t.Fatalf("failed to schedule start: %v", err)
+	}
+
+	// Exactly one of the two validators is the primary at every sequence
+	for seq := int64(11); seq < 20; seq++ {
+		isPrimary, isReplicaPrimary := primary.isPrimaryForSeq(big.NewInt(seq)), replica.isPrimaryForSeq(big.NewInt(seq))
+		if isPrimary == isReplicaPrimary || isPrimary != (seq < 15) {
+			t.Errorf("sequence %d: primary %v, replica primary %v", seq, isPrimary, isReplicaPrimary)
+		}
+	}
+
+	for n := int64(11); n < 15; n++ {
+		primarySwitched, replicaSwitched := primary.newChainHead(big.NewInt(n)), replica.newChainHead(big.NewInt(n))
+		if primarySwitched != (n == 14) || replicaSwitched != (n == 14) {
+			t.Errorf("block %d: primary switched %v, replica switched %v", n, primarySwitched, replicaSwitched)
+		}
+	}
+	if primary.isPrimary() || !replica.isPrimary() {
+		t.Errorf("roles not switched after block 14: primary %v, replica %v", primary.isPrimary(), replica.isPrimary())
+	}
+	if summary := primary.summary(); summary.StopBlock != nil {
+		t.Errorf("applied stop block still scheduled: %v", summary.StopBlock)
+	}
+
+	// The roles survive a restart
+	if restarted := newReplicaState(primaryDB, false, log.New()); restarted.isPrimary() {
+		t.Errorf("stopped validator restarted as the primary")
+	}
+	if restarted := newReplicaState(replicaDB, false, log.New()); !restarted.isPrimary() {
+		t.Errorf("started validator restarted as a replica")
+	}
+}
+
+func TestReplicaStateConflict(t *testing.T) {
+	rs := newReplicaState(rawdb.NewMemoryDatabase(), false, log.New())
+	head := big.NewInt(0)
+	if err := rs.setStopBlock(big.NewInt(5), head); err != nil {
+		t.Fatalf("failed to schedule stop: %v", err)
+	}
+	if err := rs.setStartBlock(big.NewInt(5), head); err != errReplicaBlockConflict {
+		t.Errorf("error mismatch: have %v, want %v", err, errReplicaBlockConflict)
+	}
+	if err := rs.setStartBlock(big.NewInt(8), head); err != nil {
+		t.Fatalf("failed to schedule start: %v", err)
+	}
+	for seq, want := range map[int64]bool{4: true, 5: false, 7: false, 8: true, 9: true} {
+		if have := rs.isPrimaryForSeq(big.NewInt(seq)); have != want {
+			t.Errorf("sequence %d: have primary %v, want %v", seq, have, want)
+		}
+	}
+}
@@ -0,0 +1,800 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	vet "github.com/ethereum/go-ethereum/consensus/istanbul/backend/internal/enodes"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SimBlocksMsg is the code of the simulated block sync messages on the simulation bus. It is
+// outside of the range of the eth protocol message codes.
+const SimBlocksMsg = 0xff
+
+var (
+	// errSimulationStopped is returned when waiting on a stopped simulation
+	errSimulationStopped = errors.New("simulation stopped")
+)
+
+// SimMessage is a message sent from a simulated validator to another on the simulation bus
+type SimMessage struct {
+	From, To int
+	Code     uint64            // The eth message code, or SimBlocksMsg
+	Payload  []byte            // The message payload, nil for SimBlocksMsg
+	Msg      *istanbul.Message // The decoded consensus message, nil for other codes
+	Blocks   types.Blocks      // The blocks synced, for SimBlocksMsg
+	Delay    time.Duration     // The delay before the message is delivered
+}
+
+// SimFault is applied to every message sent on the simulation bus. It returns the message to
+// deliver, nil to drop it. It may add to the delay of the message, or replace it.
+type SimFault func(s *Simulation, msg *SimMessage) *SimMessage
+
+// SimDrop drops the messages with the given probability
+func SimDrop(probability float64) SimFault {
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		if s.rand(func(r *rand.Rand) float64 { return r.Float64() }) < probability {
+			return nil
+		}
+		return msg
+	}
+}
+
+// SimDelay delays the messages by the given duration
+func SimDelay(delay time.Duration) SimFault {
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		msg.Delay += delay
+		return msg
+	}
+}
+
+// SimReorder delays the messages by a random duration up to window, which reorders them
+func SimReorder(window time.Duration) SimFault {
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		msg.Delay += time.Duration(s.rand(func(r *rand.Rand) float64 { return r.Float64() }) * float64(window))
+		return msg
+	}
+}
+
+// SimPartition drops the messages between validators of different groups. Validators not in
+// any group are isolated.
+func SimPartition(groups ...[]int) SimFault {
+	group := make(map[int]int)
+	for i, nodes := range groups {
+		for _, node := range nodes {
+			group[node] = i + 1
+		}
+	}
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		if group[msg.From] == 0 || group[msg.From] != group[msg.To] {
+			return nil
+		}
+		return msg
+	}
+}
+
+// SimSilentProposer makes the given validators never send their proposals
+func SimSilentProposer(nodes ...int) SimFault {
+	silent := make(map[int]bool)
+	for _, node := range nodes {
+		silent[node] = true
+	}
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		if silent[msg.From] && msg.Msg != nil && msg.Msg.Code == istanbul.MsgPreprepare {
+			return nil
+		}
+		return msg
+	}
+}
+
+// SimEquivocatingProposer makes the given validator send a conflicting proposal to the
+// validators with a higher index than its own.
+func SimEquivocatingProposer(node int) SimFault {
+	return func(s *Simulation, msg *SimMessage) *SimMessage {
+		if msg.From != node || msg.To < node || msg.Msg == nil || msg.Msg.Code != istanbul.MsgPreprepare {
+			return msg
+		}
+		conflicting, err := s.nodes[node].conflictingPreprepare(msg.Msg)
+		if err != nil {
+			log.Error("Failed to create conflicting proposal", "node", node, "err", err)
+			return msg
+		}
+		payload, err := conflicting.Payload()
+		if err != nil {
+			log.Error("Failed to encode conflicting proposal", "node", node, "err", err)
+			return msg
+		}
+		return &SimMessage{From: msg.From, To: msg.To, Code: msg.Code, Payload: payload, Msg: conflicting, Delay: msg.Delay}
+	}
+}
+
+// Simulation runs a network of validators in process, each with its own Backend and chain,
+// connected through an in-memory message bus on which faults can be injected.
+type Simulation struct {
+	config *istanbul.Config
+	nodes  []*SimNode
+
+	faultsMu sync.RWMutex
+	faults   []SimFault
+
+	randMu sync.Mutex
+	random *rand.Rand
+
+	quit chan struct{}
+}
+
+// SimNode is a validator of a Simulation
+type SimNode struct {
+	Index   int
+	Address common.Address
+	Backend *Backend
+	Chain   *core.BlockChain
+
+	sim  *Simulation
+	key  *ecdsa.PrivateKey
+	node *enode.Node
+
+	headCh  chan core.ChainHeadEvent
+	headSub event.Subscription
+	results chan *types.Block
+	stop    chan struct{} // Closed to stop sealing the current block
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewSimulation creates a simulation of n validators using the given istanbul config; the
+// database paths of the config are ignored. The validators are started with Start.
+func NewSimulation(n int, config *istanbul.Config, seed int64) (*Simulation, error) {
+	simConfig := *config
+	simConfig.ValidatorEnodeDBPath = ""
+	simConfig.VersionCertificateDBPath = ""
+	simConfig.RoundStateDBPath = ""
+	simConfig.EquivocationDBPath = ""
+	simConfig.SlashingProtectionDBPath = ""
+	simConfig.WAL = false
+	simConfig.Validator = true
+	simConfig.Proxy = false
+	simConfig.Proxied = false
+
+	s := &Simulation{
+		config: &simConfig,
+		nodes:  make([]*SimNode, n),
+		random: rand.New(rand.NewSource(seed)),
+		quit:   make(chan struct{}),
+	}
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	validators := make([]istanbul.ValidatorData, n)
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		blsPrivateKey, err := blscrypto.ECDSAToBLS(key)
+		if err != nil {
+			return nil, err
+		}
+		blsPublicKey, err := blscrypto.PrivateToPublic(blsPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+		validators[i] = istanbul.ValidatorData{
+			Address:      crypto.PubkeyToAddress(key.PublicKey),
+			BLSPublicKey: blsPublicKey,
+		}
+	}
+
+	chainConfig := *params.DefaultChainConfig
+	chainConfig.Istanbul = &params.IstanbulConfig{
+		Epoch:          simConfig.Epoch,
+		ProposerPolicy: uint64(simConfig.ProposerPolicy),
+		LookbackWindow: simConfig.LookbackWindow,
+		BlockPeriod:    simConfig.BlockPeriod,
+		RequestTimeout: simConfig.RequestTimeout,
+	}
+	genesis := core.DefaultGenesisBlock()
+	genesis.Config = &chainConfig
+	AppendValidatorsToGenesisBlock(genesis, validators)
+
+	for i := range s.nodes {
+		node, err := s.newNode(i, keys[i], genesis)
+		if err != nil {
+			s.Stop()
+			return nil, err
+		}
+		s.nodes[i] = node
+	}
+
+	// Every validator knows the enode of all the others
+	for _, node := range s.nodes {
+		entries := make([]*vet.AddressEntry, 0, n-1)
+		for _, other := range s.nodes {
+			if other != node {
+				entries = append(entries, &vet.AddressEntry{Address: other.Address, Node: other.node, Version: 1})
+			}
+		}
+		if err := node.Backend.valEnodeTable.UpsertVersionAndEnode(entries); err != nil {
+			s.Stop()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Simulation) newNode(index int, key *ecdsa.PrivateKey, genesis *core.Genesis) (*SimNode, error) {
+	db := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(db)
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	backend := New(s.config, db).(*Backend)
+	backend.Authorize(address, &key.PublicKey, decryptFn, SignFn(key), SignBLSFn(key))
+
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, backend, vm.Config{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	node := &SimNode{
+		Index:   index,
+		Address: address,
+		Backend: backend,
+		Chain:   chain,
+		sim:     s,
+		key:     key,
+		node:    enode.NewV4(&key.PublicKey, net.IP{127, 0, 0, 1}, 30303+index, 30303+index),
+		headCh:  make(chan core.ChainHeadEvent, 16),
+		results: make(chan *types.Block, 1),
+		quit:    make(chan struct{}),
+	}
+
+	backend.SetChain(
+		chain,
+		chain.CurrentBlock,
+		func(hash common.Hash) (*state.StateDB, error) {
+			stateRoot := chain.GetHeaderByHash(hash).Root
+			return chain.StateAt(stateRoot)
+		},
+	)
+	backend.SetSystemCaller(chain.SystemCaller())
+	backend.SetBroadcaster(&simBroadcaster{node: node})
+	backend.SetP2PServer(&consensustest.MockP2PServer{Node: node.node})
+	return node, nil
+}
+
+// Start starts the validators
+func (s *Simulation) Start() error {
+	for _, node := range s.nodes {
+		if err := node.start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the validators, and releases their resources
+func (s *Simulation) Stop() {
+	select {
+	case <-s.quit:
+		return
+	default:
+		close(s.quit)
+	}
+	for _, node := range s.nodes {
+		if node != nil {
+			node.close()
+		}
+	}
+}
+
+// Node returns the validator with the given index
+func (s *Simulation) Node(index int) *SimNode {
+	return s.nodes[index]
+}
+
+// Nodes returns the validators
+func (s *Simulation) Nodes() []*SimNode {
+	return s.nodes
+}
+
+// SetFaults replaces the faults applied to the messages sent from now on
+func (s *Simulation) SetFaults(faults ...SimFault) {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	s.faults = faults
+}
+
+// rand returns a value from the seeded source of randomness of the simulation
+func (s *Simulation) rand(fn func(*rand.Rand) float64) float64 {
+	s.randMu.Lock()
+	defer s.randMu.Unlock()
+	return fn(s.random)
+}
+
+// Heights returns the height of the chain of every validator
+func (s *Simulation) Heights() []uint64 {
+	heights := make([]uint64, len(s.nodes))
+	for i, node := range s.nodes {
+		heights[i] = node.Chain.CurrentBlock().NumberU64()
+	}
+	return heights
+}
+
+// WaitForHeight waits until the chains of the given validators (all of them if none is given)
+// reach the given height, and returns an error on timeout.
+func (s *Simulation) WaitForHeight(height uint64, timeout time.Duration, nodes ...int) error {
+	if len(nodes) == 0 {
+		for i := range s.nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		reached := true
+		for _, i := range nodes {
+			if s.nodes[i].Chain.CurrentBlock().NumberU64() < height {
+				reached = false
+				break
+			}
+		}
+		if reached {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return fmt.Errorf("validators %v didn't reach height %d in %v: heights %v", nodes, height, timeout, s.Heights())
+		case <-s.quit:
+			return errSimulationStopped
+		}
+	}
+}
+
+// CheckSafety returns an error if two validators have different blocks at the same height
+func (s *Simulation) CheckSafety() error {
+	for i, node := range s.nodes {
+		for _, other := range s.nodes[i+1:] {
+			height := node.Chain.CurrentBlock().NumberU64()
+			if otherHeight := other.Chain.CurrentBlock().NumberU64(); otherHeight < height {
+				height = otherHeight
+			}
+			for number := uint64(1); number <= height; number++ {
+				hash, otherHash := node.Chain.GetCanonicalHash(number), other.Chain.GetCanonicalHash(number)
+				if hash != otherHash {
+					return fmt.Errorf("validators %d and %d committed different blocks at height %d: %s and %s", node.Index, other.Index, number, hash.Hex(), otherHash.Hex())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// send applies the faults to a message, and delivers it after its delay unless it was dropped
+func (s *Simulation) send(msg *SimMessage) {
+	s.faultsMu.RLock()
+	faults := s.faults
+	s.faultsMu.RUnlock()
+
+	for _, fault := range faults {
+		if msg = fault(s, msg); msg == nil {
+			return
+		}
+	}
+
+	go func() {
+		if msg.Delay > 0 {
+			select {
+			case <-time.After(msg.Delay):
+			case <-s.quit:
+				return
+			}
+		}
+		select {
+		case <-s.quit:
+			return
+		default:
+		}
+		s.nodes[msg.To].receive(msg)
+	}()
+}
+
+func (n *SimNode) start() error {
+	if err := n.Backend.StartAnnouncing(); err != nil {
+		return err
+	}
+	n.headSub = n.Chain.SubscribeChainHeadEvent(n.headCh)
+	err := n.Backend.StartValidating(n.Chain.HasBadBlock,
+		func(block *types.Block, state *state.StateDB) (types.Receipts, []*types.Log, uint64, error) {
+			return n.Chain.Processor().Process(block, state, *n.Chain.GetVMConfig())
+		},
+		func(block *types.Block, state *state.StateDB, receipts types.Receipts, usedGas uint64) error {
+			return n.Chain.Validator().ValidateState(block, state, receipts, usedGas)
+		})
+	if err != nil {
+		return err
+	}
+	n.wg.Add(1)
+	go n.loop()
+	return nil
+}
+
+func (n *SimNode) close() {
+	close(n.quit)
+	n.wg.Wait()
+	if n.headSub != nil {
+		n.headSub.Unsubscribe()
+		n.Backend.StopValidating()
+		n.Backend.StopAnnouncing()
+	}
+	n.Chain.Stop()
+	n.Backend.Close()
+}
+
+// loop plays the part of the miner of the validator: it seals a new block on every new chain
+// head, and offers the new blocks to the other validators that are behind.
+func (n *SimNode) loop() {
+	defer n.wg.Done()
+
+	n.newWork(n.Chain.CurrentBlock())
+	for {
+		select {
+		case ev := <-n.headCh:
+			n.Backend.NewChainHead(ev.Block)
+			n.syncPeers(ev.Block)
+			n.newWork(ev.Block)
+		case block := <-n.results:
+			n.insert(types.Blocks{block})
+		case <-n.quit:
+			if n.stop != nil {
+				close(n.stop)
+			}
+			return
+		}
+	}
+}
+
+// newWork seals a new block on top of parent
+func (n *SimNode) newWork(parent *types.Block) {
+	if n.stop != nil {
+		close(n.stop)
+	}
+	n.stop = make(chan struct{})
+
+	if err := n.Backend.NewWork(); err != nil {
+		log.Error("Failed to start new work", "node", n.Index, "err", err)
+		return
+	}
+	block, err := n.buildBlock(parent)
+	if err != nil {
+		log.Error("Failed to build block", "node", n.Index, "number", parent.NumberU64()+1, "err", err)
+		return
+	}
+	if err := n.Backend.Seal(n.Chain, block, n.results, n.stop); err != nil {
+		log.Error("Failed to seal block", "node", n.Index, "number", block.NumberU64(), "err", err)
+	}
+}
+
+func (n *SimNode) buildBlock(parent *types.Block) (*types.Block, error) {
+	header := makeHeader(parent, n.sim.config)
+	if err := n.Backend.Prepare(n.Chain, header); err != nil {
+		return nil, err
+	}
+	state, err := n.Chain.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+	return n.Backend.FinalizeAndAssemble(n.Chain, header, state, nil, nil, nil)
+}
+
+func (n *SimNode) insert(blocks types.Blocks) {
+	if _, err := n.Chain.InsertChain(blocks); err != nil {
+		log.Debug("Failed to insert blocks", "node", n.Index, "number", blocks[0].NumberU64(), "err", err)
+	}
+}
+
+// syncPeers sends the blocks up to head to the validators that are behind, standing in for the
+// block fetcher and downloader
+func (n *SimNode) syncPeers(head *types.Block) {
+	for _, other := range n.sim.nodes {
+		otherHeight := other.Chain.CurrentBlock().NumberU64()
+		if other == n || otherHeight >= head.NumberU64() {
+			continue
+		}
+		blocks := make(types.Blocks, 0, head.NumberU64()-otherHeight)
+		for number := otherHeight + 1; number <= head.NumberU64(); number++ {
+			block := n.Chain.GetBlockByNumber(number)
+			if block == nil {
+				break
+			}
+			blocks = append(blocks, block)
+		}
+		if len(blocks) > 0 {
+			n.sim.send(&SimMessage{From: n.Index, To: other.Index, Code: SimBlocksMsg, Blocks: blocks})
+		}
+	}
+}
+
+// receive delivers a message sent on the simulation bus
+func (n *SimNode) receive(msg *SimMessage) {
+	if msg.Code == SimBlocksMsg {
+		n.insert(msg.Blocks)
+		return
+	}
+	size, r, err := rlp.EncodeToReader(msg.Payload)
+	if err != nil {
+		log.Error("Failed to encode simulated message", "err", err)
+		return
+	}
+	from := n.sim.nodes[msg.From]
+	p2pMsg := p2p.Msg{Code: msg.Code, Size: uint32(size), Payload: r}
+	if _, err := n.Backend.HandleMsg(from.Address, p2pMsg, &simPeer{local: n, remote: from}); err != nil {
+		log.Debug("Failed to handle simulated message", "node", n.Index, "from", msg.From, "code", msg.Code, "err", err)
+	}
+}
+
+// conflictingPreprepare returns a preprepare message for a block conflicting with the one
+// proposed in msg, signed by the validator
+func (n *SimNode) conflictingPreprepare(msg *istanbul.Message) (*istanbul.Message, error) {
+	var preprepare *istanbul.Preprepare
+	if err := msg.Decode(&preprepare); err != nil {
+		return nil, err
+	}
+	block, ok := preprepare.Proposal.(*types.Block)
+	if !ok {
+		return nil, errInvalidProposal
+	}
+	parent := n.Chain.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+
+	// Changing the vanity of the extra data changes the hash of the block but not its validity
+	header := block.Header()
+	header.Extra = common.CopyBytes(header.Extra)
+	header.Extra[0] ^= 0xff
+	conflicting, err := n.Backend.updateBlock(parent, block.WithSeal(header))
+	if err != nil {
+		return nil, err
+	}
+	preprepare.Proposal = conflicting
+
+	encoded, err := rlp.EncodeToBytes(preprepare)
+	if err != nil {
+		return nil, err
+	}
+	conflictingMsg := &istanbul.Message{
+		Code:    istanbul.MsgPreprepare,
+		Msg:     encoded,
+		Address: n.Address,
+	}
+	// A misbehaving validator signs without its slashing protection
+	signFn := SignFn(n.key)
+	if err := conflictingMsg.Sign(func(data []byte) ([]byte, error) {
+		return signFn(accounts.Account{Address: n.Address}, accounts.MimetypeIstanbul, data)
+	}); err != nil {
+		return nil, err
+	}
+	return conflictingMsg, nil
+}
+
+// simBroadcaster is the consensus.Broadcaster of a simulated validator
+type simBroadcaster struct {
+	node *SimNode
+}
+
+// Enqueue inserts a block committed by the validator but proposed by another one
+func (b *simBroadcaster) Enqueue(id string, block *types.Block) {
+	go b.node.insert(types.Blocks{block})
+}
+
+func (b *simBroadcaster) FindPeers(targets map[enode.ID]bool, purpose p2p.PurposeFlag) map[enode.ID]consensus.Peer {
+	peers := make(map[enode.ID]consensus.Peer)
+	for _, other := range b.node.sim.nodes {
+		if other == b.node || (targets != nil && !targets[other.node.ID()]) {
+			continue
+		}
+		peers[other.node.ID()] = &simPeer{local: b.node, remote: other}
+	}
+	return peers
+}
+
+// simPeer is the connection of a simulated validator to another one
+type simPeer struct {
+	local, remote *SimNode
+}
+
+func (p *simPeer) Send(msgcode uint64, data interface{}) error {
+	payload, ok := data.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported simulated message data %T", data)
+	}
+	msg := &SimMessage{From: p.local.Index, To: p.remote.Index, Code: msgcode, Payload: payload}
+	if msgcode == istanbul.ConsensusMsg {
+		decoded := new(istanbul.Message)
+		if err := decoded.FromPayload(payload, nil); err == nil {
+			msg.Msg = decoded
+		}
+	}
+	p.local.sim.send(msg)
+	return nil
+}
+
+func (p *simPeer) Node() *enode.Node { return p.remote.node }
+
+func (p *simPeer) Version() int { return int(istanbul.ProtocolVersions[0]) }
+
+func (p *simPeer) ReadMsg() (p2p.Msg, error) {
+	return p2p.Msg{}, errors.New("not supported by simulated peers")
+}
+
+func (p *simPeer) Inbound() bool { return false }
+
+func (p *simPeer) PurposeIsSet(purpose p2p.PurposeFlag) bool { return true }
+
+func newTestSimulation(t *testing.T, n int) *Simulation {
+	config := *istanbul.DefaultConfig
+	config.ProposerPolicy = istanbul.RoundRobin
+	config.BlockPeriod = 0
+	config.Epoch = 1000
+	config.RequestTimeout = 300
+	config.TimeoutBackoffFactor = 100
+	config.MinResendRoundChangeTimeout = 300
+	config.MaxResendRoundChangeTimeout = 1000
+
+	sim, err := NewSimulation(n, &config, 1)
+	if err != nil {
+		t.Fatalf("failed to create simulation: %v", err)
+	}
+	return sim
+}
+
+func TestSimulationLiveness(t *testing.T) {
+	sim := newTestSimulation(t, 4)
+	defer sim.Stop()
+	sim.SetFaults(SimReorder(20 * time.Millisecond))
+	if err := sim.Start(); err != nil {
+		t.Fatalf("failed to start simulation: %v", err)
+	}
+
+	if err := sim.WaitForHeight(5, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationSilentProposer(t *testing.T) {
+	sim := newTestSimulation(t, 4)
+	defer sim.Stop()
+	sim.SetFaults(SimSilentProposer(1))
+	if err := sim.Start(); err != nil {
+		t.Fatalf("failed to start simulation: %v", err)
+	}
+
+	if err := sim.WaitForHeight(6, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The blocks the silent validator should have proposed are committed after a round change
+	if roundChanges := countRoundChanges(t, sim, 6); roundChanges == 0 {
+		t.Errorf("no block was committed after a round change")
+	}
+}
+
+func TestSimulationPartition(t *testing.T) {
+	sim := newTestSimulation(t, 4)
+	defer sim.Stop()
+	if err := sim.Start(); err != nil {
+		t.Fatalf("failed to start simulation: %v", err)
+	}
+	if err := sim.WaitForHeight(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a quorum on either side of the partition, no block is committed
+	sim.SetFaults(SimPartition([]int{0, 1}, []int{2, 3}))
+	time.Sleep(500 * time.Millisecond)
+	heights := sim.Heights()
+	time.Sleep(2 * time.Second)
+	for i, height := range sim.Heights() {
+		if height != heights[i] {
+			t.Errorf("validator %d committed blocks during the partition: height %d, was %d", i, height, heights[i])
+		}
+	}
+
+	// The validators catch up once the partition heals
+	sim.SetFaults()
+	max := uint64(0)
+	for _, height := range heights {
+		if height > max {
+			max = height
+		}
+	}
+	if err := sim.WaitForHeight(max+2, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationEquivocatingProposer(t *testing.T) {
+	sim := newTestSimulation(t, 4)
+	defer sim.Stop()
+	sim.SetFaults(SimEquivocatingProposer(1), SimDrop(0.05), SimReorder(20*time.Millisecond))
+	if err := sim.Start(); err != nil {
+		t.Fatalf("failed to start simulation: %v", err)
+	}
+
+	if err := sim.WaitForHeight(6, 60*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+	// Neither of the conflicting proposals gets a quorum
+	if roundChanges := countRoundChanges(t, sim, 6); roundChanges == 0 {
+		t.Errorf("no block was committed after a round change")
+	}
+}
+
+// countRoundChanges returns the number of blocks up to the given height committed in a round
+// other than the first one
+func countRoundChanges(t *testing.T, sim *Simulation, height uint64) int {
+	roundChanges := 0
+	for number := uint64(1); number <= height; number++ {
+		extra, err := types.ExtractIstanbulExtra(sim.Node(0).Chain.GetHeaderByNumber(number))
+		if err != nil {
+			t.Fatalf("failed to extract istanbul extra of block %d: %v", number, err)
+		}
+		if extra.AggregatedSeal.Round.Sign() > 0 {
+			roundChanges++
+		}
+	}
+	return roundChanges
+}
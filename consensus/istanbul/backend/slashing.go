@@ -0,0 +1,128 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/slashing"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// consensusMessageSignature returns the kind, view and digest of the PREPREPARE, PREPARE or
+// COMMIT message whose payload is to be signed. ok is false for any other data.
+func consensusMessageSignature(data []byte) (kind slashing.Kind, view *istanbul.View, digest common.Hash, ok bool) {
+	var msg istanbul.Message
+	if err := rlp.DecodeBytes(data, &msg); err != nil {
+		return 0, nil, common.Hash{}, false
+	}
+	switch msg.Code {
+	case istanbul.MsgPreprepare:
+		var preprepare *istanbul.Preprepare
+		if err := msg.Decode(&preprepare); err != nil || preprepare.Proposal == nil {
+			return 0, nil, common.Hash{}, false
+		}
+		kind, view, digest = slashing.Preprepare, preprepare.View, preprepare.Proposal.Hash()
+	case istanbul.MsgPrepare:
+		var prepare *istanbul.Subject
+		if err := msg.Decode(&prepare); err != nil {
+			return 0, nil, common.Hash{}, false
+		}
+		kind, view, digest = slashing.Prepare, prepare.View, prepare.Digest
+	case istanbul.MsgCommit:
+		var commit *istanbul.CommittedSubject
+		if err := msg.Decode(&commit); err != nil || commit.Subject == nil {
+			return 0, nil, common.Hash{}, false
+		}
+		kind, view, digest = slashing.Commit, commit.Subject.View, commit.Subject.Digest
+	default:
+		return 0, nil, common.Hash{}, false
+	}
+	if view == nil || view.Sequence == nil || view.Round == nil {
+		return 0, nil, common.Hash{}, false
+	}
+	return kind, view, digest, true
+}
+
+// checkSlashingProtection records the signature of a consensus message in the slashing
+// protection DB, and returns an error if a conflicting message was already signed in its view.
+// Data other than PREPREPARE, PREPARE and COMMIT messages is signed without checks.
+func (sb *Backend) checkSlashingProtection(data []byte) error {
+	kind, view, digest, ok := consensusMessageSignature(data)
+	if !ok {
+		return nil
+	}
+	return sb.recordSignature(kind, view, digest)
+}
+
+// checkBLSSlashingProtection records the BLS signature of the committed seal, or of the epoch
+// validator set seal if composite, in the view of the sealed subject, and returns an error if
+// different data was already signed in that view.
+func (sb *Backend) checkBLSSlashingProtection(data []byte, extra []byte, useComposite bool, view *istanbul.View) error {
+	if view == nil || view.Sequence == nil || view.Round == nil {
+		return nil
+	}
+	kind := slashing.CommittedSeal
+	if useComposite {
+		kind = slashing.EpochValidatorSetSeal
+	}
+	return sb.recordSignature(kind, view, crypto.Keccak256Hash(data, extra))
+}
+
+// openSlashingProtection opens the slashing protection DB when the validator starts validating
+func (sb *Backend) openSlashingProtection() error {
+	sb.slashingProtectionMu.Lock()
+	defer sb.slashingProtectionMu.Unlock()
+	if sb.slashingProtection != nil {
+		return nil
+	}
+	db, err := slashing.Open(sb.config.SlashingProtectionDBPath)
+	if err != nil {
+		sb.logger.Error("Can't open SlashingProtectionDB", "err", err, "dbpath", sb.config.SlashingProtectionDBPath)
+		return err
+	}
+	sb.slashingProtection = db
+	return nil
+}
+
+// closeSlashingProtection closes the slashing protection DB when the validator stops validating
+func (sb *Backend) closeSlashingProtection() error {
+	sb.slashingProtectionMu.Lock()
+	defer sb.slashingProtectionMu.Unlock()
+	if sb.slashingProtection == nil {
+		return nil
+	}
+	err := sb.slashingProtection.Close()
+	sb.slashingProtection = nil
+	return err
+}
+
+// recordSignature records the signature in the slashing protection DB. Consensus messages are
+// only signed while validating, when the DB is open.
+func (sb *Backend) recordSignature(kind slashing.Kind, view *istanbul.View, digest common.Hash) error {
+	sb.slashingProtectionMu.RLock()
+	defer sb.slashingProtectionMu.RUnlock()
+	if sb.slashingProtection == nil {
+		return istanbul.ErrStoppedEngine
+	}
+	err := sb.slashingProtection.CheckAndRecord(sb.address, kind, view.Sequence.Uint64(), view.Round.Uint64(), digest)
+	if err != nil {
+		sb.logger.Error("Slashing protection refused to sign", "kind", kind, "view", view, "digest", digest, "err", err)
+	}
+	return err
+}
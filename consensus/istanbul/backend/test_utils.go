@@ -2,9 +2,11 @@ package backend
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/celo-org/celo-bls-go/bls"
 	"github.com/ethereum/go-ethereum/accounts"
@@ -13,7 +15,6 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	istanbulCore "github.com/ethereum/go-ethereum/consensus/istanbul/core"
 	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
-	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -40,6 +41,8 @@ func newBlockChainWithKeys(genesis *core.Genesis, nodeKeys []*ecdsa.PrivateKey)
 	config.ValidatorEnodeDBPath = ""
 	config.VersionCertificateDBPath = ""
 	config.RoundStateDBPath = ""
+	config.EquivocationDBPath = ""
+	config.SlashingProtectionDBPath = ""
 	// Use the first key as private key
 	publicKey := nodeKeys[0].PublicKey
 	address := crypto.PubkeyToAddress(publicKey)
@@ -64,6 +67,7 @@ func newBlockChainWithKeys(genesis *core.Genesis, nodeKeys []*ecdsa.PrivateKey)
 			return blockchain.StateAt(stateRoot)
 		},
 	)
+	b.SetSystemCaller(blockchain.SystemCaller())
 	b.SetBroadcaster(&consensustest.MockBroadcaster{})
 	b.SetP2PServer(consensustest.NewMockP2PServer())
 	b.StartAnnouncing()
@@ -75,8 +79,6 @@ func newBlockChainWithKeys(genesis *core.Genesis, nodeKeys []*ecdsa.PrivateKey)
 			return blockchain.Validator().ValidateState(block, state, receipts, usedGas)
 		})
 
-	contract_comm.SetInternalEVMHandler(blockchain)
-
 	return blockchain, b
 }
 
@@ -133,7 +135,11 @@ func makeBlock(keys []*ecdsa.PrivateKey, chain *core.BlockChain, engine *Backend
 	block := makeBlockWithoutSeal(chain, engine, parent)
 	block, _ = engine.updateBlock(parent.Header(), block)
 
-	// start the sealing procedure
+	// start the sealing procedure, and wait for the block to be proposed. Seal records
+	// the proposed block hash asynchronously, so calling Commit straight away races
+	// with it and the committed block can be dropped as not being the proposed one.
+	eventSub := engine.EventMux().Subscribe(istanbul.RequestEvent{})
+	defer eventSub.Unsubscribe()
 	results := make(chan *types.Block)
 	go func() {
 		err := engine.Seal(chain, block, results, nil)
@@ -141,6 +147,11 @@ func makeBlock(keys []*ecdsa.PrivateKey, chain *core.BlockChain, engine *Backend
 			panic(err)
 		}
 	}()
+	select {
+	case <-eventSub.Chan():
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("timed out waiting for the block to be proposed")
+	}
 
 	// create the sig and call Commit so that the result is pushed to the channel
 	aggregatedSeal := signBlock(keys, block)
@@ -243,12 +254,12 @@ func SignFn(key *ecdsa.PrivateKey) istanbul.SignerFn {
 	}
 }
 
-func SignBLSFn(key *ecdsa.PrivateKey) istanbul.BLSSignerFn {
+func SignBLSFn(key *ecdsa.PrivateKey) istanbul.BLSViewSignerFn {
 	if key == nil {
 		key, _ = generatePrivateKey()
 	}
 
-	return func(_ accounts.Account, data []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+	return func(_ accounts.Account, data []byte, extraData []byte, useComposite bool, _, _ *big.Int) (blscrypto.SerializedSignature, error) {
 		privateKeyBytes, err := blscrypto.ECDSAToBLS(key)
 		if err != nil {
 			return blscrypto.SerializedSignature{}, err
@@ -287,7 +298,7 @@ func signBlock(keys []*ecdsa.PrivateKey, block *types.Block) types.IstanbulAggre
 
 	for i, key := range keys {
 		signFn := SignBLSFn(key)
-		sig, err := signFn(accounts.Account{}, msg, extraData, useComposite)
+		sig, err := signFn(accounts.Account{}, msg, extraData, useComposite, nil, nil)
 		if err != nil {
 			panic("could not sign msg")
 		}
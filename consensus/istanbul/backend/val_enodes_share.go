@@ -92,7 +92,7 @@ func (sb *Backend) valEnodesShareThread() {
 		case <-ticker.C:
 			// output the valEnodeTable for debugging purposes
 			log.Trace("ValidatorEnodeTable dump", "ValidatorEnodeTable", sb.valEnodeTable.String())
-			go sb.sendValEnodesShareMsg()
+			go sb.sendValEnodesShareMsg(sb.proxies.getConnectedProxyPeers()...)
 
 		case <-sb.valEnodesShareThreadQuit:
 			ticker.Stop()
@@ -143,9 +143,10 @@ func (sb *Backend) generateValEnodesShareMsg() (*istanbul.Message, error) {
 	return msg, nil
 }
 
-func (sb *Backend) sendValEnodesShareMsg() error {
+// sendValEnodesShareMsg sends a validator enodes share message to the given proxy peers
+func (sb *Backend) sendValEnodesShareMsg(proxyPeers ...consensus.Peer) error {
 	logger := sb.logger.New("func", "sendValEnodesShareMsg")
-	if sb.proxyNode == nil || sb.proxyNode.peer == nil {
+	if len(proxyPeers) == 0 {
 		logger.Warn("No proxy peers, cannot send Istanbul Validator Enodes Share message")
 		return nil
 	}
@@ -169,13 +170,16 @@ func (sb *Backend) sendValEnodesShareMsg() error {
 		return err
 	}
 
-	logger.Trace("Sending Istanbul Validator Enodes Share payload to proxy peer")
-	if err := sb.proxyNode.peer.Send(istanbul.ValEnodesShareMsg, payload); err != nil {
-		logger.Error("Error sending Istanbul ValEnodesShare Message to proxy", "err", err)
-		return err
+	var lastErr error
+	for _, proxyPeer := range proxyPeers {
+		logger.Trace("Sending Istanbul Validator Enodes Share payload to proxy peer", "peer", proxyPeer.Node().ID())
+		if err := proxyPeer.Send(istanbul.ValEnodesShareMsg, payload); err != nil {
+			logger.Error("Error sending Istanbul ValEnodesShare Message to proxy", "peer", proxyPeer.Node().ID(), "err", err)
+			lastErr = err
+		}
 	}
 
-	return nil
+	return lastErr
 }
 
 func (sb *Backend) handleValEnodesShareMsg(_ common.Address, _ consensus.Peer, payload []byte) error {
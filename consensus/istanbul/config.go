@@ -27,6 +27,7 @@ const (
 	RoundRobin ProposerPolicy = iota
 	Sticky
 	ShuffledRoundRobin
+	StakeWeighted
 )
 
 type Config struct {
@@ -41,7 +42,23 @@ type Config struct {
 	ValidatorEnodeDBPath        string         `toml:",omitempty"` // The location for the validator enodes DB
 	VersionCertificateDBPath    string         `toml:",omitempty"` // The location for the signed announce version DB
 	RoundStateDBPath            string         `toml:",omitempty"` // The location for the round states DB
+	EquivocationDBPath          string         `toml:",omitempty"` // The location for the equivocation evidence DB
+	SlashingProtectionDBPath    string         `toml:",omitempty"` // The location for the slashing protection DB
 	Validator                   bool           `toml:",omitempty"` // Specified if this node is configured to validate (specifically if --mine command line is set)
+	Replica                     bool           `toml:",omitempty"` // Specifies if this validator starts as a replica, tracking consensus state without signing
+
+	// Consensus WAL Configs
+	WAL            bool   `toml:",omitempty"` // Specifies if the consensus events and state transitions are recorded in a write-ahead log
+	WALPath        string `toml:",omitempty"` // The location for the consensus WAL segment files
+	WALMaxFileSize uint64 `toml:",omitempty"` // The size in bytes after which a new WAL segment file is started
+	WALMaxFiles    int    `toml:",omitempty"` // The number of most recent WAL segment files to keep
+
+	// Backlog Configs, the limits on the future messages kept until their view is reached
+	BacklogMaxFutureSequences uint64 `toml:",omitempty"` // The number of sequences in the future to keep messages for
+	BacklogMaxFutureRounds    uint64 `toml:",omitempty"` // The number of rounds in the future to keep messages for (0 for no limit)
+	BacklogMaxMsgsPerSender   int    `toml:",omitempty"` // The maximum number of messages kept per validator
+	BacklogMaxBytesPerSender  uint64 `toml:",omitempty"` // The maximum size in bytes of the messages kept per validator
+	BacklogPenaltyThreshold   uint64 `toml:",omitempty"` // The number of messages over its quotas after which a validator's messages are dropped until the next sequence
 
 	// Proxy Configs
 	Proxy                   bool           `toml:",omitempty"` // Specifies if this node is a proxy
@@ -70,7 +87,17 @@ var DefaultConfig = &Config{
 	ValidatorEnodeDBPath:           "validatorenodes",
 	VersionCertificateDBPath:       "versioncertificates",
 	RoundStateDBPath:               "roundstates",
+	EquivocationDBPath:             "equivocations",
+	SlashingProtectionDBPath:       "slashingprotection",
 	Validator:                      false,
+	WAL:                            false,
+	WALPath:                        "consensuswal",
+	WALMaxFileSize:                 64 * 1024 * 1024,
+	WALMaxFiles:                    16,
+	BacklogMaxFutureSequences:      10,
+	BacklogMaxMsgsPerSender:        1000,
+	BacklogMaxBytesPerSender:       16 * 1024 * 1024,
+	BacklogPenaltyThreshold:        100,
 	Proxy:                          false,
 	Proxied:                        false,
 	AnnounceQueryEnodeGossipPeriod: 300, // 5 minutes
@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/prque"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var (
@@ -37,11 +38,8 @@ var (
 		istanbul.MsgPrepare:    3,
 	}
 
-	// Do not accept messages for views more than this many sequences in the future.
-	acceptMaxFutureSequence             = big.NewInt(10)
-	acceptMaxFutureMsgsFromOneValidator = 1000
-	acceptMaxFutureMessages             = 10 * 1000
-	acceptMaxFutureMessagesPruneBatch   = 100
+	acceptMaxFutureMessages           = 10 * 1000
+	acceptMaxFutureMessagesPruneBatch = 100
 )
 
 // checkMessage checks the message state
@@ -107,38 +105,82 @@ type MsgBacklog interface {
 }
 
 type msgBacklogImpl struct {
-	backlogBySeq  map[uint64]*prque.Prque
-	msgCountBySrc map[common.Address]int
-	msgCount      int
+	backlogBySeq   map[uint64]*prque.Prque
+	msgCountBySrc  map[common.Address]int
+	msgBytesBySrc  map[common.Address]uint64
+	overflowsBySrc map[common.Address]uint64
+	penalizedSrcs  map[common.Address]bool // Validators whose future messages are dropped until the next sequence
+	msgCount       int
 
 	currentView  *istanbul.View
 	currentState State
 
+	// Limits of the backlog, see the Backlog Configs of istanbul.Config
+	maxFutureSequence *big.Int
+	maxFutureRounds   *big.Int // nil if future rounds are only bounded by the priority queue
+	maxMsgsPerSender  int
+	maxBytesPerSender uint64
+	penaltyThreshold  uint64
+
 	backlogsMu   *sync.Mutex
 	msgProcessor func(*istanbul.Message)
 	checkMessage func(msgCode uint64, msgView *istanbul.View) error
+	penalize     func(common.Address)
 	logger       log.Logger
+
+	droppedMeter       metrics.Meter                    // Meter for all the messages dropped from the backlog
+	droppedMetersBySrc map[common.Address]metrics.Meter // Meters for the messages dropped, per sender
 }
 
-func newMsgBacklog(msgProcessor func(*istanbul.Message), checkMessage func(msgCode uint64, msgView *istanbul.View) error) MsgBacklog {
+// newMsgBacklog creates a backlog with the limits set in the config (or their defaults if unset).
+// penalize is called when the messages of a validator keep exceeding its quotas.
+func newMsgBacklog(msgProcessor func(*istanbul.Message), checkMessage func(msgCode uint64, msgView *istanbul.View) error, config *istanbul.Config, penalize func(common.Address)) MsgBacklog {
 	initialView := &istanbul.View{
 		Round:    big.NewInt(0),
 		Sequence: big.NewInt(1),
 	}
 
-	return &msgBacklogImpl{
-		backlogBySeq:  make(map[uint64]*prque.Prque),
-		msgCountBySrc: make(map[common.Address]int),
-		msgCount:      0,
+	c := &msgBacklogImpl{
+		backlogBySeq:   make(map[uint64]*prque.Prque),
+		msgCountBySrc:  make(map[common.Address]int),
+		msgBytesBySrc:  make(map[common.Address]uint64),
+		overflowsBySrc: make(map[common.Address]uint64),
+		penalizedSrcs:  make(map[common.Address]bool),
+		msgCount:       0,
 
 		currentView:  initialView,
 		currentState: StateAcceptRequest,
 
+		maxFutureSequence: new(big.Int).SetUint64(istanbul.DefaultConfig.BacklogMaxFutureSequences),
+		maxMsgsPerSender:  istanbul.DefaultConfig.BacklogMaxMsgsPerSender,
+		maxBytesPerSender: istanbul.DefaultConfig.BacklogMaxBytesPerSender,
+		penaltyThreshold:  istanbul.DefaultConfig.BacklogPenaltyThreshold,
+
 		msgProcessor: msgProcessor,
 		checkMessage: checkMessage,
+		penalize:     penalize,
 		backlogsMu:   new(sync.Mutex),
 		logger:       log.New("type", "MsgBacklog"),
+
+		droppedMeter:       metrics.GetOrRegisterMeter("consensus/istanbul/core/backlog/dropped", nil),
+		droppedMetersBySrc: make(map[common.Address]metrics.Meter),
+	}
+	if config.BacklogMaxFutureSequences > 0 {
+		c.maxFutureSequence = new(big.Int).SetUint64(config.BacklogMaxFutureSequences)
+	}
+	if config.BacklogMaxFutureRounds > 0 {
+		c.maxFutureRounds = new(big.Int).SetUint64(config.BacklogMaxFutureRounds)
+	}
+	if config.BacklogMaxMsgsPerSender > 0 {
+		c.maxMsgsPerSender = config.BacklogMaxMsgsPerSender
+	}
+	if config.BacklogMaxBytesPerSender > 0 {
+		c.maxBytesPerSender = config.BacklogMaxBytesPerSender
+	}
+	if config.BacklogPenaltyThreshold > 0 {
+		c.penaltyThreshold = config.BacklogPenaltyThreshold
 	}
+	return c
 }
 
 func (c *msgBacklogImpl) store(msg *istanbul.Message) {
@@ -151,27 +193,58 @@ func (c *msgBacklogImpl) store(msg *istanbul.Message) {
 	}
 
 	c.backlogsMu.Lock()
-	defer c.backlogsMu.Unlock()
+	penalize := false
+	defer func() {
+		c.backlogsMu.Unlock()
+		if penalize && c.penalize != nil {
+			c.penalize(msg.Address)
+		}
+	}()
 
 	// Never accept messages too far into the future
-	if view.Sequence.Cmp(new(big.Int).Add(c.currentView.Sequence, acceptMaxFutureSequence)) > 0 {
+	if view.Sequence.Cmp(new(big.Int).Add(c.currentView.Sequence, c.maxFutureSequence)) > 0 {
 		logger.Debug("Dropping message", "reason", "too far in the future", "m", msg)
+		c.markDropped(msg.Address)
 		return
 	}
 
 	if view.Round.Cmp(maxRoundForPriorityQueue) >= 0 {
 		logger.Debug("Dropping message", "reason", "round exceeds PQ bounds check", "m", msg)
+		c.markDropped(msg.Address)
 		return
 	}
 
-	// Check and inc per-validator future message limit
-	if c.msgCountBySrc[msg.Address] > acceptMaxFutureMsgsFromOneValidator {
-		logger.Debug("Dropping message", "reason", "exceeds per-address cap")
+	// Rounds of future sequences count from the first round
+	if c.maxFutureRounds != nil {
+		baseRound := common.Big0
+		if view.Sequence.Cmp(c.currentView.Sequence) == 0 {
+			baseRound = c.currentView.Round
+		}
+		if view.Round.Cmp(new(big.Int).Add(baseRound, c.maxFutureRounds)) > 0 {
+			logger.Debug("Dropping message", "reason", "too many rounds in the future", "m", msg)
+			c.markDropped(msg.Address)
+			return
+		}
+	}
+
+	if c.penalizedSrcs[msg.Address] {
+		logger.Debug("Dropping message", "reason", "sender penalized for overflowing its quotas", "m", msg)
+		c.markDropped(msg.Address)
+		return
+	}
+
+	// Check and inc per-validator future message limits
+	size := uint64(len(msg.Msg) + len(msg.Signature))
+	if c.msgCountBySrc[msg.Address] > c.maxMsgsPerSender || c.msgBytesBySrc[msg.Address]+size > c.maxBytesPerSender {
+		logger.Debug("Dropping message", "reason", "exceeds per-address cap", "count", c.msgCountBySrc[msg.Address], "bytes", c.msgBytesBySrc[msg.Address])
+		c.markDropped(msg.Address)
+		penalize = c.markOverflow(msg.Address)
 		return
 	}
 
 	logger.Trace("Store future message", "m", msg, "m_seq", view.Sequence, "m_round", view.Round)
 	c.msgCountBySrc[msg.Address]++
+	c.msgBytesBySrc[msg.Address] += size
 	c.msgCount++
 
 	// Add message to per-seq list
@@ -187,6 +260,34 @@ func (c *msgBacklogImpl) store(msg *istanbul.Message) {
 	c.removeMessagesOverflow()
 }
 
+// markDropped updates the metrics of the messages dropped from the given sender.
+// Call with backlogsMu held.
+func (c *msgBacklogImpl) markDropped(src common.Address) {
+	c.droppedMeter.Mark(1)
+	meter := c.droppedMetersBySrc[src]
+	if meter == nil {
+		meter = metrics.GetOrRegisterMeter("consensus/istanbul/core/backlog/dropped/"+src.Hex(), nil)
+		c.droppedMetersBySrc[src] = meter
+	}
+	meter.Mark(1)
+}
+
+// markOverflow counts a message of the given sender dropped for exceeding its quotas, and
+// penalizes the sender once it reaches the penalty threshold: its messages are removed from
+// the backlog, and its future messages are dropped until the next sequence. Returns whether
+// the sender was penalized. Call with backlogsMu held.
+func (c *msgBacklogImpl) markOverflow(src common.Address) bool {
+	c.overflowsBySrc[src]++
+	if c.overflowsBySrc[src] < c.penaltyThreshold {
+		return false
+	}
+	c.logger.Warn("Validator keeps overflowing the backlog", "from", src, "overflows", c.overflowsBySrc[src])
+	delete(c.overflowsBySrc, src)
+	c.penalizedSrcs[src] = true
+	c.clearBacklogForSrc(src)
+	return true
+}
+
 // removeMessagesOverflow will remove messages if necessary to maintain the number of messages <= acceptMaxFutureMessages
 // For that, it will remove messages that further on the future
 func (c *msgBacklogImpl) removeMessagesOverflow() {
@@ -226,6 +327,33 @@ func (c *msgBacklogImpl) clearBacklogForSeq(seq uint64) {
 	c.processBacklogForSeq(seq, func(_ *istanbul.Message) bool { return true })
 }
 
+// clearBacklogForSrc will remove all entries in the backlog
+// from the given sender
+func (c *msgBacklogImpl) clearBacklogForSrc(src common.Address) {
+	for seq, backlogForSeq := range c.backlogBySeq {
+		var kept []*istanbul.Message
+		var priorities []int64
+		for !backlogForSeq.Empty() {
+			m, priority := backlogForSeq.Pop()
+			msg := m.(*istanbul.Message)
+			if msg.Address != src {
+				kept = append(kept, msg)
+				priorities = append(priorities, priority)
+				continue
+			}
+			c.msgCount--
+		}
+		for i, msg := range kept {
+			backlogForSeq.Push(msg, priorities[i])
+		}
+		if backlogForSeq.Size() == 0 {
+			delete(c.backlogBySeq, seq)
+		}
+	}
+	delete(c.msgCountBySrc, src)
+	delete(c.msgBytesBySrc, src)
+}
+
 // processBacklogForSeq will call process() with each entry of the backlog
 // for the given seq, until process return "false".
 // The entry on which process() returned false will remain in the backlog
@@ -248,8 +376,10 @@ func (c *msgBacklogImpl) processBacklogForSeq(seq uint64, process func(*istanbul
 		}
 
 		c.msgCountBySrc[msg.Address]--
+		c.msgBytesBySrc[msg.Address] -= uint64(len(msg.Msg) + len(msg.Signature))
 		if c.msgCountBySrc[msg.Address] == 0 {
 			delete(c.msgCountBySrc, msg.Address)
+			delete(c.msgBytesBySrc, msg.Address)
 		}
 		c.msgCount--
 	}
@@ -263,6 +393,12 @@ func (c *msgBacklogImpl) updateState(view *istanbul.View, state State) {
 	c.backlogsMu.Lock()
 	defer c.backlogsMu.Unlock()
 
+	// Validators are only penalized for overflowing their quotas repeatedly within a sequence,
+	// and until the next sequence
+	if view.Sequence.Cmp(c.currentView.Sequence) != 0 {
+		c.overflowsBySrc = make(map[common.Address]uint64)
+		c.penalizedSrcs = make(map[common.Address]bool)
+	}
 	c.currentState = state
 	c.currentView = view
 
@@ -216,6 +216,8 @@ func TestStoreBacklog(t *testing.T) {
 	backlog := newMsgBacklog(
 		func(msg *istanbul.Message) {},
 		func(msgCode uint64, msgView *istanbul.View) error { return nil },
+		istanbul.DefaultConfig,
+		nil,
 	).(*msgBacklogImpl)
 	defer backlog.clearBacklogForSeq(12)
 
@@ -324,6 +326,8 @@ func TestProcessFutureBacklog(t *testing.T) {
 	backlog := newMsgBacklog(
 		func(msg *istanbul.Message) {},
 		func(msgCode uint64, msgView *istanbul.View) error { return nil },
+		istanbul.DefaultConfig,
+		nil,
 	).(*msgBacklogImpl)
 	defer backlog.clearBacklogForSeq(12)
 
@@ -384,6 +388,78 @@ func TestProcessFutureBacklog(t *testing.T) {
 	}
 }
 
+func TestBacklogSenderQuotas(t *testing.T) {
+	testLogger.SetHandler(elog.StdoutHandler)
+
+	config := *istanbul.DefaultConfig
+	config.BacklogMaxMsgsPerSender = 4
+	config.BacklogMaxFutureRounds = 5
+	config.BacklogPenaltyThreshold = 3
+
+	var penalized []common.Address
+	backlog := newMsgBacklog(
+		func(msg *istanbul.Message) {},
+		func(msgCode uint64, msgView *istanbul.View) error { return nil },
+		&config,
+		func(addr common.Address) { penalized = append(penalized, addr) },
+	).(*msgBacklogImpl)
+
+	newPrepare := func(addr common.Address, seq, round int64) *istanbul.Message {
+		payload, _ := Encode(&istanbul.Subject{
+			View:   &istanbul.View{Sequence: big.NewInt(seq), Round: big.NewInt(round)},
+			Digest: common.BytesToHash([]byte("1234567890")),
+		})
+		return &istanbul.Message{Code: istanbul.MsgPrepare, Msg: payload, Address: addr}
+	}
+	spammer, honest := common.Address{1}, common.Address{2}
+
+	// Rounds too far in the future are dropped
+	backlog.store(newPrepare(honest, 2, 6))
+	if backlog.msgCount != 0 {
+		t.Errorf("message too many rounds in the future stored")
+	}
+
+	for i := int64(0); i < 10; i++ {
+		backlog.store(newPrepare(spammer, 2+i%5, 0))
+	}
+	backlog.store(newPrepare(honest, 2, 0))
+	if len(penalized) != 1 || penalized[0] != spammer {
+		t.Errorf("penalized validators mismatch: have %v, want [%v]", penalized, spammer)
+	}
+
+	// The messages of the penalized validator are dropped until the next sequence
+	if have := backlog.msgCountBySrc[spammer]; have != 0 {
+		t.Errorf("spammer messages mismatch: have %d, want 0", have)
+	}
+	if have := backlog.msgCountBySrc[honest]; have != 1 || backlog.msgCount != 1 {
+		t.Errorf("honest messages mismatch: have %d of %d, want 1", have, backlog.msgCount)
+	}
+	backlog.store(newPrepare(spammer, 3, 0))
+	if have := backlog.msgCountBySrc[spammer]; have != 0 {
+		t.Errorf("penalized validator message stored")
+	}
+	backlog.updateState(&istanbul.View{Sequence: big.NewInt(2), Round: big.NewInt(0)}, StateAcceptRequest)
+	backlog.store(newPrepare(spammer, 3, 0))
+	if have := backlog.msgCountBySrc[spammer]; have != 1 {
+		t.Errorf("spammer messages mismatch after the next sequence: have %d, want 1", have)
+	}
+
+	// Per-sender byte quota
+	config.BacklogMaxBytesPerSender = uint64(len(newPrepare(honest, 2, 0).Msg)) * 2
+	backlog = newMsgBacklog(
+		func(msg *istanbul.Message) {},
+		func(msgCode uint64, msgView *istanbul.View) error { return nil },
+		&config,
+		nil,
+	).(*msgBacklogImpl)
+	for i := int64(0); i < 3; i++ {
+		backlog.store(newPrepare(honest, 2, i))
+	}
+	if have := backlog.msgCountBySrc[honest]; have != 2 {
+		t.Errorf("messages within byte quota mismatch: have %d, want 2", have)
+	}
+}
+
 func TestProcessBacklog(t *testing.T) {
 	v := &istanbul.View{
 		Round:    big.NewInt(0),
@@ -458,6 +534,8 @@ func testProcessBacklog(t *testing.T, msg *istanbul.Message) {
 	backlog := newMsgBacklog(
 		registerCall,
 		func(msgCode uint64, msgView *istanbul.View) error { return nil },
+		istanbul.DefaultConfig,
+		nil,
 	).(*msgBacklogImpl)
 	defer backlog.clearBacklogForSeq(12)
 
@@ -33,7 +33,7 @@ func (c *core) sendCommit() {
 
 func (c *core) generateCommittedSeal(sub *istanbul.Subject) (blscrypto.SerializedSignature, error) {
 	seal := PrepareCommittedSeal(sub.Digest, sub.View.Round)
-	committedSeal, err := c.backend.SignBLS(seal, []byte{}, false)
+	committedSeal, err := c.backend.SignBLS(seal, []byte{}, false, sub.View)
 	if err != nil {
 		return blscrypto.SerializedSignature{}, err
 	}
@@ -60,6 +60,11 @@ func (c *core) generateEpochValidatorSetData(blockNumber uint64, newValSet istan
 func (c *core) broadcastCommit(sub *istanbul.Subject) {
 	logger := c.newLogger("func", "broadcastCommit")
 
+	if !c.backend.IsPrimaryForSeq(sub.View.Sequence) {
+		logger.Trace("Not sending commit as a replica")
+		return
+	}
+
 	committedSeal, err := c.generateCommittedSeal(sub)
 	if err != nil {
 		logger.Error("Failed to commit seal", "err", err)
@@ -79,7 +84,7 @@ func (c *core) broadcastCommit(sub *istanbul.Subject) {
 	}
 	var epochValidatorSetSeal blscrypto.SerializedSignature
 	if err == nil {
-		epochValidatorSetSeal, err = c.backend.SignBLS(epochValidatorSetData[:], []byte{}, true)
+		epochValidatorSetSeal, err = c.backend.SignBLS(epochValidatorSetData[:], []byte{}, true, sub.View)
 		if err != nil {
 			logger.Error("Failed to sign epoch validator set seal", "err", err)
 			return
@@ -139,7 +144,10 @@ func (c *core) handleCheckedCommitForPreviousSequence(msg *istanbul.Message, com
 	headBlock := c.backend.GetCurrentHeadBlock()
 	// Retrieve the validator set for the previous proposal (which should
 	// match the one broadcast)
-	parentValset := c.backend.ParentBlockValidators(headBlock)
+	parentValset, err := c.backend.ParentBlockValidators(headBlock)
+	if err != nil {
+		return err
+	}
 	_, validator := parentValset.GetByAddress(msg.Address)
 	if validator == nil {
 		return errInvalidValidatorAddress
@@ -147,6 +155,9 @@ func (c *core) handleCheckedCommitForPreviousSequence(msg *istanbul.Message, com
 	if err := c.verifyCommittedSeal(commit, validator); err != nil {
 		return errInvalidCommittedSeal
 	}
+	// Record the COMMIT to detect validators signing conflicting ones
+	c.detectEquivocation(msg, commit, parentValset)
+
 	if headBlock.Number().Uint64() > 0 {
 		if err := c.verifyEpochValidatorSetSeal(commit, headBlock.Number().Uint64(), c.current.ValidatorSet(), validator); err != nil {
 			return errInvalidEpochValidatorSetSeal
@@ -177,6 +188,8 @@ func (c *core) handleCheckedCommitForCurrentSequence(msg *istanbul.Message, comm
 	if err := c.verifyCommittedSeal(commit, validator); err != nil {
 		return errInvalidCommittedSeal
 	}
+	// Record the COMMIT to detect validators signing conflicting ones
+	c.detectEquivocation(msg, commit, c.current.ValidatorSet())
 
 	newValSet, err := c.backend.NextBlockValidators(c.current.Proposal())
 	if err != nil {
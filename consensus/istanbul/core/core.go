@@ -60,6 +60,12 @@ type core struct {
 	current   RoundState
 	handlerWg *sync.WaitGroup
 
+	evdb          EquivocationDB // nil unless the core is started as a validator
+	evdbMu        sync.RWMutex   // Protects evdb from the handler and the API while the core starts and stops
+	equivocations equivocationDetector
+
+	wal *wal // nil if the consensus WAL is disabled
+
 	roundChangeSet *roundChangeSet
 
 	pendingRequests   *prque.Prque
@@ -91,12 +97,20 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 		rsdb:               rsdb,
 		consensusTimer:     metrics.NewRegisteredTimer("consensus/istanbul/core/consensus", nil),
 	}
+	if config.WAL {
+		w, err := openWAL(config.WALPath, config.WALMaxFileSize, config.WALMaxFiles)
+		if err != nil {
+			log.Crit("Failed to open consensus WAL", "err", err)
+		}
+		c.wal = w
+		c.backend = newWALBackend(backend, c.recordWALCall)
+	}
 	msgBacklog := newMsgBacklog(
 		func(msg *istanbul.Message) {
 			c.sendEvent(backlogEvent{
 				msg: msg,
 			})
-		}, c.checkMessage)
+		}, c.checkMessage, config, c.backend.PenalizeOverflowingValidator)
 	c.backlog = msgBacklog
 	c.validateFn = c.checkValidatorSignature
 	c.logger = istanbul.NewIstLogger(
@@ -271,6 +285,12 @@ func (c *core) unicast(msg *istanbul.Message, addr common.Address) {
 func (c *core) sendMsgTo(msg *istanbul.Message, addresses []common.Address) {
 	logger := c.newLogger("func", "sendMsgTo")
 
+	// Replicas track the consensus state of the primary without signing messages
+	if !c.backend.IsPrimaryForSeq(c.current.Sequence()) {
+		logger.Trace("Not sending message as a replica", "m", msg)
+		return
+	}
+
 	payload, err := c.finalizeMessage(msg)
 	if err != nil {
 		logger.Error("Failed to finalize message", "m", msg, "err", err)
@@ -450,7 +470,12 @@ func (c *core) startNewRound(round *big.Int) error {
 			Sequence: new(big.Int).Add(headBlock.Number(), common.Big1),
 			Round:    new(big.Int),
 		}
-		valSet = c.backend.Validators(headBlock)
+		var err error
+		valSet, err = c.backend.Validators(headBlock)
+		if err != nil {
+			logger.Error("Unable to get the validator set", "err", err)
+			return err
+		}
 		c.roundChangeSet = newRoundChangeSet(valSet)
 	}
 
@@ -531,7 +556,11 @@ func (c *core) createRoundState() (RoundState, error) {
 		} else {
 			logger.Info("Creating new RoundState", "reason", "old view", "stored_view", lastStoredView, "requested_seq", nextSequence)
 		}
-		valSet := c.backend.Validators(headBlock)
+		valSet, err := c.backend.Validators(headBlock)
+		if err != nil {
+			logger.Error("Failed to get the validator set", "err", err)
+			return nil, err
+		}
 		proposer := c.selectProposer(valSet, headAuthor, 0)
 		roundState = newRoundState(&istanbul.View{Sequence: nextSequence, Round: common.Big0}, valSet, proposer)
 	} else {
@@ -571,7 +600,11 @@ func (c *core) resetRoundState(view *istanbul.View, validatorSet istanbul.Valida
 	} else {
 		// Otherwise, we will initialize an empty ParentCommits field with the validator set of the last proposal.
 		headBlock := c.backend.GetCurrentHeadBlock()
-		newParentCommits = newMessageSet(c.backend.ParentBlockValidators(headBlock))
+		parentValSet, err := c.backend.ParentBlockValidators(headBlock)
+		if err != nil {
+			return err
+		}
+		newParentCommits = newMessageSet(parentValSet)
 	}
 	return c.current.StartNewSequence(view.Sequence, validatorSet, nextProposer, newParentCommits)
 
@@ -0,0 +1,216 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+)
+
+// EquivocationEvidence proves that a validator signed two conflicting PREPARE or COMMIT
+// messages in the same view: either for two different proposals, or two different committed
+// seals. Both messages carry the validator's signature, and the validator set of the sequence
+// allows verifying the committed seals.
+type EquivocationEvidence struct {
+	View       *istanbul.View
+	Code       uint64
+	Validator  common.Address
+	First      *istanbul.Message
+	Second     *istanbul.Message
+	Validators []istanbul.ValidatorData
+}
+
+// EquivocationEvidenceSummary is the representation of an EquivocationEvidence returned by the API
+type EquivocationEvidenceSummary struct {
+	Sequence  *big.Int       `json:"sequence"`
+	Round     *big.Int       `json:"round"`
+	Code      uint64         `json:"code"`
+	Validator common.Address `json:"validator"`
+
+	FirstDigest   common.Hash   `json:"firstDigest"`
+	FirstPayload  hexutil.Bytes `json:"firstPayload"`
+	SecondDigest  common.Hash   `json:"secondDigest"`
+	SecondPayload hexutil.Bytes `json:"secondPayload"`
+
+	ValidatorSet           []common.Address `json:"validatorSet"`
+	ValidatorBLSPublicKeys []hexutil.Bytes  `json:"validatorBLSPublicKeys"`
+}
+
+// Summary returns the API representation of the evidence, with the signed messages in the
+// format they were sent over the wire.
+func (ev *EquivocationEvidence) Summary() (*EquivocationEvidenceSummary, error) {
+	summary := &EquivocationEvidenceSummary{
+		Sequence:               ev.View.Sequence,
+		Round:                  ev.View.Round,
+		Code:                   ev.Code,
+		Validator:              ev.Validator,
+		ValidatorSet:           make([]common.Address, len(ev.Validators)),
+		ValidatorBLSPublicKeys: make([]hexutil.Bytes, len(ev.Validators)),
+	}
+	for i, val := range ev.Validators {
+		summary.ValidatorSet[i] = val.Address
+		summary.ValidatorBLSPublicKeys[i] = common.CopyBytes(val.BLSPublicKey[:])
+	}
+
+	var err error
+	if summary.FirstPayload, err = ev.First.Payload(); err != nil {
+		return nil, err
+	}
+	if summary.SecondPayload, err = ev.Second.Payload(); err != nil {
+		return nil, err
+	}
+	first, err := decodeVote(ev.First)
+	if err != nil {
+		return nil, err
+	}
+	second, err := decodeVote(ev.Second)
+	if err != nil {
+		return nil, err
+	}
+	summary.FirstDigest = first.Subject.Digest
+	summary.SecondDigest = second.Subject.Digest
+	return summary, nil
+}
+
+// equivocationSequencesToSave is the number of sequences, up to the latest one seen, for
+// which the PREPARE and COMMIT messages of the validators are kept to detect equivocations.
+const equivocationSequencesToSave = 100
+
+// equivocationDetector tracks the latest sequence whose PREPARE and COMMIT messages were
+// checked for equivocations, to prune the messages of older sequences.
+type equivocationDetector struct {
+	sequence *big.Int
+}
+
+// decodeVote decodes a PREPARE or COMMIT message. A PREPARE is decoded into a
+// CommittedSubject without seals.
+func decodeVote(msg *istanbul.Message) (*istanbul.CommittedSubject, error) {
+	if msg.Code == istanbul.MsgCommit {
+		var commit *istanbul.CommittedSubject
+		if err := msg.Decode(&commit); err != nil {
+			return nil, errFailedDecodeCommit
+		}
+		return commit, nil
+	}
+	var prepare *istanbul.Subject
+	if err := msg.Decode(&prepare); err != nil {
+		return nil, errFailedDecodePrepare
+	}
+	return &istanbul.CommittedSubject{Subject: prepare}, nil
+}
+
+// conflictingVotes returns whether two votes of a validator for the same view sign
+// different proposals or different committed seals.
+func conflictingVotes(a, b *istanbul.CommittedSubject) bool {
+	return a.Subject.Digest != b.Subject.Digest || !bytes.Equal(a.CommittedSeal, b.CommittedSeal)
+}
+
+// detectEquivocation records the given PREPARE or COMMIT message, decoded into vote, and
+// stores evidence of an equivocation if the validator already signed a conflicting message
+// for the same view. valSet is the validator set of the message's sequence. It must be
+// called from the handler goroutine, with messages whose signature, sender and view have
+// been checked. Nothing is recorded unless the core was started as a validator.
+func (c *core) detectEquivocation(msg *istanbul.Message, vote *istanbul.CommittedSubject, valSet istanbul.ValidatorSet) {
+	c.evdbMu.RLock()
+	defer c.evdbMu.RUnlock()
+
+	if c.evdb == nil {
+		return
+	}
+	view := vote.Subject.View
+	logger := c.newLogger("func", "detectEquivocation", "from", msg.Address, "msg_round", view.Round, "msg_seq", view.Sequence, "msg_code", msg.Code)
+
+	if c.equivocations.sequence == nil || c.equivocations.sequence.Cmp(view.Sequence) < 0 {
+		c.equivocations.sequence = new(big.Int).Set(view.Sequence)
+		if sequence := view.Sequence.Uint64(); sequence > equivocationSequencesToSave {
+			if err := c.evdb.PruneVotes(sequence - equivocationSequencesToSave); err != nil {
+				logger.Warn("Failed to prune votes", "err", err)
+			}
+		}
+	}
+	first, err := c.evdb.GetVote(view, msg.Code, msg.Address)
+	if err != nil {
+		logger.Error("Failed to read vote", "err", err)
+		return
+	}
+	if first == nil {
+		if err := c.evdb.AddVote(view, msg); err != nil {
+			logger.Error("Failed to store vote", "err", err)
+		}
+		return
+	}
+	firstVote, err := decodeVote(first)
+	if err != nil || !conflictingVotes(firstVote, vote) {
+		return
+	}
+
+	logger.Warn("Validator signed conflicting messages", "first_digest", firstVote.Subject.Digest, "second_digest", vote.Subject.Digest)
+	ev := &EquivocationEvidence{
+		View:       view,
+		Code:       msg.Code,
+		Validator:  msg.Address,
+		First:      first,
+		Second:     msg,
+		Validators: validator.MapValidatorsToData(valSet.List()),
+	}
+	if err := c.evdb.AddEvidence(ev); err != nil {
+		logger.Error("Failed to store equivocation evidence", "err", err)
+	}
+}
+
+// openEquivocationDB opens the equivocation evidence DB when the core starts validating
+func (c *core) openEquivocationDB() error {
+	evdb, err := newEquivocationDB(c.config.EquivocationDBPath)
+	if err != nil {
+		return err
+	}
+	c.evdbMu.Lock()
+	c.evdb = evdb
+	c.evdbMu.Unlock()
+	return nil
+}
+
+// closeEquivocationDB closes the equivocation evidence DB when the core stops validating
+func (c *core) closeEquivocationDB() {
+	c.evdbMu.Lock()
+	defer c.evdbMu.Unlock()
+
+	if c.evdb == nil {
+		return
+	}
+	if err := c.evdb.Close(); err != nil {
+		c.logger.Error("Failed to close equivocation db", "err", err)
+	}
+	c.evdb = nil
+	c.equivocations = equivocationDetector{}
+}
+
+// EquivocationEvidence implements core.Engine.EquivocationEvidence
+func (c *core) EquivocationEvidence(from, to uint64) ([]*EquivocationEvidence, error) {
+	c.evdbMu.RLock()
+	defer c.evdbMu.RUnlock()
+
+	if c.evdb == nil {
+		return nil, istanbul.ErrStoppedEngine
+	}
+	return c.evdb.GetEvidence(from, to)
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	evKey   = "ev" // Database Key Prefix for EquivocationEvidence
+	voteKey = "vt" // Database Key Prefix for the PREPARE and COMMIT messages checked for equivocations
+)
+
+// EquivocationDB stores the PREPARE and COMMIT messages signed by validators, and the
+// evidence of validators signing conflicting ones.
+type EquivocationDB interface {
+	// AddEvidence stores the evidence, replacing any previous evidence for the same
+	// validator, view and message code
+	AddEvidence(ev *EquivocationEvidence) error
+	// GetEvidence returns the stored evidence for the sequences in [from, to], ordered by view
+	GetEvidence(from, to uint64) ([]*EquivocationEvidence, error)
+	// AddVote stores the PREPARE or COMMIT message of a validator for the given view
+	AddVote(view *istanbul.View, msg *istanbul.Message) error
+	// GetVote returns the stored message of the validator with the given code for the
+	// given view, or nil if there is none
+	GetVote(view *istanbul.View, code uint64, validator common.Address) (*istanbul.Message, error)
+	// PruneVotes deletes the stored messages for the sequences before the given one
+	PruneVotes(before uint64) error
+	Close() error
+}
+
+type equivocationDBImpl struct {
+	db     *leveldb.DB
+	logger log.Logger
+}
+
+func newEquivocationDB(path string) (EquivocationDB, error) {
+	logger := log.New("func", "newEquivocationDB", "type", "equivocationDB", "evdb_path", path)
+
+	logger.Info("Open equivocation db")
+	var db *leveldb.DB
+	var err error
+	if path == "" {
+		db, err = newMemoryDB()
+	} else {
+		db, err = newPersistentDB(path)
+	}
+
+	if err != nil {
+		logger.Error("Failed to open equivocation db", "err", err)
+		return nil, err
+	}
+
+	return &equivocationDBImpl{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+func (evdb *equivocationDBImpl) AddEvidence(ev *EquivocationEvidence) error {
+	logger := evdb.logger.New("func", "AddEvidence")
+
+	entryBytes, err := rlp.EncodeToBytes(ev)
+	if err != nil {
+		logger.Error("Failed to save equivocation evidence", "reason", "rlp encoding", "err", err)
+		return err
+	}
+
+	err = evdb.db.Put(vote2Key(evKey, ev.View, ev.Code, ev.Validator), entryBytes, nil)
+	if err != nil {
+		logger.Error("Failed to save equivocation evidence", "reason", "levelDB write", "err", err)
+	}
+	return err
+}
+
+func (evdb *equivocationDBImpl) GetEvidence(from, to uint64) ([]*EquivocationEvidence, error) {
+	if from > to {
+		return nil, nil
+	}
+	limit := sequence2Key(evKey, to+1)
+	if to == math.MaxUint64 {
+		limit = util.BytesPrefix([]byte(evKey)).Limit
+	}
+
+	iter := evdb.db.NewIterator(&util.Range{Start: sequence2Key(evKey, from), Limit: limit}, nil)
+	defer iter.Release()
+
+	evidence := []*EquivocationEvidence{}
+	for iter.Next() {
+		var entry EquivocationEvidence
+		if err := rlp.DecodeBytes(iter.Value(), &entry); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, &entry)
+	}
+	return evidence, iter.Error()
+}
+
+func (evdb *equivocationDBImpl) AddVote(view *istanbul.View, msg *istanbul.Message) error {
+	logger := evdb.logger.New("func", "AddVote")
+
+	entryBytes, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		logger.Error("Failed to save vote", "reason", "rlp encoding", "err", err)
+		return err
+	}
+
+	err = evdb.db.Put(vote2Key(voteKey, view, msg.Code, msg.Address), entryBytes, nil)
+	if err != nil {
+		logger.Error("Failed to save vote", "reason", "levelDB write", "err", err)
+	}
+	return err
+}
+
+func (evdb *equivocationDBImpl) GetVote(view *istanbul.View, code uint64, validator common.Address) (*istanbul.Message, error) {
+	rawEntry, err := evdb.db.Get(vote2Key(voteKey, view, code, validator), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var msg istanbul.Message
+	if err := rlp.DecodeBytes(rawEntry, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (evdb *equivocationDBImpl) PruneVotes(before uint64) error {
+	iter := evdb.db.NewIterator(&util.Range{Start: sequence2Key(voteKey, 0), Limit: sequence2Key(voteKey, before)}, nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return evdb.db.Write(batch, nil)
+}
+
+func (evdb *equivocationDBImpl) Close() error {
+	return evdb.db.Close()
+}
+
+// sequence2Key encodes the smallest key with the given prefix for the given sequence
+func sequence2Key(prefix string, sequence uint64) []byte {
+	buff := make([]byte, len(prefix)+8)
+
+	copy(buff, prefix)
+	binary.BigEndian.PutUint64(buff[len(prefix):], sequence)
+
+	return buff
+}
+
+// vote2Key encodes a view, message code and validator in binary format, so that the keys
+// with the same prefix sort by view.
+// The key format is [ prefix . BigEndian(Sequence) . BigEndian(Round) . Code . Validator ]
+func vote2Key(prefix string, view *istanbul.View, code uint64, validator common.Address) []byte {
+	buff := make([]byte, len(prefix)+17+len(validator))
+
+	copy(buff, prefix)
+	binary.BigEndian.PutUint64(buff[len(prefix):], view.Sequence.Uint64())
+	binary.BigEndian.PutUint64(buff[len(prefix)+8:], view.Round.Uint64())
+	buff[len(prefix)+16] = byte(code)
+	copy(buff[len(prefix)+17:], validator[:])
+
+	return buff
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+)
+
+// newTestVote returns a PREPARE, or a COMMIT if seal is not nil, of the given validator,
+// and the vote it decodes into.
+func newTestVote(t *testing.T, address common.Address, view *istanbul.View, digest common.Hash, seal []byte) (*istanbul.Message, *istanbul.CommittedSubject) {
+	vote := &istanbul.CommittedSubject{Subject: &istanbul.Subject{View: view, Digest: digest}}
+	msg := &istanbul.Message{Code: istanbul.MsgPrepare, Address: address}
+	var err error
+	if seal == nil {
+		msg.Msg, err = Encode(vote.Subject)
+	} else {
+		vote.CommittedSeal, vote.EpochValidatorSetSeal = seal, []byte{}
+		msg.Code = istanbul.MsgCommit
+		msg.Msg, err = Encode(vote)
+	}
+	finishOnError(t, err)
+	return msg, vote
+}
+
+func TestEquivocationDetection(t *testing.T) {
+	valSet := newTestValidatorSet(4)
+	config := *istanbul.DefaultConfig
+	config.EquivocationDBPath = ""
+	c := &core{
+		config:  &config,
+		logger:  testLogger,
+		current: newTestRoundState(newView(2, 1), valSet),
+	}
+	finishOnError(t, c.openEquivocationDB())
+	defer c.closeEquivocationDB()
+
+	address := valSet.GetByIndex(1).Address()
+	detect := func(view *istanbul.View, digest common.Hash, seal []byte) {
+		msg, vote := newTestVote(t, address, view, digest, seal)
+		c.detectEquivocation(msg, vote, valSet)
+	}
+	digestA, digestB := common.HexToHash("0xa"), common.HexToHash("0xb")
+
+	// Repeated and non conflicting messages are not equivocations
+	detect(newView(2, 1), digestA, nil)
+	detect(newView(2, 1), digestA, nil)
+	detect(newView(2, 2), digestB, nil)
+	detect(newView(2, 1), digestA, []byte{1})
+	detect(newView(3, 1), digestA, nil)
+
+	evidence, err := c.EquivocationEvidence(0, math.MaxUint64)
+	finishOnError(t, err)
+	if len(evidence) != 0 {
+		t.Fatalf("evidence mismatch: have %d, want %d", len(evidence), 0)
+	}
+
+	// Signing two different proposals or two different seals in a view is an equivocation,
+	// including for a sequence that is no longer the latest one
+	detect(newView(2, 1), digestB, nil)
+	detect(newView(2, 1), digestA, []byte{2})
+
+	evidence, err = c.EquivocationEvidence(2, 2)
+	finishOnError(t, err)
+	if len(evidence) != 2 {
+		t.Fatalf("evidence mismatch: have %d, want %d", len(evidence), 2)
+	}
+	for i, code := range []uint64{istanbul.MsgPrepare, istanbul.MsgCommit} {
+		ev := evidence[i]
+		if ev.Code != code || ev.Validator != address || ev.View.Cmp(newView(2, 1)) != 0 {
+			t.Errorf("evidence %d mismatch: have code %d, validator %s, view %v", i, ev.Code, ev.Validator.Hex(), ev.View)
+		}
+		if len(ev.Validators) != valSet.Size() {
+			t.Errorf("evidence %d validator set size mismatch: have %d, want %d", i, len(ev.Validators), valSet.Size())
+		}
+	}
+
+	summary, err := evidence[0].Summary()
+	finishOnError(t, err)
+	if summary.FirstDigest != digestA || summary.SecondDigest != digestB {
+		t.Errorf("summary digests mismatch: have %s and %s, want %s and %s", summary.FirstDigest.Hex(), summary.SecondDigest.Hex(), digestA.Hex(), digestB.Hex())
+	}
+
+	// Evidence is only returned for the requested sequences
+	for _, r := range [][2]uint64{{0, 1}, {3, math.MaxUint64}, {3, 2}} {
+		evidence, err := c.EquivocationEvidence(r[0], r[1])
+		finishOnError(t, err)
+		if len(evidence) != 0 {
+			t.Errorf("evidence for sequences %d to %d mismatch: have %d, want %d", r[0], r[1], len(evidence), 0)
+		}
+	}
+
+	// Messages of sequences too old to be kept are pruned, and can no longer be contradicted
+	detect(newView(4+equivocationSequencesToSave, 0), digestA, nil)
+	detect(newView(3, 1), digestB, nil)
+	if evidence, _ := c.EquivocationEvidence(3, 3); len(evidence) != 0 {
+		t.Errorf("evidence for a pruned sequence mismatch: have %d, want %d", len(evidence), 0)
+	}
+}
+
+// Tests that the messages checked for equivocations survive a restart.
+func TestEquivocationDetectionPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "equivocations")
+	finishOnError(t, err)
+	defer os.RemoveAll(dir)
+
+	valSet := newTestValidatorSet(4)
+	config := *istanbul.DefaultConfig
+	config.EquivocationDBPath = filepath.Join(dir, "equivocations")
+	c := &core{
+		config:  &config,
+		logger:  testLogger,
+		current: newTestRoundState(newView(2, 1), valSet),
+	}
+	address := valSet.GetByIndex(1).Address()
+
+	finishOnError(t, c.openEquivocationDB())
+	msg, vote := newTestVote(t, address, newView(2, 1), common.HexToHash("0xa"), nil)
+	c.detectEquivocation(msg, vote, valSet)
+	c.closeEquivocationDB()
+
+	if _, err := c.EquivocationEvidence(0, math.MaxUint64); err != istanbul.ErrStoppedEngine {
+		t.Fatalf("evidence error mismatch when stopped: have %v, want %v", err, istanbul.ErrStoppedEngine)
+	}
+
+	finishOnError(t, c.openEquivocationDB())
+	defer c.closeEquivocationDB()
+	msg, vote = newTestVote(t, address, newView(2, 1), common.HexToHash("0xb"), nil)
+	c.detectEquivocation(msg, vote, valSet)
+
+	evidence, err := c.EquivocationEvidence(0, math.MaxUint64)
+	finishOnError(t, err)
+	if len(evidence) != 1 {
+		t.Fatalf("evidence mismatch: have %d, want %d", len(evidence), 1)
+	}
+}
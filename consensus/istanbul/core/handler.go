@@ -26,7 +26,26 @@ import (
 
 // Start implements core.Engine.Start
 func (c *core) Start() error {
+	if err := c.openEquivocationDB(); err != nil {
+		return err
+	}
+	c.startWALSegment(walStart)
+	if err := c.initRoundState(); err != nil {
+		c.closeEquivocationDB()
+		return err
+	}
+	c.recordWALState()
+
+	// Tests will handle events itself, so we have to make subscribeEvents()
+	// be able to call in test.
+	c.subscribeEvents()
+	go c.handleEvents()
 
+	return nil
+}
+
+// initRoundState creates or restores the RoundState to start the core with
+func (c *core) initRoundState() error {
 	roundState, err := c.createRoundState()
 	if err != nil {
 		return err
@@ -43,12 +62,6 @@ func (c *core) Start() error {
 	// Process backlog
 	c.processPendingRequests()
 	c.backlog.updateState(c.CurrentView(), c.current.State())
-
-	// Tests will handle events itself, so we have to make subscribeEvents()
-	// be able to call in test.
-	c.subscribeEvents()
-	go c.handleEvents()
-
 	return nil
 }
 
@@ -60,6 +73,13 @@ func (c *core) Stop() error {
 	// Make sure the handler goroutine exits
 	c.handlerWg.Wait()
 
+	if c.wal != nil {
+		if err := c.wal.close(); err != nil {
+			c.logger.Error("Failed to close consensus WAL", "err", err)
+		}
+	}
+	c.closeEquivocationDB()
+
 	c.current = nil
 	return nil
 }
@@ -100,60 +120,71 @@ func (c *core) handleEvents() {
 	c.handlerWg.Add(1)
 
 	for {
-		logger := c.newLogger("func", "handleEvents")
 		select {
 		case event, ok := <-c.events.Chan():
 			if !ok {
 				return
 			}
-			// A real event arrived, process interesting content
-			switch ev := event.Data.(type) {
-			case istanbul.RequestEvent:
-				r := &istanbul.Request{
-					Proposal: ev.Proposal,
-				}
-				err := c.handleRequest(r)
-				if err == errFutureMessage {
-					c.storeRequestMsg(r)
-				}
-			case istanbul.MessageEvent:
-				if err := c.handleMsg(ev.Payload); err != nil && err != errFutureMessage && err != errOldMessage {
-					logger.Info("Error in handling istanbul message", "err", err)
-				}
-			case backlogEvent:
-				if payload, err := ev.msg.Payload(); err != nil {
-					logger.Error("Error in retrieving payload from istanbul message that was sent from a backlog event", "err", err)
-				} else {
-					if err := c.handleMsg(payload); err != nil && err != errFutureMessage && err != errOldMessage {
-						logger.Info("Error in handling istanbul message that was sent from a backlog event", "err", err)
-					}
-				}
-			}
+			c.handleEvent(event.Data)
 		case event, ok := <-c.timeoutSub.Chan():
 			if !ok {
 				return
 			}
-			switch ev := event.Data.(type) {
-			case timeoutAndMoveToNextRoundEvent:
-				if err := c.handleTimeoutAndMoveToNextRound(ev.view); err != nil {
-					logger.Error("Error on handleTimeoutAndMoveToNextRound", "err", err)
-				}
-			case resendRoundChangeEvent:
-				if err := c.handleResendRoundChangeEvent(ev.view); err != nil {
-					logger.Error("Error on handleResendRoundChangeEvent", "err", err)
-				}
-			}
+			c.handleEvent(event.Data)
 		case event, ok := <-c.finalCommittedSub.Chan():
 			if !ok {
 				return
 			}
-			switch event.Data.(type) {
-			case istanbul.FinalCommittedEvent:
-				if err := c.handleFinalCommitted(); err != nil {
-					logger.Error("Error on handleFinalCommit", "err", err)
-				}
+			c.handleEvent(event.Data)
+		}
+	}
+}
+
+// handleEvent handles an event received by the core, recording it and the resulting
+// RoundState in the consensus WAL
+func (c *core) handleEvent(data interface{}) {
+	logger := c.newLogger("func", "handleEvent")
+
+	c.recordWALEvent(data)
+	defer c.recordWALState()
+
+	switch ev := data.(type) {
+	// external events
+	case istanbul.RequestEvent:
+		r := &istanbul.Request{
+			Proposal: ev.Proposal,
+		}
+		err := c.handleRequest(r)
+		if err == errFutureMessage {
+			c.storeRequestMsg(r)
+		}
+	case istanbul.MessageEvent:
+		if err := c.handleMsg(ev.Payload); err != nil && err != errFutureMessage && err != errOldMessage {
+			logger.Info("Error in handling istanbul message", "err", err)
+		}
+	// internal events
+	case backlogEvent:
+		if payload, err := ev.msg.Payload(); err != nil {
+			logger.Error("Error in retrieving payload from istanbul message that was sent from a backlog event", "err", err)
+		} else {
+			if err := c.handleMsg(payload); err != nil && err != errFutureMessage && err != errOldMessage {
+				logger.Info("Error in handling istanbul message that was sent from a backlog event", "err", err)
 			}
 		}
+	// timeout events
+	case timeoutAndMoveToNextRoundEvent:
+		if err := c.handleTimeoutAndMoveToNextRound(ev.view); err != nil {
+			logger.Error("Error on handleTimeoutAndMoveToNextRound", "err", err)
+		}
+	case resendRoundChangeEvent:
+		if err := c.handleResendRoundChangeEvent(ev.view); err != nil {
+			logger.Error("Error on handleResendRoundChangeEvent", "err", err)
+		}
+	// final committed events
+	case istanbul.FinalCommittedEvent:
+		if err := c.handleFinalCommitted(); err != nil {
+			logger.Error("Error on handleFinalCommit", "err", err)
+		}
 	}
 }
 
@@ -51,7 +51,7 @@ func TestHandleMsg(t *testing.T) {
 		Signature: []byte{},
 	}
 
-	_, val := v0.Validators(nil).GetByAddress(v0.Address())
+	_, val := v0.peers.GetByAddress(v0.Address())
 	if err := r0.handleCheckedMsg(msg, val); err != errFailedDecodePreprepare {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodePreprepare)
 	}
@@ -71,7 +71,7 @@ func TestHandleMsg(t *testing.T) {
 		Signature: []byte{},
 	}
 
-	_, val = v0.Validators(nil).GetByAddress(v0.Address())
+	_, val = v0.peers.GetByAddress(v0.Address())
 	if err := r0.handleCheckedMsg(msg, val); err != errFailedDecodePrepare {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodePreprepare)
 	}
@@ -91,7 +91,7 @@ func TestHandleMsg(t *testing.T) {
 		Signature: []byte{},
 	}
 
-	_, val = v0.Validators(nil).GetByAddress(v0.Address())
+	_, val = v0.peers.GetByAddress(v0.Address())
 	if err := r0.handleCheckedMsg(msg, val); err != errFailedDecodeCommit {
 		t.Errorf("error mismatch: have %v, want %v", err, errFailedDecodeCommit)
 	}
@@ -111,7 +111,7 @@ func TestHandleMsg(t *testing.T) {
 		Signature: []byte{},
 	}
 
-	_, val = v0.Validators(nil).GetByAddress(v0.Address())
+	_, val = v0.peers.GetByAddress(v0.Address())
 	if err := r0.handleCheckedMsg(msg, val); err == nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
 	}
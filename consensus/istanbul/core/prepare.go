@@ -185,6 +185,9 @@ func (c *core) handlePrepare(msg *istanbul.Message) error {
 		return err
 	}
 
+	// Record the PREPARE to detect validators signing conflicting ones
+	c.detectEquivocation(msg, &istanbul.CommittedSubject{Subject: prepare}, c.current.ValidatorSet())
+
 	if err := c.verifyPrepare(prepare); err != nil {
 		return err
 	}
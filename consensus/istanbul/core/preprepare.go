@@ -72,8 +72,11 @@ func (c *core) handlePreprepare(msg *istanbul.Message) error {
 	if err := c.checkMessage(istanbul.MsgPreprepare, preprepare.View); err != nil {
 		if err == errOldMessage {
 			// Get validator set for the given proposal
-			valSet := c.backend.ParentBlockValidators(preprepare.Proposal)
-			prevBlockAuthor := c.backend.AuthorForBlock(preprepare.Proposal.Number().Uint64() - 1)
+			valSet, err := c.backend.ParentBlockValidators(preprepare.Proposal)
+			if err != nil {
+				return err
+			}
+			prevBlockAuthor := c.backend.AuthorForBlock(preprepare.Proposal.ParentHash(), preprepare.Proposal.Number().Uint64()-1)
 			proposer := c.selectProposer(valSet, prevBlockAuthor, preprepare.View.Round.Uint64())
 
 			// We no longer broadcast a COMMIT if this is a PREPREPARE from the correct proposer for an existing block.
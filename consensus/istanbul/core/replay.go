@@ -0,0 +1,276 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// errReplayNoStart is returned if the WAL doesn't start with a snapshot to replay it from
+	errReplayNoStart = errors.New("consensus WAL doesn't start with a snapshot")
+)
+
+// ReplayStep is an event replayed from the consensus WAL, and the RoundState it resulted in
+type ReplayStep struct {
+	Index        int       // Index of the event in the replay
+	Time         time.Time // Time the event was originally handled
+	Event        string    // Type of the replayed event
+	Calls        int       // Number of backend calls made while handling the event
+	State        State
+	Sequence     *big.Int
+	Round        *big.Int
+	DesiredRound *big.Int
+}
+
+// replay is the state of a replay of the consensus WAL
+type replay struct {
+	core    *core
+	backend *replayBackend
+	steps   int
+
+	pending *walEntry  // Event (or start of the core) whose calls are being collected
+	calls   []*walCall // Backend calls recorded for the pending event
+	fn      func(*ReplayStep)
+}
+
+// ReplayWAL feeds the consensus WAL in the given directory back through a core running against a
+// backend answering with the recorded backend calls, and checks that every event results in the
+// recorded RoundState. fn is called for every replayed event.
+//
+// Replays starting from a checkpoint (rather than from the start of the core) are best-effort:
+// the round change messages and verified proposals of the round aren't part of the snapshot.
+func ReplayWAL(dir string, fn func(*ReplayStep)) error {
+	r := &replay{fn: fn}
+	defer r.stop()
+
+	err := readWAL(dir, func(entry *walEntry) error {
+		switch entry.Type {
+		case walStart:
+			// The core was (re)started, any event pending was interrupted
+			r.pending, r.calls = entry, nil
+		case walCheckpoint:
+			// Checkpoints are only replayed from if the start of the core is no longer in the WAL
+			if r.core == nil {
+				return r.restore(entry)
+			}
+		case walBackendCall:
+			if r.pending == nil {
+				return errReplayNoStart
+			}
+			var call walCall
+			if err := rlp.DecodeBytes(entry.Data, &call); err != nil {
+				return err
+			}
+			r.calls = append(r.calls, &call)
+		case walState:
+			if r.pending == nil {
+				return errReplayNoStart
+			}
+			var recorded walRoundState
+			if err := rlp.DecodeBytes(entry.Data, &recorded); err != nil {
+				return err
+			}
+			return r.step(&recorded)
+		default:
+			if r.core == nil {
+				return errReplayNoStart
+			}
+			r.pending, r.calls = entry, nil
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if r.core == nil {
+		return errReplayNoStart
+	}
+	return nil
+}
+
+// step replays the pending entry with its recorded backend calls, and checks the resulting state
+func (r *replay) step(recorded *walRoundState) (err error) {
+	entry, calls := r.pending, r.calls
+	r.pending, r.calls = nil, nil
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			diverged, ok := rec.(*errReplayDiverged)
+			if !ok {
+				panic(rec)
+			}
+			err = fmt.Errorf("%s event %d: %v", entry.Type, r.steps, diverged)
+		}
+	}()
+
+	switch entry.Type {
+	case walStart:
+		if err := r.start(entry, calls); err != nil {
+			return err
+		}
+	default:
+		ev, err := decodeWALEvent(entry)
+		if err != nil {
+			return err
+		}
+		r.backend.calls = calls
+		r.core.handleEvent(ev)
+	}
+
+	if len(r.backend.calls) > 0 {
+		return fmt.Errorf("%s event %d: replay diverged from the consensus WAL: %d recorded %s calls not made", entry.Type, r.steps, len(r.backend.calls), r.backend.calls[0].Method)
+	}
+	replayed := r.core.currentWALState()
+	if !replayed.Cmp(recorded) {
+		return fmt.Errorf("%s event %d: replay diverged from the consensus WAL: replayed state %v, %v was recorded", entry.Type, r.steps, replayed, recorded)
+	}
+
+	if r.fn != nil {
+		r.fn(&ReplayStep{
+			Index:        r.steps,
+			Time:         time.Unix(0, int64(entry.Time)),
+			Event:        entry.Type.String(),
+			Calls:        len(calls),
+			State:        replayed.State,
+			Sequence:     replayed.Sequence,
+			Round:        replayed.Round,
+			DesiredRound: replayed.DesiredRound,
+		})
+	}
+	r.steps++
+	return nil
+}
+
+// newCore creates the core to replay the WAL with, from the recorded snapshot
+func (r *replay) newCore(entry *walEntry) (*walSnapshot, error) {
+	var snapshot walSnapshot
+	if err := rlp.DecodeBytes(entry.Data, &snapshot); err != nil {
+		return nil, err
+	}
+	r.stop()
+
+	config := *istanbul.DefaultConfig
+	config.Epoch = snapshot.Epoch
+	config.ProposerPolicy = istanbul.ProposerPolicy(snapshot.ProposerPolicy)
+	config.RoundStateDBPath = ""
+	config.EquivocationDBPath = ""
+	config.WAL = false
+
+	r.backend = newReplayBackend(snapshot.Address)
+	r.core = New(r.backend, &config).(*core)
+	return &snapshot, nil
+}
+
+// start replays the start of the core
+func (r *replay) start(entry *walEntry, calls []*walCall) error {
+	snapshot, err := r.newCore(entry)
+	if err != nil {
+		return err
+	}
+	if len(snapshot.RoundState) > 0 {
+		rs, err := decodeWALRoundState(snapshot.RoundState)
+		if err != nil {
+			return err
+		}
+		if err := r.core.rsdb.UpdateLastRoundState(rs); err != nil {
+			return err
+		}
+	}
+	r.backend.calls = calls
+	return r.core.initRoundState()
+}
+
+// restore restores the core from the snapshot of a checkpoint
+func (r *replay) restore(entry *walEntry) error {
+	snapshot, err := r.newCore(entry)
+	if err != nil {
+		return err
+	}
+	if len(snapshot.RoundState) == 0 {
+		return errReplayNoStart
+	}
+	rs, err := decodeWALRoundState(snapshot.RoundState)
+	if err != nil {
+		return err
+	}
+	r.core.current = withSavingDecorator(r.core.rsdb, rs)
+	r.core.roundChangeSet = newRoundChangeSet(rs.ValidatorSet())
+	r.core.backlog.updateState(r.core.CurrentView(), r.core.current.State())
+	return nil
+}
+
+// stop releases the resources of the replayed core
+func (r *replay) stop() {
+	if r.core == nil {
+		return
+	}
+	r.core.stopAllTimers()
+	r.core.rsdb.Close()
+	r.backend.events.Stop()
+	r.core, r.backend = nil, nil
+}
+
+func decodeWALRoundState(data []byte) (RoundState, error) {
+	var rs roundStateImpl
+	if err := rlp.DecodeBytes(data, &rs); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// decodeWALEvent decodes the event recorded in a WAL entry
+func decodeWALEvent(entry *walEntry) (interface{}, error) {
+	switch entry.Type {
+	case walRequestEvent:
+		proposal, err := decodeProposal(entry.Data)
+		if err != nil {
+			return nil, err
+		}
+		return istanbul.RequestEvent{Proposal: proposal}, nil
+	case walMessageEvent:
+		return istanbul.MessageEvent{Payload: entry.Data}, nil
+	case walBacklogEvent:
+		msg := new(istanbul.Message)
+		if err := msg.FromPayload(entry.Data, nil); err != nil {
+			return nil, err
+		}
+		return backlogEvent{msg: msg}, nil
+	case walTimeoutEvent:
+		var view istanbul.View
+		if err := rlp.DecodeBytes(entry.Data, &view); err != nil {
+			return nil, err
+		}
+		return timeoutAndMoveToNextRoundEvent{view: &view}, nil
+	case walResendRoundChangeEvent:
+		var view istanbul.View
+		if err := rlp.DecodeBytes(entry.Data, &view); err != nil {
+			return nil, err
+		}
+		return resendRoundChangeEvent{view: &view}, nil
+	case walFinalCommittedEvent:
+		return istanbul.FinalCommittedEvent{}, nil
+	default:
+		return nil, fmt.Errorf("unknown consensus WAL entry type %d", entry.Type)
+	}
+}
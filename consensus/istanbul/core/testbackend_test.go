@@ -73,7 +73,7 @@ type testCommittedMsgs struct {
 //
 // define the functions that needs to be provided for Istanbul.
 
-func (self *testSystemBackend) Authorize(address common.Address, _ *ecdsa.PublicKey, _ istanbul.DecryptFn, _ istanbul.SignerFn, _ istanbul.BLSSignerFn) {
+func (self *testSystemBackend) Authorize(address common.Address, _ *ecdsa.PublicKey, _ istanbul.DecryptFn, _ istanbul.SignerFn, _ istanbul.BLSViewSignerFn) {
 	self.address = address
 	self.engine.SetAddress(address)
 }
@@ -83,8 +83,8 @@ func (self *testSystemBackend) Address() common.Address {
 }
 
 // Peers returns all connected peers
-func (self *testSystemBackend) Validators(proposal istanbul.Proposal) istanbul.ValidatorSet {
-	return self.peers
+func (self *testSystemBackend) Validators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return self.peers, nil
 }
 
 func (self *testSystemBackend) NextBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
@@ -121,7 +121,7 @@ func (self *testSystemBackend) Gossip(payload []byte, ethMsgCode uint64) error {
 	return nil
 }
 
-func (self *testSystemBackend) SignBLS(data []byte, extra []byte, useComposite bool) (blscrypto.SerializedSignature, error) {
+func (self *testSystemBackend) SignBLS(data []byte, extra []byte, useComposite bool, view *istanbul.View) (blscrypto.SerializedSignature, error) {
 	privateKey, _ := bls.DeserializePrivateKey(self.blsKey)
 	defer privateKey.Destroy()
 
@@ -217,12 +217,18 @@ func (self *testSystemBackend) HasBlock(hash common.Hash, number *big.Int) bool
 	return number.Cmp(big.NewInt(5)) == 0
 }
 
-func (self *testSystemBackend) AuthorForBlock(number uint64) common.Address {
+func (self *testSystemBackend) IsPrimaryForSeq(seq *big.Int) bool {
+	return true
+}
+
+func (self *testSystemBackend) PenalizeOverflowingValidator(addr common.Address) {}
+
+func (self *testSystemBackend) AuthorForBlock(hash common.Hash, number uint64) common.Address {
 	return common.Address{}
 }
 
-func (self *testSystemBackend) ParentBlockValidators(proposal istanbul.Proposal) istanbul.ValidatorSet {
-	return self.peers
+func (self *testSystemBackend) ParentBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return self.peers, nil
 }
 
 func (self *testSystemBackend) finalizeAndReturnMessage(msg *istanbul.Message) (istanbul.Message, error) {
@@ -383,6 +389,7 @@ func NewTestSystemWithBackend(n, f uint64) *testSystem {
 	config := *istanbul.DefaultConfig
 	config.ProposerPolicy = istanbul.RoundRobin
 	config.RoundStateDBPath = ""
+	config.EquivocationDBPath = ""
 	config.RequestTimeout = 300
 	config.TimeoutBackoffFactor = 100
 	config.MinResendRoundChangeTimeout = 1000
@@ -34,6 +34,9 @@ type Engine interface {
 	ParentCommits() MessageSet
 	// ForceRoundChange will force round change to the current desiredRound + 1
 	ForceRoundChange()
+	// EquivocationEvidence returns the evidence of validators signing conflicting
+	// messages in the sequences in [from, to]
+	EquivocationEvidence(from, to uint64) ([]*EquivocationEvidence, error)
 }
 
 // State represents the IBFT state
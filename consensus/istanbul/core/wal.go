@@ -0,0 +1,451 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// The consensus write-ahead log (WAL) records, in order, every event handled by the core, every
+// call the core makes to its backend along with the result, and the RoundState after each event.
+// Since the backend calls are the only source of non-determinism of the core, feeding the WAL back
+// through ReplayWAL reproduces the same RoundState sequence.
+//
+// The WAL is split in segment files of a bounded size, and only the most recent ones are kept. Each
+// segment starts with a snapshot of the RoundState it can be replayed from.
+
+const (
+	walFilePrefix = "wal-"
+	walFileSuffix = ".log"
+)
+
+type walEntryType uint8
+
+const (
+	walStart                  walEntryType = iota // The core started; Data is a walSnapshot
+	walCheckpoint                                 // A new segment of a running core; Data is a walSnapshot
+	walRequestEvent                               // Data is the RLP encoded proposal
+	walMessageEvent                               // Data is the message payload
+	walBacklogEvent                               // Data is the message payload
+	walTimeoutEvent                               // Data is the RLP encoded timed out view
+	walResendRoundChangeEvent                     // Data is the RLP encoded desired view
+	walFinalCommittedEvent                        // Data is empty
+	walBackendCall                                // Data is a walCall
+	walState                                      // Data is a walRoundState
+)
+
+func (t walEntryType) String() string {
+	switch t {
+	case walStart:
+		return "Start"
+	case walCheckpoint:
+		return "Checkpoint"
+	case walRequestEvent:
+		return "Request"
+	case walMessageEvent:
+		return "Message"
+	case walBacklogEvent:
+		return "BacklogMessage"
+	case walTimeoutEvent:
+		return "TimeoutAndMoveToNextRound"
+	case walResendRoundChangeEvent:
+		return "ResendRoundChange"
+	case walFinalCommittedEvent:
+		return "FinalCommitted"
+	case walBackendCall:
+		return "BackendCall"
+	case walState:
+		return "State"
+	default:
+		return "Unknown"
+	}
+}
+
+// walEntry is a record of the WAL
+type walEntry struct {
+	Type walEntryType
+	Time uint64 // Unix time in nanoseconds
+	Data []byte
+}
+
+// walSnapshot is the information needed to replay a WAL from the start of a segment
+type walSnapshot struct {
+	Address        common.Address
+	Epoch          uint64
+	ProposerPolicy uint64
+	RoundState     []byte // RLP encoded RoundState, empty if none was stored
+}
+
+// walRoundState is the part of the RoundState compared during a replay
+type walRoundState struct {
+	State        State
+	Sequence     *big.Int
+	Round        *big.Int
+	DesiredRound *big.Int
+}
+
+func (rs *walRoundState) String() string {
+	return fmt.Sprintf("{State: %v, Sequence: %v, Round: %v, DesiredRound: %v}", rs.State, rs.Sequence, rs.Round, rs.DesiredRound)
+}
+
+func (rs *walRoundState) Cmp(other *walRoundState) bool {
+	return rs.State == other.State && rs.Sequence.Cmp(other.Sequence) == 0 && rs.Round.Cmp(other.Round) == 0 && rs.DesiredRound.Cmp(other.DesiredRound) == 0
+}
+
+type wal struct {
+	dir         string
+	maxFileSize uint64
+	maxFiles    int
+
+	mu    sync.Mutex
+	file  *os.File
+	buf   *bufio.Writer
+	size  uint64
+	index uint64 // Index of the current (or last) segment file
+
+	logger log.Logger
+}
+
+func openWAL(dir string, maxFileSize uint64, maxFiles int) (*wal, error) {
+	logger := log.New("func", "openWAL", "type", "wal", "wal_dir", dir)
+
+	logger.Info("Open consensus WAL")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		logger.Error("Failed to open consensus WAL", "err", err)
+		return nil, err
+	}
+	indexes, err := walSegments(dir)
+	if err != nil {
+		logger.Error("Failed to open consensus WAL", "err", err)
+		return nil, err
+	}
+
+	w := &wal{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+		logger:      logger,
+	}
+	if len(indexes) > 0 {
+		w.index = indexes[len(indexes)-1]
+	}
+	return w, nil
+}
+
+// walSegments returns the indexes of the segment files in dir, in ascending order
+func walSegments(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]uint64, 0, len(files))
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, walFilePrefix) || !strings.HasSuffix(name, walFileSuffix) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, walFilePrefix), walFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+func walSegmentPath(dir string, index uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%08d%s", walFilePrefix, index, walFileSuffix))
+}
+
+// startSegment closes the current segment file, and starts a new one with the given snapshot.
+// The oldest segment files are removed to keep at most maxFiles.
+func (w *wal) startSegment(entryType walEntryType, snapshot *walSnapshot) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.closeSegment(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(walSegmentPath(w.dir, w.index+1), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	w.index++
+	w.file, w.buf, w.size = file, bufio.NewWriter(file), 0
+
+	if err := w.prune(); err != nil {
+		w.logger.Warn("Failed to remove old consensus WAL segments", "err", err)
+	}
+
+	data, err := rlp.EncodeToBytes(snapshot)
+	if err != nil {
+		return err
+	}
+	return w.write(entryType, data)
+}
+
+// append writes an entry to the current segment, if any
+func (w *wal) append(entryType walEntryType, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.write(entryType, data)
+}
+
+func (w *wal) write(entryType walEntryType, data []byte) error {
+	entry, err := rlp.EncodeToBytes(&walEntry{Type: entryType, Time: uint64(time.Now().UnixNano()), Data: data})
+	if err != nil {
+		return err
+	}
+	if _, err := w.buf.Write(entry); err != nil {
+		return err
+	}
+	w.size += uint64(len(entry))
+	return nil
+}
+
+// flush writes the buffered entries to the segment file
+func (w *wal) flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.buf.Flush()
+}
+
+// full returns whether the current segment reached the maximum file size
+func (w *wal) full() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file != nil && w.size >= w.maxFileSize
+}
+
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.closeSegment()
+}
+
+func (w *wal) closeSegment() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.buf.Flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.file, w.buf = nil, nil
+	return err
+}
+
+func (w *wal) prune() error {
+	indexes, err := walSegments(w.dir)
+	if err != nil {
+		return err
+	}
+	for len(indexes) > w.maxFiles {
+		if err := os.Remove(walSegmentPath(w.dir, indexes[0])); err != nil {
+			return err
+		}
+		indexes = indexes[1:]
+	}
+	return nil
+}
+
+// readWAL calls fn with all the entries of the segment files in dir, in order. A truncated
+// entry at the end of a segment, as left by a crash, ends the segment.
+func readWAL(dir string, fn func(*walEntry) error) error {
+	indexes, err := walSegments(dir)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if err := readWALSegment(walSegmentPath(dir, index), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readWALSegment(path string, fn func(*walEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stream := rlp.NewStream(bufio.NewReader(file), 0)
+	for {
+		entry := new(walEntry)
+		if err := stream.Decode(entry); err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid consensus WAL entry in %s: %v", path, err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// walSnapshot returns the snapshot starting a WAL segment, with the last RoundState stored
+func (c *core) walSnapshot() (*walSnapshot, error) {
+	snapshot := &walSnapshot{
+		Address:        c.address,
+		Epoch:          c.config.Epoch,
+		ProposerPolicy: uint64(c.config.ProposerPolicy),
+	}
+	lastView, err := c.rsdb.GetLastView()
+	if err == leveldb.ErrNotFound {
+		return snapshot, nil
+	} else if err != nil {
+		return nil, err
+	}
+	rs, err := c.rsdb.GetRoundStateFor(lastView)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.RoundState, err = rlp.EncodeToBytes(rs); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// startWALSegment starts a new WAL segment, if the WAL is enabled
+func (c *core) startWALSegment(entryType walEntryType) {
+	if c.wal == nil {
+		return
+	}
+	snapshot, err := c.walSnapshot()
+	if err == nil {
+		err = c.wal.startSegment(entryType, snapshot)
+	}
+	if err != nil {
+		c.logger.Error("Failed to start consensus WAL segment", "err", err)
+	}
+}
+
+// recordWALEntry appends an entry to the WAL, if enabled
+func (c *core) recordWALEntry(entryType walEntryType, data []byte) {
+	if c.wal == nil {
+		return
+	}
+	if err := c.wal.append(entryType, data); err != nil {
+		c.logger.Error("Failed to append to consensus WAL", "type", entryType, "err", err)
+	}
+}
+
+// recordWALEvent appends an event about to be handled to the WAL, if enabled
+func (c *core) recordWALEvent(ev interface{}) {
+	if c.wal == nil {
+		return
+	}
+	var (
+		entryType walEntryType
+		data      []byte
+		err       error
+	)
+	switch ev := ev.(type) {
+	case istanbul.RequestEvent:
+		entryType, data = walRequestEvent, encodeProposal(ev.Proposal)
+	case istanbul.MessageEvent:
+		entryType, data = walMessageEvent, ev.Payload
+	case backlogEvent:
+		entryType = walBacklogEvent
+		data, err = ev.msg.Payload()
+	case timeoutAndMoveToNextRoundEvent:
+		entryType = walTimeoutEvent
+		data, err = rlp.EncodeToBytes(ev.view)
+	case resendRoundChangeEvent:
+		entryType = walResendRoundChangeEvent
+		data, err = rlp.EncodeToBytes(ev.view)
+	case istanbul.FinalCommittedEvent:
+		entryType = walFinalCommittedEvent
+	default:
+		return
+	}
+	if err != nil {
+		c.logger.Error("Failed to encode consensus WAL event", "type", entryType, "err", err)
+		return
+	}
+	c.recordWALEntry(entryType, data)
+}
+
+// recordWALCall appends a backend call to the WAL
+func (c *core) recordWALCall(call *walCall) {
+	data, err := rlp.EncodeToBytes(call)
+	if err != nil {
+		c.logger.Error("Failed to encode consensus WAL backend call", "method", call.Method, "err", err)
+		return
+	}
+	c.recordWALEntry(walBackendCall, data)
+}
+
+// currentWALState returns the part of the current RoundState recorded in the WAL
+func (c *core) currentWALState() *walRoundState {
+	return &walRoundState{
+		State:        c.current.State(),
+		Sequence:     c.current.Sequence(),
+		Round:        c.current.Round(),
+		DesiredRound: c.current.DesiredRound(),
+	}
+}
+
+// recordWALState appends the current RoundState to the WAL, and starts a new segment if the
+// current one is full. It is called after every handled event.
+func (c *core) recordWALState() {
+	if c.wal == nil {
+		return
+	}
+	data, err := rlp.EncodeToBytes(c.currentWALState())
+	if err != nil {
+		c.logger.Error("Failed to encode consensus WAL state", "err", err)
+		return
+	}
+	c.recordWALEntry(walState, data)
+
+	if c.wal.full() {
+		c.startWALSegment(walCheckpoint)
+	} else if err := c.wal.flush(); err != nil {
+		c.logger.Error("Failed to flush consensus WAL", "err", err)
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/consensus/istanbul/validator"
+	"github.com/ethereum/go-ethereum/core/types"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// walCall is a call the core made to its backend, along with its result
+type walCall struct {
+	Method string
+	Value  []byte // Method specific encoding of the returned value
+	Err    string // Returned error, empty if none
+}
+
+// walHead is the value of a GetCurrentHeadBlockAndAuthor call
+type walHead struct {
+	Proposal rlp.RawValue
+	Author   common.Address
+}
+
+// walMulticast is the value of a Multicast call, i.e. the message sent
+type walMulticast struct {
+	Addresses []common.Address
+	Payload   []byte
+}
+
+// walErrors are the errors returned by the backend that the core checks for
+var walErrors = []error{consensus.ErrFutureBlock}
+
+func walErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func walError(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, err := range walErrors {
+		if err.Error() == s {
+			return err
+		}
+	}
+	return errors.New(s)
+}
+
+func encodeValidatorSet(valSet istanbul.ValidatorSet) []byte {
+	if valSet == nil {
+		return nil
+	}
+	data, _ := valSet.Serialize()
+	return data
+}
+
+func decodeValidatorSet(data []byte) (istanbul.ValidatorSet, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return validator.DeserializeValidatorSet(data)
+}
+
+func encodeProposal(proposal istanbul.Proposal) []byte {
+	if proposal == nil {
+		return nil
+	}
+	data, _ := rlp.EncodeToBytes(proposal)
+	return data
+}
+
+func decodeProposal(data []byte) (istanbul.Proposal, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var block *types.Block
+	if err := rlp.DecodeBytes(data, &block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// walBackend is an istanbul.Backend recording the results of the calls the core makes to the
+// wrapped backend in the WAL.
+type walBackend struct {
+	istanbul.Backend
+	record func(call *walCall)
+}
+
+func newWALBackend(backend istanbul.Backend, record func(call *walCall)) *walBackend {
+	return &walBackend{Backend: backend, record: record}
+}
+
+func (wb *walBackend) Validators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	valSet, err := wb.Backend.Validators(proposal)
+	wb.record(&walCall{Method: "Validators", Value: encodeValidatorSet(valSet), Err: walErrorString(err)})
+	return valSet, err
+}
+
+func (wb *walBackend) NextBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	valSet, err := wb.Backend.NextBlockValidators(proposal)
+	wb.record(&walCall{Method: "NextBlockValidators", Value: encodeValidatorSet(valSet), Err: walErrorString(err)})
+	return valSet, err
+}
+
+func (wb *walBackend) Multicast(addresses []common.Address, payload []byte, ethMsgCode uint64, sendToSelf bool) error {
+	err := wb.Backend.Multicast(addresses, payload, ethMsgCode, sendToSelf)
+	value, _ := rlp.EncodeToBytes(&walMulticast{Addresses: addresses, Payload: payload})
+	wb.record(&walCall{Method: "Multicast", Value: value, Err: walErrorString(err)})
+	return err
+}
+
+func (wb *walBackend) Commit(proposal istanbul.Proposal, aggregatedSeal types.IstanbulAggregatedSeal, aggregatedEpochValidatorSetSeal types.IstanbulEpochValidatorSetSeal) error {
+	err := wb.Backend.Commit(proposal, aggregatedSeal, aggregatedEpochValidatorSetSeal)
+	wb.record(&walCall{Method: "Commit", Value: proposal.Hash().Bytes(), Err: walErrorString(err)})
+	return err
+}
+
+func (wb *walBackend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
+	duration, err := wb.Backend.Verify(proposal)
+	value, _ := rlp.EncodeToBytes(uint64(duration))
+	wb.record(&walCall{Method: "Verify", Value: value, Err: walErrorString(err)})
+	return duration, err
+}
+
+func (wb *walBackend) Sign(data []byte) ([]byte, error) {
+	sig, err := wb.Backend.Sign(data)
+	wb.record(&walCall{Method: "Sign", Value: sig, Err: walErrorString(err)})
+	return sig, err
+}
+
+func (wb *walBackend) SignBLS(data []byte, extra []byte, useComposite bool, view *istanbul.View) (blscrypto.SerializedSignature, error) {
+	sig, err := wb.Backend.SignBLS(data, extra, useComposite, view)
+	wb.record(&walCall{Method: "SignBLS", Value: common.CopyBytes(sig[:]), Err: walErrorString(err)})
+	return sig, err
+}
+
+func (wb *walBackend) GetCurrentHeadBlock() istanbul.Proposal {
+	proposal := wb.Backend.GetCurrentHeadBlock()
+	wb.record(&walCall{Method: "GetCurrentHeadBlock", Value: encodeProposal(proposal)})
+	return proposal
+}
+
+func (wb *walBackend) GetCurrentHeadBlockAndAuthor() (istanbul.Proposal, common.Address) {
+	proposal, author := wb.Backend.GetCurrentHeadBlockAndAuthor()
+	value, _ := rlp.EncodeToBytes(&walHead{Proposal: encodeProposal(proposal), Author: author})
+	wb.record(&walCall{Method: "GetCurrentHeadBlockAndAuthor", Value: value})
+	return proposal, author
+}
+
+func (wb *walBackend) LastSubject() (istanbul.Subject, error) {
+	subject, err := wb.Backend.LastSubject()
+	var value []byte
+	if err == nil {
+		value, _ = rlp.EncodeToBytes(&subject)
+	}
+	wb.record(&walCall{Method: "LastSubject", Value: value, Err: walErrorString(err)})
+	return subject, err
+}
+
+func (wb *walBackend) HasBlock(hash common.Hash, number *big.Int) bool {
+	hasBlock := wb.Backend.HasBlock(hash, number)
+	value, _ := rlp.EncodeToBytes(hasBlock)
+	wb.record(&walCall{Method: "HasBlock", Value: value})
+	return hasBlock
+}
+
+func (wb *walBackend) IsPrimaryForSeq(seq *big.Int) bool {
+	isPrimary := wb.Backend.IsPrimaryForSeq(seq)
+	value, _ := rlp.EncodeToBytes(isPrimary)
+	wb.record(&walCall{Method: "IsPrimaryForSeq", Value: value})
+	return isPrimary
+}
+
+func (wb *walBackend) PenalizeOverflowingValidator(addr common.Address) {
+	wb.Backend.PenalizeOverflowingValidator(addr)
+	wb.record(&walCall{Method: "PenalizeOverflowingValidator", Value: addr.Bytes()})
+}
+
+func (wb *walBackend) AuthorForBlock(hash common.Hash, number uint64) common.Address {
+	author := wb.Backend.AuthorForBlock(hash, number)
+	wb.record(&walCall{Method: "AuthorForBlock", Value: author.Bytes()})
+	return author
+}
+
+func (wb *walBackend) ParentBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	valSet, err := wb.Backend.ParentBlockValidators(proposal)
+	wb.record(&walCall{Method: "ParentBlockValidators", Value: encodeValidatorSet(valSet), Err: walErrorString(err)})
+	return valSet, err
+}
+
+// errReplayDiverged is raised (as a panic, recovered by the replay) when the replayed core
+// makes a backend call that was not recorded
+type errReplayDiverged struct {
+	reason string
+}
+
+func (e *errReplayDiverged) Error() string {
+	return "replay diverged from the consensus WAL: " + e.reason
+}
+
+func diverged(format string, args ...interface{}) {
+	panic(&errReplayDiverged{reason: fmt.Sprintf(format, args...)})
+}
+
+// replayBackend is an istanbul.Backend answering the calls of a replayed core with the results
+// recorded in the WAL for the event being replayed.
+type replayBackend struct {
+	address common.Address
+	events  *event.TypeMux
+	calls   []*walCall
+}
+
+func newReplayBackend(address common.Address) *replayBackend {
+	return &replayBackend{
+		address: address,
+		events:  new(event.TypeMux),
+	}
+}
+
+// next returns the next recorded call, which must be for the given method
+func (rb *replayBackend) next(method string) *walCall {
+	if len(rb.calls) == 0 {
+		diverged("unexpected %s call", method)
+	}
+	call := rb.calls[0]
+	if call.Method != method {
+		diverged("unexpected %s call, %s was recorded", method, call.Method)
+	}
+	rb.calls = rb.calls[1:]
+	return call
+}
+
+func (rb *replayBackend) validatorSet(method string) (istanbul.ValidatorSet, error) {
+	call := rb.next(method)
+	valSet, err := decodeValidatorSet(call.Value)
+	if err != nil {
+		diverged("invalid recorded %s validator set: %v", method, err)
+	}
+	return valSet, walError(call.Err)
+}
+
+func (rb *replayBackend) proposal(method string, data []byte) istanbul.Proposal {
+	proposal, err := decodeProposal(data)
+	if err != nil {
+		diverged("invalid recorded %s proposal: %v", method, err)
+	}
+	return proposal
+}
+
+func (rb *replayBackend) Address() common.Address { return rb.address }
+
+func (rb *replayBackend) EventMux() *event.TypeMux { return rb.events }
+
+func (rb *replayBackend) Validators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return rb.validatorSet("Validators")
+}
+
+func (rb *replayBackend) NextBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return rb.validatorSet("NextBlockValidators")
+}
+
+func (rb *replayBackend) ParentBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return rb.validatorSet("ParentBlockValidators")
+}
+
+func (rb *replayBackend) Gossip(payload []byte, ethMsgCode uint64) error { return nil }
+
+func (rb *replayBackend) Multicast(addresses []common.Address, payload []byte, ethMsgCode uint64, sendToSelf bool) error {
+	call := rb.next("Multicast")
+	var sent walMulticast
+	if err := rlp.DecodeBytes(call.Value, &sent); err != nil {
+		diverged("invalid recorded Multicast: %v", err)
+	}
+	if !bytes.Equal(sent.Payload, payload) {
+		diverged("sent message %x, %x was recorded", payload, sent.Payload)
+	}
+	return walError(call.Err)
+}
+
+func (rb *replayBackend) Commit(proposal istanbul.Proposal, aggregatedSeal types.IstanbulAggregatedSeal, aggregatedEpochValidatorSetSeal types.IstanbulEpochValidatorSetSeal) error {
+	call := rb.next("Commit")
+	if recorded := common.BytesToHash(call.Value); recorded != proposal.Hash() {
+		diverged("committed proposal %s, %s was recorded", proposal.Hash().Hex(), recorded.Hex())
+	}
+	return walError(call.Err)
+}
+
+func (rb *replayBackend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
+	call := rb.next("Verify")
+	var duration uint64
+	if err := rlp.DecodeBytes(call.Value, &duration); err != nil {
+		diverged("invalid recorded Verify duration: %v", err)
+	}
+	return time.Duration(duration), walError(call.Err)
+}
+
+func (rb *replayBackend) Sign(data []byte) ([]byte, error) {
+	call := rb.next("Sign")
+	return call.Value, walError(call.Err)
+}
+
+func (rb *replayBackend) SignBLS(data []byte, extra []byte, useComposite bool, view *istanbul.View) (blscrypto.SerializedSignature, error) {
+	call := rb.next("SignBLS")
+	if call.Err != "" {
+		return blscrypto.SerializedSignature{}, walError(call.Err)
+	}
+	return blscrypto.SerializedSignatureFromBytes(call.Value)
+}
+
+func (rb *replayBackend) CheckSignature(data []byte, addr common.Address, sig []byte) error {
+	return nil
+}
+
+func (rb *replayBackend) GetCurrentHeadBlock() istanbul.Proposal {
+	call := rb.next("GetCurrentHeadBlock")
+	return rb.proposal("GetCurrentHeadBlock", call.Value)
+}
+
+func (rb *replayBackend) GetCurrentHeadBlockAndAuthor() (istanbul.Proposal, common.Address) {
+	call := rb.next("GetCurrentHeadBlockAndAuthor")
+	var head walHead
+	if err := rlp.DecodeBytes(call.Value, &head); err != nil {
+		diverged("invalid recorded GetCurrentHeadBlockAndAuthor: %v", err)
+	}
+	return rb.proposal("GetCurrentHeadBlockAndAuthor", head.Proposal), head.Author
+}
+
+func (rb *replayBackend) LastSubject() (istanbul.Subject, error) {
+	call := rb.next("LastSubject")
+	var subject istanbul.Subject
+	if call.Err != "" {
+		return subject, walError(call.Err)
+	}
+	if err := rlp.DecodeBytes(call.Value, &subject); err != nil {
+		diverged("invalid recorded LastSubject: %v", err)
+	}
+	return subject, nil
+}
+
+func (rb *replayBackend) HasBlock(hash common.Hash, number *big.Int) bool {
+	call := rb.next("HasBlock")
+	var hasBlock bool
+	if err := rlp.DecodeBytes(call.Value, &hasBlock); err != nil {
+		diverged("invalid recorded HasBlock: %v", err)
+	}
+	return hasBlock
+}
+
+func (rb *replayBackend) IsPrimaryForSeq(seq *big.Int) bool {
+	call := rb.next("IsPrimaryForSeq")
+	var isPrimary bool
+	if err := rlp.DecodeBytes(call.Value, &isPrimary); err != nil {
+		diverged("invalid recorded IsPrimaryForSeq: %v", err)
+	}
+	return isPrimary
+}
+
+func (rb *replayBackend) PenalizeOverflowingValidator(addr common.Address) {
+	if penalized := common.BytesToAddress(rb.next("PenalizeOverflowingValidator").Value); penalized != addr {
+		diverged("penalized validator %v, %v was recorded", addr, penalized)
+	}
+}
+
+func (rb *replayBackend) AuthorForBlock(hash common.Hash, number uint64) common.Address {
+	return common.BytesToAddress(rb.next("AuthorForBlock").Value)
+}
+
+func (rb *replayBackend) RefreshValPeers() error { return nil }
+
+func (rb *replayBackend) Authorize(address common.Address, publicKey *ecdsa.PublicKey, decryptFn istanbul.DecryptFn, signFn istanbul.SignerFn, signBLSFn istanbul.BLSViewSignerFn) {
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestWALSegments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consensuswal")
+	finishOnError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := openWAL(dir, 64, 2)
+	finishOnError(t, err)
+
+	// Entries appended before the first segment is started are dropped
+	finishOnError(t, w.append(walMessageEvent, []byte{0xff}))
+
+	finishOnError(t, w.startSegment(walStart, &walSnapshot{Epoch: 1}))
+	for i := byte(0); i < 10; i++ {
+		finishOnError(t, w.append(walMessageEvent, bytes.Repeat([]byte{i}, 16)))
+		if w.full() {
+			finishOnError(t, w.startSegment(walCheckpoint, &walSnapshot{Epoch: 1}))
+		}
+	}
+	finishOnError(t, w.close())
+
+	indexes, err := walSegments(dir)
+	finishOnError(t, err)
+	if len(indexes) != 2 {
+		t.Fatalf("segments mismatch: have %v, want 2 segments", indexes)
+	}
+
+	var types []walEntryType
+	var last []byte
+	finishOnError(t, readWAL(dir, func(entry *walEntry) error {
+		types = append(types, entry.Type)
+		if entry.Type == walMessageEvent {
+			last = entry.Data
+		}
+		return nil
+	}))
+	if types[0] != walCheckpoint {
+		t.Errorf("first entry mismatch: have %v, want %v", types[0], walCheckpoint)
+	}
+	if !bytes.Equal(last, bytes.Repeat([]byte{9}, 16)) {
+		t.Errorf("last entry mismatch: have %x", last)
+	}
+
+	// Reopening the WAL continues after the last segment
+	w, err = openWAL(dir, 64, 2)
+	finishOnError(t, err)
+	finishOnError(t, w.startSegment(walStart, &walSnapshot{Epoch: 1}))
+	finishOnError(t, w.close())
+	if indexes, _ := walSegments(dir); indexes[len(indexes)-1] != w.index || len(indexes) != 2 {
+		t.Errorf("segments mismatch: have %v, want last %d", indexes, w.index)
+	}
+}
+
+func TestWALReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consensuswal")
+	finishOnError(t, err)
+	defer os.RemoveAll(dir)
+
+	N := uint64(4)
+	F := uint64(1)
+
+	sys := NewTestSystemWithBackend(N, F)
+	backend := sys.backends[0]
+	config := *backend.engine.(*core).config
+	config.WAL = true
+	config.WALPath = dir
+	config.WALMaxFileSize = 1024 * 1024
+	config.WALMaxFiles = 2
+	c := New(backend, &config).(*core)
+	c.logger = testLogger
+	c.validateFn = backend.CheckValidatorSignature
+	backend.engine = c
+
+	close := sys.Run(true)
+	backend.NewRequest(makeBlock(1))
+	<-time.After(1 * time.Second)
+	backend.NewRequest(makeBlock(2))
+	<-time.After(1 * time.Second)
+	close()
+
+	if len(backend.committedMsgs) != 2 {
+		t.Fatalf("the number of executed requests mismatch: have %v, want 2", len(backend.committedMsgs))
+	}
+
+	var steps []*ReplayStep
+	finishOnError(t, ReplayWAL(dir, func(step *ReplayStep) {
+		steps = append(steps, step)
+	}))
+	if len(steps) == 0 || steps[0].Event != walStart.String() {
+		t.Fatalf("replay didn't start with the start of the core: %v", steps)
+	}
+	last := steps[len(steps)-1]
+	if last.Sequence.Uint64() != 3 {
+		t.Errorf("replayed sequence mismatch: have %v, want %v", last.Sequence, 3)
+	}
+	for i, step := range steps {
+		if step.Index != i {
+			t.Errorf("step index mismatch: have %d, want %d", step.Index, i)
+		}
+	}
+
+	// A replay of a WAL missing a sent message diverges
+	altered, err := ioutil.TempDir("", "consensuswal")
+	finishOnError(t, err)
+	defer os.RemoveAll(altered)
+
+	var buf bytes.Buffer
+	dropped := false
+	finishOnError(t, readWAL(dir, func(entry *walEntry) error {
+		if entry.Type == walBackendCall && !dropped {
+			var call walCall
+			finishOnError(t, rlp.DecodeBytes(entry.Data, &call))
+			if call.Method == "Multicast" {
+				dropped = true
+				return nil
+			}
+		}
+		return rlp.Encode(&buf, entry)
+	}))
+	finishOnError(t, ioutil.WriteFile(walSegmentPath(altered, 1), buf.Bytes(), 0600))
+	if err := ReplayWAL(altered, nil); err == nil {
+		t.Errorf("replay of an altered WAL didn't diverge")
+	}
+}
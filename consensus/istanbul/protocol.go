@@ -49,6 +49,12 @@ const (
 	ValidatorHandshakeMsg  = 0x18
 )
 
+// ProxyStatusMsg is the istanbul.Message code of the proxy status requests and
+// responses exchanged between a proxied validator and its proxies.  It is not a
+// p2p message code: the messages are carried in a ConsensusMsg, which nodes that
+// don't support them ignore when exchanged between a proxy and its validator.
+const ProxyStatusMsg = 0x19
+
 func IsIstanbulMsg(msg p2p.Msg) bool {
 	return msg.Code >= ConsensusMsg && msg.Code <= ValidatorHandshakeMsg
 }
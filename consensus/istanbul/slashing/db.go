@@ -0,0 +1,253 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package slashing implements the slashing-protection database of a validator, which
+// refuses to sign consensus messages conflicting with messages already signed in the
+// same view.
+package slashing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvlerrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	signatureKey = "sig" // Database Key Prefix for the signed digests
+	watermarkKey = "wm"  // Database Key Prefix for the watermark sequences
+
+	// retainedSequences is the number of sequences below the highest signed one whose
+	// signatures are kept. Older signatures are pruned, and signing in their sequences is
+	// refused.
+	retainedSequences = 1024
+)
+
+var (
+	// ErrConflictingSignature is returned if a different message of the same kind was
+	// already signed in the view
+	ErrConflictingSignature = errors.New("a conflicting message was already signed in this view")
+	// ErrBelowWatermark is returned if the view is older than the signatures kept by the database
+	ErrBelowWatermark = errors.New("view is below the slashing protection watermark")
+)
+
+// Kind is the kind of a consensus message signed by a validator
+type Kind byte
+
+const (
+	Preprepare Kind = iota
+	Prepare
+	Commit
+	CommittedSeal
+	EpochValidatorSetSeal
+)
+
+var kindNames = map[Kind]string{
+	Preprepare:            "preprepare",
+	Prepare:               "prepare",
+	Commit:                "commit",
+	CommittedSeal:         "committedSeal",
+	EpochValidatorSetSeal: "epochValidatorSetSeal",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("Kind(%d)", byte(k))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (k Kind) MarshalText() ([]byte, error) {
+	name, ok := kindNames[k]
+	if !ok {
+		return nil, fmt.Errorf("unknown signature kind %d", byte(k))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *Kind) UnmarshalText(input []byte) error {
+	for kind, name := range kindNames {
+		if name == string(input) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown signature kind %q", input)
+}
+
+// DB is a slashing-protection database. It records the digest of every consensus
+// message signed by a validator, by view and kind, and refuses to sign a message with
+// a different digest for a recorded view and kind.
+type DB struct {
+	db     *leveldb.DB
+	mu     sync.Mutex // Makes the checks and records of signatures atomic
+	logger log.Logger
+}
+
+// Open opens the slashing-protection database at the given path.
+// If the path is empty, the database is created in memory.
+func Open(path string) (*DB, error) {
+	logger := log.New("type", "slashingProtectionDB", "dbpath", path)
+
+	var db *leveldb.DB
+	var err error
+	if path == "" {
+		db, err = leveldb.Open(storage.NewMemStorage(), nil)
+	} else {
+		db, err = leveldb.OpenFile(path, &opt.Options{OpenFilesCacheCapacity: 5})
+		if _, iscorrupted := err.(*lvlerrors.ErrCorrupted); iscorrupted {
+			db, err = leveldb.RecoverFile(path, nil)
+		}
+	}
+	if err != nil {
+		logger.Error("Failed to open slashing protection db", "err", err)
+		return nil, err
+	}
+	return &DB{db: db, logger: logger}, nil
+}
+
+// Close closes the database
+func (spdb *DB) Close() error {
+	return spdb.db.Close()
+}
+
+// CheckAndRecord checks that the signer didn't sign a message of the same kind with a
+// different digest in the view, and records the digest. It returns ErrConflictingSignature
+// if it did, and ErrBelowWatermark if the view is older than the signatures kept.
+// Signing the same digest again is allowed.
+func (spdb *DB) CheckAndRecord(signer common.Address, kind Kind, sequence, round uint64, digest common.Hash) error {
+	spdb.mu.Lock()
+	defer spdb.mu.Unlock()
+
+	watermark, err := spdb.watermark(signer)
+	if err != nil {
+		return err
+	}
+	if sequence < watermark {
+		return ErrBelowWatermark
+	}
+	key := signatureToKey(signer, sequence, round, kind)
+	signed, err := spdb.db.Get(key, nil)
+	if err == nil {
+		if !bytes.Equal(signed, digest[:]) {
+			spdb.logger.Warn("Refusing to sign a conflicting message", "signer", signer, "kind", kind, "sequence", sequence, "round", round, "signed", common.BytesToHash(signed), "digest", digest)
+			return ErrConflictingSignature
+		}
+		return nil
+	} else if err != leveldb.ErrNotFound {
+		return err
+	}
+	// Records must be synced before the signature is released
+	if err := spdb.db.Put(key, digest[:], &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+
+	if sequence >= watermark+2*retainedSequences {
+		return spdb.prune(signer, sequence-retainedSequences)
+	}
+	return nil
+}
+
+// watermark returns the sequence below which the signer is refused to sign
+func (spdb *DB) watermark(signer common.Address) (uint64, error) {
+	blob, err := spdb.db.Get(watermarkToKey(signer), nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return decodeUint64(blob), nil
+}
+
+// prune raises the watermark of the signer to the given sequence, and deletes the signatures
+// below it
+func (spdb *DB) prune(signer common.Address, watermark uint64) error {
+	batch := new(leveldb.Batch)
+	batch.Put(watermarkToKey(signer), encodeUint64(watermark))
+
+	iter := spdb.db.NewIterator(&util.Range{Start: sequenceToKey(signer, 0), Limit: sequenceToKey(signer, watermark)}, nil)
+	for iter.Next() {
+		batch.Delete(common.CopyBytes(iter.Key()))
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return spdb.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+func decodeUint64(b []byte) uint64 {
+	return binary.BigEndian.Uint64(b)
+}
+
+func encodeUint64(n uint64) []byte {
+	buff := make([]byte, 8)
+	binary.BigEndian.PutUint64(buff, n)
+	return buff
+}
+
+func watermarkToKey(signer common.Address) []byte {
+	return append([]byte(watermarkKey), signer[:]...)
+}
+
+// sequenceToKey encodes the smallest key for the signatures of the signer in the given sequence
+func sequenceToKey(signer common.Address, sequence uint64) []byte {
+	prefix := []byte(signatureKey)
+	buff := make([]byte, len(prefix)+common.AddressLength+8)
+
+	copy(buff, prefix)
+	copy(buff[len(prefix):], signer[:])
+	binary.BigEndian.PutUint64(buff[len(prefix)+common.AddressLength:], sequence)
+
+	return buff
+}
+
+// signatureToKey encodes the signer, view and kind of a signature in binary format, so that
+// the keys of a signer sort by view.
+// The key format is [ prefix . Signer . BigEndian(Sequence) . BigEndian(Round) . Kind ]
+func signatureToKey(signer common.Address, sequence, round uint64, kind Kind) []byte {
+	prefix := sequenceToKey(signer, sequence)
+	buff := make([]byte, len(prefix)+9)
+
+	copy(buff, prefix)
+	binary.BigEndian.PutUint64(buff[len(prefix):], round)
+	buff[len(prefix)+8] = byte(kind)
+
+	return buff
+}
+
+// keyToSignature decodes the signer, view and kind of a signature key
+func keyToSignature(key []byte) (signer common.Address, sequence, round uint64, kind Kind, err error) {
+	prefix := []byte(signatureKey)
+	if len(key) != len(prefix)+common.AddressLength+17 || !bytes.HasPrefix(key, prefix) {
+		return common.Address{}, 0, 0, 0, fmt.Errorf("invalid slashing protection key %x", key)
+	}
+	key = key[len(prefix):]
+	signer = common.BytesToAddress(key[:common.AddressLength])
+	key = key[common.AddressLength:]
+	return signer, binary.BigEndian.Uint64(key), binary.BigEndian.Uint64(key[8:]), Kind(key[16]), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testSigner = common.HexToAddress("0x694267f14675d7e1b9494fd8d72fefe1755710fa")
+	testDigest = common.HexToHash("0x01")
+	testOther  = common.HexToHash("0x02")
+)
+
+func TestCheckAndRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slashingprotection")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "db")
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		signer   common.Address
+		kind     Kind
+		sequence uint64
+		round    uint64
+		digest   common.Hash
+		want     error
+	}{
+		{testSigner, Prepare, 10, 0, testDigest, nil},
+		// Signing the same message again is allowed
+		{testSigner, Prepare, 10, 0, testDigest, nil},
+		{testSigner, Prepare, 10, 0, testOther, ErrConflictingSignature},
+		// Other kinds, rounds and signers are independent
+		{testSigner, Commit, 10, 0, testOther, nil},
+		{testSigner, Prepare, 10, 1, testOther, nil},
+		{common.Address{}, Prepare, 10, 0, testOther, nil},
+	}
+	for i, test := range tests {
+		if err := db.CheckAndRecord(test.signer, test.kind, test.sequence, test.round, test.digest); err != test.want {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.want)
+		}
+	}
+
+	// The signatures are persisted
+	db.Close()
+	if db, err = Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CheckAndRecord(testSigner, Prepare, 10, 0, testOther); err != ErrConflictingSignature {
+		t.Errorf("error mismatch after reopening: have %v, want %v", err, ErrConflictingSignature)
+	}
+
+	// Old signatures are pruned, and signing in their sequences refused
+	if err := db.CheckAndRecord(testSigner, Prepare, 2*retainedSequences+10, 0, testDigest); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CheckAndRecord(testSigner, Prepare, 10, 0, testDigest); err != ErrBelowWatermark {
+		t.Errorf("error mismatch below watermark: have %v, want %v", err, ErrBelowWatermark)
+	}
+	interchange, err := db.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, signer := range interchange.Signers {
+		if signer.Address == testSigner && (signer.Watermark != retainedSequences+10 || len(signer.Signatures) != 1) {
+			t.Errorf("pruning mismatch: have watermark %d and %d signatures, want %d and 1", signer.Watermark, len(signer.Signatures), retainedSequences+10)
+		}
+	}
+}
+
+func TestInterchange(t *testing.T) {
+	db, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for i, kind := range []Kind{Preprepare, Prepare, Commit, CommittedSeal, EpochValidatorSetSeal} {
+		if err := db.CheckAndRecord(testSigner, kind, 100, uint64(i), testDigest); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exported, err := db.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var interchange Interchange
+	if err := json.Unmarshal(blob, &interchange); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&interchange, exported) {
+		t.Fatalf("interchange mismatch after JSON roundtrip: have %v, want %v", interchange, exported)
+	}
+
+	// An imported signature protects against signing conflicting messages
+	other, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if err := other.CheckAndRecord(testSigner, Commit, 100, 2, testOther); err != nil {
+		t.Fatal(err)
+	}
+	interchange.Signers[0].Watermark = 50
+	if err := other.Import(&interchange); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.CheckAndRecord(testSigner, Prepare, 100, 1, testOther); err != ErrConflictingSignature {
+		t.Errorf("error mismatch for imported signature: have %v, want %v", err, ErrConflictingSignature)
+	}
+	if err := other.CheckAndRecord(testSigner, Commit, 100, 2, testOther); err != nil {
+		t.Errorf("recorded signature replaced by a conflicting imported one: %v", err)
+	}
+	if err := other.CheckAndRecord(testSigner, Prepare, 49, 0, testOther); err != ErrBelowWatermark {
+		t.Errorf("error mismatch below imported watermark: have %v, want %v", err, ErrBelowWatermark)
+	}
+
+	interchange.Version = InterchangeVersion + 1
+	if err := other.Import(&interchange); err == nil {
+		t.Errorf("expected import of an unsupported version to fail")
+	}
+}
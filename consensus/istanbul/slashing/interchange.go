@@ -0,0 +1,145 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// InterchangeVersion is the version of the interchange format written by Export
+const InterchangeVersion = 1
+
+// Interchange is the format slashing-protection records are exported and imported in, to
+// migrate validator keys between nodes.
+type Interchange struct {
+	Version uint64              `json:"version"`
+	Signers []InterchangeSigner `json:"signers"`
+}
+
+// InterchangeSigner holds the signatures of a validator
+type InterchangeSigner struct {
+	Address    common.Address         `json:"address"`
+	Watermark  uint64                 `json:"watermark"` // Sequence below which signing is refused
+	Signatures []InterchangeSignature `json:"signatures"`
+}
+
+// InterchangeSignature is the digest of a consensus message signed in a view
+type InterchangeSignature struct {
+	Kind     Kind        `json:"kind"`
+	Sequence uint64      `json:"sequence"`
+	Round    uint64      `json:"round"`
+	Digest   common.Hash `json:"digest"`
+}
+
+// Export returns the signatures and watermarks recorded for every signer
+func (spdb *DB) Export() (*Interchange, error) {
+	spdb.mu.Lock()
+	defer spdb.mu.Unlock()
+
+	signers := make(map[common.Address]*InterchangeSigner)
+	getSigner := func(address common.Address) *InterchangeSigner {
+		if signer, ok := signers[address]; ok {
+			return signer
+		}
+		signer := &InterchangeSigner{Address: address, Signatures: []InterchangeSignature{}}
+		signers[address] = signer
+		return signer
+	}
+
+	iter := spdb.db.NewIterator(util.BytesPrefix([]byte(watermarkKey)), nil)
+	for iter.Next() {
+		address := common.BytesToAddress(iter.Key()[len(watermarkKey):])
+		getSigner(address).Watermark = decodeUint64(iter.Value())
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	iter = spdb.db.NewIterator(util.BytesPrefix([]byte(signatureKey)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		address, sequence, round, kind, err := keyToSignature(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		signer := getSigner(address)
+		signer.Signatures = append(signer.Signatures, InterchangeSignature{
+			Kind:     kind,
+			Sequence: sequence,
+			Round:    round,
+			Digest:   common.BytesToHash(iter.Value()),
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	interchange := &Interchange{Version: InterchangeVersion, Signers: make([]InterchangeSigner, 0, len(signers))}
+	for _, signer := range signers {
+		interchange.Signers = append(interchange.Signers, *signer)
+	}
+	sort.Slice(interchange.Signers, func(i, j int) bool {
+		return bytes.Compare(interchange.Signers[i].Address[:], interchange.Signers[j].Address[:]) < 0
+	})
+	return interchange, nil
+}
+
+// Import merges the signatures and watermarks of the interchange into the database. The
+// highest of the watermarks is kept. If a different digest is already recorded for an
+// imported signature, the recorded one is kept; signing is refused for any other digest
+// in that view anyway.
+func (spdb *DB) Import(interchange *Interchange) error {
+	if interchange.Version != InterchangeVersion {
+		return fmt.Errorf("unsupported slashing protection interchange version %d", interchange.Version)
+	}
+	spdb.mu.Lock()
+	defer spdb.mu.Unlock()
+
+	batch := new(leveldb.Batch)
+	for _, signer := range interchange.Signers {
+		watermark, err := spdb.watermark(signer.Address)
+		if err != nil {
+			return err
+		}
+		if signer.Watermark > watermark {
+			batch.Put(watermarkToKey(signer.Address), encodeUint64(signer.Watermark))
+		}
+		for _, sig := range signer.Signatures {
+			if _, ok := kindNames[sig.Kind]; !ok {
+				return fmt.Errorf("unknown signature kind %d", byte(sig.Kind))
+			}
+			key := signatureToKey(signer.Address, sig.Sequence, sig.Round, sig.Kind)
+			signed, err := spdb.db.Get(key, nil)
+			if err == leveldb.ErrNotFound {
+				batch.Put(key, common.CopyBytes(sig.Digest[:]))
+			} else if err != nil {
+				return err
+			} else if !bytes.Equal(signed, sig.Digest[:]) {
+				spdb.logger.Warn("Imported signature conflicts with a recorded one", "signer", signer.Address, "kind", sig.Kind, "sequence", sig.Sequence, "round", sig.Round, "recorded", common.BytesToHash(signed), "imported", sig.Digest)
+			}
+		}
+	}
+	return spdb.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
@@ -45,6 +45,44 @@ type Uptime struct {
 	Entries     []UptimeEntry
 }
 
+// Purposes of the CELO minted at the end of an epoch
+const (
+	ValidatorRewardsMint = "validatorRewards" // Validator payments converted from cUSD, minted to the Reserve
+	VoterRewardsMint     = "voterRewards"     // Voter rewards, minted to LockedGold
+	CommunityRewardMint  = "communityReward"  // Community reward, minted to Governance (or the Reserve if it is low)
+	CarbonOffsettingMint = "carbonOffsetting" // Carbon offsetting reward, minted to the carbon offsetting partner
+)
+
+// ValidatorEpochReward is the payment, in cUSD, of a validator at the end of an epoch
+type ValidatorEpochReward struct {
+	Validator common.Address
+	Group     common.Address
+	Uptime    *big.Int // Uptime score as a fixidity fraction
+	Payment   *big.Int
+}
+
+// GroupEpochReward is the reward of the voters of a group at the end of an epoch
+type GroupEpochReward struct {
+	Group  common.Address
+	Reward *big.Int
+}
+
+// EpochRewardMint is an amount of CELO minted to a beneficiary at the end of an epoch
+type EpochRewardMint struct {
+	Beneficiary common.Address
+	Purpose     string
+	Amount      *big.Int
+}
+
+// EpochRewards is the breakdown of the rewards distributed at the last block of an epoch
+type EpochRewards struct {
+	Epoch      uint64
+	Frozen     bool // Set if the distribution was skipped because epoch rewards are frozen
+	Validators []ValidatorEpochReward
+	Groups     []GroupEpochReward
+	Mints      []EpochRewardMint
+}
+
 // Proposal supports retrieving height and serialized block to be used during Istanbul consensus.
 type Proposal interface {
 	// Number retrieves the sequence number of this proposal.
@@ -83,6 +83,11 @@ type ValidatorSet interface {
 	SetRandomness(seed common.Hash)
 	// Sets the randomness for use in the proposer policy
 	GetRandomness() common.Hash
+	// Sets the weights of the validators for use in the proposer policy, in the order of List().
+	// This is injected into the ValidatorSet when we call `getOrderedValidators`
+	SetWeights(weights []*big.Int)
+	// Gets the weights of the validators for use in the proposer policy
+	GetWeights() []*big.Int
 
 	// Return the validator size
 	Size() int
@@ -117,6 +122,7 @@ type ValidatorSet interface {
 type ValidatorSetData struct {
 	Validators []ValidatorData
 	Randomness common.Hash
+	Weights    []*big.Int `rlp:"tail"`
 }
 
 // ----------------------------------------------------------------------------
@@ -89,6 +89,9 @@ type defaultSet struct {
 	// This is set when we call `getOrderedValidators`
 	// TODO Rename to `EpochState` that has validators & randomness
 	randomness common.Hash
+	// This is set when we call `getOrderedValidators` with the StakeWeighted proposer policy,
+	// and cleared when validators are added or removed
+	weights []*big.Int
 }
 
 func newDefaultSet(validators []istanbul.ValidatorData) *defaultSet {
@@ -124,6 +127,18 @@ func (valSet *defaultSet) MinQuorumSize() int { return int(math.Ceil(float64(2*v
 func (valSet *defaultSet) SetRandomness(seed common.Hash) { valSet.randomness = seed }
 func (valSet *defaultSet) GetRandomness() common.Hash     { return valSet.randomness }
 
+func (valSet *defaultSet) SetWeights(weights []*big.Int) {
+	valSet.validatorMu.Lock()
+	defer valSet.validatorMu.Unlock()
+	valSet.weights = weights
+}
+
+func (valSet *defaultSet) GetWeights() []*big.Int {
+	valSet.validatorMu.RLock()
+	defer valSet.validatorMu.RUnlock()
+	return valSet.weights
+}
+
 func (valSet *defaultSet) String() string {
 	var buf strings.Builder
 	if _, err := buf.WriteString("["); err != nil {
@@ -205,6 +220,7 @@ func (valSet *defaultSet) AddValidators(validators []istanbul.ValidatorData) boo
 	}
 
 	valSet.validators = append(valSet.validators, newValidators...)
+	valSet.weights = nil
 
 	return true
 }
@@ -231,6 +247,7 @@ func (valSet *defaultSet) RemoveValidators(removedValidators *big.Int) bool {
 	}
 
 	valSet.validators = tempList
+	valSet.weights = nil
 	return true
 }
 
@@ -239,6 +256,7 @@ func (valSet *defaultSet) Copy() istanbul.ValidatorSet {
 	defer valSet.validatorMu.RUnlock()
 	newValSet := NewSet(MapValidatorsToData(valSet.validators))
 	newValSet.SetRandomness(valSet.randomness)
+	newValSet.SetWeights(copyWeights(valSet.weights))
 	return newValSet
 }
 
@@ -248,6 +266,7 @@ func (valSet *defaultSet) AsData() *istanbul.ValidatorSetData {
 	return &istanbul.ValidatorSetData{
 		Validators: MapValidatorsToData(valSet.validators),
 		Randomness: valSet.randomness,
+		Weights:    copyWeights(valSet.weights),
 	}
 }
 
@@ -262,6 +281,9 @@ func (val *defaultSet) UnmarshalJSON(b []byte) error {
 	}
 	*val = *newDefaultSet(data.Validators)
 	val.SetRandomness(data.Randomness)
+	if len(data.Weights) > 0 {
+		val.SetWeights(data.Weights)
+	}
 	return nil
 }
 
@@ -276,6 +298,9 @@ func (val *defaultSet) DecodeRLP(stream *rlp.Stream) error {
 	}
 	*val = *newDefaultSet(data.Validators)
 	val.SetRandomness(data.Randomness)
+	if len(data.Weights) > 0 {
+		val.SetWeights(data.Weights)
+	}
 	return nil
 }
 
@@ -283,6 +308,17 @@ func (val *defaultSet) Serialize() ([]byte, error) { return rlp.EncodeToBytes(va
 
 // Utility Functions
 
+func copyWeights(weights []*big.Int) []*big.Int {
+	if weights == nil {
+		return nil
+	}
+	copied := make([]*big.Int, len(weights))
+	for i, weight := range weights {
+		copied[i] = new(big.Int).Set(weight)
+	}
+	return copied
+}
+
 func MapValidatorsToData(validators []istanbul.Validator) []istanbul.ValidatorData {
 	validatorsData := make([]istanbul.ValidatorData, len(validators))
 	for i, v := range validators {
@@ -253,3 +253,42 @@ func TestValidatorSetRLPEncoding(t *testing.T) {
 		t.Errorf("validatorSet mismatch: have %v, want %v", valSet, result)
 	}
 }
+
+func TestValidatorSetWeightsRLPEncoding(t *testing.T) {
+	validators := []istanbul.ValidatorData{
+		{Address: common.HexToAddress(testAddress), BLSPublicKey: blscrypto.SerializedPublicKey{1, 2, 3}},
+		{Address: common.HexToAddress(testAddress2), BLSPublicKey: blscrypto.SerializedPublicKey{3, 1, 4}},
+	}
+	valSet := NewSet(validators)
+	valSet.SetRandomness(common.HexToHash("f36aa9716b892ec8"))
+	valSet.SetWeights([]*big.Int{big.NewInt(5), big.NewInt(0)})
+
+	rawVal, err := rlp.EncodeToBytes(valSet)
+	if err != nil {
+		t.Fatalf("Error %v", err)
+	}
+	var result *defaultSet
+	if err = rlp.DecodeBytes(rawVal, &result); err != nil {
+		t.Fatalf("Error %v", err)
+	}
+	if !reflect.DeepEqual(valSet.GetWeights(), result.GetWeights()) {
+		t.Errorf("weights mismatch: have %v, want %v", result.GetWeights(), valSet.GetWeights())
+	}
+
+	// Validator sets encoded before weights were added decode without weights
+	rawVal, err = rlp.EncodeToBytes([]interface{}{validators, valSet.GetRandomness()})
+	if err != nil {
+		t.Fatalf("Error %v", err)
+	}
+	if err = rlp.DecodeBytes(rawVal, &result); err != nil {
+		t.Fatalf("Error %v", err)
+	}
+	if result.GetWeights() != nil || result.GetRandomness() != valSet.GetRandomness() {
+		t.Errorf("legacy validator set mismatch: have %v with weights %v", result, result.GetWeights())
+	}
+
+	// Adding or removing validators clears the weights
+	if valSet.RemoveValidators(big.NewInt(1)); valSet.GetWeights() != nil {
+		t.Errorf("weights not cleared after removing a validator: %v", valSet.GetWeights())
+	}
+}
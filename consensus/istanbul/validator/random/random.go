@@ -5,6 +5,7 @@ package random
 import (
 	"encoding/binary"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/sha3"
@@ -39,6 +40,58 @@ func Permutation(seed common.Hash, n int) []int {
 	return array
 }
 
+// WeightedPermutation produces an array with a random permutation of [0, 1, ... n-1], where n is the
+// number of weights. Each position is drawn among the remaining indexes with a probability
+// proportional to their weight, so that index i comes first with probability weights[i] / sum(weights).
+// Indexes with a zero weight come last, in ascending order.
+func WeightedPermutation(seed common.Hash, weights []*big.Int) []int {
+	n := len(weights)
+	if n <= 0 {
+		return nil
+	}
+
+	remaining := make([]int, 0, n)
+	total := new(big.Int)
+	for i, weight := range weights {
+		if weight != nil && weight.Sign() > 0 {
+			remaining = append(remaining, i)
+			total.Add(total, weight)
+		}
+	}
+
+	// Create the Shake256 pseudo random stream.
+	randomness := sha3.NewShake256()
+	_, err := randomness.Write(seed[:])
+	if err != nil {
+		// ShakeHash never returns an error.
+		panic(err)
+	}
+
+	// Draw the indexes with a positive weight one at a time, removing each from the remaining ones.
+	array := make([]int, 0, n)
+	for len(remaining) > 0 {
+		r := uniformBig(randomness.(io.Reader), total) // r in [0, total)
+		j := 0
+		for ; j < len(remaining)-1; j++ {
+			if r.Cmp(weights[remaining[j]]) < 0 {
+				break
+			}
+			r.Sub(r, weights[remaining[j]])
+		}
+		array = append(array, remaining[j])
+		total.Sub(total, weights[remaining[j]])
+		remaining = append(remaining[:j], remaining[j+1:]...)
+	}
+
+	// Append the indexes with a zero weight.
+	for i, weight := range weights {
+		if weight == nil || weight.Sign() <= 0 {
+			array = append(array, i)
+		}
+	}
+	return array
+}
+
 // compress produces a 64-bit random value from a byte stream.
 func randUint64(randomness io.Reader) uint64 {
 	raw := make([]byte, 8)
@@ -61,3 +114,24 @@ func uniform(randomness io.Reader, k uint64) uint64 {
 	}
 	return r
 }
+
+// uniformBig produces an integer in the range [0, k) from the provided randomness, by rejecting
+// the random values of k's bit length that are not lower than k.
+func uniformBig(randomness io.Reader, k *big.Int) *big.Int {
+	bits := k.BitLen()
+	raw := make([]byte, (bits+7)/8)
+	for {
+		_, err := randomness.Read(raw)
+		if err != nil {
+			// Random stream should never return an error.
+			panic(err)
+		}
+		// Clear the bits above k's bit length.
+		if excess := uint(len(raw)*8 - bits); excess > 0 {
+			raw[0] &= 0xff >> excess
+		}
+		if r := new(big.Int).SetBytes(raw); r.Cmp(k) < 0 {
+			return r
+		}
+	}
+}
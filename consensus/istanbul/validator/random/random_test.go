@@ -1,6 +1,7 @@
 package random
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -60,3 +61,39 @@ func TestUniform(t *testing.T) {
 		t.Errorf("uniform(_, %d) did not cover [0, %d)", bound, bound)
 	})
 }
+
+func TestWeightedPermutation(t *testing.T) {
+	weights := []*big.Int{big.NewInt(0), big.NewInt(3), big.NewInt(1), big.NewInt(0), big.NewInt(6), big.NewInt(1)}
+
+	// Verify that the returned value is a permutation, with the zero weights last.
+	for i := 0; i < 1000; i++ {
+		perm := WeightedPermutation(randomHash(), weights)
+		if len(perm) != len(weights) {
+			t.Fatalf("WeightedPermutation(_, %v) = %v, want %d indexes", weights, perm, len(weights))
+		}
+		seen := make([]bool, len(weights))
+		for _, index := range perm {
+			if seen[index] {
+				t.Fatalf("WeightedPermutation(_, %v) = %v, index %d repeated", weights, perm, index)
+			}
+			seen[index] = true
+		}
+		if perm[4] != 0 || perm[5] != 3 {
+			t.Fatalf("WeightedPermutation(_, %v) = %v, want zero weights last", weights, perm)
+		}
+	}
+}
+
+func TestUniformBig(t *testing.T) {
+	randomness := rand.New(rand.NewSource(rand.Int63()))
+
+	// Verify that the returned value is always in the desired range, for bounds within and across bytes.
+	for _, k := range []int64{1, 2, 3, 255, 256, 257, 1000003} {
+		bound := big.NewInt(k)
+		for i := 0; i < 1000; i++ {
+			if got := uniformBig(randomness, bound); got.Sign() < 0 || got.Cmp(bound) >= 0 {
+				t.Errorf("uniformBig(_, %d) = %d, want in [0, %d)", k, got, k)
+			}
+		}
+	}
+}
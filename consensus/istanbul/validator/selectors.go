@@ -50,6 +50,23 @@ func ShuffledRoundRobinProposer(valSet istanbul.ValidatorSet, proposer common.Ad
 	return valSet.List()[shuffle[idx%uint64(valSet.Size())]]
 }
 
+// StakeWeightedProposer selects the proposer of a block at random, with a probability proportional to each
+// validator's weight, using the randomness of the previous block as the seed. On round changes, the next proposers
+// are drawn among the validators that didn't propose yet, so that every validator gets a turn within Size() rounds.
+// Until the weights are set on the validator set, it falls back to ShuffledRoundRobinProposer.
+func StakeWeightedProposer(valSet istanbul.ValidatorSet, proposer common.Address, round uint64) istanbul.Validator {
+	if valSet.Size() == 0 {
+		return nil
+	}
+	weights := valSet.GetWeights()
+	if len(weights) != valSet.Size() {
+		return ShuffledRoundRobinProposer(valSet, proposer, round)
+	}
+
+	order := random.WeightedPermutation(valSet.GetRandomness(), weights)
+	return valSet.List()[order[round%uint64(valSet.Size())]]
+}
+
 // RoundRobinProposer selects the next proposer with a round robin strategy according to storage order.
 func RoundRobinProposer(valSet istanbul.ValidatorSet, proposer common.Address, round uint64) istanbul.Validator {
 	if valSet.Size() == 0 {
@@ -83,6 +100,8 @@ func GetProposerSelector(pp istanbul.ProposerPolicy) istanbul.ProposerSelector {
 		return RoundRobinProposer
 	case istanbul.ShuffledRoundRobin:
 		return ShuffledRoundRobinProposer
+	case istanbul.StakeWeighted:
+		return StakeWeightedProposer
 	default:
 		// Programming error.
 		panic(fmt.Sprintf("unknown proposer selection policy: %v", pp))
@@ -18,11 +18,14 @@ package validator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	"github.com/ethereum/go-ethereum/crypto"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
 )
 
@@ -268,3 +271,134 @@ func TestShuffledRoundRobinProposer(t *testing.T) {
 		}
 	})
 }
+
+func TestStakeWeightedProposer(t *testing.T) {
+	var addrs []common.Address
+	for _, strAddr := range testAddresses {
+		addrs = append(addrs, common.HexToAddress(strAddr))
+	}
+
+	v, err := istanbul.CombineIstanbulExtraToValidatorData(addrs, make([]blscrypto.SerializedPublicKey, len(addrs)))
+	if err != nil {
+		t.Fatalf("CombineIstanbulExtraToValidatorData(...): %v", err)
+	}
+	valSet := newDefaultSet(v)
+	selector := GetProposerSelector(istanbul.StakeWeighted)
+	testSeed := common.HexToHash("f36aa9716b892ec8")
+
+	// Verify that the proposer is selected with a shuffled round robin until the weights are set.
+	t.Run("no weights", func(t *testing.T) {
+		valSet.SetRandomness(testSeed)
+		valSet.SetWeights(nil)
+		for round := uint64(0); round < 10; round++ {
+			for _, lastProposer := range addrs {
+				want := ShuffledRoundRobinProposer(valSet, lastProposer, round)
+				if proposer := selector(valSet, lastProposer, round); proposer.Address() != want.Address() {
+					t.Errorf("proposer mismatch on round %d: have %v, want %v", round, proposer.Address(), want.Address())
+				}
+			}
+		}
+	})
+
+	// Verify that the selection only depends on the randomness and the round.
+	t.Run("deterministic", func(t *testing.T) {
+		valSet.SetRandomness(testSeed)
+		valSet.SetWeights(testWeights(1, 2, 3, 4, 5))
+		want := selector(valSet, common.Address{}, 0)
+		for _, lastProposer := range addrs {
+			if proposer := selector(valSet.Copy(), lastProposer, 0); proposer.Address() != want.Address() {
+				t.Errorf("proposer mismatch after %v: have %v, want %v", lastProposer, proposer.Address(), want.Address())
+			}
+		}
+	})
+
+	// Verify that every validator gets a turn within Size() round changes, zero weighted validators last.
+	t.Run("round changes", func(t *testing.T) {
+		valSet.SetWeights(testWeights(0, 7, 0, 3, 1))
+		for i := 0; i < 100; i++ {
+			valSet.SetRandomness(testRandomness(i))
+			seen := make(map[common.Address]bool)
+			for round := uint64(0); round < uint64(len(addrs)); round++ {
+				proposer := selector(valSet, common.Address{}, round)
+				if seen[proposer.Address()] {
+					t.Fatalf("proposer %v selected twice within %d rounds", proposer.Address(), len(addrs))
+				}
+				seen[proposer.Address()] = true
+				if round < 3 && (proposer.Address() == addrs[0] || proposer.Address() == addrs[2]) {
+					t.Fatalf("zero weighted proposer %v selected on round %d", proposer.Address(), round)
+				}
+			}
+			if proposer := selector(valSet, common.Address{}, uint64(len(addrs))); proposer.Address() != selector(valSet, common.Address{}, 0).Address() {
+				t.Errorf("proposer order didn't wrap around after %d rounds", len(addrs))
+			}
+		}
+	})
+
+	// Verify that validators propose proportionally to their weight.
+	cases := []struct {
+		name    string
+		weights []*big.Int
+	}{{
+		name:    "equal",
+		weights: testWeights(1, 1, 1, 1, 1),
+	}, {
+		name:    "increasing",
+		weights: testWeights(1, 2, 3, 4, 10),
+	}, {
+		name:    "dominant",
+		weights: testWeights(1, 1, 1, 1, 96),
+	}, {
+		name:    "zero",
+		weights: testWeights(0, 5, 0, 3, 2),
+	}, {
+		name: "votes",
+		weights: []*big.Int{
+			new(big.Int).Mul(big.NewInt(3), big.NewInt(1e18)),
+			new(big.Int).Mul(big.NewInt(1250000), big.NewInt(1e18)),
+			new(big.Int).Mul(big.NewInt(2500000), big.NewInt(1e18)),
+			new(big.Int).Mul(big.NewInt(1250000), big.NewInt(1e18)),
+			new(big.Int).Mul(big.NewInt(5000000), big.NewInt(1e18)),
+		},
+	}}
+	for _, c := range cases {
+		t.Run("fairness "+c.name, func(t *testing.T) {
+			valSet.SetWeights(c.weights)
+			checkProposerDistribution(t, valSet, selector, c.weights, 20000)
+		})
+	}
+}
+
+func testWeights(weights ...int64) []*big.Int {
+	bigWeights := make([]*big.Int, len(weights))
+	for i, weight := range weights {
+		bigWeights[i] = big.NewInt(weight)
+	}
+	return bigWeights
+}
+
+func testRandomness(i int) common.Hash {
+	return crypto.Keccak256Hash(big.NewInt(int64(i)).Bytes())
+}
+
+// checkProposerDistribution selects the proposer of the first round with the randomness of n blocks,
+// and checks that each validator was selected within 5 standard deviations of its expected count.
+func checkProposerDistribution(t *testing.T, valSet istanbul.ValidatorSet, selector istanbul.ProposerSelector, weights []*big.Int, n int) {
+	counts := make(map[common.Address]int)
+	for i := 0; i < n; i++ {
+		valSet.SetRandomness(testRandomness(i))
+		counts[selector(valSet, common.Address{}, 0).Address()]++
+	}
+
+	total := new(big.Int)
+	for _, weight := range weights {
+		total.Add(total, weight)
+	}
+	for i, val := range valSet.List() {
+		p, _ := new(big.Float).Quo(new(big.Float).SetInt(weights[i]), new(big.Float).SetInt(total)).Float64()
+		expected := p * float64(n)
+		tolerance := 5 * math.Sqrt(expected*(1-p))
+		if count := float64(counts[val.Address()]); math.Abs(count-expected) > tolerance {
+			t.Errorf("proposer %v selected %v times out of %d, want %.0f ± %.0f", val.Address(), count, n, expected, tolerance)
+		}
+	}
+}
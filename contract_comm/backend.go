@@ -0,0 +1,235 @@
+// Copyright 2020 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package contract_comm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
+	ccerrors "github.com/ethereum/go-ethereum/contract_comm/errors"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// systemAddress is the sender of calls made from within the blockchain system.
+var systemAddress = vm.AccountRef(common.ZeroAddress)
+
+var errNoSystemEvents = errors.New("system backend does not support events")
+
+// systemMethods maps the selectors of the methods of the core contracts to their names, which
+// the system call metrics are named after.
+var systemMethods = func() map[[4]byte]string {
+	methods := make(map[[4]byte]string)
+	for _, definition := range []string{
+		bindings.BlockchainParametersABI,
+		bindings.ElectionABI,
+		bindings.EpochRewardsABI,
+		bindings.ERC20ABI,
+		bindings.FeeCurrencyWhitelistABI,
+		bindings.FreezerABI,
+		bindings.GasPriceMinimumABI,
+		bindings.GoldTokenABI,
+		bindings.RandomABI,
+		bindings.SortedOraclesABI,
+		bindings.TransferWhitelistABI,
+		bindings.ValidatorsABI,
+	} {
+		parsed, err := abi.JSON(strings.NewReader(definition))
+		if err != nil {
+			panic(err)
+		}
+		for name, method := range parsed.Methods {
+			var selector [4]byte
+			copy(selector[:], method.ID())
+			methods[selector] = name
+		}
+	}
+	return methods
+}()
+
+// SystemBackend implements bind.ContractBackend on top of the in-process EVM, so that the
+// typed bindings in contract_comm/bindings can be used to call the core contracts. Calls and
+// transactions are sent from the system address against the header and state the backend was
+// created for, and transactions are applied to that state directly rather than being signed.
+//
+// A SystemBackend is not safe for concurrent use.
+type SystemBackend struct {
+	caller *SystemCaller
+	header *types.Header
+	state  vm.StateDB
+
+	gasLeft uint64 // Gas left over by the last call or transaction
+	output  []byte // Return data of the last transaction
+}
+
+// Backend returns a SystemBackend making calls against the given header and state. If they
+// are nil, the current head of the chain and its state are used.
+func (sc *SystemCaller) Backend(header *types.Header, state vm.StateDB) *SystemBackend {
+	return &SystemBackend{caller: sc, header: header, state: state}
+}
+
+// CallOpts returns the options for a system call with the given gas allowance.
+func CallOpts(gas uint64) *bind.CallOpts {
+	return &bind.CallOpts{GasLimit: gas}
+}
+
+// TransactOpts returns the options for a state changing system call with the given gas
+// allowance. System transactions are not signed, so the signer passes them through.
+func TransactOpts(gas uint64) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		Nonce:    common.Big0,
+		GasPrice: common.Big0,
+		GasLimit: gas,
+		Signer: func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+}
+
+// RegisteredAddress returns the address of the contract registered under registryId in the
+// Registry contract.
+func (b *SystemBackend) RegisteredAddress(registryId [32]byte) (common.Address, error) {
+	address, err := b.caller.GetRegisteredAddress(registryId, b.header, b.state)
+	if err != nil {
+		if err == ccerrors.ErrSmartContractNotDeployed {
+			log.Debug("Contract not yet registered", "registryId", hexutil.Encode(registryId[:]))
+		} else if err == ccerrors.ErrRegistryContractNotDeployed {
+			log.Debug("Registry contract not yet deployed", "registryId", hexutil.Encode(registryId[:]))
+		} else {
+			log.Error("Error in getting registered address", "registryId", hexutil.Encode(registryId[:]), "err", err)
+		}
+		return common.Address{}, err
+	}
+	return *address, nil
+}
+
+// GasLeft returns the gas left over by the last call or transaction.
+func (b *SystemBackend) GasLeft() uint64 {
+	return b.gasLeft
+}
+
+// Output returns the return data of the last transaction, which the transactor bindings
+// do not surface.
+func (b *SystemBackend) Output() []byte {
+	return b.output
+}
+
+// CodeAt implements bind.ContractCaller, returning the code of the given account.
+func (b *SystemBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	evm, err := b.caller.createEVM(b.header, b.state)
+	if err != nil {
+		return nil, err
+	}
+	return evm.StateDB.GetCode(contract), nil
+}
+
+// CallContract implements bind.ContractCaller, executing a static call from the system address.
+func (b *SystemBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return b.execute(*call.To, call.Data, call.Gas, nil, true)
+}
+
+// PendingCodeAt implements bind.ContractTransactor. System transactions are applied to the
+// backend's state, so this is the same as CodeAt.
+func (b *SystemBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return b.CodeAt(ctx, account, nil)
+}
+
+// PendingNonceAt implements bind.ContractTransactor. System transactions carry no nonce.
+func (b *SystemBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+// SuggestGasPrice implements bind.ContractTransactor. System transactions are not charged for gas.
+func (b *SystemBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// EstimateGas implements bind.ContractTransactor. System transactions must always be given a
+// gas allowance, see TransactOpts.
+func (b *SystemBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 0, errors.New("system transactions require a gas limit")
+}
+
+// SendTransaction implements bind.ContractTransactor, applying the transaction to the
+// backend's state as a call from the system address.
+func (b *SystemBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if tx.To() == nil {
+		return errors.New("system transactions cannot create contracts")
+	}
+	output, err := b.execute(*tx.To(), tx.Data(), tx.Gas(), tx.Value(), false)
+	b.output = output
+	return err
+}
+
+// FilterLogs implements bind.ContractFilterer. Events are not supported.
+func (b *SystemBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, errNoSystemEvents
+}
+
+// SubscribeFilterLogs implements bind.ContractFilterer. Events are not supported.
+func (b *SystemBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errNoSystemEvents
+}
+
+func (b *SystemBackend) execute(contract common.Address, input []byte, gas uint64, value *big.Int, static bool) ([]byte, error) {
+	var method string
+	if len(input) >= 4 {
+		var selector [4]byte
+		copy(selector[:], input)
+		if name, ok := systemMethods[selector]; ok {
+			method = name
+		} else {
+			method = hexutil.Encode(selector[:])
+		}
+	}
+	// Record a metrics data point about execution time.
+	timer := metrics.GetOrRegisterTimer("contract_comm/systemcall/"+method, nil)
+	start := time.Now()
+	defer timer.UpdateSince(start)
+
+	evm, err := b.caller.createEVM(b.header, b.state)
+	if err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	if static {
+		output, b.gasLeft, err = evm.StaticCall(systemAddress, contract, input, gas)
+	} else {
+		if value == nil {
+			value = new(big.Int)
+		}
+		output, b.gasLeft, err = evm.Call(systemAddress, contract, input, gas, value)
+	}
+	if err != nil {
+		log.Error("Error when invoking evm function", "err", err, "method", method, "static", static, "address", contract, "input", hexutil.Encode(input), "gas", gas, "gasLeft", b.gasLeft, "value", value)
+		return output, err
+	}
+	log.Trace("EVM call successful", "method", method, "input", hexutil.Encode(input), "output", hexutil.Encode(output))
+	return output, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bindings contains typed Go bindings for the core Celo contracts called from
+// within the blockchain system. The ABIs in the abi directory are taken from
+// celo-monorepo/packages/protocol/build/<env>/contracts/<Contract>.json, and the bindings
+// are backed by contract_comm.SystemBackend.
+package bindings
+
+//go:generate abigen --abi abi/BlockchainParameters.json --pkg bindings --type BlockchainParameters --out blockchain_parameters.go
+//go:generate abigen --abi abi/Election.json --pkg bindings --type Election --out election.go
+//go:generate abigen --abi abi/EpochRewards.json --pkg bindings --type EpochRewards --out epoch_rewards.go
+//go:generate abigen --abi abi/ERC20.json --pkg bindings --type ERC20 --out erc20.go
+//go:generate abigen --abi abi/FeeCurrencyWhitelist.json --pkg bindings --type FeeCurrencyWhitelist --out fee_currency_whitelist.go
+//go:generate abigen --abi abi/Freezer.json --pkg bindings --type Freezer --out freezer.go
+//go:generate abigen --abi abi/GasPriceMinimum.json --pkg bindings --type GasPriceMinimum --out gas_price_minimum.go
+//go:generate abigen --abi abi/GoldToken.json --pkg bindings --type GoldToken --out gold_token.go
+//go:generate abigen --abi abi/Random.json --pkg bindings --type Random --out random.go
+//go:generate abigen --abi abi/SortedOracles.json --pkg bindings --type SortedOracles --out sorted_oracles.go
+//go:generate abigen --abi abi/TransferWhitelist.json --pkg bindings --type TransferWhitelist --out transfer_whitelist.go
+//go:generate abigen --abi abi/Validators.json --pkg bindings --type Validators --out validators.go
@@ -0,0 +1,363 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ElectionABI is the input ABI used to generate the binding from.
+const ElectionABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"electValidatorSigners\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getTotalVotesForEligibleValidatorGroups\",\"outputs\":[{\"name\":\"groups\",\"type\":\"address[]\"},{\"name\":\"values\",\"type\":\"uint256[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"group\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"lesser\",\"type\":\"address\"},{\"name\":\"greater\",\"type\":\"address\"}],\"name\":\"distributeEpochRewards\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"group\",\"type\":\"address\"},{\"name\":\"maxTotalRewards\",\"type\":\"uint256\"},{\"name\":\"uptimes\",\"type\":\"uint256[]\"}],\"name\":\"getGroupEpochRewards\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"minElectableValidators\",\"type\":\"uint256\"},{\"name\":\"maxElectableValidators\",\"type\":\"uint256\"}],\"name\":\"electNValidatorSigners\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getElectableValidators\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Election is an auto generated Go binding around an Ethereum contract.
+type Election struct {
+	ElectionCaller     // Read-only binding to the contract
+	ElectionTransactor // Write-only binding to the contract
+	ElectionFilterer   // Log filterer for contract events
+}
+
+// ElectionCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ElectionCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ElectionTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ElectionTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ElectionFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ElectionFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ElectionSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ElectionSession struct {
+	Contract     *Election         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ElectionCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ElectionCallerSession struct {
+	Contract *ElectionCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// ElectionTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ElectionTransactorSession struct {
+	Contract     *ElectionTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// ElectionRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ElectionRaw struct {
+	Contract *Election // Generic contract binding to access the raw methods on
+}
+
+// ElectionCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ElectionCallerRaw struct {
+	Contract *ElectionCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ElectionTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ElectionTransactorRaw struct {
+	Contract *ElectionTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewElection creates a new instance of Election, bound to a specific deployed contract.
+func NewElection(address common.Address, backend bind.ContractBackend) (*Election, error) {
+	contract, err := bindElection(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Election{ElectionCaller: ElectionCaller{contract: contract}, ElectionTransactor: ElectionTransactor{contract: contract}, ElectionFilterer: ElectionFilterer{contract: contract}}, nil
+}
+
+// NewElectionCaller creates a new read-only instance of Election, bound to a specific deployed contract.
+func NewElectionCaller(address common.Address, caller bind.ContractCaller) (*ElectionCaller, error) {
+	contract, err := bindElection(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ElectionCaller{contract: contract}, nil
+}
+
+// NewElectionTransactor creates a new write-only instance of Election, bound to a specific deployed contract.
+func NewElectionTransactor(address common.Address, transactor bind.ContractTransactor) (*ElectionTransactor, error) {
+	contract, err := bindElection(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ElectionTransactor{contract: contract}, nil
+}
+
+// NewElectionFilterer creates a new log filterer instance of Election, bound to a specific deployed contract.
+func NewElectionFilterer(address common.Address, filterer bind.ContractFilterer) (*ElectionFilterer, error) {
+	contract, err := bindElection(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ElectionFilterer{contract: contract}, nil
+}
+
+// bindElection binds a generic wrapper to an already deployed contract.
+func bindElection(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ElectionABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseElectionABI parses the ABI
+func ParseElectionABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(ElectionABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Election *ElectionRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Election.Contract.ElectionCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Election *ElectionRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Election.Contract.ElectionTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Election *ElectionRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Election.Contract.ElectionTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Election *ElectionCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Election.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Election *ElectionTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Election.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Election *ElectionTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Election.Contract.contract.Transact(opts, method, params...)
+}
+
+// ElectNValidatorSigners is a free data retrieval call binding the contract method 0x90a4dd5c.
+//
+// Solidity: function electNValidatorSigners(uint256 minElectableValidators, uint256 maxElectableValidators) constant returns(address[])
+func (_Election *ElectionCaller) ElectNValidatorSigners(opts *bind.CallOpts, minElectableValidators *big.Int, maxElectableValidators *big.Int) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _Election.contract.Call(opts, out, "electNValidatorSigners", minElectableValidators, maxElectableValidators)
+	return *ret0, err
+}
+
+// ElectNValidatorSigners is a free data retrieval call binding the contract method 0x90a4dd5c.
+//
+// Solidity: function electNValidatorSigners(uint256 minElectableValidators, uint256 maxElectableValidators) constant returns(address[])
+func (_Election *ElectionSession) ElectNValidatorSigners(minElectableValidators *big.Int, maxElectableValidators *big.Int) ([]common.Address, error) {
+	return _Election.Contract.ElectNValidatorSigners(&_Election.CallOpts, minElectableValidators, maxElectableValidators)
+}
+
+// ElectNValidatorSigners is a free data retrieval call binding the contract method 0x90a4dd5c.
+//
+// Solidity: function electNValidatorSigners(uint256 minElectableValidators, uint256 maxElectableValidators) constant returns(address[])
+func (_Election *ElectionCallerSession) ElectNValidatorSigners(minElectableValidators *big.Int, maxElectableValidators *big.Int) ([]common.Address, error) {
+	return _Election.Contract.ElectNValidatorSigners(&_Election.CallOpts, minElectableValidators, maxElectableValidators)
+}
+
+// ElectValidatorSigners is a free data retrieval call binding the contract method 0x2ba38e69.
+//
+// Solidity: function electValidatorSigners() constant returns(address[])
+func (_Election *ElectionCaller) ElectValidatorSigners(opts *bind.CallOpts) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _Election.contract.Call(opts, out, "electValidatorSigners")
+	return *ret0, err
+}
+
+// ElectValidatorSigners is a free data retrieval call binding the contract method 0x2ba38e69.
+//
+// Solidity: function electValidatorSigners() constant returns(address[])
+func (_Election *ElectionSession) ElectValidatorSigners() ([]common.Address, error) {
+	return _Election.Contract.ElectValidatorSigners(&_Election.CallOpts)
+}
+
+// ElectValidatorSigners is a free data retrieval call binding the contract method 0x2ba38e69.
+//
+// Solidity: function electValidatorSigners() constant returns(address[])
+func (_Election *ElectionCallerSession) ElectValidatorSigners() ([]common.Address, error) {
+	return _Election.Contract.ElectValidatorSigners(&_Election.CallOpts)
+}
+
+// GetElectableValidators is a free data retrieval call binding the contract method 0xf9f41a7a.
+//
+// Solidity: function getElectableValidators() constant returns(uint256, uint256)
+func (_Election *ElectionCaller) GetElectableValidators(opts *bind.CallOpts) (*big.Int, *big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+		ret1 = new(*big.Int)
+	)
+	out := &[]interface{}{
+		ret0,
+		ret1,
+	}
+	err := _Election.contract.Call(opts, out, "getElectableValidators")
+	return *ret0, *ret1, err
+}
+
+// GetElectableValidators is a free data retrieval call binding the contract method 0xf9f41a7a.
+//
+// Solidity: function getElectableValidators() constant returns(uint256, uint256)
+func (_Election *ElectionSession) GetElectableValidators() (*big.Int, *big.Int, error) {
+	return _Election.Contract.GetElectableValidators(&_Election.CallOpts)
+}
+
+// GetElectableValidators is a free data retrieval call binding the contract method 0xf9f41a7a.
+//
+// Solidity: function getElectableValidators() constant returns(uint256, uint256)
+func (_Election *ElectionCallerSession) GetElectableValidators() (*big.Int, *big.Int, error) {
+	return _Election.Contract.GetElectableValidators(&_Election.CallOpts)
+}
+
+// GetGroupEpochRewards is a free data retrieval call binding the contract method 0xf23263f9.
+//
+// Solidity: function getGroupEpochRewards(address group, uint256 maxTotalRewards, uint256[] uptimes) constant returns(uint256)
+func (_Election *ElectionCaller) GetGroupEpochRewards(opts *bind.CallOpts, group common.Address, maxTotalRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _Election.contract.Call(opts, out, "getGroupEpochRewards", group, maxTotalRewards, uptimes)
+	return *ret0, err
+}
+
+// GetGroupEpochRewards is a free data retrieval call binding the contract method 0xf23263f9.
+//
+// Solidity: function getGroupEpochRewards(address group, uint256 maxTotalRewards, uint256[] uptimes) constant returns(uint256)
+func (_Election *ElectionSession) GetGroupEpochRewards(group common.Address, maxTotalRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
+	return _Election.Contract.GetGroupEpochRewards(&_Election.CallOpts, group, maxTotalRewards, uptimes)
+}
+
+// GetGroupEpochRewards is a free data retrieval call binding the contract method 0xf23263f9.
+//
+// Solidity: function getGroupEpochRewards(address group, uint256 maxTotalRewards, uint256[] uptimes) constant returns(uint256)
+func (_Election *ElectionCallerSession) GetGroupEpochRewards(group common.Address, maxTotalRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
+	return _Election.Contract.GetGroupEpochRewards(&_Election.CallOpts, group, maxTotalRewards, uptimes)
+}
+
+// GetTotalVotesForEligibleValidatorGroups is a free data retrieval call binding the contract method 0x7046c96b.
+//
+// Solidity: function getTotalVotesForEligibleValidatorGroups() constant returns(address[] groups, uint256[] values)
+func (_Election *ElectionCaller) GetTotalVotesForEligibleValidatorGroups(opts *bind.CallOpts) (struct {
+	Groups []common.Address
+	Values []*big.Int
+}, error) {
+	ret := new(struct {
+		Groups []common.Address
+		Values []*big.Int
+	})
+	out := ret
+	err := _Election.contract.Call(opts, out, "getTotalVotesForEligibleValidatorGroups")
+	return *ret, err
+}
+
+// GetTotalVotesForEligibleValidatorGroups is a free data retrieval call binding the contract method 0x7046c96b.
+//
+// Solidity: function getTotalVotesForEligibleValidatorGroups() constant returns(address[] groups, uint256[] values)
+func (_Election *ElectionSession) GetTotalVotesForEligibleValidatorGroups() (struct {
+	Groups []common.Address
+	Values []*big.Int
+}, error) {
+	return _Election.Contract.GetTotalVotesForEligibleValidatorGroups(&_Election.CallOpts)
+}
+
+// GetTotalVotesForEligibleValidatorGroups is a free data retrieval call binding the contract method 0x7046c96b.
+//
+// Solidity: function getTotalVotesForEligibleValidatorGroups() constant returns(address[] groups, uint256[] values)
+func (_Election *ElectionCallerSession) GetTotalVotesForEligibleValidatorGroups() (struct {
+	Groups []common.Address
+	Values []*big.Int
+}, error) {
+	return _Election.Contract.GetTotalVotesForEligibleValidatorGroups(&_Election.CallOpts)
+}
+
+// DistributeEpochRewards is a paid mutator transaction binding the contract method 0x12541a6b.
+//
+// Solidity: function distributeEpochRewards(address group, uint256 value, address lesser, address greater) returns()
+func (_Election *ElectionTransactor) DistributeEpochRewards(opts *bind.TransactOpts, group common.Address, value *big.Int, lesser common.Address, greater common.Address) (*types.Transaction, error) {
+	return _Election.contract.Transact(opts, "distributeEpochRewards", group, value, lesser, greater)
+}
+
+// DistributeEpochRewards is a paid mutator transaction binding the contract method 0x12541a6b.
+//
+// Solidity: function distributeEpochRewards(address group, uint256 value, address lesser, address greater) returns()
+func (_Election *ElectionSession) DistributeEpochRewards(group common.Address, value *big.Int, lesser common.Address, greater common.Address) (*types.Transaction, error) {
+	return _Election.Contract.DistributeEpochRewards(&_Election.TransactOpts, group, value, lesser, greater)
+}
+
+// DistributeEpochRewards is a paid mutator transaction binding the contract method 0x12541a6b.
+//
+// Solidity: function distributeEpochRewards(address group, uint256 value, address lesser, address greater) returns()
+func (_Election *ElectionTransactorSession) DistributeEpochRewards(group common.Address, value *big.Int, lesser common.Address, greater common.Address) (*types.Transaction, error) {
+	return _Election.Contract.DistributeEpochRewards(&_Election.TransactOpts, group, value, lesser, greater)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_Election *ElectionFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _Election.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
@@ -0,0 +1,331 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// EpochRewardsABI is the input ABI used to generate the binding from.
+const EpochRewardsABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"calculateTargetEpochRewards\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"carbonOffsettingPartner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"updateTargetVotingYield\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"isReserveLow\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"frozen\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// EpochRewards is an auto generated Go binding around an Ethereum contract.
+type EpochRewards struct {
+	EpochRewardsCaller     // Read-only binding to the contract
+	EpochRewardsTransactor // Write-only binding to the contract
+	EpochRewardsFilterer   // Log filterer for contract events
+}
+
+// EpochRewardsCaller is an auto generated read-only Go binding around an Ethereum contract.
+type EpochRewardsCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochRewardsTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type EpochRewardsTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochRewardsFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EpochRewardsFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EpochRewardsSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EpochRewardsSession struct {
+	Contract     *EpochRewards     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EpochRewardsCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EpochRewardsCallerSession struct {
+	Contract *EpochRewardsCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// EpochRewardsTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EpochRewardsTransactorSession struct {
+	Contract     *EpochRewardsTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// EpochRewardsRaw is an auto generated low-level Go binding around an Ethereum contract.
+type EpochRewardsRaw struct {
+	Contract *EpochRewards // Generic contract binding to access the raw methods on
+}
+
+// EpochRewardsCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EpochRewardsCallerRaw struct {
+	Contract *EpochRewardsCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// EpochRewardsTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EpochRewardsTransactorRaw struct {
+	Contract *EpochRewardsTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEpochRewards creates a new instance of EpochRewards, bound to a specific deployed contract.
+func NewEpochRewards(address common.Address, backend bind.ContractBackend) (*EpochRewards, error) {
+	contract, err := bindEpochRewards(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochRewards{EpochRewardsCaller: EpochRewardsCaller{contract: contract}, EpochRewardsTransactor: EpochRewardsTransactor{contract: contract}, EpochRewardsFilterer: EpochRewardsFilterer{contract: contract}}, nil
+}
+
+// NewEpochRewardsCaller creates a new read-only instance of EpochRewards, bound to a specific deployed contract.
+func NewEpochRewardsCaller(address common.Address, caller bind.ContractCaller) (*EpochRewardsCaller, error) {
+	contract, err := bindEpochRewards(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochRewardsCaller{contract: contract}, nil
+}
+
+// NewEpochRewardsTransactor creates a new write-only instance of EpochRewards, bound to a specific deployed contract.
+func NewEpochRewardsTransactor(address common.Address, transactor bind.ContractTransactor) (*EpochRewardsTransactor, error) {
+	contract, err := bindEpochRewards(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochRewardsTransactor{contract: contract}, nil
+}
+
+// NewEpochRewardsFilterer creates a new log filterer instance of EpochRewards, bound to a specific deployed contract.
+func NewEpochRewardsFilterer(address common.Address, filterer bind.ContractFilterer) (*EpochRewardsFilterer, error) {
+	contract, err := bindEpochRewards(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EpochRewardsFilterer{contract: contract}, nil
+}
+
+// bindEpochRewards binds a generic wrapper to an already deployed contract.
+func bindEpochRewards(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(EpochRewardsABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseEpochRewardsABI parses the ABI
+func ParseEpochRewardsABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(EpochRewardsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EpochRewards *EpochRewardsRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _EpochRewards.Contract.EpochRewardsCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EpochRewards *EpochRewardsRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochRewards.Contract.EpochRewardsTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EpochRewards *EpochRewardsRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EpochRewards.Contract.EpochRewardsTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EpochRewards *EpochRewardsCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _EpochRewards.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EpochRewards *EpochRewardsTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochRewards.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EpochRewards *EpochRewardsTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EpochRewards.Contract.contract.Transact(opts, method, params...)
+}
+
+// CalculateTargetEpochRewards is a free data retrieval call binding the contract method 0x64347043.
+//
+// Solidity: function calculateTargetEpochRewards() constant returns(uint256, uint256, uint256, uint256)
+func (_EpochRewards *EpochRewardsCaller) CalculateTargetEpochRewards(opts *bind.CallOpts) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+		ret1 = new(*big.Int)
+		ret2 = new(*big.Int)
+		ret3 = new(*big.Int)
+	)
+	out := &[]interface{}{
+		ret0,
+		ret1,
+		ret2,
+		ret3,
+	}
+	err := _EpochRewards.contract.Call(opts, out, "calculateTargetEpochRewards")
+	return *ret0, *ret1, *ret2, *ret3, err
+}
+
+// CalculateTargetEpochRewards is a free data retrieval call binding the contract method 0x64347043.
+//
+// Solidity: function calculateTargetEpochRewards() constant returns(uint256, uint256, uint256, uint256)
+func (_EpochRewards *EpochRewardsSession) CalculateTargetEpochRewards() (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	return _EpochRewards.Contract.CalculateTargetEpochRewards(&_EpochRewards.CallOpts)
+}
+
+// CalculateTargetEpochRewards is a free data retrieval call binding the contract method 0x64347043.
+//
+// Solidity: function calculateTargetEpochRewards() constant returns(uint256, uint256, uint256, uint256)
+func (_EpochRewards *EpochRewardsCallerSession) CalculateTargetEpochRewards() (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	return _EpochRewards.Contract.CalculateTargetEpochRewards(&_EpochRewards.CallOpts)
+}
+
+// CarbonOffsettingPartner is a free data retrieval call binding the contract method 0x22dae21f.
+//
+// Solidity: function carbonOffsettingPartner() constant returns(address)
+func (_EpochRewards *EpochRewardsCaller) CarbonOffsettingPartner(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _EpochRewards.contract.Call(opts, out, "carbonOffsettingPartner")
+	return *ret0, err
+}
+
+// CarbonOffsettingPartner is a free data retrieval call binding the contract method 0x22dae21f.
+//
+// Solidity: function carbonOffsettingPartner() constant returns(address)
+func (_EpochRewards *EpochRewardsSession) CarbonOffsettingPartner() (common.Address, error) {
+	return _EpochRewards.Contract.CarbonOffsettingPartner(&_EpochRewards.CallOpts)
+}
+
+// CarbonOffsettingPartner is a free data retrieval call binding the contract method 0x22dae21f.
+//
+// Solidity: function carbonOffsettingPartner() constant returns(address)
+func (_EpochRewards *EpochRewardsCallerSession) CarbonOffsettingPartner() (common.Address, error) {
+	return _EpochRewards.Contract.CarbonOffsettingPartner(&_EpochRewards.CallOpts)
+}
+
+// Frozen is a free data retrieval call binding the contract method 0x054f7d9c.
+//
+// Solidity: function frozen() constant returns(bool)
+func (_EpochRewards *EpochRewardsCaller) Frozen(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _EpochRewards.contract.Call(opts, out, "frozen")
+	return *ret0, err
+}
+
+// Frozen is a free data retrieval call binding the contract method 0x054f7d9c.
+//
+// Solidity: function frozen() constant returns(bool)
+func (_EpochRewards *EpochRewardsSession) Frozen() (bool, error) {
+	return _EpochRewards.Contract.Frozen(&_EpochRewards.CallOpts)
+}
+
+// Frozen is a free data retrieval call binding the contract method 0x054f7d9c.
+//
+// Solidity: function frozen() constant returns(bool)
+func (_EpochRewards *EpochRewardsCallerSession) Frozen() (bool, error) {
+	return _EpochRewards.Contract.Frozen(&_EpochRewards.CallOpts)
+}
+
+// IsReserveLow is a free data retrieval call binding the contract method 0x9ad0cce7.
+//
+// Solidity: function isReserveLow() constant returns(bool)
+func (_EpochRewards *EpochRewardsCaller) IsReserveLow(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _EpochRewards.contract.Call(opts, out, "isReserveLow")
+	return *ret0, err
+}
+
+// IsReserveLow is a free data retrieval call binding the contract method 0x9ad0cce7.
+//
+// Solidity: function isReserveLow() constant returns(bool)
+func (_EpochRewards *EpochRewardsSession) IsReserveLow() (bool, error) {
+	return _EpochRewards.Contract.IsReserveLow(&_EpochRewards.CallOpts)
+}
+
+// IsReserveLow is a free data retrieval call binding the contract method 0x9ad0cce7.
+//
+// Solidity: function isReserveLow() constant returns(bool)
+func (_EpochRewards *EpochRewardsCallerSession) IsReserveLow() (bool, error) {
+	return _EpochRewards.Contract.IsReserveLow(&_EpochRewards.CallOpts)
+}
+
+// UpdateTargetVotingYield is a paid mutator transaction binding the contract method 0x92ecd745.
+//
+// Solidity: function updateTargetVotingYield() returns()
+func (_EpochRewards *EpochRewardsTransactor) UpdateTargetVotingYield(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EpochRewards.contract.Transact(opts, "updateTargetVotingYield")
+}
+
+// UpdateTargetVotingYield is a paid mutator transaction binding the contract method 0x92ecd745.
+//
+// Solidity: function updateTargetVotingYield() returns()
+func (_EpochRewards *EpochRewardsSession) UpdateTargetVotingYield() (*types.Transaction, error) {
+	return _EpochRewards.Contract.UpdateTargetVotingYield(&_EpochRewards.TransactOpts)
+}
+
+// UpdateTargetVotingYield is a paid mutator transaction binding the contract method 0x92ecd745.
+//
+// Solidity: function updateTargetVotingYield() returns()
+func (_EpochRewards *EpochRewardsTransactorSession) UpdateTargetVotingYield() (*types.Transaction, error) {
+	return _EpochRewards.Contract.UpdateTargetVotingYield(&_EpochRewards.TransactOpts)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_EpochRewards *EpochRewardsFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _EpochRewards.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
@@ -0,0 +1,224 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FeeCurrencyWhitelistABI is the input ABI used to generate the binding from.
+const FeeCurrencyWhitelistABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getWhitelist\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// FeeCurrencyWhitelist is an auto generated Go binding around an Ethereum contract.
+type FeeCurrencyWhitelist struct {
+	FeeCurrencyWhitelistCaller     // Read-only binding to the contract
+	FeeCurrencyWhitelistTransactor // Write-only binding to the contract
+	FeeCurrencyWhitelistFilterer   // Log filterer for contract events
+}
+
+// FeeCurrencyWhitelistCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FeeCurrencyWhitelistCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeeCurrencyWhitelistTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FeeCurrencyWhitelistTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeeCurrencyWhitelistFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FeeCurrencyWhitelistFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeeCurrencyWhitelistSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FeeCurrencyWhitelistSession struct {
+	Contract     *FeeCurrencyWhitelist // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// FeeCurrencyWhitelistCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FeeCurrencyWhitelistCallerSession struct {
+	Contract *FeeCurrencyWhitelistCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// FeeCurrencyWhitelistTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FeeCurrencyWhitelistTransactorSession struct {
+	Contract     *FeeCurrencyWhitelistTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// FeeCurrencyWhitelistRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FeeCurrencyWhitelistRaw struct {
+	Contract *FeeCurrencyWhitelist // Generic contract binding to access the raw methods on
+}
+
+// FeeCurrencyWhitelistCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FeeCurrencyWhitelistCallerRaw struct {
+	Contract *FeeCurrencyWhitelistCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FeeCurrencyWhitelistTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FeeCurrencyWhitelistTransactorRaw struct {
+	Contract *FeeCurrencyWhitelistTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFeeCurrencyWhitelist creates a new instance of FeeCurrencyWhitelist, bound to a specific deployed contract.
+func NewFeeCurrencyWhitelist(address common.Address, backend bind.ContractBackend) (*FeeCurrencyWhitelist, error) {
+	contract, err := bindFeeCurrencyWhitelist(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeCurrencyWhitelist{FeeCurrencyWhitelistCaller: FeeCurrencyWhitelistCaller{contract: contract}, FeeCurrencyWhitelistTransactor: FeeCurrencyWhitelistTransactor{contract: contract}, FeeCurrencyWhitelistFilterer: FeeCurrencyWhitelistFilterer{contract: contract}}, nil
+}
+
+// NewFeeCurrencyWhitelistCaller creates a new read-only instance of FeeCurrencyWhitelist, bound to a specific deployed contract.
+func NewFeeCurrencyWhitelistCaller(address common.Address, caller bind.ContractCaller) (*FeeCurrencyWhitelistCaller, error) {
+	contract, err := bindFeeCurrencyWhitelist(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeCurrencyWhitelistCaller{contract: contract}, nil
+}
+
+// NewFeeCurrencyWhitelistTransactor creates a new write-only instance of FeeCurrencyWhitelist, bound to a specific deployed contract.
+func NewFeeCurrencyWhitelistTransactor(address common.Address, transactor bind.ContractTransactor) (*FeeCurrencyWhitelistTransactor, error) {
+	contract, err := bindFeeCurrencyWhitelist(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeCurrencyWhitelistTransactor{contract: contract}, nil
+}
+
+// NewFeeCurrencyWhitelistFilterer creates a new log filterer instance of FeeCurrencyWhitelist, bound to a specific deployed contract.
+func NewFeeCurrencyWhitelistFilterer(address common.Address, filterer bind.ContractFilterer) (*FeeCurrencyWhitelistFilterer, error) {
+	contract, err := bindFeeCurrencyWhitelist(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeCurrencyWhitelistFilterer{contract: contract}, nil
+}
+
+// bindFeeCurrencyWhitelist binds a generic wrapper to an already deployed contract.
+func bindFeeCurrencyWhitelist(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FeeCurrencyWhitelistABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseFeeCurrencyWhitelistABI parses the ABI
+func ParseFeeCurrencyWhitelistABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(FeeCurrencyWhitelistABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _FeeCurrencyWhitelist.Contract.FeeCurrencyWhitelistCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeeCurrencyWhitelist.Contract.FeeCurrencyWhitelistTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeeCurrencyWhitelist.Contract.FeeCurrencyWhitelistTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _FeeCurrencyWhitelist.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeeCurrencyWhitelist.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeeCurrencyWhitelist.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistCaller) GetWhitelist(opts *bind.CallOpts) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _FeeCurrencyWhitelist.contract.Call(opts, out, "getWhitelist")
+	return *ret0, err
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistSession) GetWhitelist() ([]common.Address, error) {
+	return _FeeCurrencyWhitelist.Contract.GetWhitelist(&_FeeCurrencyWhitelist.CallOpts)
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistCallerSession) GetWhitelist() ([]common.Address, error) {
+	return _FeeCurrencyWhitelist.Contract.GetWhitelist(&_FeeCurrencyWhitelist.CallOpts)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_FeeCurrencyWhitelist *FeeCurrencyWhitelistFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _FeeCurrencyWhitelist.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
@@ -0,0 +1,224 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// FreezerABI is the input ABI used to generate the binding from.
+const FreezerABI = "[{\"constant\":true,\"inputs\":[{\"name\":\"\",\"type\":\"address\"}],\"name\":\"isFrozen\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Freezer is an auto generated Go binding around an Ethereum contract.
+type Freezer struct {
+	FreezerCaller     // Read-only binding to the contract
+	FreezerTransactor // Write-only binding to the contract
+	FreezerFilterer   // Log filterer for contract events
+}
+
+// FreezerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FreezerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FreezerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FreezerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FreezerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FreezerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FreezerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FreezerSession struct {
+	Contract     *Freezer          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FreezerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FreezerCallerSession struct {
+	Contract *FreezerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// FreezerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FreezerTransactorSession struct {
+	Contract     *FreezerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// FreezerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FreezerRaw struct {
+	Contract *Freezer // Generic contract binding to access the raw methods on
+}
+
+// FreezerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FreezerCallerRaw struct {
+	Contract *FreezerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FreezerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FreezerTransactorRaw struct {
+	Contract *FreezerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFreezer creates a new instance of Freezer, bound to a specific deployed contract.
+func NewFreezer(address common.Address, backend bind.ContractBackend) (*Freezer, error) {
+	contract, err := bindFreezer(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Freezer{FreezerCaller: FreezerCaller{contract: contract}, FreezerTransactor: FreezerTransactor{contract: contract}, FreezerFilterer: FreezerFilterer{contract: contract}}, nil
+}
+
+// NewFreezerCaller creates a new read-only instance of Freezer, bound to a specific deployed contract.
+func NewFreezerCaller(address common.Address, caller bind.ContractCaller) (*FreezerCaller, error) {
+	contract, err := bindFreezer(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FreezerCaller{contract: contract}, nil
+}
+
+// NewFreezerTransactor creates a new write-only instance of Freezer, bound to a specific deployed contract.
+func NewFreezerTransactor(address common.Address, transactor bind.ContractTransactor) (*FreezerTransactor, error) {
+	contract, err := bindFreezer(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FreezerTransactor{contract: contract}, nil
+}
+
+// NewFreezerFilterer creates a new log filterer instance of Freezer, bound to a specific deployed contract.
+func NewFreezerFilterer(address common.Address, filterer bind.ContractFilterer) (*FreezerFilterer, error) {
+	contract, err := bindFreezer(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FreezerFilterer{contract: contract}, nil
+}
+
+// bindFreezer binds a generic wrapper to an already deployed contract.
+func bindFreezer(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(FreezerABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseFreezerABI parses the ABI
+func ParseFreezerABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(FreezerABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Freezer *FreezerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Freezer.Contract.FreezerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Freezer *FreezerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Freezer.Contract.FreezerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Freezer *FreezerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Freezer.Contract.FreezerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Freezer *FreezerCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Freezer.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Freezer *FreezerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Freezer.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Freezer *FreezerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Freezer.Contract.contract.Transact(opts, method, params...)
+}
+
+// IsFrozen is a free data retrieval call binding the contract method 0xe5839836.
+//
+// Solidity: function isFrozen(address ) constant returns(bool)
+func (_Freezer *FreezerCaller) IsFrozen(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _Freezer.contract.Call(opts, out, "isFrozen", arg0)
+	return *ret0, err
+}
+
+// IsFrozen is a free data retrieval call binding the contract method 0xe5839836.
+//
+// Solidity: function isFrozen(address ) constant returns(bool)
+func (_Freezer *FreezerSession) IsFrozen(arg0 common.Address) (bool, error) {
+	return _Freezer.Contract.IsFrozen(&_Freezer.CallOpts, arg0)
+}
+
+// IsFrozen is a free data retrieval call binding the contract method 0xe5839836.
+//
+// Solidity: function isFrozen(address ) constant returns(bool)
+func (_Freezer *FreezerCallerSession) IsFrozen(arg0 common.Address) (bool, error) {
+	return _Freezer.Contract.IsFrozen(&_Freezer.CallOpts, arg0)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_Freezer *FreezerFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _Freezer.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
@@ -0,0 +1,245 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// GasPriceMinimumABI is the input ABI used to generate the binding from.
+const GasPriceMinimumABI = "[{\"constant\":true,\"inputs\":[{\"name\":\"_tokenAddress\",\"type\":\"address\"}],\"name\":\"getGasPriceMinimum\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_blockGasTotal\",\"type\":\"uint256\"},{\"name\":\"_blockGasLimit\",\"type\":\"uint256\"}],\"name\":\"updateGasPriceMinimum\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// GasPriceMinimum is an auto generated Go binding around an Ethereum contract.
+type GasPriceMinimum struct {
+	GasPriceMinimumCaller     // Read-only binding to the contract
+	GasPriceMinimumTransactor // Write-only binding to the contract
+	GasPriceMinimumFilterer   // Log filterer for contract events
+}
+
+// GasPriceMinimumCaller is an auto generated read-only Go binding around an Ethereum contract.
+type GasPriceMinimumCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GasPriceMinimumTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type GasPriceMinimumTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GasPriceMinimumFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type GasPriceMinimumFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GasPriceMinimumSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type GasPriceMinimumSession struct {
+	Contract     *GasPriceMinimum  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// GasPriceMinimumCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type GasPriceMinimumCallerSession struct {
+	Contract *GasPriceMinimumCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// GasPriceMinimumTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type GasPriceMinimumTransactorSession struct {
+	Contract     *GasPriceMinimumTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// GasPriceMinimumRaw is an auto generated low-level Go binding around an Ethereum contract.
+type GasPriceMinimumRaw struct {
+	Contract *GasPriceMinimum // Generic contract binding to access the raw methods on
+}
+
+// GasPriceMinimumCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type GasPriceMinimumCallerRaw struct {
+	Contract *GasPriceMinimumCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// GasPriceMinimumTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type GasPriceMinimumTransactorRaw struct {
+	Contract *GasPriceMinimumTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewGasPriceMinimum creates a new instance of GasPriceMinimum, bound to a specific deployed contract.
+func NewGasPriceMinimum(address common.Address, backend bind.ContractBackend) (*GasPriceMinimum, error) {
+	contract, err := bindGasPriceMinimum(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &GasPriceMinimum{GasPriceMinimumCaller: GasPriceMinimumCaller{contract: contract}, GasPriceMinimumTransactor: GasPriceMinimumTransactor{contract: contract}, GasPriceMinimumFilterer: GasPriceMinimumFilterer{contract: contract}}, nil
+}
+
+// NewGasPriceMinimumCaller creates a new read-only instance of GasPriceMinimum, bound to a specific deployed contract.
+func NewGasPriceMinimumCaller(address common.Address, caller bind.ContractCaller) (*GasPriceMinimumCaller, error) {
+	contract, err := bindGasPriceMinimum(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GasPriceMinimumCaller{contract: contract}, nil
+}
+
+// NewGasPriceMinimumTransactor creates a new write-only instance of GasPriceMinimum, bound to a specific deployed contract.
+func NewGasPriceMinimumTransactor(address common.Address, transactor bind.ContractTransactor) (*GasPriceMinimumTransactor, error) {
+	contract, err := bindGasPriceMinimum(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GasPriceMinimumTransactor{contract: contract}, nil
+}
+
+// NewGasPriceMinimumFilterer creates a new log filterer instance of GasPriceMinimum, bound to a specific deployed contract.
+func NewGasPriceMinimumFilterer(address common.Address, filterer bind.ContractFilterer) (*GasPriceMinimumFilterer, error) {
+	contract, err := bindGasPriceMinimum(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &GasPriceMinimumFilterer{contract: contract}, nil
+}
+
+// bindGasPriceMinimum binds a generic wrapper to an already deployed contract.
+func bindGasPriceMinimum(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(GasPriceMinimumABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseGasPriceMinimumABI parses the ABI
+func ParseGasPriceMinimumABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(GasPriceMinimumABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GasPriceMinimum *GasPriceMinimumRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _GasPriceMinimum.Contract.GasPriceMinimumCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GasPriceMinimum *GasPriceMinimumRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.GasPriceMinimumTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GasPriceMinimum *GasPriceMinimumRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.GasPriceMinimumTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GasPriceMinimum *GasPriceMinimumCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _GasPriceMinimum.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GasPriceMinimum *GasPriceMinimumTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GasPriceMinimum *GasPriceMinimumTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetGasPriceMinimum is a free data retrieval call binding the contract method 0xa54b7fc0.
+//
+// Solidity: function getGasPriceMinimum(address _tokenAddress) constant returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumCaller) GetGasPriceMinimum(opts *bind.CallOpts, _tokenAddress common.Address) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _GasPriceMinimum.contract.Call(opts, out, "getGasPriceMinimum", _tokenAddress)
+	return *ret0, err
+}
+
+// GetGasPriceMinimum is a free data retrieval call binding the contract method 0xa54b7fc0.
+//
+// Solidity: function getGasPriceMinimum(address _tokenAddress) constant returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumSession) GetGasPriceMinimum(_tokenAddress common.Address) (*big.Int, error) {
+	return _GasPriceMinimum.Contract.GetGasPriceMinimum(&_GasPriceMinimum.CallOpts, _tokenAddress)
+}
+
+// GetGasPriceMinimum is a free data retrieval call binding the contract method 0xa54b7fc0.
+//
+// Solidity: function getGasPriceMinimum(address _tokenAddress) constant returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumCallerSession) GetGasPriceMinimum(_tokenAddress common.Address) (*big.Int, error) {
+	return _GasPriceMinimum.Contract.GetGasPriceMinimum(&_GasPriceMinimum.CallOpts, _tokenAddress)
+}
+
+// UpdateGasPriceMinimum is a paid mutator transaction binding the contract method 0xc12398b4.
+//
+// Solidity: function updateGasPriceMinimum(uint256 _blockGasTotal, uint256 _blockGasLimit) returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumTransactor) UpdateGasPriceMinimum(opts *bind.TransactOpts, _blockGasTotal *big.Int, _blockGasLimit *big.Int) (*types.Transaction, error) {
+	return _GasPriceMinimum.contract.Transact(opts, "updateGasPriceMinimum", _blockGasTotal, _blockGasLimit)
+}
+
+// UpdateGasPriceMinimum is a paid mutator transaction binding the contract method 0xc12398b4.
+//
+// Solidity: function updateGasPriceMinimum(uint256 _blockGasTotal, uint256 _blockGasLimit) returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumSession) UpdateGasPriceMinimum(_blockGasTotal *big.Int, _blockGasLimit *big.Int) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.UpdateGasPriceMinimum(&_GasPriceMinimum.TransactOpts, _blockGasTotal, _blockGasLimit)
+}
+
+// UpdateGasPriceMinimum is a paid mutator transaction binding the contract method 0xc12398b4.
+//
+// Solidity: function updateGasPriceMinimum(uint256 _blockGasTotal, uint256 _blockGasLimit) returns(uint256)
+func (_GasPriceMinimum *GasPriceMinimumTransactorSession) UpdateGasPriceMinimum(_blockGasTotal *big.Int, _blockGasLimit *big.Int) (*types.Transaction, error) {
+	return _GasPriceMinimum.Contract.UpdateGasPriceMinimum(&_GasPriceMinimum.TransactOpts, _blockGasTotal, _blockGasLimit)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_GasPriceMinimum *GasPriceMinimumFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _GasPriceMinimum.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
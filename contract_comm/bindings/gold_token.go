@@ -0,0 +1,266 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// GoldTokenABI is the input ABI used to generate the binding from.
+const GoldTokenABI = "[{\"constant\":false,\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"increaseSupply\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"to\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// GoldToken is an auto generated Go binding around an Ethereum contract.
+type GoldToken struct {
+	GoldTokenCaller     // Read-only binding to the contract
+	GoldTokenTransactor // Write-only binding to the contract
+	GoldTokenFilterer   // Log filterer for contract events
+}
+
+// GoldTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type GoldTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GoldTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type GoldTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GoldTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type GoldTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GoldTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type GoldTokenSession struct {
+	Contract     *GoldToken        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// GoldTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type GoldTokenCallerSession struct {
+	Contract *GoldTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// GoldTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type GoldTokenTransactorSession struct {
+	Contract     *GoldTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// GoldTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type GoldTokenRaw struct {
+	Contract *GoldToken // Generic contract binding to access the raw methods on
+}
+
+// GoldTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type GoldTokenCallerRaw struct {
+	Contract *GoldTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// GoldTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type GoldTokenTransactorRaw struct {
+	Contract *GoldTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewGoldToken creates a new instance of GoldToken, bound to a specific deployed contract.
+func NewGoldToken(address common.Address, backend bind.ContractBackend) (*GoldToken, error) {
+	contract, err := bindGoldToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &GoldToken{GoldTokenCaller: GoldTokenCaller{contract: contract}, GoldTokenTransactor: GoldTokenTransactor{contract: contract}, GoldTokenFilterer: GoldTokenFilterer{contract: contract}}, nil
+}
+
+// NewGoldTokenCaller creates a new read-only instance of GoldToken, bound to a specific deployed contract.
+func NewGoldTokenCaller(address common.Address, caller bind.ContractCaller) (*GoldTokenCaller, error) {
+	contract, err := bindGoldToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GoldTokenCaller{contract: contract}, nil
+}
+
+// NewGoldTokenTransactor creates a new write-only instance of GoldToken, bound to a specific deployed contract.
+func NewGoldTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*GoldTokenTransactor, error) {
+	contract, err := bindGoldToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GoldTokenTransactor{contract: contract}, nil
+}
+
+// NewGoldTokenFilterer creates a new log filterer instance of GoldToken, bound to a specific deployed contract.
+func NewGoldTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*GoldTokenFilterer, error) {
+	contract, err := bindGoldToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &GoldTokenFilterer{contract: contract}, nil
+}
+
+// bindGoldToken binds a generic wrapper to an already deployed contract.
+func bindGoldToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(GoldTokenABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseGoldTokenABI parses the ABI
+func ParseGoldTokenABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(GoldTokenABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GoldToken *GoldTokenRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _GoldToken.Contract.GoldTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GoldToken *GoldTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GoldToken.Contract.GoldTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GoldToken *GoldTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GoldToken.Contract.GoldTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GoldToken *GoldTokenCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _GoldToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GoldToken *GoldTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GoldToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GoldToken *GoldTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GoldToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() constant returns(uint256)
+func (_GoldToken *GoldTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _GoldToken.contract.Call(opts, out, "totalSupply")
+	return *ret0, err
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() constant returns(uint256)
+func (_GoldToken *GoldTokenSession) TotalSupply() (*big.Int, error) {
+	return _GoldToken.Contract.TotalSupply(&_GoldToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() constant returns(uint256)
+func (_GoldToken *GoldTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _GoldToken.Contract.TotalSupply(&_GoldToken.CallOpts)
+}
+
+// IncreaseSupply is a paid mutator transaction binding the contract method 0xb921e163.
+//
+// Solidity: function increaseSupply(uint256 amount) returns()
+func (_GoldToken *GoldTokenTransactor) IncreaseSupply(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _GoldToken.contract.Transact(opts, "increaseSupply", amount)
+}
+
+// IncreaseSupply is a paid mutator transaction binding the contract method 0xb921e163.
+//
+// Solidity: function increaseSupply(uint256 amount) returns()
+func (_GoldToken *GoldTokenSession) IncreaseSupply(amount *big.Int) (*types.Transaction, error) {
+	return _GoldToken.Contract.IncreaseSupply(&_GoldToken.TransactOpts, amount)
+}
+
+// IncreaseSupply is a paid mutator transaction binding the contract method 0xb921e163.
+//
+// Solidity: function increaseSupply(uint256 amount) returns()
+func (_GoldToken *GoldTokenTransactorSession) IncreaseSupply(amount *big.Int) (*types.Transaction, error) {
+	return _GoldToken.Contract.IncreaseSupply(&_GoldToken.TransactOpts, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 value) returns(bool)
+func (_GoldToken *GoldTokenTransactor) Mint(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _GoldToken.contract.Transact(opts, "mint", to, value)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 value) returns(bool)
+func (_GoldToken *GoldTokenSession) Mint(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _GoldToken.Contract.Mint(&_GoldToken.TransactOpts, to, value)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 value) returns(bool)
+func (_GoldToken *GoldTokenTransactorSession) Mint(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _GoldToken.Contract.Mint(&_GoldToken.TransactOpts, to, value)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_GoldToken *GoldTokenFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _GoldToken.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
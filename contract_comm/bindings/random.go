@@ -0,0 +1,323 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// RandomABI is the input ABI used to generate the binding from.
+const RandomABI = "[{\"constant\":false,\"inputs\":[{\"name\":\"randomness\",\"type\":\"bytes32\"},{\"name\":\"newCommitment\",\"type\":\"bytes32\"},{\"name\":\"proposer\",\"type\":\"address\"}],\"name\":\"revealAndCommit\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"\",\"type\":\"address\"}],\"name\":\"commitments\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"randomness\",\"type\":\"bytes32\"}],\"name\":\"computeCommitment\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"random\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"blockNumber\",\"type\":\"uint256\"}],\"name\":\"getBlockRandomness\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Random is an auto generated Go binding around an Ethereum contract.
+type Random struct {
+	RandomCaller     // Read-only binding to the contract
+	RandomTransactor // Write-only binding to the contract
+	RandomFilterer   // Log filterer for contract events
+}
+
+// RandomCaller is an auto generated read-only Go binding around an Ethereum contract.
+type RandomCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RandomTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type RandomTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RandomFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type RandomFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RandomSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type RandomSession struct {
+	Contract     *Random           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// RandomCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type RandomCallerSession struct {
+	Contract *RandomCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// RandomTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type RandomTransactorSession struct {
+	Contract     *RandomTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// RandomRaw is an auto generated low-level Go binding around an Ethereum contract.
+type RandomRaw struct {
+	Contract *Random // Generic contract binding to access the raw methods on
+}
+
+// RandomCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type RandomCallerRaw struct {
+	Contract *RandomCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// RandomTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type RandomTransactorRaw struct {
+	Contract *RandomTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewRandom creates a new instance of Random, bound to a specific deployed contract.
+func NewRandom(address common.Address, backend bind.ContractBackend) (*Random, error) {
+	contract, err := bindRandom(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Random{RandomCaller: RandomCaller{contract: contract}, RandomTransactor: RandomTransactor{contract: contract}, RandomFilterer: RandomFilterer{contract: contract}}, nil
+}
+
+// NewRandomCaller creates a new read-only instance of Random, bound to a specific deployed contract.
+func NewRandomCaller(address common.Address, caller bind.ContractCaller) (*RandomCaller, error) {
+	contract, err := bindRandom(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RandomCaller{contract: contract}, nil
+}
+
+// NewRandomTransactor creates a new write-only instance of Random, bound to a specific deployed contract.
+func NewRandomTransactor(address common.Address, transactor bind.ContractTransactor) (*RandomTransactor, error) {
+	contract, err := bindRandom(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RandomTransactor{contract: contract}, nil
+}
+
+// NewRandomFilterer creates a new log filterer instance of Random, bound to a specific deployed contract.
+func NewRandomFilterer(address common.Address, filterer bind.ContractFilterer) (*RandomFilterer, error) {
+	contract, err := bindRandom(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &RandomFilterer{contract: contract}, nil
+}
+
+// bindRandom binds a generic wrapper to an already deployed contract.
+func bindRandom(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(RandomABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseRandomABI parses the ABI
+func ParseRandomABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(RandomABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Random *RandomRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Random.Contract.RandomCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Random *RandomRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Random.Contract.RandomTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Random *RandomRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Random.Contract.RandomTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Random *RandomCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Random.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Random *RandomTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Random.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Random *RandomTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Random.Contract.contract.Transact(opts, method, params...)
+}
+
+// Commitments is a free data retrieval call binding the contract method 0xe8fcf723.
+//
+// Solidity: function commitments(address ) constant returns(bytes32)
+func (_Random *RandomCaller) Commitments(opts *bind.CallOpts, arg0 common.Address) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Random.contract.Call(opts, out, "commitments", arg0)
+	return *ret0, err
+}
+
+// Commitments is a free data retrieval call binding the contract method 0xe8fcf723.
+//
+// Solidity: function commitments(address ) constant returns(bytes32)
+func (_Random *RandomSession) Commitments(arg0 common.Address) ([32]byte, error) {
+	return _Random.Contract.Commitments(&_Random.CallOpts, arg0)
+}
+
+// Commitments is a free data retrieval call binding the contract method 0xe8fcf723.
+//
+// Solidity: function commitments(address ) constant returns(bytes32)
+func (_Random *RandomCallerSession) Commitments(arg0 common.Address) ([32]byte, error) {
+	return _Random.Contract.Commitments(&_Random.CallOpts, arg0)
+}
+
+// ComputeCommitment is a free data retrieval call binding the contract method 0xc387742b.
+//
+// Solidity: function computeCommitment(bytes32 randomness) constant returns(bytes32)
+func (_Random *RandomCaller) ComputeCommitment(opts *bind.CallOpts, randomness [32]byte) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Random.contract.Call(opts, out, "computeCommitment", randomness)
+	return *ret0, err
+}
+
+// ComputeCommitment is a free data retrieval call binding the contract method 0xc387742b.
+//
+// Solidity: function computeCommitment(bytes32 randomness) constant returns(bytes32)
+func (_Random *RandomSession) ComputeCommitment(randomness [32]byte) ([32]byte, error) {
+	return _Random.Contract.ComputeCommitment(&_Random.CallOpts, randomness)
+}
+
+// ComputeCommitment is a free data retrieval call binding the contract method 0xc387742b.
+//
+// Solidity: function computeCommitment(bytes32 randomness) constant returns(bytes32)
+func (_Random *RandomCallerSession) ComputeCommitment(randomness [32]byte) ([32]byte, error) {
+	return _Random.Contract.ComputeCommitment(&_Random.CallOpts, randomness)
+}
+
+// GetBlockRandomness is a free data retrieval call binding the contract method 0xfc484726.
+//
+// Solidity: function getBlockRandomness(uint256 blockNumber) constant returns(bytes32)
+func (_Random *RandomCaller) GetBlockRandomness(opts *bind.CallOpts, blockNumber *big.Int) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Random.contract.Call(opts, out, "getBlockRandomness", blockNumber)
+	return *ret0, err
+}
+
+// GetBlockRandomness is a free data retrieval call binding the contract method 0xfc484726.
+//
+// Solidity: function getBlockRandomness(uint256 blockNumber) constant returns(bytes32)
+func (_Random *RandomSession) GetBlockRandomness(blockNumber *big.Int) ([32]byte, error) {
+	return _Random.Contract.GetBlockRandomness(&_Random.CallOpts, blockNumber)
+}
+
+// GetBlockRandomness is a free data retrieval call binding the contract method 0xfc484726.
+//
+// Solidity: function getBlockRandomness(uint256 blockNumber) constant returns(bytes32)
+func (_Random *RandomCallerSession) GetBlockRandomness(blockNumber *big.Int) ([32]byte, error) {
+	return _Random.Contract.GetBlockRandomness(&_Random.CallOpts, blockNumber)
+}
+
+// Random is a free data retrieval call binding the contract method 0x5ec01e4d.
+//
+// Solidity: function random() constant returns(bytes32)
+func (_Random *RandomCaller) Random(opts *bind.CallOpts) ([32]byte, error) {
+	var (
+		ret0 = new([32]byte)
+	)
+	out := ret0
+	err := _Random.contract.Call(opts, out, "random")
+	return *ret0, err
+}
+
+// Random is a free data retrieval call binding the contract method 0x5ec01e4d.
+//
+// Solidity: function random() constant returns(bytes32)
+func (_Random *RandomSession) Random() ([32]byte, error) {
+	return _Random.Contract.Random(&_Random.CallOpts)
+}
+
+// Random is a free data retrieval call binding the contract method 0x5ec01e4d.
+//
+// Solidity: function random() constant returns(bytes32)
+func (_Random *RandomCallerSession) Random() ([32]byte, error) {
+	return _Random.Contract.Random(&_Random.CallOpts)
+}
+
+// RevealAndCommit is a paid mutator transaction binding the contract method 0x75832efc.
+//
+// Solidity: function revealAndCommit(bytes32 randomness, bytes32 newCommitment, address proposer) returns()
+func (_Random *RandomTransactor) RevealAndCommit(opts *bind.TransactOpts, randomness [32]byte, newCommitment [32]byte, proposer common.Address) (*types.Transaction, error) {
+	return _Random.contract.Transact(opts, "revealAndCommit", randomness, newCommitment, proposer)
+}
+
+// RevealAndCommit is a paid mutator transaction binding the contract method 0x75832efc.
+//
+// Solidity: function revealAndCommit(bytes32 randomness, bytes32 newCommitment, address proposer) returns()
+func (_Random *RandomSession) RevealAndCommit(randomness [32]byte, newCommitment [32]byte, proposer common.Address) (*types.Transaction, error) {
+	return _Random.Contract.RevealAndCommit(&_Random.TransactOpts, randomness, newCommitment, proposer)
+}
+
+// RevealAndCommit is a paid mutator transaction binding the contract method 0x75832efc.
+//
+// Solidity: function revealAndCommit(bytes32 randomness, bytes32 newCommitment, address proposer) returns()
+func (_Random *RandomTransactorSession) RevealAndCommit(randomness [32]byte, newCommitment [32]byte, proposer common.Address) (*types.Transaction, error) {
+	return _Random.Contract.RevealAndCommit(&_Random.TransactOpts, randomness, newCommitment, proposer)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_Random *RandomFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _Random.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
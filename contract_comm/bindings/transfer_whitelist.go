@@ -0,0 +1,224 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// TransferWhitelistABI is the input ABI used to generate the binding from.
+const TransferWhitelistABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getWhitelist\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// TransferWhitelist is an auto generated Go binding around an Ethereum contract.
+type TransferWhitelist struct {
+	TransferWhitelistCaller     // Read-only binding to the contract
+	TransferWhitelistTransactor // Write-only binding to the contract
+	TransferWhitelistFilterer   // Log filterer for contract events
+}
+
+// TransferWhitelistCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TransferWhitelistCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TransferWhitelistTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TransferWhitelistTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TransferWhitelistFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TransferWhitelistFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TransferWhitelistSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TransferWhitelistSession struct {
+	Contract     *TransferWhitelist // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// TransferWhitelistCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TransferWhitelistCallerSession struct {
+	Contract *TransferWhitelistCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// TransferWhitelistTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TransferWhitelistTransactorSession struct {
+	Contract     *TransferWhitelistTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// TransferWhitelistRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TransferWhitelistRaw struct {
+	Contract *TransferWhitelist // Generic contract binding to access the raw methods on
+}
+
+// TransferWhitelistCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TransferWhitelistCallerRaw struct {
+	Contract *TransferWhitelistCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TransferWhitelistTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TransferWhitelistTransactorRaw struct {
+	Contract *TransferWhitelistTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTransferWhitelist creates a new instance of TransferWhitelist, bound to a specific deployed contract.
+func NewTransferWhitelist(address common.Address, backend bind.ContractBackend) (*TransferWhitelist, error) {
+	contract, err := bindTransferWhitelist(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferWhitelist{TransferWhitelistCaller: TransferWhitelistCaller{contract: contract}, TransferWhitelistTransactor: TransferWhitelistTransactor{contract: contract}, TransferWhitelistFilterer: TransferWhitelistFilterer{contract: contract}}, nil
+}
+
+// NewTransferWhitelistCaller creates a new read-only instance of TransferWhitelist, bound to a specific deployed contract.
+func NewTransferWhitelistCaller(address common.Address, caller bind.ContractCaller) (*TransferWhitelistCaller, error) {
+	contract, err := bindTransferWhitelist(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferWhitelistCaller{contract: contract}, nil
+}
+
+// NewTransferWhitelistTransactor creates a new write-only instance of TransferWhitelist, bound to a specific deployed contract.
+func NewTransferWhitelistTransactor(address common.Address, transactor bind.ContractTransactor) (*TransferWhitelistTransactor, error) {
+	contract, err := bindTransferWhitelist(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferWhitelistTransactor{contract: contract}, nil
+}
+
+// NewTransferWhitelistFilterer creates a new log filterer instance of TransferWhitelist, bound to a specific deployed contract.
+func NewTransferWhitelistFilterer(address common.Address, filterer bind.ContractFilterer) (*TransferWhitelistFilterer, error) {
+	contract, err := bindTransferWhitelist(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferWhitelistFilterer{contract: contract}, nil
+}
+
+// bindTransferWhitelist binds a generic wrapper to an already deployed contract.
+func bindTransferWhitelist(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(TransferWhitelistABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseTransferWhitelistABI parses the ABI
+func ParseTransferWhitelistABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(TransferWhitelistABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TransferWhitelist *TransferWhitelistRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _TransferWhitelist.Contract.TransferWhitelistCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TransferWhitelist *TransferWhitelistRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TransferWhitelist.Contract.TransferWhitelistTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TransferWhitelist *TransferWhitelistRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TransferWhitelist.Contract.TransferWhitelistTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TransferWhitelist *TransferWhitelistCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _TransferWhitelist.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TransferWhitelist *TransferWhitelistTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TransferWhitelist.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TransferWhitelist *TransferWhitelistTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TransferWhitelist.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_TransferWhitelist *TransferWhitelistCaller) GetWhitelist(opts *bind.CallOpts) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _TransferWhitelist.contract.Call(opts, out, "getWhitelist")
+	return *ret0, err
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_TransferWhitelist *TransferWhitelistSession) GetWhitelist() ([]common.Address, error) {
+	return _TransferWhitelist.Contract.GetWhitelist(&_TransferWhitelist.CallOpts)
+}
+
+// GetWhitelist is a free data retrieval call binding the contract method 0xd01f63f5.
+//
+// Solidity: function getWhitelist() constant returns(address[])
+func (_TransferWhitelist *TransferWhitelistCallerSession) GetWhitelist() ([]common.Address, error) {
+	return _TransferWhitelist.Contract.GetWhitelist(&_TransferWhitelist.CallOpts)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_TransferWhitelist *TransferWhitelistFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _TransferWhitelist.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
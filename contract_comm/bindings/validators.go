@@ -0,0 +1,392 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = abi.U256
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ValidatorsABI is the input ABI used to generate the binding from.
+const ValidatorsABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getRegisteredValidatorSigners\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getRegisteredValidators\",\"outputs\":[{\"name\":\"\",\"type\":\"address[]\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"signer\",\"type\":\"address\"}],\"name\":\"getValidatorBlsPublicKeyFromSigner\",\"outputs\":[{\"name\":\"blsKey\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getValidator\",\"outputs\":[{\"name\":\"ecdsaPublicKey\",\"type\":\"bytes\"},{\"name\":\"blsPublicKey\",\"type\":\"bytes\"},{\"name\":\"affiliation\",\"type\":\"address\"},{\"name\":\"score\",\"type\":\"uint256\"},{\"name\":\"signer\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"validator\",\"type\":\"address\"},{\"name\":\"maxPayment\",\"type\":\"uint256\"}],\"name\":\"distributeEpochPaymentsFromSigner\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"validator\",\"type\":\"address\"},{\"name\":\"uptime\",\"type\":\"uint256\"}],\"name\":\"updateValidatorScoreFromSigner\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getMembershipInLastEpochFromSigner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// Validators is an auto generated Go binding around an Ethereum contract.
+type Validators struct {
+	ValidatorsCaller     // Read-only binding to the contract
+	ValidatorsTransactor // Write-only binding to the contract
+	ValidatorsFilterer   // Log filterer for contract events
+}
+
+// ValidatorsCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ValidatorsCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorsTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ValidatorsTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorsFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ValidatorsFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ValidatorsSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ValidatorsSession struct {
+	Contract     *Validators       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ValidatorsCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ValidatorsCallerSession struct {
+	Contract *ValidatorsCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// ValidatorsTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ValidatorsTransactorSession struct {
+	Contract     *ValidatorsTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// ValidatorsRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ValidatorsRaw struct {
+	Contract *Validators // Generic contract binding to access the raw methods on
+}
+
+// ValidatorsCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ValidatorsCallerRaw struct {
+	Contract *ValidatorsCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ValidatorsTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ValidatorsTransactorRaw struct {
+	Contract *ValidatorsTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewValidators creates a new instance of Validators, bound to a specific deployed contract.
+func NewValidators(address common.Address, backend bind.ContractBackend) (*Validators, error) {
+	contract, err := bindValidators(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Validators{ValidatorsCaller: ValidatorsCaller{contract: contract}, ValidatorsTransactor: ValidatorsTransactor{contract: contract}, ValidatorsFilterer: ValidatorsFilterer{contract: contract}}, nil
+}
+
+// NewValidatorsCaller creates a new read-only instance of Validators, bound to a specific deployed contract.
+func NewValidatorsCaller(address common.Address, caller bind.ContractCaller) (*ValidatorsCaller, error) {
+	contract, err := bindValidators(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorsCaller{contract: contract}, nil
+}
+
+// NewValidatorsTransactor creates a new write-only instance of Validators, bound to a specific deployed contract.
+func NewValidatorsTransactor(address common.Address, transactor bind.ContractTransactor) (*ValidatorsTransactor, error) {
+	contract, err := bindValidators(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorsTransactor{contract: contract}, nil
+}
+
+// NewValidatorsFilterer creates a new log filterer instance of Validators, bound to a specific deployed contract.
+func NewValidatorsFilterer(address common.Address, filterer bind.ContractFilterer) (*ValidatorsFilterer, error) {
+	contract, err := bindValidators(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorsFilterer{contract: contract}, nil
+}
+
+// bindValidators binds a generic wrapper to an already deployed contract.
+func bindValidators(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ValidatorsABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// ParseValidatorsABI parses the ABI
+func ParseValidatorsABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(ValidatorsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Validators *ValidatorsRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Validators.Contract.ValidatorsCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Validators *ValidatorsRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Validators.Contract.ValidatorsTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Validators *ValidatorsRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Validators.Contract.ValidatorsTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Validators *ValidatorsCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _Validators.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Validators *ValidatorsTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Validators.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Validators *ValidatorsTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Validators.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetMembershipInLastEpochFromSigner is a free data retrieval call binding the contract method 0x51b52225.
+//
+// Solidity: function getMembershipInLastEpochFromSigner(address account) constant returns(address)
+func (_Validators *ValidatorsCaller) GetMembershipInLastEpochFromSigner(opts *bind.CallOpts, account common.Address) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _Validators.contract.Call(opts, out, "getMembershipInLastEpochFromSigner", account)
+	return *ret0, err
+}
+
+// GetMembershipInLastEpochFromSigner is a free data retrieval call binding the contract method 0x51b52225.
+//
+// Solidity: function getMembershipInLastEpochFromSigner(address account) constant returns(address)
+func (_Validators *ValidatorsSession) GetMembershipInLastEpochFromSigner(account common.Address) (common.Address, error) {
+	return _Validators.Contract.GetMembershipInLastEpochFromSigner(&_Validators.CallOpts, account)
+}
+
+// GetMembershipInLastEpochFromSigner is a free data retrieval call binding the contract method 0x51b52225.
+//
+// Solidity: function getMembershipInLastEpochFromSigner(address account) constant returns(address)
+func (_Validators *ValidatorsCallerSession) GetMembershipInLastEpochFromSigner(account common.Address) (common.Address, error) {
+	return _Validators.Contract.GetMembershipInLastEpochFromSigner(&_Validators.CallOpts, account)
+}
+
+// GetRegisteredValidatorSigners is a free data retrieval call binding the contract method 0xd55dcbcf.
+//
+// Solidity: function getRegisteredValidatorSigners() constant returns(address[])
+func (_Validators *ValidatorsCaller) GetRegisteredValidatorSigners(opts *bind.CallOpts) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _Validators.contract.Call(opts, out, "getRegisteredValidatorSigners")
+	return *ret0, err
+}
+
+// GetRegisteredValidatorSigners is a free data retrieval call binding the contract method 0xd55dcbcf.
+//
+// Solidity: function getRegisteredValidatorSigners() constant returns(address[])
+func (_Validators *ValidatorsSession) GetRegisteredValidatorSigners() ([]common.Address, error) {
+	return _Validators.Contract.GetRegisteredValidatorSigners(&_Validators.CallOpts)
+}
+
+// GetRegisteredValidatorSigners is a free data retrieval call binding the contract method 0xd55dcbcf.
+//
+// Solidity: function getRegisteredValidatorSigners() constant returns(address[])
+func (_Validators *ValidatorsCallerSession) GetRegisteredValidatorSigners() ([]common.Address, error) {
+	return _Validators.Contract.GetRegisteredValidatorSigners(&_Validators.CallOpts)
+}
+
+// GetRegisteredValidators is a free data retrieval call binding the contract method 0xd93ab5ad.
+//
+// Solidity: function getRegisteredValidators() constant returns(address[])
+func (_Validators *ValidatorsCaller) GetRegisteredValidators(opts *bind.CallOpts) ([]common.Address, error) {
+	var (
+		ret0 = new([]common.Address)
+	)
+	out := ret0
+	err := _Validators.contract.Call(opts, out, "getRegisteredValidators")
+	return *ret0, err
+}
+
+// GetRegisteredValidators is a free data retrieval call binding the contract method 0xd93ab5ad.
+//
+// Solidity: function getRegisteredValidators() constant returns(address[])
+func (_Validators *ValidatorsSession) GetRegisteredValidators() ([]common.Address, error) {
+	return _Validators.Contract.GetRegisteredValidators(&_Validators.CallOpts)
+}
+
+// GetRegisteredValidators is a free data retrieval call binding the contract method 0xd93ab5ad.
+//
+// Solidity: function getRegisteredValidators() constant returns(address[])
+func (_Validators *ValidatorsCallerSession) GetRegisteredValidators() ([]common.Address, error) {
+	return _Validators.Contract.GetRegisteredValidators(&_Validators.CallOpts)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address account) constant returns(bytes ecdsaPublicKey, bytes blsPublicKey, address affiliation, uint256 score, address signer)
+func (_Validators *ValidatorsCaller) GetValidator(opts *bind.CallOpts, account common.Address) (struct {
+	EcdsaPublicKey []byte
+	BlsPublicKey   []byte
+	Affiliation    common.Address
+	Score          *big.Int
+	Signer         common.Address
+}, error) {
+	ret := new(struct {
+		EcdsaPublicKey []byte
+		BlsPublicKey   []byte
+		Affiliation    common.Address
+		Score          *big.Int
+		Signer         common.Address
+	})
+	out := ret
+	err := _Validators.contract.Call(opts, out, "getValidator", account)
+	return *ret, err
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address account) constant returns(bytes ecdsaPublicKey, bytes blsPublicKey, address affiliation, uint256 score, address signer)
+func (_Validators *ValidatorsSession) GetValidator(account common.Address) (struct {
+	EcdsaPublicKey []byte
+	BlsPublicKey   []byte
+	Affiliation    common.Address
+	Score          *big.Int
+	Signer         common.Address
+}, error) {
+	return _Validators.Contract.GetValidator(&_Validators.CallOpts, account)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0x1904bb2e.
+//
+// Solidity: function getValidator(address account) constant returns(bytes ecdsaPublicKey, bytes blsPublicKey, address affiliation, uint256 score, address signer)
+func (_Validators *ValidatorsCallerSession) GetValidator(account common.Address) (struct {
+	EcdsaPublicKey []byte
+	BlsPublicKey   []byte
+	Affiliation    common.Address
+	Score          *big.Int
+	Signer         common.Address
+}, error) {
+	return _Validators.Contract.GetValidator(&_Validators.CallOpts, account)
+}
+
+// GetValidatorBlsPublicKeyFromSigner is a free data retrieval call binding the contract method 0xb730a299.
+//
+// Solidity: function getValidatorBlsPublicKeyFromSigner(address signer) constant returns(bytes blsKey)
+func (_Validators *ValidatorsCaller) GetValidatorBlsPublicKeyFromSigner(opts *bind.CallOpts, signer common.Address) ([]byte, error) {
+	var (
+		ret0 = new([]byte)
+	)
+	out := ret0
+	err := _Validators.contract.Call(opts, out, "getValidatorBlsPublicKeyFromSigner", signer)
+	return *ret0, err
+}
+
+// GetValidatorBlsPublicKeyFromSigner is a free data retrieval call binding the contract method 0xb730a299.
+//
+// Solidity: function getValidatorBlsPublicKeyFromSigner(address signer) constant returns(bytes blsKey)
+func (_Validators *ValidatorsSession) GetValidatorBlsPublicKeyFromSigner(signer common.Address) ([]byte, error) {
+	return _Validators.Contract.GetValidatorBlsPublicKeyFromSigner(&_Validators.CallOpts, signer)
+}
+
+// GetValidatorBlsPublicKeyFromSigner is a free data retrieval call binding the contract method 0xb730a299.
+//
+// Solidity: function getValidatorBlsPublicKeyFromSigner(address signer) constant returns(bytes blsKey)
+func (_Validators *ValidatorsCallerSession) GetValidatorBlsPublicKeyFromSigner(signer common.Address) ([]byte, error) {
+	return _Validators.Contract.GetValidatorBlsPublicKeyFromSigner(&_Validators.CallOpts, signer)
+}
+
+// DistributeEpochPaymentsFromSigner is a paid mutator transaction binding the contract method 0xd69ef6cf.
+//
+// Solidity: function distributeEpochPaymentsFromSigner(address validator, uint256 maxPayment) returns(uint256)
+func (_Validators *ValidatorsTransactor) DistributeEpochPaymentsFromSigner(opts *bind.TransactOpts, validator common.Address, maxPayment *big.Int) (*types.Transaction, error) {
+	return _Validators.contract.Transact(opts, "distributeEpochPaymentsFromSigner", validator, maxPayment)
+}
+
+// DistributeEpochPaymentsFromSigner is a paid mutator transaction binding the contract method 0xd69ef6cf.
+//
+// Solidity: function distributeEpochPaymentsFromSigner(address validator, uint256 maxPayment) returns(uint256)
+func (_Validators *ValidatorsSession) DistributeEpochPaymentsFromSigner(validator common.Address, maxPayment *big.Int) (*types.Transaction, error) {
+	return _Validators.Contract.DistributeEpochPaymentsFromSigner(&_Validators.TransactOpts, validator, maxPayment)
+}
+
+// DistributeEpochPaymentsFromSigner is a paid mutator transaction binding the contract method 0xd69ef6cf.
+//
+// Solidity: function distributeEpochPaymentsFromSigner(address validator, uint256 maxPayment) returns(uint256)
+func (_Validators *ValidatorsTransactorSession) DistributeEpochPaymentsFromSigner(validator common.Address, maxPayment *big.Int) (*types.Transaction, error) {
+	return _Validators.Contract.DistributeEpochPaymentsFromSigner(&_Validators.TransactOpts, validator, maxPayment)
+}
+
+// UpdateValidatorScoreFromSigner is a paid mutator transaction binding the contract method 0xc0c6ad6f.
+//
+// Solidity: function updateValidatorScoreFromSigner(address validator, uint256 uptime) returns()
+func (_Validators *ValidatorsTransactor) UpdateValidatorScoreFromSigner(opts *bind.TransactOpts, validator common.Address, uptime *big.Int) (*types.Transaction, error) {
+	return _Validators.contract.Transact(opts, "updateValidatorScoreFromSigner", validator, uptime)
+}
+
+// UpdateValidatorScoreFromSigner is a paid mutator transaction binding the contract method 0xc0c6ad6f.
+//
+// Solidity: function updateValidatorScoreFromSigner(address validator, uint256 uptime) returns()
+func (_Validators *ValidatorsSession) UpdateValidatorScoreFromSigner(validator common.Address, uptime *big.Int) (*types.Transaction, error) {
+	return _Validators.Contract.UpdateValidatorScoreFromSigner(&_Validators.TransactOpts, validator, uptime)
+}
+
+// UpdateValidatorScoreFromSigner is a paid mutator transaction binding the contract method 0xc0c6ad6f.
+//
+// Solidity: function updateValidatorScoreFromSigner(address validator, uint256 uptime) returns()
+func (_Validators *ValidatorsTransactorSession) UpdateValidatorScoreFromSigner(validator common.Address, uptime *big.Int) (*types.Transaction, error) {
+	return _Validators.Contract.UpdateValidatorScoreFromSigner(&_Validators.TransactOpts, validator, uptime)
+}
+
+// TryParseLog attempts to parse a log. Returns the parsed log, evenName and whether it was succesfull
+func (_Validators *ValidatorsFilterer) TryParseLog(log types.Log) (eventName string, event interface{}, ok bool, err error) {
+	eventName, ok, err = _Validators.contract.LogEventName(log)
+	if err != nil || !ok {
+		return "", nil, false, err
+	}
+
+	switch eventName {
+	}
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return eventName, event, ok, nil
+}
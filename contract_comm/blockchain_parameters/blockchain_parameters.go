@@ -18,12 +18,11 @@ package blockchain_parameters
 
 import (
 	"math/big"
-	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -31,87 +30,25 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	blockchainParametersABIString = `[{
-			"constant": true,
-			"inputs": [],
-			"name": "getMinimumClientVersion",
-			"outputs": [
-			  {
-				"name": "major",
-				"type": "uint256"
-			  },
-			  {
-				"name": "minor",
-				"type": "uint256"
-			  },
-			  {
-				"name": "patch",
-				"type": "uint256"
-			  }
-			],
-			"payable": false,
-			"stateMutability": "view",
-			"type": "function"
-	},
-	{
-		"constant": true,
-		"inputs": [],
-		"name": "blockGasLimit",
-		"outputs": [
-		  {
-			"name": "",
-			"type": "uint256"
-		  }
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	  },
-	  {
-		"constant": true,
-		"inputs": [],
-		"name": "intrinsicGasForAlternativeFeeCurrency",
-		"outputs": [
-		  {
-			"name": "",
-			"type": "uint256"
-		  }
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	  }
-]`
-)
-
-var blockchainParametersABI abi.ABI
-
-func init() {
-	var err error
-	blockchainParametersABI, err = abi.JSON(strings.NewReader(blockchainParametersABIString))
+// newBlockchainParameters binds the BlockchainParameters contract registered in the backend's state.
+func newBlockchainParameters(backend *contract_comm.SystemBackend) (*bindings.BlockchainParametersCaller, error) {
+	address, err := backend.RegisteredAddress(params.BlockchainParametersRegistryId)
 	if err != nil {
-		log.Crit("Error reading ABI for BlockchainParameters", "err", err)
+		return nil, err
 	}
+	return bindings.NewBlockchainParametersCaller(address, backend)
 }
 
-func GetMinimumVersion(header *types.Header, state vm.StateDB) (*params.VersionInfo, error) {
-	version := [3]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
-	var err error
-	_, err = contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		"getMinimumClientVersion",
-		[]interface{}{},
-		&version,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+func GetMinimumVersion(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (*params.VersionInfo, error) {
+	blockchainParameters, err := newBlockchainParameters(caller.Backend(header, state))
 	if err != nil {
 		return nil, err
 	}
-	return &params.VersionInfo{Major: version[0].Uint64(), Minor: version[1].Uint64(), Patch: version[2].Uint64()}, nil
+	version, err := blockchainParameters.GetMinimumClientVersion(contract_comm.CallOpts(params.MaxGasForReadBlockchainParameter))
+	if err != nil {
+		return nil, err
+	}
+	return &params.VersionInfo{Major: version.Major.Uint64(), Minor: version.Minor.Uint64(), Patch: version.Patch.Uint64()}, nil
 }
 
 // Returns a triplet (gte, ok, error)
@@ -119,9 +56,9 @@ func GetMinimumVersion(header *types.Header, state vm.StateDB) (*params.VersionI
 // `ok` is false if something prevented the minimum version check.
 // This allows the function to give the caller more information and
 // is currently only used to signal the `ContractNotDeployed` errors.
-func IsMinimumVersionAtLeast(major uint64, minor uint64, patch uint64) (bool, bool, error) {
+func IsMinimumVersionAtLeast(caller *contract_comm.SystemCaller, major uint64, minor uint64, patch uint64) (bool, bool, error) {
 	desiredVersion := &params.VersionInfo{Major: major, Minor: minor, Patch: patch}
-	minVersion, err := GetMinimumVersion(nil, nil)
+	minVersion, err := GetMinimumVersion(caller, nil, nil)
 	if err != nil {
 		if err != errors.ErrRegistryContractNotDeployed && err != errors.ErrSmartContractNotDeployed {
 			log.Debug("Error checking client version", "err", err, "contract", hexutil.Encode(params.BlockchainParametersRegistryId[:]))
@@ -132,33 +69,22 @@ func IsMinimumVersionAtLeast(major uint64, minor uint64, patch uint64) (bool, bo
 	return minVersion.Cmp(desiredVersion) >= 0, true, nil
 }
 
-func GetGasCost(header *types.Header, state vm.StateDB, defaultGas uint64, method string) uint64 {
+func GetIntrinsicGasForAlternativeFeeCurrency(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) uint64 {
+	blockchainParameters, err := newBlockchainParameters(caller.Backend(header, state))
 	var gas *big.Int
-	var err error
-	_, err = contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		method,
-		[]interface{}{},
-		&gas,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+	if err == nil {
+		gas, err = blockchainParameters.IntrinsicGasForAlternativeFeeCurrency(contract_comm.CallOpts(params.MaxGasForReadBlockchainParameter))
+	}
 	if err != nil {
-		log.Trace("Default gas", "gas", defaultGas, "method", method)
-		return defaultGas
+		log.Trace("Default gas", "gas", params.IntrinsicGasForAlternativeFeeCurrency, "method", "intrinsicGasForAlternativeFeeCurrency")
+		return params.IntrinsicGasForAlternativeFeeCurrency
 	}
 	log.Trace("Reading gas", "gas", gas)
 	return gas.Uint64()
 }
 
-func GetIntrinsicGasForAlternativeFeeCurrency(header *types.Header, state vm.StateDB) uint64 {
-	return GetGasCost(header, state, params.IntrinsicGasForAlternativeFeeCurrency, "intrinsicGasForAlternativeFeeCurrency")
-}
-
-func CheckMinimumVersion(header *types.Header, state vm.StateDB) {
-	version, err := GetMinimumVersion(header, state)
+func CheckMinimumVersion(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) {
+	version, err := GetMinimumVersion(caller, header, state)
 
 	if err != nil {
 		if err == errors.ErrRegistryContractNotDeployed {
@@ -176,27 +102,21 @@ func CheckMinimumVersion(header *types.Header, state vm.StateDB) {
 
 }
 
-func SpawnCheck() {
+func SpawnCheck(caller *contract_comm.SystemCaller) {
 	go func() {
 		for {
 			time.Sleep(60 * time.Second)
-			CheckMinimumVersion(nil, nil)
+			CheckMinimumVersion(caller, nil, nil)
 		}
 	}()
 }
 
-func GetBlockGasLimit(header *types.Header, state vm.StateDB) (uint64, error) {
+func GetBlockGasLimit(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (uint64, error) {
+	blockchainParameters, err := newBlockchainParameters(caller.Backend(header, state))
 	var gasLimit *big.Int
-	_, err := contract_comm.MakeStaticCall(
-		params.BlockchainParametersRegistryId,
-		blockchainParametersABI,
-		"blockGasLimit",
-		[]interface{}{},
-		&gasLimit,
-		params.MaxGasForReadBlockchainParameter,
-		header,
-		state,
-	)
+	if err == nil {
+		gasLimit, err = blockchainParameters.BlockGasLimit(contract_comm.CallOpts(params.MaxGasForReadBlockchainParameter))
+	}
 	if err != nil {
 		if err == errors.ErrRegistryContractNotDeployed {
 			log.Debug("Error obtaining block gas limit", "err", err, "contract", hexutil.Encode(params.BlockchainParametersRegistryId[:]))
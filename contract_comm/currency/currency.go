@@ -18,116 +18,84 @@ package currency
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/SortedOracles.json
-	medianRateABI = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "token",
-          "type": "address"
-        }
-      ],
-      "name": "medianRate",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint128"
-        },
-        {
-          "name": "",
-          "type": "uint128"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/ERC20.json
-	balanceOfABI = `[{"constant": true,
-                          "inputs": [
-                               {
-                                   "name": "who",
-                                   "type": "address"
-                               }
-                          ],
-                          "name": "balanceOf",
-                          "outputs": [
-                               {
-                                   "name": "",
-                                   "type": "uint256"
-                               }
-                          ],
-                          "payable": false,
-                          "stateMutability": "view",
-                          "type": "function"
-                         }]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/FeeCurrency.json
-	getWhitelistABI = `[{"constant": true,
-	                     "inputs": [],
-	                     "name": "getWhitelist",
-	                     "outputs": [
-			          {
-			              "name": "",
-				      "type": "address[]"
-				  }
-			     ],
-			     "payable": false,
-			     "stateMutability": "view",
-			     "type": "function"
-			    }]`
+	exchangeRateCacheLimit = 1024 // Maximum number of cached (block, currency) exchange rates
 )
 
 var (
 	cgExchangeRateNum = big.NewInt(1)
 	cgExchangeRateDen = big.NewInt(1)
 
-	medianRateFuncABI, _   = abi.JSON(strings.NewReader(medianRateABI))
-	balanceOfFuncABI, _    = abi.JSON(strings.NewReader(balanceOfABI))
-	getWhitelistFuncABI, _ = abi.JSON(strings.NewReader(getWhitelistABI))
+	exchangeRateCache, _ = lru.New(exchangeRateCacheLimit) // Exchange rates by block hash and currency
 )
 
+// newSortedOracles binds the SortedOracles contract registered in the backend's state.
+func newSortedOracles(backend *contract_comm.SystemBackend) (*bindings.SortedOraclesCaller, error) {
+	address, err := backend.RegisteredAddress(params.SortedOraclesRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewSortedOraclesCaller(address, backend)
+}
+
+// newFeeCurrencyWhitelist binds the FeeCurrencyWhitelist contract registered in the backend's state.
+func newFeeCurrencyWhitelist(backend *contract_comm.SystemBackend) (*bindings.FeeCurrencyWhitelistCaller, error) {
+	address, err := backend.RegisteredAddress(params.FeeCurrencyWhitelistRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewFeeCurrencyWhitelistCaller(address, backend)
+}
+
 type exchangeRate struct {
 	Numerator   *big.Int
 	Denominator *big.Int
 }
 
-func ConvertToGold(val *big.Int, currencyFrom *common.Address) (*big.Int, error) {
-	celoGoldAddress, err := contract_comm.GetRegisteredAddress(params.GoldTokenRegistryId, nil, nil)
+// exchangeRateCacheKey identifies the exchange rate of a currency as of a given block.
+type exchangeRateCacheKey struct {
+	blockHash common.Hash
+	currency  common.Address
+}
+
+// ConvertToGold converts val from currencyFrom to Celo Gold, using the exchange rates as of the given
+// header and state.  If both are nil, the exchange rates at the chain head are used.
+func ConvertToGold(caller *contract_comm.SystemCaller, val *big.Int, currencyFrom *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
+	celoGoldAddress, err := caller.GetRegisteredAddress(params.GoldTokenRegistryId, header, state)
 	if err == errors.ErrSmartContractNotDeployed || err == errors.ErrRegistryContractNotDeployed {
 		log.Warn("Registry address lookup failed", "err", err)
 		return val, err
 	}
 
-	if currencyFrom == celoGoldAddress {
+	if currencyFrom == nil || (celoGoldAddress != nil && *currencyFrom == *celoGoldAddress) {
 		return val, err
 	} else if err != nil {
 		log.Error(err.Error())
 		return val, err
 	}
-	return Convert(val, currencyFrom, celoGoldAddress)
+	return Convert(caller, val, currencyFrom, nil, header, state)
 }
 
+// Convert converts val from currencyFrom to currencyTo, using the exchange rates as of the given
+// header and state.  If both are nil, the exchange rates at the chain head are used.  The state may
+// be the one of a block still being processed, so the rates are read from it every time.
 // NOTE (jarmg 4/24/19): values are rounded down which can cause
 // an estimate to be off by 1 (at most)
-func Convert(val *big.Int, currencyFrom *common.Address, currencyTo *common.Address) (*big.Int, error) {
-	exchangeRateFrom, err1 := getExchangeRate(currencyFrom)
-	exchangeRateTo, err2 := getExchangeRate(currencyTo)
+func Convert(caller *contract_comm.SystemCaller, val *big.Int, currencyFrom *common.Address, currencyTo *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
+	exchangeRateFrom, err1 := getExchangeRate(caller, currencyFrom, header, state)
+	exchangeRateTo, err2 := getExchangeRate(caller, currencyTo, header, state)
 
 	if err1 != nil || err2 != nil {
 		log.Error("Convert - Error in retreiving currency exchange rates")
@@ -146,13 +114,16 @@ func Convert(val *big.Int, currencyFrom *common.Address, currencyTo *common.Addr
 	return new(big.Int).Div(numerator, denominator), nil
 }
 
-func Cmp(val1 *big.Int, currency1 *common.Address, val2 *big.Int, currency2 *common.Address) int {
+// Cmp compares val1 in currency1 with val2 in currency2, using the exchange rates as of the given
+// header and state.  If both are nil, the exchange rates at the chain head are used.  Otherwise the
+// state must be the state after the block of the header, as the rates are cached by block.
+func Cmp(caller *contract_comm.SystemCaller, val1 *big.Int, currency1 *common.Address, val2 *big.Int, currency2 *common.Address, header *types.Header, state vm.StateDB) int {
 	if currency1 == currency2 {
 		return val1.Cmp(val2)
 	}
 
-	exchangeRate1, err1 := getExchangeRate(currency1)
-	exchangeRate2, err2 := getExchangeRate(currency2)
+	exchangeRate1, err1 := getBlockExchangeRate(caller, currency1, header, state)
+	exchangeRate2, err2 := getBlockExchangeRate(caller, currency2, header, state)
 
 	if err1 != nil || err2 != nil {
 		currency1Output := "nil"
@@ -176,34 +147,72 @@ func Cmp(val1 *big.Int, currency1 *common.Address, val2 *big.Int, currency2 *com
 	return leftSide.Cmp(rightSide)
 }
 
-func getExchangeRate(currencyAddress *common.Address) (*exchangeRate, error) {
-	var (
-		returnArray [2]*big.Int
-		leftoverGas uint64
-	)
+// GetExchangeRate returns the exchange rate of the given currency as of the given header and state, as
+// the numerator and denominator of the rate from Celo Gold, i.e. a value in the currency is worth
+// value * denominator / numerator Celo Gold.  A nil currency is Celo Gold itself.  The state must be
+// the state after the block of the header, as the rates are cached by block.
+func GetExchangeRate(caller *contract_comm.SystemCaller, currencyAddress *common.Address, header *types.Header, state vm.StateDB) (*big.Int, *big.Int, error) {
+	rate, err := getBlockExchangeRate(caller, currencyAddress, header, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rate.Numerator, rate.Denominator, nil
+}
 
+// getBlockExchangeRate returns the exchange rate of the given currency as of the given header and the
+// state after that block.  The rate is cached by block hash and currency, so that repeated lookups for
+// the same block don't re-enter the EVM.
+func getBlockExchangeRate(caller *contract_comm.SystemCaller, currencyAddress *common.Address, header *types.Header, state vm.StateDB) (*exchangeRate, error) {
+	if currencyAddress == nil || header == nil {
+		return getExchangeRate(caller, currencyAddress, header, state)
+	}
+	cacheKey := exchangeRateCacheKey{header.Hash(), *currencyAddress}
+	if rate, ok := exchangeRateCache.Get(cacheKey); ok {
+		return rate.(*exchangeRate), nil
+	}
+	rate, err := getExchangeRate(caller, currencyAddress, header, state)
+	if err == nil {
+		exchangeRateCache.Add(cacheKey, rate)
+	}
+	return rate, err
+}
+
+// getExchangeRate returns the exchange rate of the given currency as of the given header and state,
+// reading it from the state.
+func getExchangeRate(caller *contract_comm.SystemCaller, currencyAddress *common.Address, header *types.Header, state vm.StateDB) (*exchangeRate, error) {
 	if currencyAddress == nil {
 		return &exchangeRate{cgExchangeRateNum, cgExchangeRateDen}, nil
-	} else {
-		if leftoverGas, err := contract_comm.MakeStaticCall(params.SortedOraclesRegistryId, medianRateFuncABI, "medianRate", []interface{}{currencyAddress}, &returnArray, params.MaxGasForMedianRate, nil, nil); err != nil {
-			if err == errors.ErrSmartContractNotDeployed {
-				log.Warn("Registry address lookup failed", "err", err)
-				return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
-			} else {
-				log.Error("medianRate invocation error", "feeCurrencyAddress", currencyAddress.Hex(), "leftoverGas", leftoverGas, "err", err)
-				return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
-			}
+	}
+
+	backend := caller.Backend(header, state)
+	sortedOracles, err := newSortedOracles(backend)
+	var numerator, denominator *big.Int
+	if err == nil {
+		numerator, denominator, err = sortedOracles.MedianRate(contract_comm.CallOpts(params.MaxGasForMedianRate), *currencyAddress)
+	}
+	if err != nil {
+		if err == errors.ErrSmartContractNotDeployed {
+			log.Warn("Registry address lookup failed", "err", err)
+		} else {
+			log.Error("medianRate invocation error", "feeCurrencyAddress", currencyAddress.Hex(), "leftoverGas", backend.GasLeft(), "err", err)
 		}
+		return &exchangeRate{big.NewInt(1), big.NewInt(1)}, err
 	}
-	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "returnArray", returnArray, "leftoverGas", leftoverGas)
-	return &exchangeRate{returnArray[0], returnArray[1]}, nil
+	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "numerator", numerator, "denominator", denominator, "leftoverGas", backend.GasLeft())
+
+	return &exchangeRate{numerator, denominator}, nil
 }
 
 // This function will retrieve the balance of an ERC20 token.
-func GetBalanceOf(accountOwner common.Address, contractAddress common.Address, gas uint64, header *types.Header, state vm.StateDB) (result *big.Int, gasUsed uint64, err error) {
+func GetBalanceOf(caller *contract_comm.SystemCaller, accountOwner common.Address, contractAddress common.Address, gas uint64, header *types.Header, state vm.StateDB) (result *big.Int, gasUsed uint64, err error) {
 	log.Trace("GetBalanceOf() Called", "accountOwner", accountOwner.Hex(), "contractAddress", contractAddress, "gas", gas)
 
-	leftoverGas, err := contract_comm.MakeStaticCallWithAddress(contractAddress, balanceOfFuncABI, "balanceOf", []interface{}{accountOwner}, &result, gas, header, state)
+	backend := caller.Backend(header, state)
+	token, err := bindings.NewERC20Caller(contractAddress, backend)
+	if err == nil {
+		result, err = token.BalanceOf(contract_comm.CallOpts(gas), accountOwner)
+	}
+	leftoverGas := backend.GasLeft()
 
 	if err != nil {
 		log.Error("GetBalanceOf evm invocation error", "leftoverGas", leftoverGas, "err", err)
@@ -219,10 +228,13 @@ func GetBalanceOf(accountOwner common.Address, contractAddress common.Address, g
 // ------------------------------
 // FeeCurrencyWhiteList Functions
 //-------------------------------
-func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
+func retrieveWhitelist(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
 	returnList := []common.Address{}
 
-	_, err := contract_comm.MakeStaticCall(params.FeeCurrencyWhitelistRegistryId, getWhitelistFuncABI, "getWhitelist", []interface{}{}, &returnList, params.MaxGasForGetWhiteList, header, state)
+	feeCurrencyWhitelist, err := newFeeCurrencyWhitelist(caller.Backend(header, state))
+	if err == nil {
+		returnList, err = feeCurrencyWhitelist.GetWhitelist(contract_comm.CallOpts(params.MaxGasForGetWhiteList))
+	}
 	if err != nil {
 		if err == errors.ErrSmartContractNotDeployed {
 			log.Warn("Registry address lookup failed", "err", err)
@@ -236,8 +248,8 @@ func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address
 	return returnList, err
 }
 
-func IsWhitelisted(currencyAddress common.Address, header *types.Header, state vm.StateDB) bool {
-	whitelist, err := retrieveWhitelist(header, state)
+func IsWhitelisted(caller *contract_comm.SystemCaller, currencyAddress common.Address, header *types.Header, state vm.StateDB) bool {
+	whitelist, err := retrieveWhitelist(caller, header, state)
 	if err != nil {
 		log.Warn("Failed to get fee currency whitelist", "err", err)
 		return true
@@ -254,8 +266,8 @@ func containsCurrency(currencyAddr common.Address, currencyList []common.Address
 	return false
 }
 
-func CurrencyWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	whitelist, err := retrieveWhitelist(header, state)
+func CurrencyWhitelist(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	whitelist, err := retrieveWhitelist(caller, header, state)
 	if err != nil {
 		log.Warn("Failed to get fee currency whitelist", "err", err)
 	}
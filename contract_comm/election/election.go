@@ -18,171 +18,53 @@ package election
 import (
 	"math/big"
 	"sort"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Election.json
-const electionABIString string = `[
-  {"constant": true,
-              "inputs": [],
-        "name": "electValidatorSigners",
-        "outputs": [
-       {
-            "name": "",
-      "type": "address[]"
-       }
-        ],
-        "payable": false,
-        "stateMutability": "view",
-        "type": "function"
-       },
-			     {
-      "constant": true,
-      "inputs": [],
-      "name": "getTotalVotesForEligibleValidatorGroups",
-      "outputs": [
-        {
-          "name": "groups",
-          "type": "address[]"
-        },
-        {
-          "name": "values",
-          "type": "uint256[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-		    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "group",
-          "type": "address"
-        },
-        {
-          "name": "value",
-          "type": "uint256"
-        },
-        {
-          "name": "lesser",
-          "type": "address"
-        },
-        {
-          "name": "greater",
-          "type": "address"
-        }
-      ],
-      "name": "distributeEpochRewards",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-		    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "group",
-          "type": "address"
-        },
-        {
-          "name": "maxTotalRewards",
-          "type": "uint256"
-        },
-        {
-          "name": "uptimes",
-          "type": "uint256[]"
-        }
-      ],
-      "name": "getGroupEpochRewards",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "minElectableValidators",
-          "type": "uint256"
-        },
-        {
-          "name": "maxElectableValidators",
-          "type": "uint256"
-        }
-      ],
-      "name": "electNValidatorSigners",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "getElectableValidators",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-
-var electionABI, _ = abi.JSON(strings.NewReader(electionABIString))
+// newElection binds the Election contract registered in the backend's state.
+func newElection(backend *contract_comm.SystemBackend) (*bindings.Election, error) {
+	address, err := backend.RegisteredAddress(params.ElectionRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewElection(address, backend)
+}
 
-func GetElectedValidators(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var newValSet []common.Address
+func GetElectedValidators(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	election, err := newElection(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
 	// Get the new epoch's validator set
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "electValidatorSigners", []interface{}{}, &newValSet, params.MaxGasForElectValidators, header, state)
+	newValSet, err := election.ElectValidatorSigners(contract_comm.CallOpts(params.MaxGasForElectValidators))
 	if err != nil {
 		return nil, err
 	}
 	return newValSet, nil
 }
 
-func ElectNValidatorSigners(header *types.Header, state vm.StateDB, additionalAboveMaxElectable int64) ([]common.Address, error) {
-	var minElectableValidators *big.Int
-	var maxElectableValidators *big.Int
+func ElectNValidatorSigners(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, additionalAboveMaxElectable int64) ([]common.Address, error) {
+	election, err := newElection(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the electable min and max
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getElectableValidators", []interface{}{}, &[]interface{}{&minElectableValidators, &maxElectableValidators}, params.MaxGasForGetElectableValidators, header, state)
+	minElectableValidators, maxElectableValidators, err := election.GetElectableValidators(contract_comm.CallOpts(params.MaxGasForGetElectableValidators))
 	if err != nil {
 		return nil, err
 	}
 
-	var electedValidators []common.Address
 	// Run the validator election for up to maxElectable + getTotalVotesForEligibleValidatorGroup
-	_, err = contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "electNValidatorSigners", []interface{}{minElectableValidators, maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable))}, &electedValidators, params.MaxGasForElectNValidatorSigners, header, state)
+	maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable))
+	electedValidators, err := election.ElectNValidatorSigners(contract_comm.CallOpts(params.MaxGasForElectNValidatorSigners), minElectableValidators, maxElectableValidators)
 	if err != nil {
 		return nil, err
 	}
@@ -196,44 +78,60 @@ type voteTotal struct {
 	Value *big.Int
 }
 
-func getTotalVotesForEligibleValidatorGroups(header *types.Header, state vm.StateDB) ([]voteTotal, error) {
-	var groups []common.Address
-	var values []*big.Int
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getTotalVotesForEligibleValidatorGroups", []interface{}{}, &[]interface{}{&groups, &values}, params.MaxGasForGetEligibleValidatorGroupsVoteTotals, header, state)
+func getTotalVotesForEligibleValidatorGroups(election *bindings.Election) ([]voteTotal, error) {
+	totals, err := election.GetTotalVotesForEligibleValidatorGroups(contract_comm.CallOpts(params.MaxGasForGetEligibleValidatorGroupsVoteTotals))
 	if err != nil {
 		return nil, err
 	}
 
-	voteTotals := make([]voteTotal, len(groups))
-	for i, group := range groups {
-		log.Trace("Got group vote total", "group", group, "value", values[i])
+	voteTotals := make([]voteTotal, len(totals.Groups))
+	for i, group := range totals.Groups {
+		log.Trace("Got group vote total", "group", group, "value", totals.Values[i])
 		voteTotals[i].Group = group
-		voteTotals[i].Value = values[i]
+		voteTotals[i].Value = totals.Values[i]
 	}
 	return voteTotals, err
 }
 
-func getGroupEpochRewards(header *types.Header, state vm.StateDB, group common.Address, maxRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
-	var groupEpochRewards *big.Int
-	_, err := contract_comm.MakeStaticCall(params.ElectionRegistryId, electionABI, "getGroupEpochRewards", []interface{}{group, maxRewards, uptimes}, &groupEpochRewards, params.MaxGasForGetGroupEpochRewards, header, state)
+// GetGroupVoteTotals returns the total votes, pending and active, for each eligible validator group.
+func GetGroupVoteTotals(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (map[common.Address]*big.Int, error) {
+	election, err := newElection(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
+	voteTotals, err := getTotalVotesForEligibleValidatorGroups(election)
 	if err != nil {
 		return nil, err
 	}
-	return groupEpochRewards, nil
+	groupVotes := make(map[common.Address]*big.Int, len(voteTotals))
+	for _, voteTotal := range voteTotals {
+		groupVotes[voteTotal.Group] = voteTotal.Value
+	}
+	return groupVotes, nil
+}
+
+func getGroupEpochRewards(election *bindings.Election, group common.Address, maxRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
+	return election.GetGroupEpochRewards(contract_comm.CallOpts(params.MaxGasForGetGroupEpochRewards), group, maxRewards, uptimes)
 }
 
-func DistributeEpochRewards(header *types.Header, state vm.StateDB, groups []common.Address, maxTotalRewards *big.Int, uptimes map[common.Address][]*big.Int) (*big.Int, error) {
+// DistributeEpochRewards distributes the epoch rewards to the voters of the given groups, and returns
+// the reward of each group along with the total.
+func DistributeEpochRewards(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, groups []common.Address, maxTotalRewards *big.Int, uptimes map[common.Address][]*big.Int) ([]*big.Int, *big.Int, error) {
 	totalRewards := big.NewInt(0)
-	voteTotals, err := getTotalVotesForEligibleValidatorGroups(header, state)
+	election, err := newElection(caller.Backend(header, state))
+	if err != nil {
+		return nil, totalRewards, err
+	}
+	voteTotals, err := getTotalVotesForEligibleValidatorGroups(election)
 	if err != nil {
-		return totalRewards, err
+		return nil, totalRewards, err
 	}
 
 	rewards := make([]*big.Int, len(groups))
 	for i, group := range groups {
-		reward, err := getGroupEpochRewards(header, state, group, maxTotalRewards, uptimes[group])
+		reward, err := getGroupEpochRewards(election, group, maxTotalRewards, uptimes[group])
 		if err != nil {
-			return totalRewards, err
+			return nil, totalRewards, err
 		}
 		rewards[i] = reward
 		log.Debug("Reward for group voters", "reward", reward, "group", group.String())
@@ -267,11 +165,11 @@ func DistributeEpochRewards(header *types.Header, state vm.StateDB, groups []com
 				break
 			}
 		}
-		_, err := contract_comm.MakeCall(params.ElectionRegistryId, electionABI, "distributeEpochRewards", []interface{}{group, reward, lesser, greater}, nil, params.MaxGasForDistributeEpochRewards, common.Big0, header, state, false)
+		_, err := election.DistributeEpochRewards(contract_comm.TransactOpts(params.MaxGasForDistributeEpochRewards), group, reward, lesser, greater)
 		if err != nil {
-			return totalRewards, err
+			return nil, totalRewards, err
 		}
 		totalRewards.Add(totalRewards, reward)
 	}
-	return totalRewards, nil
+	return rewards, totalRewards, nil
 }
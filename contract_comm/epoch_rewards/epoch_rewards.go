@@ -17,113 +17,41 @@ package epoch_rewards
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Election.json
-const epochRewardsABIString string = `[
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "calculateTargetEpochRewards",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        },
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    { 
-      "constant": true,
-      "inputs": [],
-      "name": "carbonOffsettingPartner",
-      "outputs": [
-        { 
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [],
-      "name": "updateTargetVotingYield",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "isReserveLow",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "frozen",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]
-`
-
-var epochRewardsABI, _ = abi.JSON(strings.NewReader(epochRewardsABIString))
+// newEpochRewards binds the EpochRewards contract registered in the backend's state.
+func newEpochRewards(backend *contract_comm.SystemBackend) (*bindings.EpochRewards, error) {
+	address, err := backend.RegisteredAddress(params.EpochRewardsRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewEpochRewards(address, backend)
+}
 
-func UpdateTargetVotingYield(header *types.Header, state vm.StateDB) error {
-	_, err := contract_comm.MakeCall(params.EpochRewardsRegistryId, epochRewardsABI, "updateTargetVotingYield", []interface{}{}, nil, params.MaxGasForUpdateTargetVotingYield, common.Big0, header, state, false)
+func UpdateTargetVotingYield(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) error {
+	epochRewards, err := newEpochRewards(caller.Backend(header, state))
+	if err != nil {
+		return err
+	}
+	_, err = epochRewards.UpdateTargetVotingYield(contract_comm.TransactOpts(params.MaxGasForUpdateTargetVotingYield))
 	return err
 }
 
 // Returns the per validator epoch reward, the total voter reward, the total community reward, and
 // the total carbon offsetting partner award, for the epoch.
-func CalculateTargetEpochRewards(header *types.Header, state vm.StateDB) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
-	var validatorEpochReward *big.Int
-	var totalVoterRewards *big.Int
-	var totalCommunityReward *big.Int
-	var totalCarbonOffsettingPartnerReward *big.Int
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "calculateTargetEpochRewards", []interface{}{}, &[]interface{}{&validatorEpochReward, &totalVoterRewards, &totalCommunityReward, &totalCarbonOffsettingPartnerReward}, params.MaxGasForCalculateTargetEpochPaymentAndRewards, header, state)
+func CalculateTargetEpochRewards(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (*big.Int, *big.Int, *big.Int, *big.Int, error) {
+	epochRewards, err := newEpochRewards(caller.Backend(header, state))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	validatorEpochReward, totalVoterRewards, totalCommunityReward, totalCarbonOffsettingPartnerReward, err := epochRewards.CalculateTargetEpochRewards(contract_comm.CallOpts(params.MaxGasForCalculateTargetEpochPaymentAndRewards))
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -131,21 +59,19 @@ func CalculateTargetEpochRewards(header *types.Header, state vm.StateDB) (*big.I
 }
 
 // Determines if the reserve is below it's critical threshold
-func IsReserveLow(header *types.Header, state vm.StateDB) (bool, error) {
-	var isLow bool
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "isReserveLow", []interface{}{}, &isLow, params.MaxGasForIsReserveLow, header, state)
+func IsReserveLow(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (bool, error) {
+	epochRewards, err := newEpochRewards(caller.Backend(header, state))
 	if err != nil {
 		return false, err
 	}
-	return isLow, nil
+	return epochRewards.IsReserveLow(contract_comm.CallOpts(params.MaxGasForIsReserveLow))
 }
 
 // Returns the address of the carbon offsetting partner
-func GetCarbonOffsettingPartnerAddress(header *types.Header, state vm.StateDB) (common.Address, error) {
-	var carbonOffsettingPartner common.Address
-	_, err := contract_comm.MakeStaticCall(params.EpochRewardsRegistryId, epochRewardsABI, "carbonOffsettingPartner", []interface{}{}, &carbonOffsettingPartner, params.MaxGasForGetCarbonOffsettingPartner, header, state)
+func GetCarbonOffsettingPartnerAddress(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (common.Address, error) {
+	epochRewards, err := newEpochRewards(caller.Backend(header, state))
 	if err != nil {
 		return common.ZeroAddress, err
 	}
-	return carbonOffsettingPartner, nil
+	return epochRewards.CarbonOffsettingPartner(contract_comm.CallOpts(params.MaxGasForGetCarbonOffsettingPartner))
 }
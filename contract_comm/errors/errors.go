@@ -6,7 +6,7 @@ import (
 
 var (
 	// ErrSmartContractNotDeployed is returned when the RegisteredAddresses mapping does not contain the specified contract
-	ErrSmartContractNotDeployed      = errors.New("Contract not in Registry")
-	ErrRegistryContractNotDeployed   = errors.New("Registry not deployed")
-	ErrNoInternalEvmHandlerSingleton = errors.New("No internalEvmHandlerSingleton set for contract communication")
+	ErrSmartContractNotDeployed    = errors.New("Contract not in Registry")
+	ErrRegistryContractNotDeployed = errors.New("Registry not deployed")
+	ErrNoSystemCaller              = errors.New("No system caller set for contract communication")
 )
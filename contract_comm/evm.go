@@ -17,70 +17,55 @@
 package contract_comm
 
 import (
-	"math/big"
 	"reflect"
-	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var (
-	emptyMessage                = types.NewMessage(common.HexToAddress("0x0"), nil, 0, common.Big0, 0, common.Big0, nil, nil, common.Big0, []byte{}, false)
-	internalEvmHandlerSingleton *InternalEVMHandler
+	emptyMessage = types.NewMessage(common.HexToAddress("0x0"), nil, 0, common.Big0, 0, common.Big0, nil, nil, common.Big0, []byte{}, false)
 )
 
-// An EVM handler to make calls to smart contracts from within geth
-type InternalEVMHandler struct {
+// SystemCaller makes calls to smart contracts from within geth, against the chain it was created for.
+// Note that this should NOT be used when executing smart contract calls done via end user transactions.
+// A nil *SystemCaller is valid, and fails every call with errors.ErrNoSystemCaller.
+type SystemCaller struct {
 	chain vm.ChainContext
 }
 
-func MakeStaticCall(registryId [32]byte, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, header *types.Header, state vm.StateDB) (uint64, error) {
-	return makeCallWithContractId(registryId, abi, funcName, args, returnObj, gas, nil, header, state, true)
+// NewSystemCaller creates a SystemCaller that makes calls against the given chain.
+func NewSystemCaller(chain vm.ChainContext) *SystemCaller {
+	return &SystemCaller{chain: chain}
 }
 
-func MakeCall(registryId [32]byte, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, value *big.Int, header *types.Header, state vm.StateDB, finaliseState bool) (uint64, error) {
-	gasLeft, err := makeCallWithContractId(registryId, abi, funcName, args, returnObj, gas, value, header, state, false)
-	if err == nil && finaliseState {
-		state.Finalise(true)
-	}
-	return gasLeft, err
-}
-
-func MakeStaticCallWithAddress(scAddress common.Address, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, header *types.Header, state vm.StateDB) (uint64, error) {
-	return makeCallFromSystem(scAddress, abi, funcName, args, returnObj, gas, nil, header, state, true)
-}
-
-func GetRegisteredAddress(registryId [32]byte, header *types.Header, state vm.StateDB) (*common.Address, error) {
-	vmevm, err := createEVM(header, state)
+func (sc *SystemCaller) GetRegisteredAddress(registryId [32]byte, header *types.Header, state vm.StateDB) (*common.Address, error) {
+	vmevm, err := sc.createEVM(header, state)
 	if err != nil {
 		return nil, err
 	}
 	return vm.GetRegisteredAddressWithEvm(registryId, vmevm)
 }
 
-func createEVM(header *types.Header, state vm.StateDB) (*vm.EVM, error) {
+func (sc *SystemCaller) createEVM(header *types.Header, state vm.StateDB) (*vm.EVM, error) {
 	// Normally, when making an evm call, we should use the current block's state.  However,
 	// there are times (e.g. retrieving the set of validators when an epoch ends) that we need
 	// to call the evm using the currently mined block.  In that case, the header and state params
 	// will be non nil.
-	if internalEvmHandlerSingleton == nil {
-		return nil, errors.ErrNoInternalEvmHandlerSingleton
+	if sc == nil || sc.chain == nil {
+		return nil, errors.ErrNoSystemCaller
 	}
 
 	if header == nil {
-		header = internalEvmHandlerSingleton.chain.CurrentHeader()
+		header = sc.chain.CurrentHeader()
 	}
 
 	if state == nil || reflect.ValueOf(state).IsNil() {
 		var err error
-		state, err = internalEvmHandlerSingleton.chain.State()
+		state, err = sc.chain.State()
 		if err != nil {
 			log.Error("Error in retrieving the state from the blockchain", "err", err)
 			return nil, err
@@ -89,67 +74,8 @@ func createEVM(header *types.Header, state vm.StateDB) (*vm.EVM, error) {
 
 	// The EVM Context requires a msg, but the actual field values don't really matter for this case.
 	// Putting in zero values.
-	context := vm.NewEVMContext(emptyMessage, header, internalEvmHandlerSingleton.chain, nil)
-	evm := vm.NewEVM(context, state, internalEvmHandlerSingleton.chain.Config(), *internalEvmHandlerSingleton.chain.GetVMConfig())
+	context := vm.NewEVMContext(emptyMessage, header, sc.chain, nil)
+	evm := vm.NewEVM(context, state, sc.chain.Config(), *sc.chain.GetVMConfig())
 
 	return evm, nil
 }
-
-func makeCallFromSystem(scAddress common.Address, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, value *big.Int, header *types.Header, state vm.StateDB, static bool) (uint64, error) {
-	// Record a metrics data point about execution time.
-	timer := metrics.GetOrRegisterTimer("contract_comm/systemcall/"+funcName, nil)
-	start := time.Now()
-	defer timer.UpdateSince(start)
-
-	vmevm, err := createEVM(header, state)
-	if err != nil {
-		return 0, err
-	}
-
-	var gasLeft uint64
-
-	if static {
-		gasLeft, err = vmevm.StaticCallFromSystem(scAddress, abi, funcName, args, returnObj, gas)
-	} else {
-		gasLeft, err = vmevm.CallFromSystem(scAddress, abi, funcName, args, returnObj, gas, value)
-	}
-	if err != nil {
-		log.Error("Error when invoking evm function", "err", err, "funcName", funcName, "static", static, "address", scAddress, "args", args, "gas", gas, "gasLeft", gasLeft, "value", value)
-		return gasLeft, err
-	}
-
-	return gasLeft, nil
-}
-
-func SetInternalEVMHandler(chain vm.ChainContext) {
-	if internalEvmHandlerSingleton == nil {
-		log.Trace("Setting the InternalEVMHandler Singleton")
-		internalEvmHandler := InternalEVMHandler{
-			chain: chain,
-		}
-		internalEvmHandlerSingleton = &internalEvmHandler
-	}
-}
-
-func makeCallWithContractId(registryId [32]byte, abi abi.ABI, funcName string, args []interface{}, returnObj interface{}, gas uint64, value *big.Int, header *types.Header, state vm.StateDB, static bool) (uint64, error) {
-	scAddress, err := GetRegisteredAddress(registryId, header, state)
-
-	if err != nil {
-		if err == errors.ErrSmartContractNotDeployed {
-			log.Debug("Contract not yet registered", "function", funcName, "registryId", hexutil.Encode(registryId[:]))
-			return 0, err
-		} else if err == errors.ErrRegistryContractNotDeployed {
-			log.Debug("Registry contract not yet deployed", "function", funcName, "registryId", hexutil.Encode(registryId[:]))
-			return 0, err
-		} else {
-			log.Error("Error in getting registered address", "function", funcName, "registryId", hexutil.Encode(registryId[:]), "err", err)
-			return 0, err
-		}
-	}
-
-	gasLeft, err := makeCallFromSystem(*scAddress, abi, funcName, args, returnObj, gas, value, header, state, static)
-	if err != nil {
-		log.Error("Error in executing function on registered contract", "function", funcName, "registryId", hexutil.Encode(registryId[:]), "err", err)
-	}
-	return gasLeft, err
-}
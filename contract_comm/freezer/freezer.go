@@ -17,51 +17,26 @@
 package freezer
 
 import (
-	"strings"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Freezer.json
-	isFrozenABI = `[{
-      "constant": true,
-      "inputs": [
-        {
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "name": "isFrozen",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bool"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-)
-
-var (
-	isFrozenFuncABI, _ = abi.JSON(strings.NewReader(isFrozenABI))
-)
-
-func IsFrozen(registryId [32]byte, header *types.Header, state vm.StateDB) (bool, error) {
-	address, err := contract_comm.GetRegisteredAddress(registryId, header, state)
+func IsFrozen(caller *contract_comm.SystemCaller, registryId [32]byte, header *types.Header, state vm.StateDB) (bool, error) {
+	backend := caller.Backend(header, state)
+	address, err := backend.RegisteredAddress(registryId)
 	if err != nil {
 		return false, err
 	}
-	var isFrozen bool
-	if _, err := contract_comm.MakeStaticCall(params.FreezerRegistryId, isFrozenFuncABI, "isFrozen", []interface{}{address}, &isFrozen, params.MaxGasForIsFrozen, header, state); err != nil {
+	freezerAddress, err := backend.RegisteredAddress(params.FreezerRegistryId)
+	if err != nil {
 		return false, err
 	}
-
-	return isFrozen, nil
+	freezer, err := bindings.NewFreezerCaller(freezerAddress, backend)
+	if err != nil {
+		return false, err
+	}
+	return freezer.IsFrozen(contract_comm.CallOpts(params.MaxGasForIsFrozen), address)
 }
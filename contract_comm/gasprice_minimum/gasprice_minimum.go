@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -30,77 +31,37 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// TODO (jarmg 5/22/19): Store ABIs in a central location
-const (
-	gasPriceMinimumABIString = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "_tokenAddress",
-          "type": "address"
-        }
-      ],
-      "name": "getGasPriceMinimum",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "_blockGasTotal",
-          "type": "uint256"
-        },
-        {
-          "name": "_blockGasLimit",
-          "type": "uint256"
-        }
-      ],
-      "name": "updateGasPriceMinimum",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-      } 
-    
-  ]`
-)
-
 var (
-	gasPriceMinimumABI, _            = abi.JSON(strings.NewReader(gasPriceMinimumABIString))
+	gasPriceMinimumABI, _            = abi.JSON(strings.NewReader(bindings.GasPriceMinimumABI))
 	FallbackGasPriceMinimum *big.Int = big.NewInt(0) // gas price minimum to return if unable to fetch from contract
 	suggestionMultiplier    *big.Int = big.NewInt(5) // The multiplier that we apply to the minimum when suggesting gas price
 )
 
-func GetGasPriceSuggestion(currency *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
-	gasPriceMinimum, err := GetGasPriceMinimum(currency, header, state)
+// newGasPriceMinimum binds the GasPriceMinimum contract registered in the backend's state.
+func newGasPriceMinimum(backend *contract_comm.SystemBackend) (*bindings.GasPriceMinimum, error) {
+	address, err := backend.RegisteredAddress(params.GasPriceMinimumRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewGasPriceMinimum(address, backend)
+}
+
+func GetGasPriceSuggestion(caller *contract_comm.SystemCaller, currency *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
+	gasPriceMinimum, err := GetGasPriceMinimum(caller, currency, header, state)
 	return new(big.Int).Mul(gasPriceMinimum, suggestionMultiplier), err
 }
 
-func GetGasPriceMinimum(currency *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
+func GetGasPriceMinimum(caller *contract_comm.SystemCaller, currency *common.Address, header *types.Header, state vm.StateDB) (*big.Int, error) {
 	var currencyAddress *common.Address
 	var err error
 
 	if currency == nil {
-		currencyAddress, err = contract_comm.GetRegisteredAddress(params.GoldTokenRegistryId, header, state)
+		currencyAddress, err = caller.GetRegisteredAddress(params.GoldTokenRegistryId, header, state)
 
 		if err == errors.ErrSmartContractNotDeployed || err == errors.ErrRegistryContractNotDeployed {
 			return FallbackGasPriceMinimum, nil
 		}
-		if err == errors.ErrNoInternalEvmHandlerSingleton {
+		if err == errors.ErrNoSystemCaller {
 			return FallbackGasPriceMinimum, nil
 		}
 		if err != nil {
@@ -111,21 +72,15 @@ func GetGasPriceMinimum(currency *common.Address, header *types.Header, state vm
 	}
 
 	var gasPriceMinimum *big.Int
-	_, err = contract_comm.MakeStaticCall(
-		params.GasPriceMinimumRegistryId,
-		gasPriceMinimumABI,
-		"getGasPriceMinimum",
-		[]interface{}{currencyAddress},
-		&gasPriceMinimum,
-		params.MaxGasForGetGasPriceMinimum,
-		header,
-		state,
-	)
+	gasPriceMinimumContract, err := newGasPriceMinimum(caller.Backend(header, state))
+	if err == nil {
+		gasPriceMinimum, err = gasPriceMinimumContract.GetGasPriceMinimum(contract_comm.CallOpts(params.MaxGasForGetGasPriceMinimum), *currencyAddress)
+	}
 
 	if err == errors.ErrSmartContractNotDeployed || err == errors.ErrRegistryContractNotDeployed {
 		return FallbackGasPriceMinimum, nil
 	}
-	if err == errors.ErrNoInternalEvmHandlerSingleton {
+	if err == errors.ErrNoSystemCaller {
 		return FallbackGasPriceMinimum, nil
 	}
 	if err != nil {
@@ -135,27 +90,22 @@ func GetGasPriceMinimum(currency *common.Address, header *types.Header, state vm
 	return gasPriceMinimum, err
 }
 
-func UpdateGasPriceMinimum(header *types.Header, state vm.StateDB) (*big.Int, error) {
+func UpdateGasPriceMinimum(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (*big.Int, error) {
 	var updatedGasPriceMinimum *big.Int
 
 	// If an error occurs, the default block gas limit will be returned and a log statement will be produced by contract_comm
-	gasLimit, _ := blockchain_parameters.GetBlockGasLimit(header, state)
+	gasLimit, _ := blockchain_parameters.GetBlockGasLimit(caller, header, state)
 
-	_, err := contract_comm.MakeCall(
-		params.GasPriceMinimumRegistryId,
-		gasPriceMinimumABI,
-		"updateGasPriceMinimum",
-		[]interface{}{big.NewInt(int64(header.GasUsed)),
-			big.NewInt(int64(gasLimit))},
-		&updatedGasPriceMinimum,
-		params.MaxGasForUpdateGasPriceMinimum,
-		big.NewInt(0),
-		header,
-		state,
-		false,
-	)
+	backend := caller.Backend(header, state)
+	gasPriceMinimumContract, err := newGasPriceMinimum(backend)
+	if err != nil {
+		return nil, err
+	}
+	_, err = gasPriceMinimumContract.UpdateGasPriceMinimum(contract_comm.TransactOpts(params.MaxGasForUpdateGasPriceMinimum), big.NewInt(int64(header.GasUsed)), big.NewInt(int64(gasLimit)))
 	if err != nil {
 		return nil, err
 	}
+	// The transactor binding does not surface return values, so unpack the new minimum from the call output.
+	err = gasPriceMinimumABI.Unpack(&updatedGasPriceMinimum, "updateGasPriceMinimum", backend.Output())
 	return updatedGasPriceMinimum, err
 }
@@ -2,140 +2,50 @@ package gold_token
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/GoldToken.json
-	increaseSupplyABI = `[{
-		"constant": false,
-		"inputs": [
-		  {
-			"name": "amount",
-			"type": "uint256"
-		  }
-		],
-		"name": "increaseSupply",
-		"outputs": [],
-		"payable": false,
-		"stateMutability": "nonpayable",
-		"type": "function"
-		}]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/GoldToken.json
-	mintABI = `[{
-		"constant": false,
-		"inputs": [
-			{
-				"name": "to",
-				"type": "address"
-			},
-			{
-				"name": "value",
-				"type": "uint256"
-			}
-		],
-		"name": "mint",
-		"outputs": [
-			{
-				"name": "",
-				"type": "bool"
-			}
-		],
-		"payable": false,
-		"stateMutability": "nonpayable",
-		"type": "function"
-	}]`
-
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/GoldToken.json
-	totalSupplyABI = `[{
-		"constant": true,
-		"inputs": [],
-		"name": "totalSupply",
-		"outputs": [
-		  {
-			"name": "",
-			"type": "uint256"
-		  }
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	  }]`
-)
-
-var mintFuncABI, totalSupplyFuncABI, increaseSupplyFuncABI abi.ABI
-
-func init() {
-	var err error
-	increaseSupplyFuncABI, err = abi.JSON(strings.NewReader(increaseSupplyABI))
-	if err != nil {
-		panic(err)
-	}
-	mintFuncABI, err = abi.JSON(strings.NewReader(mintABI))
+// newGoldToken binds the GoldToken contract registered in the backend's state.
+func newGoldToken(backend *contract_comm.SystemBackend) (*bindings.GoldToken, error) {
+	address, err := backend.RegisteredAddress(params.GoldTokenRegistryId)
 	if err != nil {
-		panic(err)
-	}
-	totalSupplyFuncABI, err = abi.JSON(strings.NewReader(totalSupplyABI))
-	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	return bindings.NewGoldToken(address, backend)
 }
 
-func GetTotalSupply(header *types.Header, state vm.StateDB) (*big.Int, error) {
-	var totalSupply *big.Int
-	_, err := contract_comm.MakeStaticCall(
-		params.GoldTokenRegistryId,
-		totalSupplyFuncABI,
-		"totalSupply",
-		[]interface{}{},
-		&totalSupply,
-		params.MaxGasForTotalSupply,
-		header,
-		state,
-	)
-	return totalSupply, err
+func GetTotalSupply(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (*big.Int, error) {
+	goldToken, err := newGoldToken(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
+	return goldToken.TotalSupply(contract_comm.CallOpts(params.MaxGasForTotalSupply))
 }
 
-func IncreaseSupply(header *types.Header, state vm.StateDB, value *big.Int) error {
-	_, err := contract_comm.MakeCall(
-		params.GoldTokenRegistryId,
-		increaseSupplyFuncABI,
-		"increaseSupply",
-		[]interface{}{value},
-		nil,
-		params.MaxGasForIncreaseSupply,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+func IncreaseSupply(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, value *big.Int) error {
+	goldToken, err := newGoldToken(caller.Backend(header, state))
+	if err != nil {
+		return err
+	}
+	_, err = goldToken.IncreaseSupply(contract_comm.TransactOpts(params.MaxGasForIncreaseSupply), value)
 	return err
 }
 
-func Mint(header *types.Header, state vm.StateDB, benficiary common.Address, value *big.Int) error {
+func Mint(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, benficiary common.Address, value *big.Int) error {
 	if value.Cmp(new(big.Int)) <= 0 {
 		return nil
 	}
 
-	_, err := contract_comm.MakeCall(
-		params.GoldTokenRegistryId,
-		mintFuncABI,
-		"mint",
-		[]interface{}{benficiary, value},
-		nil,
-		params.MaxGasForMintGas,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+	goldToken, err := newGoldToken(caller.Backend(header, state))
+	if err != nil {
+		return err
+	}
+	_, err = goldToken.Mint(contract_comm.TransactOpts(params.MaxGasForMintGas), benficiary, value)
 	return err
 }
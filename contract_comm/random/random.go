@@ -2,13 +2,12 @@ package random
 
 import (
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -18,130 +17,25 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Random.json
-	revealAndCommitABI = `[
-	{
-		"constant": false,
-		"inputs": [
-			{
-				"name": "randomness",
-				"type": "bytes32"
-			},
-			{
-				"name": "newCommitment",
-				"type": "bytes32"
-			},
-			{
-				"name": "proposer",
-				"type": "address"
-			}
-		],
-		"name": "revealAndCommit",
-		"outputs": [],
-		"payable": false,
-		"stateMutability": "nonpayable",
-		"type": "function"
-	}
-]`
-	commitmentsAbi = `[
-	{
-		"constant": true,
-		"inputs": [
-			{
-				"name": "",
-				"type": "address"
-			}
-		],
-		"name": "commitments",
-		"outputs": [
-			{
-				"name": "",
-				"type": "bytes32"
-			}
-		],
-		"payable": false,
-		"stateMutability": "view",
-		"type": "function"
-	}
-]`
-	computeCommitmentAbi = `[
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "randomness",
-          "type": "bytes32"
-        }
-      ],
-      "name": "computeCommitment",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-	randomAbi = `[
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "random",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-
-	historicRandomAbi = `[
-    {
-      "constant": true,
-      "inputs": [
-		{
-			"name": "blockNumber",
-			"type": "uint256"
-		}
-	  ],
-      "name": "getBlockRandomness",
-      "outputs": [
-        {
-          "name": "",
-          "type": "bytes32"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-)
-
 var (
-	revealAndCommitFuncABI, _   = abi.JSON(strings.NewReader(revealAndCommitABI))
-	commitmentsFuncABI, _       = abi.JSON(strings.NewReader(commitmentsAbi))
-	computeCommitmentFuncABI, _ = abi.JSON(strings.NewReader(computeCommitmentAbi))
-	randomFuncABI, _            = abi.JSON(strings.NewReader(randomAbi))
-	historicRandomFuncABI, _    = abi.JSON(strings.NewReader(historicRandomAbi))
-	zeroValue                   = common.Big0
-	dbRandomnessPrefix          = []byte("db-randomness-prefix")
+	dbRandomnessPrefix = []byte("db-randomness-prefix")
 )
 
+// newRandom binds the Random contract registered in the backend's state.
+func newRandom(backend *contract_comm.SystemBackend) (*bindings.Random, error) {
+	address, err := backend.RegisteredAddress(params.RandomRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewRandom(address, backend)
+}
+
 func commitmentDbLocation(commitment common.Hash) []byte {
 	return append(dbRandomnessPrefix, commitment.Bytes()...)
 }
 
-func address() *common.Address {
-	randomAddress, err := contract_comm.GetRegisteredAddress(params.RandomRegistryId, nil, nil)
+func address(caller *contract_comm.SystemCaller) *common.Address {
+	randomAddress, err := caller.GetRegisteredAddress(params.RandomRegistryId, nil, nil)
 	if err == errors.ErrSmartContractNotDeployed || err == errors.ErrRegistryContractNotDeployed {
 		log.Debug("Registry address lookup failed", "err", err, "contract", hexutil.Encode(params.RandomRegistryId[:]))
 	} else if err != nil {
@@ -150,8 +44,8 @@ func address() *common.Address {
 	return randomAddress
 }
 
-func IsRunning() bool {
-	randomAddress := address()
+func IsRunning(caller *contract_comm.SystemCaller) bool {
+	randomAddress := address(caller)
 	return randomAddress != nil && *randomAddress != common.ZeroAddress
 }
 
@@ -159,9 +53,12 @@ func IsRunning() bool {
 // looking up our last commitment in the smart contract, and then finding the
 // corresponding preimage in a (commitment => randomness) mapping we keep in the
 // database.
-func GetLastRandomness(coinbase common.Address, db *ethdb.Database, header *types.Header, state vm.StateDB, chain consensus.ChainReader, seed []byte) (common.Hash, error) {
+func GetLastRandomness(caller *contract_comm.SystemCaller, coinbase common.Address, db *ethdb.Database, header *types.Header, state vm.StateDB, chain consensus.ChainReader, seed []byte) (common.Hash, error) {
 	lastCommitment := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, commitmentsFuncABI, "commitments", []interface{}{coinbase}, &lastCommitment, params.MaxGasForCommitments, header, state)
+	random, err := newRandom(caller.Backend(header, state))
+	if err == nil {
+		lastCommitment, err = random.Commitments(contract_comm.CallOpts(params.MaxGasForCommitments), coinbase)
+	}
 	if err != nil {
 		log.Error("Failed to get last commitment", "err", err)
 		return lastCommitment, err
@@ -190,11 +87,14 @@ func GetLastRandomness(coinbase common.Address, db *ethdb.Database, header *type
 
 // GenerateNewRandomnessAndCommitment generates a new random number and a corresponding commitment.
 // The random number is stored in the database, keyed by the corresponding commitment.
-func GenerateNewRandomnessAndCommitment(header *types.Header, state vm.StateDB, db *ethdb.Database, seed []byte) (common.Hash, error) {
+func GenerateNewRandomnessAndCommitment(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, db *ethdb.Database, seed []byte) (common.Hash, error) {
 	commitment := common.Hash{}
 	randomness := crypto.Keccak256Hash(append(seed, header.ParentHash.Bytes()...))
 	// TODO(asa): Make an issue to not have to do this via StaticCall
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, computeCommitmentFuncABI, "computeCommitment", []interface{}{randomness}, &commitment, params.MaxGasForComputeCommitment, header, state)
+	random, err := newRandom(caller.Backend(header, state))
+	if err == nil {
+		commitment, err = random.ComputeCommitment(contract_comm.CallOpts(params.MaxGasForComputeCommitment), randomness)
+	}
 	if err != nil {
 		log.Error("Failed to call computeCommitment()", "err", err)
 		return common.Hash{}, err
@@ -210,22 +110,33 @@ func GenerateNewRandomnessAndCommitment(header *types.Header, state vm.StateDB,
 // RevealAndCommit performs an internal call to the EVM that reveals a
 // proposer's previously committed to randomness, and commits new randomness for
 // a future block.
-func RevealAndCommit(randomness, newCommitment common.Hash, proposer common.Address, header *types.Header, state vm.StateDB) error {
-	args := []interface{}{randomness, newCommitment, proposer}
+func RevealAndCommit(caller *contract_comm.SystemCaller, randomness, newCommitment common.Hash, proposer common.Address, header *types.Header, state vm.StateDB) error {
 	log.Trace("Revealing and committing randomness", "randomness", randomness.Hex(), "commitment", newCommitment.Hex())
-	_, err := contract_comm.MakeCall(params.RandomRegistryId, revealAndCommitFuncABI, "revealAndCommit", args, nil, params.MaxGasForRevealAndCommit, zeroValue, header, state, true)
-	return err
+	random, err := newRandom(caller.Backend(header, state))
+	if err != nil {
+		return err
+	}
+	_, err = random.RevealAndCommit(contract_comm.TransactOpts(params.MaxGasForRevealAndCommit), randomness, newCommitment, proposer)
+	if err != nil {
+		return err
+	}
+	state.Finalise(true)
+	return nil
 }
 
 // Random performs an internal call to the EVM to retrieve the current randomness from the official Random contract.
-func Random(header *types.Header, state vm.StateDB) (common.Hash, error) {
-	randomness := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, randomFuncABI, "random", []interface{}{}, &randomness, params.MaxGasForBlockRandomness, header, state)
-	return randomness, err
+func Random(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) (common.Hash, error) {
+	random, err := newRandom(caller.Backend(header, state))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return random.Random(contract_comm.CallOpts(params.MaxGasForBlockRandomness))
 }
 
-func BlockRandomness(header *types.Header, state vm.StateDB, blockNumber uint64) (common.Hash, error) {
-	randomness := common.Hash{}
-	_, err := contract_comm.MakeStaticCall(params.RandomRegistryId, historicRandomFuncABI, "getBlockRandomness", []interface{}{big.NewInt(int64(blockNumber))}, &randomness, params.MaxGasForBlockRandomness, header, state)
-	return randomness, err
+func BlockRandomness(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, blockNumber uint64) (common.Hash, error) {
+	random, err := newRandom(caller.Backend(header, state))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return random.GetBlockRandomness(contract_comm.CallOpts(params.MaxGasForBlockRandomness), big.NewInt(int64(blockNumber)))
 }
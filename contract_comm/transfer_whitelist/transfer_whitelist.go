@@ -17,11 +17,9 @@
 package transfer_whitelist
 
 import (
-	"strings"
-
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -29,32 +27,22 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const (
-	// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/TransferWhitelist.json
-	getWhitelistABI = `[{
-      "constant": true,
-      "inputs": [],
-      "name": "getWhitelist",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }]`
-)
-
-var (
-	getWhitelistFuncABI, _ = abi.JSON(strings.NewReader(getWhitelistABI))
-)
+// newTransferWhitelist binds the TransferWhitelist contract registered in the backend's state.
+func newTransferWhitelist(backend *contract_comm.SystemBackend) (*bindings.TransferWhitelistCaller, error) {
+	address, err := backend.RegisteredAddress(params.TransferWhitelistRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewTransferWhitelistCaller(address, backend)
+}
 
-func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
+func retrieveWhitelist(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	transferWhitelist, err := newTransferWhitelist(caller.Backend(header, state))
 	var whitelist []common.Address
-
-	if _, err := contract_comm.MakeStaticCall(params.TransferWhitelistRegistryId, getWhitelistFuncABI, "getWhitelist", []interface{}{}, &whitelist, params.MaxGasForGetTransferWhitelist, header, state); err != nil {
+	if err == nil {
+		whitelist, err = transferWhitelist.GetWhitelist(contract_comm.CallOpts(params.MaxGasForGetTransferWhitelist))
+	}
+	if err != nil {
 		if err == errors.ErrSmartContractNotDeployed {
 			log.Warn("Registry address lookup failed", "err", err)
 		} else {
@@ -67,8 +55,8 @@ func retrieveWhitelist(header *types.Header, state vm.StateDB) ([]common.Address
 	return whitelist, nil
 }
 
-func IsWhitelisted(to common.Address, from common.Address, header *types.Header, state vm.StateDB) bool {
-	whitelist, err := retrieveWhitelist(header, state)
+func IsWhitelisted(caller *contract_comm.SystemCaller, to common.Address, from common.Address, header *types.Header, state vm.StateDB) bool {
+	whitelist, err := retrieveWhitelist(caller, header, state)
 	if err != nil {
 		log.Warn("Failed to get transfer whitelist", "err", err)
 		return true
@@ -85,8 +73,8 @@ func containsAddress(target common.Address, whitelist []common.Address) bool {
 	return false
 }
 
-func GetWhitelist(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	whitelist, err := retrieveWhitelist(header, state)
+func GetWhitelist(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	whitelist, err := retrieveWhitelist(caller, header, state)
 	if err != nil {
 		log.Warn("Failed to get transfer whitelist", "err", err)
 	}
@@ -24,158 +24,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/contract_comm"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// This is taken from celo-monorepo/packages/protocol/build/<env>/contracts/Validators.json
-const validatorsABIString string = `[
-    {
-        "constant": true,
-        "inputs": [],
-        "name": "getRegisteredValidatorSigners",
-        "outputs": [
-        {
-            "name": "",
-            "type": "address[]"
-        }
-        ],
-        "payable": false,
-        "stateMutability": "view",
-        "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [],
-      "name": "getRegisteredValidators",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address[]"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "signer",
-          "type": "address"
-        }
-      ],
-      "name": "getValidatorBlsPublicKeyFromSigner",
-      "outputs": [
-        {
-          "name": "blsKey",
-          "type": "bytes"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-	  {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "account",
-          "type": "address"
-        }
-      ],
-      "name": "getValidator",
-      "outputs": [
-        {
-          "name": "ecdsaPublicKey",
-          "type": "bytes"
-        },
-        {
-          "name": "blsPublicKey",
-          "type": "bytes"
-        },
-        {
-          "name": "affiliation",
-          "type": "address"
-        },
-        {
-          "name": "score",
-          "type": "uint256"
-        },
-        {
-          "name": "signer",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "validator",
-          "type": "address"
-        },
-        {
-          "name": "maxPayment",
-          "type": "uint256"
-        }
-      ],
-      "name": "distributeEpochPaymentsFromSigner",
-      "outputs": [
-        {
-          "name": "",
-          "type": "uint256"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": false,
-      "inputs": [
-        {
-          "name": "validator",
-          "type": "address"
-        },
-        {
-          "name": "uptime",
-          "type": "uint256"
-        }
-      ],
-      "name": "updateValidatorScoreFromSigner",
-      "outputs": [],
-      "payable": false,
-      "stateMutability": "nonpayable",
-      "type": "function"
-    },
-    {
-      "constant": true,
-      "inputs": [
-        {
-          "name": "account",
-          "type": "address"
-        }
-      ],
-      "name": "getMembershipInLastEpochFromSigner",
-      "outputs": [
-        {
-          "name": "",
-          "type": "address"
-        }
-      ],
-      "payable": false,
-      "stateMutability": "view",
-      "type": "function"
-    }
-]`
-
 type ValidatorContractData struct {
 	EcdsaPublicKey []byte
 	BlsPublicKey   []byte
@@ -184,42 +39,55 @@ type ValidatorContractData struct {
 	Signer         common.Address
 }
 
-var validatorsABI, _ = abi.JSON(strings.NewReader(validatorsABIString))
+var validatorsABI, _ = abi.JSON(strings.NewReader(bindings.ValidatorsABI))
+
+// newValidators binds the Validators contract registered in the backend's state.
+func newValidators(backend *contract_comm.SystemBackend) (*bindings.Validators, error) {
+	address, err := backend.RegisteredAddress(params.ValidatorsRegistryId)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.NewValidators(address, backend)
+}
 
-func RetrieveRegisteredValidatorSigners(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var regVals []common.Address
+func RetrieveRegisteredValidatorSigners(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the new epoch's validator signer set
-	if _, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getRegisteredValidatorSigners", []interface{}{}, &regVals, params.MaxGasForGetRegisteredValidators, header, state); err != nil {
+	regVals, err := validators.GetRegisteredValidatorSigners(contract_comm.CallOpts(params.MaxGasForGetRegisteredValidators))
+	if err != nil {
 		return nil, err
 	}
 
 	return regVals, nil
 }
 
-func RetrieveRegisteredValidators(header *types.Header, state vm.StateDB) ([]common.Address, error) {
-	var regVals []common.Address
+func RetrieveRegisteredValidators(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB) ([]common.Address, error) {
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the new epoch's validator set
-	if _, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getRegisteredValidators", []interface{}{}, &regVals, params.MaxGasForGetRegisteredValidators, header, state); err != nil {
+	regVals, err := validators.GetRegisteredValidators(contract_comm.CallOpts(params.MaxGasForGetRegisteredValidators))
+	if err != nil {
 		return nil, err
 	}
 
 	return regVals, nil
 }
 
-func GetValidator(header *types.Header, state vm.StateDB, validatorAddress common.Address) (ValidatorContractData, error) {
+func GetValidator(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, validatorAddress common.Address) (ValidatorContractData, error) {
 	var validator ValidatorContractData
-	_, err := contract_comm.MakeStaticCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"getValidator",
-		[]interface{}{validatorAddress},
-		&validator,
-		params.MaxGasForGetValidator,
-		header,
-		state,
-	)
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return validator, err
+	}
+	data, err := validators.GetValidator(contract_comm.CallOpts(params.MaxGasForGetValidator), validatorAddress)
+	validator = ValidatorContractData(data)
 	if err != nil {
 		return validator, err
 	}
@@ -229,11 +97,15 @@ func GetValidator(header *types.Header, state vm.StateDB, validatorAddress commo
 	return validator, nil
 }
 
-func GetValidatorData(header *types.Header, state vm.StateDB, validatorAddresses []common.Address) ([]istanbul.ValidatorData, error) {
+func GetValidatorData(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, validatorAddresses []common.Address) ([]istanbul.ValidatorData, error) {
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return nil, err
+	}
+
 	var validatorData []istanbul.ValidatorData
 	for _, addr := range validatorAddresses {
-		var blsKey []byte
-		_, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getValidatorBlsPublicKeyFromSigner", []interface{}{addr}, &blsKey, params.MaxGasForGetValidator, header, state)
+		blsKey, err := validators.GetValidatorBlsPublicKeyFromSigner(contract_comm.CallOpts(params.MaxGasForGetValidator), addr)
 		if err != nil {
 			return nil, err
 		}
@@ -252,42 +124,37 @@ func GetValidatorData(header *types.Header, state vm.StateDB, validatorAddresses
 	return validatorData, nil
 }
 
-func UpdateValidatorScore(header *types.Header, state vm.StateDB, address common.Address, uptime *big.Int) error {
-	_, err := contract_comm.MakeCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"updateValidatorScoreFromSigner",
-		[]interface{}{address, uptime},
-		nil,
-		params.MaxGasForUpdateValidatorScore,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+func UpdateValidatorScore(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, address common.Address, uptime *big.Int) error {
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return err
+	}
+	_, err = validators.UpdateValidatorScoreFromSigner(contract_comm.TransactOpts(params.MaxGasForUpdateValidatorScore), address, uptime)
 	return err
 }
 
-func DistributeEpochReward(header *types.Header, state vm.StateDB, address common.Address, maxReward *big.Int) (*big.Int, error) {
+func DistributeEpochReward(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, address common.Address, maxReward *big.Int) (*big.Int, error) {
 	var epochReward *big.Int
-	_, err := contract_comm.MakeCall(
-		params.ValidatorsRegistryId,
-		validatorsABI,
-		"distributeEpochPaymentsFromSigner",
-		[]interface{}{address, maxReward},
-		&epochReward,
-		params.MaxGasForDistributeEpochPayment,
-		common.Big0,
-		header,
-		state,
-		false,
-	)
+	backend := caller.Backend(header, state)
+	validators, err := newValidators(backend)
+	if err != nil {
+		return nil, err
+	}
+	_, err = validators.DistributeEpochPaymentsFromSigner(contract_comm.TransactOpts(params.MaxGasForDistributeEpochPayment), address, maxReward)
+	if err != nil {
+		return nil, err
+	}
+	// The transactor binding does not surface return values, so unpack the payment from the call output.
+	err = validatorsABI.Unpack(&epochReward, "distributeEpochPaymentsFromSigner", backend.Output())
 	return epochReward, err
 }
 
-func GetMembershipInLastEpoch(header *types.Header, state vm.StateDB, validator common.Address) (common.Address, error) {
-	var group common.Address
-	_, err := contract_comm.MakeStaticCall(params.ValidatorsRegistryId, validatorsABI, "getMembershipInLastEpochFromSigner", []interface{}{validator}, &group, params.MaxGasForGetMembershipInLastEpoch, header, state)
+func GetMembershipInLastEpoch(caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, validator common.Address) (common.Address, error) {
+	validators, err := newValidators(caller.Backend(header, state))
+	if err != nil {
+		return common.ZeroAddress, err
+	}
+	group, err := validators.GetMembershipInLastEpochFromSigner(contract_comm.CallOpts(params.MaxGasForGetMembershipInLastEpoch), validator)
 	if err != nil {
 		return common.ZeroAddress, err
 	}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -99,10 +100,10 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 // to keep the baseline gas above the provided floor, and increase it towards the
 // ceil if the blocks are full. If the ceil is exceeded, it will always decrease
 // the gas allowance.
-func CalcGasLimit(parent *types.Block, statedb *state.StateDB) uint64 {
+func CalcGasLimit(caller *contract_comm.SystemCaller, parent *types.Block, statedb *state.StateDB) uint64 {
 	header := parent.Header()
 
-	limit, err := blockchain_parameters.GetBlockGasLimit(header, statedb)
+	limit, err := blockchain_parameters.GetBlockGasLimit(caller, header, statedb)
 
 	// Already logged a warning in GetBlockGasLimit, just return.
 	if err != nil {
@@ -231,7 +231,7 @@ func testBlockChainImport(chain types.Blocks, blockchain *BlockChain) error {
 			}
 			return err
 		}
-		statedb, err := state.New(blockchain.GetBlockByHash(block.ParentHash()).Root(), blockchain.stateCache)
+		statedb, err := state.New(blockchain.GetBlockByHash(block.ParentHash()).Root(), blockchain.stateCache, nil)
 		if err != nil {
 			return err
 		}
@@ -2317,3 +2317,75 @@ func TestDeleteCreateRevert(t *testing.T) {
 		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
 	}
 }
+
+// Tests that with the state snapshot enabled, imported blocks are tracked by the
+// snapshot tree and state reads served from it match the state trie.
+func TestSnapshotStateReads(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{address: {Balance: funds}},
+		}
+		genesis = gspec.MustCommit(db)
+		signer  = types.HomesteadSigner{}
+	)
+	cacheConfig := &CacheConfig{
+		TrieCleanLimit: 256,
+		TrieDirtyLimit: 256,
+		TrieTimeLimit:  5 * time.Minute,
+		SnapshotLimit:  16,
+		SnapshotWait:   true,
+	}
+	blockchain, err := NewBlockChain(db, cacheConfig, gspec.Config, mockEngine.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	recipients := make([]common.Address, 4)
+	for i := range recipients {
+		recipients[i] = common.Address{byte(i + 1)}
+	}
+	blocks, _ := GenerateChain(gspec.Config, genesis, mockEngine.NewFaker(), db, 8, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), recipients[i%len(recipients)], big.NewInt(int64(i+1)), 21000, new(big.Int), nil, nil, nil, nil), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.AddTx(tx)
+	})
+	if n, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	head := blockchain.CurrentBlock()
+	snap := blockchain.Snapshot().Snapshot(head.Root())
+	if snap == nil {
+		t.Fatalf("snapshot missing for head %x", head.Root())
+	}
+	snapState, err := blockchain.StateAt(head.Root())
+	if err != nil {
+		t.Fatalf("failed to open snapshot backed state: %v", err)
+	}
+	trieState, err := state.New(head.Root(), state.NewDatabase(db), nil)
+	if err != nil {
+		t.Fatalf("failed to open trie backed state: %v", err)
+	}
+	for _, addr := range append(recipients, address) {
+		if have, want := snapState.GetBalance(addr), trieState.GetBalance(addr); have.Cmp(want) != 0 {
+			t.Errorf("balance mismatch for %x: have %v, want %v", addr, have, want)
+		}
+		if have, want := snapState.GetNonce(addr), trieState.GetNonce(addr); have != want {
+			t.Errorf("nonce mismatch for %x: have %d, want %d", addr, have, want)
+		}
+		acc, err := snap.Account(crypto.Keccak256Hash(addr[:]))
+		if err != nil || acc == nil {
+			t.Fatalf("account %x missing from snapshot: %v", addr, err)
+		}
+		if want := trieState.GetBalance(addr); acc.Balance.Cmp(want) != 0 {
+			t.Errorf("snapshot balance mismatch for %x: have %v, want %v", addr, acc.Balance, want)
+		}
+	}
+}
@@ -429,6 +429,61 @@ func DeleteAccumulatedEpochUptime(db ethdb.KeyValueWriter, epoch uint64) {
 	}
 }
 
+// ReadParentSealBitmap retrieves the bitmap of the validators that signed the parent of the
+// specified block, as indexed from the block's parent aggregated seal
+func ReadParentSealBitmap(db ethdb.Reader, hash common.Hash, number uint64) *big.Int {
+	data, _ := db.Get(parentSealBitmapKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// WriteParentSealBitmap stores the bitmap of the validators that signed the parent of the
+// specified block
+func WriteParentSealBitmap(db ethdb.KeyValueWriter, hash common.Hash, number uint64, bitmap *big.Int) {
+	// An empty bitmap is stored as a single zero byte, so that it can be told apart from a missing entry
+	data := bitmap.Bytes()
+	if len(data) == 0 {
+		data = []byte{0}
+	}
+	if err := db.Put(parentSealBitmapKey(number, hash), data); err != nil {
+		log.Crit("Failed to store parent seal bitmap", "err", err)
+	}
+}
+
+// DeleteParentSealBitmap removes the parent seal bitmap of the specified block
+func DeleteParentSealBitmap(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(parentSealBitmapKey(number, hash)); err != nil {
+		log.Crit("Failed to delete parent seal bitmap", "err", err)
+	}
+}
+
+// ReadEpochRewards retrieves the breakdown of the epoch rewards distributed by the specified block
+func ReadEpochRewards(db ethdb.Reader, hash common.Hash, number uint64) *istanbul.EpochRewards {
+	data, _ := db.Get(epochRewardsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	rewards := new(istanbul.EpochRewards)
+	if err := rlp.Decode(bytes.NewReader(data), rewards); err != nil {
+		log.Error("Invalid epoch rewards RLP", "err", err)
+		return nil
+	}
+	return rewards
+}
+
+// WriteEpochRewards stores the breakdown of the epoch rewards distributed by the specified block
+func WriteEpochRewards(db ethdb.KeyValueWriter, hash common.Hash, number uint64, rewards *istanbul.EpochRewards) {
+	data, err := rlp.EncodeToBytes(rewards)
+	if err != nil {
+		log.Crit("Failed to RLP encode epoch rewards", "err", err)
+	}
+	if err := db.Put(epochRewardsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store epoch rewards", "err", err)
+	}
+}
+
 // WriteTd stores the total difficulty of a block into the database.
 func WriteTd(db ethdb.KeyValueWriter, hash common.Hash, number uint64, td *big.Int) {
 	data, err := rlp.EncodeToBytes(td)
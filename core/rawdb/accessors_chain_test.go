@@ -227,6 +227,56 @@ func TestUptimeStorage(t *testing.T) {
 	}
 }
 
+// Tests parent seal bitmap index storage and retrieval operations.
+func TestParentSealBitmapStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	hash, number := common.HexToHash("0x1"), uint64(7)
+
+	if entry := ReadParentSealBitmap(db, hash, number); entry != nil {
+		t.Fatalf("Non existent bitmap returned: %v", entry)
+	}
+	// Write and verify the bitmaps in the database, including an empty one
+	for _, bitmap := range []*big.Int{big.NewInt(0), big.NewInt(0x2d)} {
+		WriteParentSealBitmap(db, hash, number, bitmap)
+		if entry := ReadParentSealBitmap(db, hash, number); entry == nil {
+			t.Fatalf("Stored bitmap not found")
+		} else if entry.Cmp(bitmap) != 0 {
+			t.Fatalf("Retrieved bitmap mismatch: have %v, want %v", entry, bitmap)
+		}
+	}
+	// Delete the bitmap and verify the execution
+	DeleteParentSealBitmap(db, hash, number)
+	if entry := ReadParentSealBitmap(db, hash, number); entry != nil {
+		t.Fatalf("Deleted bitmap returned: %v", entry)
+	}
+}
+
+func TestEpochRewardsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	hash, number := common.HexToHash("0x1"), uint64(720)
+
+	if entry := ReadEpochRewards(db, hash, number); entry != nil {
+		t.Fatalf("Non existent epoch rewards returned: %v", entry)
+	}
+	rewards := &istanbul.EpochRewards{
+		Epoch: 1,
+		Validators: []istanbul.ValidatorEpochReward{
+			{Validator: common.HexToAddress("0x2"), Group: common.HexToAddress("0x3"), Uptime: big.NewInt(99), Payment: big.NewInt(1000)},
+		},
+		Groups: []istanbul.GroupEpochReward{{Group: common.HexToAddress("0x3"), Reward: big.NewInt(500)}},
+		Mints:  []istanbul.EpochRewardMint{{Beneficiary: common.HexToAddress("0x4"), Purpose: istanbul.VoterRewardsMint, Amount: big.NewInt(500)}},
+	}
+	WriteEpochRewards(db, hash, number, rewards)
+	if entry := ReadEpochRewards(db, hash, number); entry == nil {
+		t.Fatalf("Stored epoch rewards not found")
+	} else if !reflect.DeepEqual(entry, rewards) {
+		t.Fatalf("Retrieved epoch rewards mismatch: have %v, want %v", entry, rewards)
+	}
+	if entry := ReadEpochRewards(db, common.HexToHash("0x2"), number); entry != nil {
+		t.Fatalf("Epoch rewards returned for another block: %v", entry)
+	}
+}
+
 // Tests block total difficulty storage and retrieval operations.
 func TestTdStorage(t *testing.T) {
 	db := NewMemoryDatabase()
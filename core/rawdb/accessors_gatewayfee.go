@@ -0,0 +1,52 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadGatewayFeeClientEarnings retrieves the gateway fees a light server earned from the
+// transactions relayed for a client.
+func ReadGatewayFeeClientEarnings(db ethdb.KeyValueReader, client string) []byte {
+	data, _ := db.Get(gatewayFeeClientKey(client))
+	return data
+}
+
+// WriteGatewayFeeClientEarnings stores the gateway fees a light server earned from the
+// transactions relayed for a client.
+func WriteGatewayFeeClientEarnings(db ethdb.KeyValueWriter, client string, earnings []byte) {
+	if err := db.Put(gatewayFeeClientKey(client), earnings); err != nil {
+		log.Crit("Failed to store gateway fee client earnings", "err", err)
+	}
+}
+
+// ReadGatewayFeeDayEarnings retrieves the gateway fees a light server earned from the
+// relayed transactions mined during a UTC day.
+func ReadGatewayFeeDayEarnings(db ethdb.KeyValueReader, day string) []byte {
+	data, _ := db.Get(gatewayFeeDayKey(day))
+	return data
+}
+
+// WriteGatewayFeeDayEarnings stores the gateway fees a light server earned from the
+// relayed transactions mined during a UTC day.
+func WriteGatewayFeeDayEarnings(db ethdb.KeyValueWriter, day string, earnings []byte) {
+	if err := db.Put(gatewayFeeDayKey(day), earnings); err != nil {
+		log.Crit("Failed to store gateway fee day earnings", "err", err)
+	}
+}
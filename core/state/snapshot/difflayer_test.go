@@ -0,0 +1,228 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that merging something into a disk layer persists it into the database
+// and invalidates any previously written and cached values, and that deleted
+// accounts drop their storage along with them.
+func TestDiffToDisk(t *testing.T) {
+	var (
+		accOne   = common.HexToHash("0xa1")
+		accTwo   = common.HexToHash("0xa2")
+		slotOne  = common.HexToHash("0xb1")
+		slotTwo  = common.HexToHash("0xb2")
+		slotData = []byte{0x01}
+	)
+	base := newTestDiskLayer()
+
+	first := base.Update(common.HexToHash("0x02"), nil, randomAccountSet("0xa1", "0xa2"), map[common.Hash]map[common.Hash][]byte{
+		accOne: {slotOne: slotData},
+		accTwo: {slotTwo: slotData},
+	})
+	second := first.Update(common.HexToHash("0x03"), map[common.Hash]struct{}{accTwo: {}}, map[common.Hash][]byte{accTwo: nil}, nil)
+
+	disk := diffToDisk(second.flatten().(*diffLayer))
+	if !base.Stale() {
+		t.Fatalf("original disk layer not marked stale")
+	}
+	if disk.Root() != second.Root() {
+		t.Fatalf("disk layer root mismatch: have %x, want %x", disk.Root(), second.Root())
+	}
+	if blob, _ := disk.AccountRLP(accOne); !bytes.Equal(blob, first.accountData[accOne]) {
+		t.Errorf("live account mismatch: have %x, want %x", blob, first.accountData[accOne])
+	}
+	if blob, _ := disk.Storage(accOne, slotOne); !bytes.Equal(blob, slotData) {
+		t.Errorf("live slot mismatch: have %x, want %x", blob, slotData)
+	}
+	if blob, _ := disk.AccountRLP(accTwo); len(blob) != 0 {
+		t.Errorf("deleted account still present: %x", blob)
+	}
+	if blob, _ := disk.Storage(accTwo, slotTwo); len(blob) != 0 {
+		t.Errorf("deleted account storage still present: %x", blob)
+	}
+}
+
+// Tests that flattening diff layers merges account, storage and deletion data
+// correctly, with the most recent layer taking precedence.
+func TestMergeBasics(t *testing.T) {
+	var (
+		destructs = make(map[common.Hash]struct{})
+		accounts  = make(map[common.Hash][]byte)
+		storage   = make(map[common.Hash]map[common.Hash][]byte)
+	)
+	// Fill up a parent
+	for i := 0; i < 100; i++ {
+		h := randomHash()
+		data := randomAccount()
+
+		accounts[h] = data
+		if i%4 == 0 {
+			destructs[h] = struct{}{}
+		}
+		if i%2 == 0 {
+			accStorage := make(map[common.Hash][]byte)
+			value := make([]byte, 32)
+			copy(value, randomHash().Bytes())
+			accStorage[randomHash()] = value
+			storage[h] = accStorage
+		}
+	}
+	// Add some (identical) layers on top
+	parent := newDiffLayer(newTestDiskLayer(), common.Hash{}, copyDestructs(destructs), copyAccounts(accounts), copyStorage(storage))
+	child := newDiffLayer(parent, common.Hash{}, copyDestructs(destructs), copyAccounts(accounts), copyStorage(storage))
+	child = newDiffLayer(child, common.Hash{}, copyDestructs(destructs), copyAccounts(accounts), copyStorage(storage))
+	child = newDiffLayer(child, common.Hash{}, copyDestructs(destructs), copyAccounts(accounts), copyStorage(storage))
+	child = newDiffLayer(child, common.Hash{}, copyDestructs(destructs), copyAccounts(accounts), copyStorage(storage))
+
+	// And flatten
+	merged := (child.flatten()).(*diffLayer)
+
+	{ // Check account lists
+		if have, want := len(merged.accountList), 0; have != want {
+			t.Errorf("accountList wrong: have %v, want %v", have, want)
+		}
+		if have, want := len(merged.AccountList()), len(accounts); have != want {
+			t.Errorf("AccountList() wrong: have %v, want %v", have, want)
+		}
+		if have, want := len(merged.accountList), len(accounts); have != want {
+			t.Errorf("accountList [2] wrong: have %v, want %v", have, want)
+		}
+	}
+	{ // Check account drops
+		if have, want := len(merged.destructSet), len(destructs); have != want {
+			t.Errorf("accountDrop wrong: have %v, want %v", have, want)
+		}
+	}
+	{ // Check storage lists
+		i := 0
+		for aHash, sMap := range storage {
+			if have, want := len(merged.storageList), i; have != want {
+				t.Errorf("[1] storageList wrong: have %v, want %v", have, want)
+			}
+			list, _ := merged.StorageList(aHash)
+			if have, want := len(list), len(sMap); have != want {
+				t.Errorf("[2] StorageList() wrong: have %v, want %v", have, want)
+			}
+			if have, want := len(merged.storageList[aHash]), len(sMap); have != want {
+				t.Errorf("storageList wrong: have %v, want %v", have, want)
+			}
+			i++
+		}
+	}
+}
+
+// Tests that deleting an account in a higher layer shadows the lower layers,
+// and that flattening retains the deletion.
+func TestMergeDelete(t *testing.T) {
+	var (
+		h1 = common.HexToHash("0x01")
+		h2 = common.HexToHash("0x02")
+	)
+	flipDrops := func() map[common.Hash]struct{} {
+		return map[common.Hash]struct{}{h2: {}}
+	}
+	flipAccs := func() map[common.Hash][]byte {
+		return map[common.Hash][]byte{h1: randomAccount(), h2: nil}
+	}
+	flopDrops := func() map[common.Hash]struct{} {
+		return map[common.Hash]struct{}{h1: {}}
+	}
+	flopAccs := func() map[common.Hash][]byte {
+		return map[common.Hash][]byte{h1: nil, h2: randomAccount()}
+	}
+	// Add some flipAccs-flopping layers on top
+	parent := newDiffLayer(newTestDiskLayer(), common.Hash{}, flipDrops(), flipAccs(), nil)
+	child := parent.Update(common.Hash{}, flopDrops(), flopAccs(), nil)
+	child = child.Update(common.Hash{}, flipDrops(), flipAccs(), nil)
+	child = child.Update(common.Hash{}, flopDrops(), flopAccs(), nil)
+	child = child.Update(common.Hash{}, flipDrops(), flipAccs(), nil)
+	child = child.Update(common.Hash{}, flopDrops(), flopAccs(), nil)
+	child = child.Update(common.Hash{}, flipDrops(), flipAccs(), nil)
+
+	if data, _ := child.Account(h1); data == nil {
+		t.Errorf("last diff layer: expected %x account to be non-nil", h1)
+	}
+	if data, _ := child.Account(h2); data != nil {
+		t.Errorf("last diff layer: expected %x account to be nil", h2)
+	}
+	// And flatten
+	merged := (child.flatten()).(*diffLayer)
+
+	if data, _ := merged.Account(h1); data == nil {
+		t.Errorf("merged layer: expected %x account to be non-nil", h1)
+	}
+	if data, _ := merged.Account(h2); data != nil {
+		t.Errorf("merged layer: expected %x account to be nil", h2)
+	}
+}
+
+// Tests that storage slots of an account destructed in a higher layer are not
+// resolved from the layers below it.
+func TestDestructShadowsStorage(t *testing.T) {
+	var (
+		acc  = common.HexToHash("0xa1")
+		slot = common.HexToHash("0xb1")
+	)
+	parent := newDiffLayer(newTestDiskLayer(), common.HexToHash("0x02"), nil, randomAccountSet("0xa1"), map[common.Hash]map[common.Hash][]byte{
+		acc: {slot: []byte{0x01}},
+	})
+	child := parent.Update(common.HexToHash("0x03"), map[common.Hash]struct{}{acc: {}}, nil, nil)
+
+	if blob, err := parent.Storage(acc, slot); err != nil || !bytes.Equal(blob, []byte{0x01}) {
+		t.Errorf("parent slot mismatch: have %x (err: %v), want %x", blob, err, []byte{0x01})
+	}
+	if blob, err := child.Storage(acc, slot); err != nil || blob != nil {
+		t.Errorf("destructed slot resolved: have %x (err: %v)", blob, err)
+	}
+	if list, destructed := child.StorageList(acc); len(list) != 0 || !destructed {
+		t.Errorf("storage list mismatch: have %d slots (destructed: %v), want 0 (destructed: true)", len(list), destructed)
+	}
+}
+
+func copyDestructs(destructs map[common.Hash]struct{}) map[common.Hash]struct{} {
+	copy := make(map[common.Hash]struct{})
+	for hash := range destructs {
+		copy[hash] = struct{}{}
+	}
+	return copy
+}
+
+func copyAccounts(accounts map[common.Hash][]byte) map[common.Hash][]byte {
+	copy := make(map[common.Hash][]byte)
+	for hash, blob := range accounts {
+		copy[hash] = blob
+	}
+	return copy
+}
+
+func copyStorage(storage map[common.Hash]map[common.Hash][]byte) map[common.Hash]map[common.Hash][]byte {
+	copy := make(map[common.Hash]map[common.Hash][]byte)
+	for accHash, slots := range storage {
+		copy[accHash] = make(map[common.Hash][]byte)
+		for slotHash, blob := range slots {
+			copy[accHash][slotHash] = blob
+		}
+	}
+	return copy
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// testState is a small state used to check snapshot generation against.
+type testState struct {
+	diskdb ethdb.KeyValueStore
+	triedb *trie.Database
+	root   common.Hash
+
+	accounts map[common.Hash][]byte                 // Expected slim accounts by hash
+	storage  map[common.Hash]map[common.Hash][]byte // Expected storage slots by account hash
+}
+
+// makeTestState creates a committed state trie with a few accounts, some of
+// them with storage, and returns the expected snapshot content.
+func makeTestState(t *testing.T) *testState {
+	state := &testState{
+		diskdb:   memorydb.New(),
+		accounts: make(map[common.Hash][]byte),
+		storage:  make(map[common.Hash]map[common.Hash][]byte),
+	}
+	state.triedb = trie.NewDatabase(state.diskdb)
+
+	accTrie, _ := trie.NewSecure(common.Hash{}, state.triedb)
+	for i := byte(1); i <= 16; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		hash := crypto.Keccak256Hash(addr[:])
+
+		storageRoot := emptyRoot
+		if i%3 == 0 {
+			stTrie, _ := trie.NewSecure(common.Hash{}, state.triedb)
+			slots := make(map[common.Hash][]byte)
+			for j := byte(1); j <= i; j++ {
+				key := common.BytesToHash([]byte{j})
+				val, _ := rlp.EncodeToBytes([]byte{i, j})
+				stTrie.Update(key[:], val)
+				slots[crypto.Keccak256Hash(key[:])] = val
+			}
+			root, err := stTrie.Commit(nil)
+			if err != nil {
+				t.Fatalf("failed to commit storage trie: %v", err)
+			}
+			storageRoot = root
+			state.storage[hash] = slots
+		}
+		balance := big.NewInt(int64(i) * 1000)
+		blob, _ := rlp.EncodeToBytes(struct {
+			Nonce    uint64
+			Balance  *big.Int
+			Root     common.Hash
+			CodeHash []byte
+		}{uint64(i), balance, storageRoot, emptyCode[:]})
+		accTrie.Update(addr[:], blob)
+
+		state.accounts[hash] = AccountRLP(uint64(i), balance, storageRoot, emptyCode[:])
+	}
+	root, err := accTrie.Commit(nil)
+	if err != nil {
+		t.Fatalf("failed to commit account trie: %v", err)
+	}
+	if err := state.triedb.Commit(root, false); err != nil {
+		t.Fatalf("failed to persist tries: %v", err)
+	}
+	state.root = root
+	return state
+}
+
+// checkSnapshot verifies that the snapshot at the given root contains exactly
+// the expected accounts and storage slots, both via lookups and iteration.
+func checkSnapshot(t *testing.T, snaps *Tree, root common.Hash, state *testState) {
+	snap := snaps.Snapshot(root)
+	if snap == nil {
+		t.Fatalf("snapshot %x missing", root)
+	}
+	for hash, want := range state.accounts {
+		if have, err := snap.AccountRLP(hash); err != nil || !bytes.Equal(have, want) {
+			t.Errorf("account %x mismatch: have %x (err: %v), want %x", hash, have, err, want)
+		}
+		for slot, want := range state.storage[hash] {
+			if have, err := snap.Storage(hash, slot); err != nil || !bytes.Equal(have, want) {
+				t.Errorf("slot %x:%x mismatch: have %x (err: %v), want %x", hash, slot, have, err, want)
+			}
+		}
+	}
+	it, err := snaps.AccountIterator(root, common.Hash{})
+	if err != nil {
+		t.Fatalf("failed to create account iterator: %v", err)
+	}
+	defer it.Release()
+
+	var (
+		count int
+		last  common.Hash
+	)
+	for it.Next() {
+		hash := it.Hash()
+		if count > 0 && bytes.Compare(last[:], hash[:]) >= 0 {
+			t.Errorf("account iteration out of order: %x after %x", hash, last)
+		}
+		if want := state.accounts[hash]; !bytes.Equal(it.Account(), want) {
+			t.Errorf("iterated account %x mismatch: have %x, want %x", hash, it.Account(), want)
+		}
+		last = hash
+		count++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("account iteration failed: %v", err)
+	}
+	if count != len(state.accounts) {
+		t.Errorf("iterated account count mismatch: have %d, want %d", count, len(state.accounts))
+	}
+}
+
+// Tests that a snapshot generated from a committed state trie contains all the
+// accounts and storage slots of that state.
+func TestGeneration(t *testing.T) {
+	state := makeTestState(t)
+
+	snaps := New(state.diskdb, state.triedb, 16, state.root, false)
+	checkSnapshot(t, snaps, state.root, state)
+
+	for hash, slots := range state.storage {
+		it, err := snaps.StorageIterator(state.root, hash, common.Hash{})
+		if err != nil {
+			t.Fatalf("failed to create storage iterator: %v", err)
+		}
+		count := 0
+		for it.Next() {
+			if want := slots[it.Hash()]; !bytes.Equal(it.Slot(), want) {
+				t.Errorf("iterated slot %x mismatch: have %x, want %x", it.Hash(), it.Slot(), want)
+			}
+			count++
+		}
+		it.Release()
+		if count != len(slots) {
+			t.Errorf("iterated slot count mismatch for %x: have %d, want %d", hash, count, len(slots))
+		}
+	}
+}
+
+// Tests that a journalled snapshot is loaded back with all its diff layers, and
+// that a journal not matching the persisted disk layer is rejected.
+func TestJournalRoundtrip(t *testing.T) {
+	state := makeTestState(t)
+
+	snaps := New(state.diskdb, state.triedb, 16, state.root, false)
+
+	// Stack a couple of diff layers on top and journal them
+	var (
+		changed = common.HexToHash("0xa1")
+		blob    = randomAccount()
+		first   = common.HexToHash("0x02")
+		second  = common.HexToHash("0x03")
+	)
+	if err := snaps.Update(first, state.root, nil, map[common.Hash][]byte{changed: blob}, nil); err != nil {
+		t.Fatalf("failed to create diff layer: %v", err)
+	}
+	if err := snaps.Update(second, first, nil, randomAccountSet("0xa2"), nil); err != nil {
+		t.Fatalf("failed to create diff layer: %v", err)
+	}
+	base, err := snaps.Journal(second)
+	if err != nil {
+		t.Fatalf("failed to journal snapshot: %v", err)
+	}
+	if base != state.root {
+		t.Fatalf("journalled base mismatch: have %x, want %x", base, state.root)
+	}
+	// Reload the snapshot and ensure all the layers are retained
+	loaded, err := loadSnapshot(state.diskdb, state.triedb, 16, second)
+	if err != nil {
+		t.Fatalf("failed to load journalled snapshot: %v", err)
+	}
+	var layers int
+	for layer := snapshot(loaded); layer != nil; layer = layer.Parent() {
+		layers++
+	}
+	if layers != 3 {
+		t.Errorf("loaded layer count mismatch: have %d, want %d", layers, 3)
+	}
+	if have, err := loaded.AccountRLP(changed); err != nil || !bytes.Equal(have, blob) {
+		t.Errorf("journalled account mismatch: have %x (err: %v), want %x", have, err, blob)
+	}
+	// A head other than the journalled one must be rejected
+	if _, err := loadSnapshot(state.diskdb, state.triedb, 16, first); err == nil {
+		t.Errorf("loaded snapshot with mismatching head")
+	}
+}
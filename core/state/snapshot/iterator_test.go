@@ -0,0 +1,128 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// collectAccounts drains an account iterator, returning the visited hashes.
+func collectAccounts(t *testing.T, it AccountIterator) []common.Hash {
+	defer it.Release()
+
+	var hashes []common.Hash
+	for it.Next() {
+		hashes = append(hashes, it.Hash())
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	return hashes
+}
+
+// Tests that the fast account iterator merges the accounts of all the layers in
+// order, with the higher layers overriding and deleting the lower ones.
+func TestAccountIteratorTraversal(t *testing.T) {
+	base := newTestDiskLayer()
+	for _, hash := range []string{"0xaa", "0xcc", "0xee"} {
+		rawdb.WriteAccountSnapshot(base.diskdb, common.HexToHash(hash), randomAccount())
+	}
+	snaps := &Tree{
+		layers: map[common.Hash]snapshot{
+			base.root: base,
+		},
+	}
+	override := randomAccount()
+	snaps.Update(common.HexToHash("0x02"), common.HexToHash("0x01"), nil,
+		map[common.Hash][]byte{common.HexToHash("0xbb"): randomAccount(), common.HexToHash("0xcc"): override}, nil)
+	snaps.Update(common.HexToHash("0x03"), common.HexToHash("0x02"),
+		map[common.Hash]struct{}{common.HexToHash("0xee"): {}},
+		map[common.Hash][]byte{common.HexToHash("0xdd"): randomAccount()}, nil)
+
+	it, err := snaps.AccountIterator(common.HexToHash("0x03"), common.Hash{})
+	if err != nil {
+		t.Fatalf("failed to create iterator: %v", err)
+	}
+	want := []common.Hash{common.HexToHash("0xaa"), common.HexToHash("0xbb"), common.HexToHash("0xcc"), common.HexToHash("0xdd")}
+	if have := collectAccounts(t, it); !equalHashes(have, want) {
+		t.Errorf("iterated accounts mismatch: have %x, want %x", have, want)
+	}
+	// Ensure the override of the middle layer wins over the disk value
+	it, _ = snaps.AccountIterator(common.HexToHash("0x03"), common.HexToHash("0xcc"))
+	if !it.Next() || it.Hash() != common.HexToHash("0xcc") || !bytes.Equal(it.Account(), override) {
+		t.Errorf("seeked account mismatch: have %x %x, want %x", it.Hash(), it.Account(), override)
+	}
+	it.Release()
+
+	// Iterating the older layer must still see the account deleted above it
+	it, _ = snaps.AccountIterator(common.HexToHash("0x02"), common.Hash{})
+	if have := collectAccounts(t, it); len(have) != 4 || have[3] != common.HexToHash("0xee") {
+		t.Errorf("parent layer accounts mismatch: have %x", have)
+	}
+}
+
+// Tests that the fast storage iterator doesn't descend below a layer in which
+// the account was destructed, while still returning the recreated slots.
+func TestStorageIteratorDestructed(t *testing.T) {
+	var (
+		acc  = common.HexToHash("0xa1")
+		old  = common.HexToHash("0x01")
+		live = common.HexToHash("0x02")
+	)
+	base := newTestDiskLayer()
+	rawdb.WriteStorageSnapshot(base.diskdb, acc, old, []byte{0x01})
+
+	snaps := &Tree{
+		layers: map[common.Hash]snapshot{
+			base.root: base,
+		},
+	}
+	snaps.Update(common.HexToHash("0x02"), common.HexToHash("0x01"),
+		map[common.Hash]struct{}{acc: {}}, randomAccountSet("0xa1"),
+		map[common.Hash]map[common.Hash][]byte{acc: {live: []byte{0x02}}})
+
+	it, err := snaps.StorageIterator(common.HexToHash("0x02"), acc, common.Hash{})
+	if err != nil {
+		t.Fatalf("failed to create iterator: %v", err)
+	}
+	defer it.Release()
+
+	var slots []common.Hash
+	for it.Next() {
+		slots = append(slots, it.Hash())
+	}
+	if want := []common.Hash{live}; !equalHashes(slots, want) {
+		t.Errorf("iterated slots mismatch: have %x, want %x", slots, want)
+	}
+}
+
+// equalHashes reports whether two hash lists contain the same items in order.
+func equalHashes(a, b []common.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
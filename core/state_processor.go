@@ -59,20 +59,20 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		usedGas  = new(uint64)
 		header   = block.Header()
 		allLogs  []*types.Log
-		gp       = new(GasPool).AddGas(CalcGasLimit(block, statedb))
+		gp       = new(GasPool).AddGas(CalcGasLimit(p.bc.SystemCaller(), block, statedb))
 	)
 	// Mutate the block and state according to any hard-fork specs
 	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
 		misc.ApplyDAOHardFork(statedb)
 	}
 
-	if random.IsRunning() {
+	if random.IsRunning(p.bc.SystemCaller()) {
 		author, err := p.bc.Engine().Author(header)
 		if err != nil {
 			return nil, nil, 0, err
 		}
 
-		err = random.RevealAndCommit(block.Randomness().Revealed, block.Randomness().Committed, author, header, statedb)
+		err = random.RevealAndCommit(p.bc.SystemCaller(), block.Randomness().Revealed, block.Randomness().Committed, author, header, statedb)
 		if err != nil {
 			return nil, nil, 0, err
 		}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/contract_comm/currency"
 	commerrs "github.com/ethereum/go-ethereum/contract_comm/errors"
@@ -67,11 +68,12 @@ type StateTransition struct {
 	data            []byte
 	state           vm.StateDB
 	evm             *vm.EVM
+	caller          *contract_comm.SystemCaller
 	gasPriceMinimum *big.Int
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation bool, header *types.Header, state vm.StateDB, feeCurrency *common.Address, isEIP2028 bool) (uint64, error) {
+func IntrinsicGas(data []byte, contractCreation bool, caller *contract_comm.SystemCaller, header *types.Header, state vm.StateDB, feeCurrency *common.Address, isEIP2028 bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation {
@@ -119,7 +121,7 @@ func IntrinsicGas(data []byte, contractCreation bool, header *types.Header, stat
 	// In this case, however, the user always ends up paying maxGasForDebitAndCreditTransactions
 	// keeping it consistent.
 	if feeCurrency != nil {
-		gas += blockchain_parameters.GetIntrinsicGasForAlternativeFeeCurrency(header, state)
+		gas += blockchain_parameters.GetIntrinsicGasForAlternativeFeeCurrency(caller, header, state)
 	}
 
 	return gas, nil
@@ -127,11 +129,13 @@ func IntrinsicGas(data []byte, contractCreation bool, header *types.Header, stat
 
 // NewStateTransition initialises and returns a new state transition object.
 func NewStateTransition(evm *vm.EVM, msg vm.Message, gp *GasPool) *StateTransition {
-	gasPriceMinimum, _ := gpm.GetGasPriceMinimum(msg.FeeCurrency(), evm.GetHeader(), evm.GetStateDB())
+	caller := contract_comm.NewSystemCaller(evm.Chain)
+	gasPriceMinimum, _ := gpm.GetGasPriceMinimum(caller, msg.FeeCurrency(), evm.GetHeader(), evm.GetStateDB())
 
 	return &StateTransition{
 		gp:              gp,
 		evm:             evm,
+		caller:          caller,
 		msg:             msg,
 		gasPrice:        msg.GasPrice(),
 		value:           msg.Value(),
@@ -161,6 +165,7 @@ func NewStateTransitionGasEstimator(evm *vm.EVM, msg vm.Message, gp *GasPool) *S
 	return &StateTransition{
 		gp:              gp,
 		evm:             evm,
+		caller:          contract_comm.NewSystemCaller(evm.Chain),
 		msg:             msg,
 		gasPrice:        common.Big0,
 		value:           msg.Value(),
@@ -196,7 +201,7 @@ func (st *StateTransition) useGas(amount uint64) error {
 
 // payFees deducts gas and gateway fees from sender balance and adds the purchased amount of gas to the state.
 func (st *StateTransition) payFees() error {
-	if st.msg.FeeCurrency() != nil && (!currency.IsWhitelisted(*st.msg.FeeCurrency(), st.evm.GetHeader(), st.evm.GetStateDB())) {
+	if st.msg.FeeCurrency() != nil && (!currency.IsWhitelisted(st.caller, *st.msg.FeeCurrency(), st.evm.GetHeader(), st.evm.GetStateDB())) {
 		log.Trace("Fee currency not whitelisted", "fee currency address", st.msg.FeeCurrency())
 		return errNonWhitelistedFeeCurrency
 	}
@@ -226,7 +231,7 @@ func (st *StateTransition) canPayFee(accountOwner common.Address, fee *big.Int,
 		return st.state.GetBalance(accountOwner).Cmp(fee) >= 0
 	}
 
-	balanceOf, gasUsed, err := currency.GetBalanceOf(accountOwner, *feeCurrency, params.MaxGasToReadErc20Balance, st.evm.GetHeader(), st.evm.GetStateDB())
+	balanceOf, gasUsed, err := currency.GetBalanceOf(st.caller, accountOwner, *feeCurrency, params.MaxGasToReadErc20Balance, st.evm.GetHeader(), st.evm.GetStateDB())
 	log.Debug("balanceOf called", "feeCurrency", *feeCurrency, "gasUsed", gasUsed)
 
 	if err != nil {
@@ -336,7 +341,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	contractCreation := msg.To() == nil
 
 	// Calculate intrinsic gas.
-	gas, err := IntrinsicGas(st.data, contractCreation, st.evm.GetHeader(), st.state, msg.FeeCurrency(), istanbul)
+	gas, err := IntrinsicGas(st.data, contractCreation, st.caller, st.evm.GetHeader(), st.state, msg.FeeCurrency(), istanbul)
 	if err != nil {
 		return nil, 0, false, err
 	}
@@ -17,13 +17,17 @@
 package core
 
 import (
+	"bytes"
 	"container/heap"
+	"errors"
 	"math"
 	"math/big"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/currency"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -377,6 +381,13 @@ func (l *txList) Flatten() types.Transactions {
 	return l.txs.Flatten()
 }
 
+var (
+	// goldRate is the exchange rate of Celo Gold to itself.
+	goldRate = big.NewRat(1, 1)
+
+	errZeroExchangeRate = errors.New("zero exchange rate")
+)
+
 // priceHeap is a heap.Interface implementation over transactions for retrieving
 // price-sorted transactions to discard when the pool fills up.
 type priceHeap []*types.Transaction
@@ -415,15 +426,69 @@ type txPricedList struct {
 	nonNilCurrencyHeaps map[common.Address]*priceHeap // Heap of prices of all the stored non-nil currency transactions
 	nilCurrencyHeap     *priceHeap                    // Heap of prices of all the stored nil currency transactions
 	stales              int                           // Number of stale price points to (re-heap trigger)
+	caller              *contract_comm.SystemCaller   // Caller used to read exchange rates from the system contracts
+
+	header *types.Header               // Block whose exchange rates are used to compare prices across currencies
+	state  *state.StateDB              // State after header, used to read the exchange rates
+	rates  map[common.Address]*big.Rat // Celo Gold value of a unit of each fee currency as of header
 }
 
 // newTxPricedList creates a new price-sorted transaction heap.
-func newTxPricedList(all *txLookup) *txPricedList {
+func newTxPricedList(all *txLookup, caller *contract_comm.SystemCaller) *txPricedList {
 	return &txPricedList{
 		all:                 all,
+		caller:              caller,
 		nonNilCurrencyHeaps: make(map[common.Address]*priceHeap),
 		nilCurrencyHeap:     new(priceHeap),
+		rates:               make(map[common.Address]*big.Rat),
+	}
+}
+
+// SetHead updates the block, and the state after it, whose exchange rates are used
+// to compare prices of transactions paying fees in different currencies. The rates
+// are read lazily, at most once per currency until the head changes again.
+func (l *txPricedList) SetHead(header *types.Header, state *state.StateDB) {
+	l.header, l.state = header, state
+	l.rates = make(map[common.Address]*big.Rat)
+}
+
+// rate returns the Celo Gold value of a unit of the given fee currency as of the
+// current head.
+func (l *txPricedList) rate(feeCurrency *common.Address) *big.Rat {
+	if feeCurrency == nil {
+		return goldRate
+	}
+	if rate, ok := l.rates[*feeCurrency]; ok {
+		return rate
+	}
+	rate := goldRate
+	numerator, denominator, err := currency.GetExchangeRate(l.caller, feeCurrency, l.header, l.state)
+	if err == nil && numerator.Sign() == 0 {
+		err = errZeroExchangeRate
+	}
+	if err != nil {
+		log.Warn("Error in retrieving exchange rate. Will compare prices without exchange rate conversion.", "feeCurrency", feeCurrency.Hex(), "err", err)
+	} else {
+		rate = new(big.Rat).SetFrac(denominator, numerator)
+	}
+	l.rates[*feeCurrency] = rate
+	return rate
+}
+
+// goldPrice returns the gas price of the transaction normalized to Celo Gold.
+func (l *txPricedList) goldPrice(tx *types.Transaction) *big.Rat {
+	return new(big.Rat).Mul(new(big.Rat).SetInt(tx.GasPrice()), l.rate(tx.FeeCurrency()))
+}
+
+// cmpPrice compares the gas prices of two transactions, normalized to Celo Gold.
+func (l *txPricedList) cmpPrice(tx1, tx2 *types.Transaction) int {
+	if tx1.FeeCurrency() == nil && tx2.FeeCurrency() == nil {
+		return tx1.GasPrice().Cmp(tx2.GasPrice())
+	}
+	if tx1.FeeCurrency() != nil && tx2.FeeCurrency() != nil && *tx1.FeeCurrency() == *tx2.FeeCurrency() {
+		return tx1.GasPrice().Cmp(tx2.GasPrice())
 	}
+	return l.goldPrice(tx1).Cmp(l.goldPrice(tx2))
 }
 
 // Gets the price heap for the given currency
@@ -491,7 +556,7 @@ func (l *txPricedList) Cap(cgThreshold *big.Int, local *accountSet) types.Transa
 			continue
 		}
 
-		if currency.Cmp(tx.GasPrice(), tx.FeeCurrency(), cgThreshold, nil) >= 0 {
+		if l.goldPrice(tx).Cmp(new(big.Rat).SetInt(cgThreshold)) >= 0 {
 			save = append(save, tx)
 			break
 		}
@@ -533,7 +598,32 @@ func (l *txPricedList) Underpriced(tx *types.Transaction, local *accountSet) boo
 	}
 
 	cheapest := l.getMinPricedTx()
-	return currency.Cmp(cheapest.GasPrice(), cheapest.FeeCurrency(), tx.GasPrice(), tx.FeeCurrency()) >= 0
+	return l.cmpPrice(cheapest, tx) >= 0
+}
+
+// UnderpricedInCurrency checks whether a transaction is cheaper than (or as cheap as)
+// the lowest priced transaction currently being tracked in the same fee currency.
+func (l *txPricedList) UnderpricedInCurrency(tx *types.Transaction, local *accountSet) bool {
+	// Local transactions cannot be underpriced
+	if local.containsTx(tx) {
+		return false
+	}
+	// Discard stale price points if found at the heap start
+	pHeap := l.getPriceHeap(tx)
+	for len(*pHeap) > 0 {
+		head := (*pHeap)[0]
+		if l.all.Get(head.Hash()) == nil {
+			l.stales--
+			heap.Pop(pHeap)
+			continue
+		}
+		break
+	}
+	// Check if the transaction is underpriced or not
+	if len(*pHeap) == 0 {
+		return false
+	}
+	return (*pHeap)[0].GasPrice().Cmp(tx.GasPrice()) >= 0
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
@@ -563,6 +653,59 @@ func (l *txPricedList) Discard(count int, local *accountSet) types.Transactions
 	return drop
 }
 
+// DiscardInCurrency finds a number of most underpriced transactions paying fees in
+// the given currency, removes them from the priced list and returns them for further
+// removal from the entire pool.
+func (l *txPricedList) DiscardInCurrency(feeCurrency common.Address, count int, local *accountSet) types.Transactions {
+	drop := make(types.Transactions, 0, count) // Remote underpriced transactions to drop
+	save := make(types.Transactions, 0, 64)    // Local underpriced transactions to keep
+
+	pHeap, ok := l.nonNilCurrencyHeaps[feeCurrency]
+	if !ok {
+		return drop
+	}
+	for len(*pHeap) > 0 && count > 0 {
+		// Discard stale transactions if found during cleanup
+		tx := heap.Pop(pHeap).(*types.Transaction)
+		if l.all.Get(tx.Hash()) == nil {
+			l.stales--
+			continue
+		}
+		// Non stale transaction found, discard unless local
+		if local.containsTx(tx) {
+			save = append(save, tx)
+		} else {
+			drop = append(drop, tx)
+			count--
+		}
+	}
+	for _, tx := range save {
+		l.Put(tx)
+	}
+	return drop
+}
+
+// DiscardDeprioritized finds a number of cheapest deprioritized transactions and
+// returns them for removal from the entire pool. Transactions are sorted by their
+// Celo Gold normalized price, then by nonce (high nonce is worse), and finally by
+// hash, so the same ones are dropped whatever the order they were added in.
+func (l *txPricedList) DiscardDeprioritized(count int) types.Transactions {
+	txs := l.all.Deprioritized()
+	sort.Slice(txs, func(i, j int) bool {
+		if cmp := l.cmpPrice(txs[i], txs[j]); cmp != 0 {
+			return cmp < 0
+		}
+		if txs[i].Nonce() != txs[j].Nonce() {
+			return txs[i].Nonce() > txs[j].Nonce()
+		}
+		return bytes.Compare(txs[i].Hash().Bytes(), txs[j].Hash().Bytes()) < 0
+	})
+	if count < len(txs) {
+		txs = txs[:count]
+	}
+	return txs
+}
+
 // Retrieves the heap with the lowest normalized price at it's head
 func (l *txPricedList) getHeapWithMinHead() (*priceHeap, *types.Transaction) {
 	// Initialize it to the nilCurrencyHeap
@@ -581,8 +724,9 @@ func (l *txPricedList) getHeapWithMinHead() (*priceHeap, *types.Transaction) {
 				cheapestTxn = []*types.Transaction(*cheapestHeap)[0]
 			} else {
 				txn := []*types.Transaction(*priceHeap)[0]
-				if currency.Cmp(txn.GasPrice(), txn.FeeCurrency(), cheapestTxn.GasPrice(), cheapestTxn.FeeCurrency()) < 0 {
+				if l.cmpPrice(txn, cheapestTxn) < 0 {
 					cheapestHeap = priceHeap
+					cheapestTxn = txn
 				}
 			}
 		}
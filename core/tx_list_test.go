@@ -17,9 +17,11 @@
 package core
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -49,3 +51,80 @@ func TestStrictTxListAdd(t *testing.T) {
 		}
 	}
 }
+
+func currencyTransaction(nonce uint64, gasprice int64, feeCurrency *common.Address) *types.Transaction {
+	key, _ := crypto.GenerateKey()
+	tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), 0, big.NewInt(gasprice), feeCurrency, nil, nil, nil), types.HomesteadSigner{}, key)
+	return tx
+}
+
+// Tests that the priced list pops transactions in increasing price order across
+// the heaps of all fee currencies. Without exchange rates, prices compare as is.
+func TestTxPricedListPopAcrossCurrencies(t *testing.T) {
+	currencyA, currencyB := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	txs := types.Transactions{
+		currencyTransaction(0, 5, nil),
+		currencyTransaction(0, 7, nil),
+		currencyTransaction(0, 3, &currencyA),
+		currencyTransaction(0, 8, &currencyA),
+		currencyTransaction(0, 4, &currencyB),
+		currencyTransaction(0, 6, &currencyB),
+	}
+	all := newTxLookup()
+	priced := newTxPricedList(all, nil)
+	for _, tx := range txs {
+		all.Add(tx)
+		priced.Put(tx)
+	}
+	for want := int64(3); want <= 8; want++ {
+		if price := priced.pop().GasPrice(); price.Int64() != want {
+			t.Fatalf("popped price mismatch: have %v, want %v", price, want)
+		}
+	}
+}
+
+// Tests that discarding in a fee currency only drops the cheapest remote
+// transactions paying fees in that currency.
+func TestTxPricedListDiscardInCurrency(t *testing.T) {
+	currencyA, currencyB := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	local := currencyTransaction(0, 1, &currencyA)
+	txs := types.Transactions{
+		local,
+		currencyTransaction(0, 1, nil),
+		currencyTransaction(0, 2, &currencyA),
+		currencyTransaction(0, 3, &currencyA),
+		currencyTransaction(0, 4, &currencyA),
+		currencyTransaction(0, 1, &currencyB),
+	}
+	all := newTxLookup()
+	priced := newTxPricedList(all, nil)
+	for _, tx := range txs {
+		all.Add(tx)
+		priced.Put(tx)
+	}
+	if count := all.CurrencyCount(currencyA); count != 4 {
+		t.Fatalf("currency count mismatch: have %d, want %d", count, 4)
+	}
+	locals := newAccountSet(types.HomesteadSigner{})
+	from, _ := types.Sender(types.HomesteadSigner{}, local)
+	locals.add(from)
+
+	drop := priced.DiscardInCurrency(currencyA, 2, locals)
+	if len(drop) != 2 {
+		t.Fatalf("dropped transaction count mismatch: have %d, want %d", len(drop), 2)
+	}
+	for i, tx := range drop {
+		if tx.FeeCurrency() == nil || *tx.FeeCurrency() != currencyA || tx.GasPrice().Int64() != int64(i+2) {
+			t.Errorf("dropped transaction %d mismatch: have currency %v price %v", i, tx.FeeCurrency(), tx.GasPrice())
+		}
+	}
+	if priced.Len() != 4 {
+		t.Errorf("priced list length mismatch: have %d, want %d", priced.Len(), 4)
+	}
+	if !priced.UnderpricedInCurrency(currencyTransaction(0, 1, &currencyA), locals) {
+		t.Errorf("transaction priced below the remaining currency transactions not underpriced")
+	}
+	if priced.UnderpricedInCurrency(currencyTransaction(0, 5, &currencyA), locals) {
+		t.Errorf("transaction priced above the remaining currency transactions underpriced")
+	}
+}
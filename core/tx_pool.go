@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/prque"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/contract_comm/blockchain_parameters"
 	"github.com/ethereum/go-ethereum/contract_comm/currency"
 	ccerrors "github.com/ethereum/go-ethereum/contract_comm/errors"
@@ -164,6 +165,9 @@ type blockChain interface {
 	GetHeader(common.Hash, uint64) *types.Header
 
 	GetVMConfig() *vm.Config
+
+	// SystemCaller returns the caller used to read the system contracts.
+	SystemCaller() *contract_comm.SystemCaller
 }
 
 // TxPoolConfig are the configuration parameters of the transaction pool.
@@ -181,6 +185,8 @@ type TxPoolConfig struct {
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
+	CurrencySlots map[common.Address]uint64 // Maximum number of transaction slots (executable and non-executable) per non-native fee currency
+
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 }
 
@@ -233,6 +239,17 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultTxPoolConfig.Lifetime)
 		conf.Lifetime = DefaultTxPoolConfig.Lifetime
 	}
+	if len(conf.CurrencySlots) > 0 {
+		currencySlots := make(map[common.Address]uint64, len(conf.CurrencySlots))
+		for feeCurrency, slots := range conf.CurrencySlots {
+			if slots < 1 {
+				log.Warn("Sanitizing invalid txpool currency slots", "currency", feeCurrency, "provided", slots, "updated", "unlimited")
+				continue
+			}
+			currencySlots[feeCurrency] = slots
+		}
+		conf.CurrencySlots = currencySlots
+	}
 	return conf
 }
 
@@ -255,10 +272,13 @@ type TxPool struct {
 
 	istanbul bool // Fork indicator whether we are in the istanbul stage.
 
+	currentHeader *types.Header  // Current head of the blockchain, which currentState belongs to
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
 
+	gasPriceMinimums map[common.Address]*big.Int // Gas price minimum per fee currency at currentHeader, Celo Gold keyed by the zero address
+
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
 
@@ -311,7 +331,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
-	pool.priced = newTxPricedList(pool.all)
+	pool.priced = newTxPricedList(pool.all, chain.SystemCaller())
 
 	pool.reset(nil, chain.CurrentBlock().Header())
 
@@ -465,7 +485,7 @@ func (pool *TxPool) SetGasLimit(gasLimit uint64) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	limitFromContract, err := blockchain_parameters.GetBlockGasLimit(pool.chain.CurrentBlock().Header(), pool.currentState)
+	limitFromContract, err := blockchain_parameters.GetBlockGasLimit(pool.chain.SystemCaller(), pool.chain.CurrentBlock().Header(), pool.currentState)
 	if err == nil {
 		pool.currentMaxGas = limitFromContract
 		return
@@ -515,6 +535,48 @@ func (pool *TxPool) stats() (int, int) {
 	return pending, queued
 }
 
+// TxPoolCurrencyStats is the occupancy of the transaction pool by the transactions
+// paying fees in a single currency.
+type TxPoolCurrencyStats struct {
+	Pending int    // Number of executable transactions
+	Queued  int    // Number of non-executable transactions
+	Slots   uint64 // Maximum number of transactions, 0 if only the global limits apply
+}
+
+// StatsByCurrency retrieves the current pool stats broken down by fee currency.
+// Transactions paying fees in Celo Gold are keyed by the zero address.
+func (pool *TxPool) StatsByCurrency() map[common.Address]TxPoolCurrencyStats {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	stats := make(map[common.Address]TxPoolCurrencyStats)
+	for feeCurrency, slots := range pool.config.CurrencySlots {
+		stats[feeCurrency] = TxPoolCurrencyStats{Slots: slots}
+	}
+	count := func(list *txList, pending bool) {
+		for _, tx := range list.txs.items {
+			feeCurrency := common.ZeroAddress
+			if tx.FeeCurrency() != nil {
+				feeCurrency = *tx.FeeCurrency()
+			}
+			currencyStats := stats[feeCurrency]
+			if pending {
+				currencyStats.Pending++
+			} else {
+				currencyStats.Queued++
+			}
+			stats[feeCurrency] = currencyStats
+		}
+	}
+	for _, list := range pool.pending {
+		count(list, true)
+	}
+	for _, list := range pool.queue {
+		count(list, false)
+	}
+	return stats
+}
+
 // Content retrieves the data content of the transaction pool, returning all the
 // pending as well as queued transactions, grouped by account and sorted by nonce.
 func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions) {
@@ -570,9 +632,24 @@ func (pool *TxPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// GatewayFeeRequirement is the gateway fee a light server requires of the transactions
+// it relays for its clients.
+type GatewayFeeRequirement struct {
+	Recipient    common.Address // Required gateway fee recipient, any if zero
+	Minimum      *big.Int       // Minimum gateway fee, any if nil or not positive
+	Deprioritize bool           // Whether to deprioritize rather than reject transactions not meeting the requirement
+}
+
+// gatewayFeeError is the validation error of a transaction not meeting the gateway fee
+// requirement it was relayed with.
+type gatewayFeeError struct {
+	error
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
-func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
+// Transactions relayed by a light server must also meet its gateway fee requirement.
+func (pool *TxPool) validateTx(tx *types.Transaction, local bool, gatewayFee *GatewayFeeRequirement) error {
 	// Heuristic limit, reject transactions over MaxCodeSize to prevent DOS attacks
 	if tx.Size() > params.MaxCodeSize {
 		return ErrOversizedData
@@ -594,13 +671,13 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	}
 
 	// Ensure the fee currency is native or whitelisted.
-	if tx.FeeCurrency() != nil && !currency.IsWhitelisted(*tx.FeeCurrency(), nil, nil) {
+	if tx.FeeCurrency() != nil && !currency.IsWhitelisted(pool.chain.SystemCaller(), *tx.FeeCurrency(), nil, nil) {
 		return ErrNonWhitelistedFeeCurrency
 	}
 
 	// Drop non-local transactions under our own minimal accepted gas price
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
-	if !local && currency.Cmp(pool.gasPrice, nil, tx.GasPrice(), tx.FeeCurrency()) > 0 {
+	if !local && currency.Cmp(pool.chain.SystemCaller(), pool.gasPrice, nil, tx.GasPrice(), tx.FeeCurrency(), pool.currentHeader, pool.currentState) > 0 {
 		return ErrUnderpriced
 	}
 	// Ensure the transaction adheres to nonce ordering
@@ -608,11 +685,11 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrNonceTooLow
 	}
 	// Transactor should have enough funds to cover the costs
-	err = ValidateTransactorBalanceCoversTx(tx, from, pool.currentState)
+	err = ValidateTransactorBalanceCoversTx(pool.chain.SystemCaller(), tx, from, pool.currentState)
 	if err != nil {
 		return err
 	}
-	intrGas, err := IntrinsicGas(tx.Data(), tx.To() == nil, pool.chain.CurrentBlock().Header(), pool.currentState, tx.FeeCurrency(), pool.istanbul)
+	intrGas, err := IntrinsicGas(tx.Data(), tx.To() == nil, pool.chain.SystemCaller(), pool.chain.CurrentBlock().Header(), pool.currentState, tx.FeeCurrency(), pool.istanbul)
 	if err != nil {
 		log.Debug("validateTx gas less than intrinsic gas", "intrGas", intrGas, "err", err)
 		return err
@@ -622,7 +699,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrIntrinsicGas
 	}
 
-	gasPriceMinimum, err := gpm.GetGasPriceMinimum(tx.FeeCurrency(), nil, nil)
+	gasPriceMinimum, err := pool.gasPriceMinimum(tx.FeeCurrency())
 	if err != nil && err != ccerrors.ErrSmartContractNotDeployed && err != ccerrors.ErrRegistryContractNotDeployed {
 		log.Debug("unable to fetch gas price minimum", "err", err)
 		return err
@@ -635,19 +712,19 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 
 	// Ensure gold transfers are whitelisted if transfers are frozen.
 	if tx.Value().Sign() > 0 {
-		if isFrozen, err := freezer.IsFrozen(params.GoldTokenRegistryId, nil, nil); err != nil {
+		if isFrozen, err := freezer.IsFrozen(pool.chain.SystemCaller(), params.GoldTokenRegistryId, nil, nil); err != nil {
 			log.Warn("Error determining if transfers are frozen, will proceed as if they are not", "err", err)
 		} else if isFrozen {
 			log.Info("Transfers are frozen")
 			if tx.To() == nil {
-				if !transfer_whitelist.IsWhitelisted(from, from, nil, nil) {
+				if !transfer_whitelist.IsWhitelisted(pool.chain.SystemCaller(), from, from, nil, nil) {
 					log.Debug("Attempt to transfer to new contract from non-whitelisted address", "hash", tx.Hash(), "from", from)
 					return ErrTransfersFrozen
 				}
 				log.Info("New contract transfer is whitelisted", "hash", tx.Hash(), "from", from)
 			} else {
 				to := *tx.To()
-				if !transfer_whitelist.IsWhitelisted(to, from, nil, nil) {
+				if !transfer_whitelist.IsWhitelisted(pool.chain.SystemCaller(), to, from, nil, nil) {
 					log.Debug("Attempt to transfer between non-whitelisted addresses", "hash", tx.Hash(), "to", to, "from", from)
 					return ErrTransfersFrozen
 				}
@@ -656,17 +733,67 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		}
 	}
 
+	// Ensure light client transactions pay the gateway fee required by the light server.
+	if gatewayFee != nil {
+		if err := ValidateGatewayFee(tx, gatewayFee.Recipient, gatewayFee.Minimum); err != nil {
+			return gatewayFeeError{err}
+		}
+	}
+
 	return nil
 }
 
+// gasPriceMinimum returns the gas price minimum of the given fee currency as of the
+// current head. Each minimum is read from the GasPriceMinimum contract at most once
+// per block.
+func (pool *TxPool) gasPriceMinimum(feeCurrency *common.Address) (*big.Int, error) {
+	key := common.ZeroAddress
+	if feeCurrency != nil {
+		key = *feeCurrency
+	}
+	if gasPriceMinimum, ok := pool.gasPriceMinimums[key]; ok {
+		return gasPriceMinimum, nil
+	}
+	gasPriceMinimum, err := gpm.GetGasPriceMinimum(pool.chain.SystemCaller(), feeCurrency, pool.currentHeader, pool.currentState)
+	if err != nil {
+		return gasPriceMinimum, err
+	}
+	pool.gasPriceMinimums[key] = gasPriceMinimum
+	return gasPriceMinimum, nil
+}
+
+// replaceable returns the pending or queued transaction of the given account that a
+// transaction with the given nonce would replace, if any.
+func (pool *TxPool) replaceable(from common.Address, nonce uint64) *types.Transaction {
+	for _, list := range []*txList{pool.pending[from], pool.queue[from]} {
+		if list == nil {
+			continue
+		}
+		if old := list.txs.Get(nonce); old != nil {
+			return old
+		}
+	}
+	return nil
+}
+
+// replacesInCurrency reports whether the transaction has the nonce of a pending or
+// queued transaction of the same account paying fees in the same currency.
+func (pool *TxPool) replacesInCurrency(from common.Address, tx *types.Transaction) bool {
+	old := pool.replaceable(from, tx.Nonce())
+	return old != nil && old.FeeCurrency() != nil && tx.FeeCurrency() != nil && *old.FeeCurrency() == *tx.FeeCurrency()
+}
+
 // add validates a transaction and inserts it into the non-executable queue for later
 // pending promotion and execution. If the transaction is a replacement for an already
 // pending or queued one, it overwrites the previous transaction if its price is higher.
 //
 // If a newly added transaction is marked as local, its sending account will be
 // whitelisted, preventing any associated transaction from being dropped out of the pool
-// due to pricing constraints.
-func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err error) {
+// due to pricing constraints. If it was relayed by a light server and doesn't meet its
+// gateway fee requirement, it may be deprioritized instead of rejected: it is then only
+// accepted if the pool has room for it, can only replace deprioritized transactions, and
+// is the first to be dropped when the pool fills up.
+func (pool *TxPool) add(tx *types.Transaction, local bool, gatewayFee *GatewayFeeRequirement) (replaced bool, err error) {
 	// If the transaction is already known, discard it
 	hash := tx.Hash()
 	if pool.all.Get(hash) != nil {
@@ -674,13 +801,62 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		knownTxMeter.Mark(1)
 		return false, fmt.Errorf("known transaction: %x", hash)
 	}
-	// If the transaction fails basic validation, discard it
-	if err := pool.validateTx(tx, local); err != nil {
-		log.Debug("Discarding invalid transaction", "hash", hash, "err", err)
-		invalidTxMeter.Mark(1)
-		return false, err
+	// If the transaction fails basic validation, discard it, unless it only fails the
+	// gateway fee requirement and such transactions are deprioritized
+	deprioritized := false
+	if err := pool.validateTx(tx, local, gatewayFee); err != nil {
+		if _, ok := err.(gatewayFeeError); !ok || !gatewayFee.Deprioritize {
+			log.Debug("Discarding invalid transaction", "hash", hash, "err", err)
+			invalidTxMeter.Mark(1)
+			return false, err
+		}
+		log.Debug("Deprioritizing transaction", "hash", hash, "err", err)
+		deprioritized = true
 	}
-	// If the transaction pool is full, discard underpriced transactions
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	// A deprioritized transaction never replaces one that isn't
+	if deprioritized {
+		if old := pool.replaceable(from, tx.Nonce()); old != nil && !pool.all.IsDeprioritized(old.Hash()) {
+			log.Debug("Discarding deprioritized replacement transaction", "hash", hash, "old", old.Hash())
+			underpricedTxMeter.Mark(1)
+			return false, ErrReplaceUnderpriced
+		}
+	}
+	// If the fee currency has used up its slots, discard underpriced transactions in that currency.
+	// Replacing a transaction paying fees in the same currency doesn't take up another slot.
+	if feeCurrency := tx.FeeCurrency(); feeCurrency != nil && !pool.replacesInCurrency(from, tx) {
+		if slots, ok := pool.config.CurrencySlots[*feeCurrency]; ok && pool.all.CurrencyCount(*feeCurrency) >= slots {
+			// If the new transaction is underpriced, don't accept it
+			if !local && pool.priced.UnderpricedInCurrency(tx, pool.locals) {
+				log.Debug("Discarding underpriced transaction", "hash", hash, "price", tx.GasPrice(), "currency", feeCurrency)
+				underpricedTxMeter.Mark(1)
+				return false, ErrUnderpriced
+			}
+			// New transaction is better than our worse ones in the currency, make room for it
+			drop := pool.priced.DiscardInCurrency(*feeCurrency, int(pool.all.CurrencyCount(*feeCurrency)-slots+1), pool.locals)
+			for _, tx := range drop {
+				log.Debug("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice(), "currency", feeCurrency)
+				underpricedTxMeter.Mark(1)
+				pool.removeTx(tx.Hash(), false)
+			}
+		}
+	}
+	// If the transaction pool is full, discard deprioritized transactions first
+	if uint64(pool.all.Count()) >= pool.config.GlobalSlots+pool.config.GlobalQueue {
+		// A deprioritized transaction never displaces another one
+		if deprioritized {
+			log.Debug("Discarding deprioritized transaction", "hash", hash)
+			underpricedTxMeter.Mark(1)
+			return false, ErrUnderpriced
+		}
+		drop := pool.priced.DiscardDeprioritized(pool.all.Count() - int(pool.config.GlobalSlots+pool.config.GlobalQueue-1))
+		for _, tx := range drop {
+			log.Debug("Discarding deprioritized transaction", "hash", tx.Hash(), "price", tx.GasPrice())
+			underpricedTxMeter.Mark(1)
+			pool.removeTx(tx.Hash(), true)
+		}
+	}
+	// If the transaction pool is still full, discard underpriced transactions
 	if uint64(pool.all.Count()) >= pool.config.GlobalSlots+pool.config.GlobalQueue {
 		// If the new transaction is underpriced, don't accept it
 		if !local && pool.priced.Underpriced(tx, pool.locals) {
@@ -697,7 +873,6 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		}
 	}
 	// Try to replace an existing transaction in the pending pool
-	from, _ := types.Sender(pool.signer, tx) // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
 		inserted, old := list.Add(tx, pool.config.PriceBump)
@@ -712,6 +887,9 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			pendingReplaceMeter.Mark(1)
 		}
 		pool.all.Add(tx)
+		if deprioritized {
+			pool.all.Deprioritize(hash)
+		}
 		pool.priced.Put(tx)
 		pool.journalTx(from, tx)
 		pool.queueTxEvent(tx)
@@ -723,6 +901,9 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 	if err != nil {
 		return false, err
 	}
+	if deprioritized {
+		pool.all.Deprioritize(hash)
+	}
 	// Mark local addresses and journal local transactions
 	if local {
 		if !pool.locals.contains(from) {
@@ -830,7 +1011,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 // This method is used to add transactions from the RPC API and performs synchronous pool
 // reorganization and event propagation.
 func (pool *TxPool) AddLocals(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, !pool.config.NoLocals, true)
+	return pool.addTxs(txs, !pool.config.NoLocals, nil, true)
 }
 
 // AddLocal enqueues a single local transaction into the pool if it is valid. This is
@@ -846,12 +1027,28 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 // This method is used to add transactions from the p2p network and does not wait for pool
 // reorganization and internal event propagation.
 func (pool *TxPool) AddRemotes(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, false)
+	return pool.addTxs(txs, false, nil, false)
 }
 
 // This is like AddRemotes, but waits for pool reorganization. Tests use this method.
 func (pool *TxPool) AddRemotesSync(txs []*types.Transaction) []error {
-	return pool.addTxs(txs, false, true)
+	return pool.addTxs(txs, false, nil, true)
+}
+
+// AddLightRemotes is like AddRemotes, but the transactions must also meet the given
+// gateway fee requirement. If the requirement deprioritizes rather than rejects the
+// transactions not meeting it, those are kept at the lowest priority: they cannot
+// displace any other transactions, and are the first to be discarded to make room
+// for others.
+//
+// This method is used by light servers to relay the transactions of their clients.
+func (pool *TxPool) AddLightRemotes(txs []*types.Transaction, gatewayFee GatewayFeeRequirement) []error {
+	return pool.addTxs(txs, false, &gatewayFee, false)
+}
+
+// This is like AddLightRemotes, but waits for pool reorganization. Tests use this method.
+func (pool *TxPool) AddLightRemotesSync(txs []*types.Transaction, gatewayFee GatewayFeeRequirement) []error {
+	return pool.addTxs(txs, false, &gatewayFee, true)
 }
 
 // This is like AddRemotes with a single transaction, but waits for pool reorganization. Tests use this method.
@@ -870,7 +1067,7 @@ func (pool *TxPool) AddRemote(tx *types.Transaction) error {
 }
 
 // addTxs attempts to queue a batch of transactions if they are valid.
-func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
+func (pool *TxPool) addTxs(txs []*types.Transaction, local bool, gatewayFee *GatewayFeeRequirement, sync bool) []error {
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
 		errs = make([]error, len(txs))
@@ -895,7 +1092,7 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 	}
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
-	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
+	newErrs, dirtyAddrs := pool.addTxsLocked(news, local, gatewayFee)
 	pool.mu.Unlock()
 
 	var nilSlot = 0
@@ -915,11 +1112,11 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 
 // addTxsLocked attempts to queue a batch of transactions if they are valid.
 // The transaction pool lock must be held.
-func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error, *accountSet) {
+func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool, gatewayFee *GatewayFeeRequirement) ([]error, *accountSet) {
 	dirty := newAccountSet(pool.signer)
 	errs := make([]error, len(txs))
 	for i, tx := range txs {
-		replaced, err := pool.add(tx, local)
+		replaced, err := pool.add(tx, local, gatewayFee)
 		errs[i] = err
 		if err == nil && !replaced {
 			dirty.addTx(tx)
@@ -1244,14 +1441,17 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 		log.Error("Failed to reset txpool state", "err", err)
 		return
 	}
+	pool.currentHeader = newHead
 	pool.currentState = statedb
+	pool.gasPriceMinimums = make(map[common.Address]*big.Int)
+	pool.priced.SetHead(newHead, statedb)
 	pool.pendingNonces = newTxNoncer(statedb)
-	pool.currentMaxGas = CalcGasLimit(pool.chain.CurrentBlock(), statedb)
+	pool.currentMaxGas = CalcGasLimit(pool.chain.SystemCaller(), pool.chain.CurrentBlock(), statedb)
 
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	senderCacher.recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject, false)
+	pool.addTxsLocked(reinject, false, nil)
 
 	// Update all fork indicator by next pending block number.
 	next := new(big.Int).Add(newHead.Number, big.NewInt(1))
@@ -1508,7 +1708,7 @@ func (pool *TxPool) demoteUnexecutables() {
 }
 
 // ValidateTransactorBalanceCoversTx validates transactor has enough funds to cover transaction cost: V + GP * GL.
-func ValidateTransactorBalanceCoversTx(tx *types.Transaction, from common.Address, currentState *state.StateDB) error {
+func ValidateTransactorBalanceCoversTx(caller *contract_comm.SystemCaller, tx *types.Transaction, from common.Address, currentState *state.StateDB) error {
 	if tx.FeeCurrency() == nil && currentState.GetBalance(from).Cmp(tx.Cost()) < 0 {
 		log.Debug("Insufficient funds",
 			"from", from, "Transaction cost", tx.Cost(), "to", tx.To(),
@@ -1516,7 +1716,7 @@ func ValidateTransactorBalanceCoversTx(tx *types.Transaction, from common.Addres
 			"value", tx.Value(), "fee currency", tx.FeeCurrency(), "balance", currentState.GetBalance(from))
 		return ErrInsufficientFunds
 	} else if tx.FeeCurrency() != nil {
-		feeCurrencyBalance, _, err := currency.GetBalanceOf(from, *tx.FeeCurrency(), params.MaxGasToReadErc20Balance, nil, nil)
+		feeCurrencyBalance, _, err := currency.GetBalanceOf(caller, from, *tx.FeeCurrency(), params.MaxGasToReadErc20Balance, nil, nil)
 
 		if err != nil {
 			log.Debug("validateTx error in getting fee currency balance", "feeCurrency", tx.FeeCurrency(), "error", err)
@@ -1537,6 +1737,36 @@ func ValidateTransactorBalanceCoversTx(tx *types.Transaction, from common.Addres
 	return nil
 }
 
+// ValidateGatewayFee checks that a transaction pays a gateway fee of at least minimum to
+// recipient, as light servers require of the transactions they relay for their clients.
+// A zero recipient or a non-positive minimum accept any gateway fee.
+func ValidateGatewayFee(tx *types.Transaction, recipient common.Address, minimum *big.Int) error {
+	// If no recipient is specified, accept any GatewayFeeRecipient.
+	if recipient == common.ZeroAddress {
+		return nil
+	}
+
+	// If no non-zero minimum is specified, accept any value.
+	if minimum == nil || minimum.Cmp(common.Big0) <= 0 {
+		return nil
+	}
+
+	// Otherwise, reject transactions that don't pay the gateway fee to the recipient.
+	gatewayFeeRecipient := tx.GatewayFeeRecipient()
+	if gatewayFeeRecipient == nil {
+		return fmt.Errorf("gateway fee recipient must be %s, got <nil>", recipient.String())
+	}
+	if *gatewayFeeRecipient != recipient {
+		return fmt.Errorf("gateway fee recipient must be %s, got %s", recipient.String(), (*gatewayFeeRecipient).String())
+	}
+
+	// Check that the value of the supplied gateway fee is at least the minimum.
+	if gatewayFee := tx.GatewayFee(); gatewayFee == nil || gatewayFee.Cmp(minimum) < 0 {
+		return fmt.Errorf("gateway fee value must be at least %s, got %s", minimum, gatewayFee)
+	}
+	return nil
+}
+
 // addressByHeartbeat is an account address tagged with its last activity timestamp.
 type addressByHeartbeat struct {
 	address   common.Address
@@ -1632,6 +1862,7 @@ type txLookup struct {
 	all                       map[common.Hash]*types.Transaction
 	nonNilCurrencyTxCurrCount map[common.Address]uint64
 	nilCurrencyTxCurrCount    uint64
+	deprioritized             map[common.Hash]struct{}
 	lock                      sync.RWMutex
 }
 
@@ -1640,6 +1871,7 @@ func newTxLookup() *txLookup {
 	return &txLookup{
 		all:                       make(map[common.Hash]*types.Transaction),
 		nonNilCurrencyTxCurrCount: make(map[common.Address]uint64),
+		deprioritized:             make(map[common.Hash]struct{}),
 	}
 }
 
@@ -1671,6 +1903,15 @@ func (t *txLookup) Count() int {
 	return len(t.all)
 }
 
+// CurrencyCount returns the current number of transactions paying fees in the
+// given non-native currency.
+func (t *txLookup) CurrencyCount(feeCurrency common.Address) uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.nonNilCurrencyTxCurrCount[feeCurrency]
+}
+
 // Add adds a transaction to the lookup.
 func (t *txLookup) Add(tx *types.Transaction) {
 	t.lock.Lock()
@@ -1684,6 +1925,39 @@ func (t *txLookup) Add(tx *types.Transaction) {
 	t.all[tx.Hash()] = tx
 }
 
+// Deprioritize marks a transaction in the lookup as being the first to be dropped
+// when the pool is full.
+func (t *txLookup) Deprioritize(hash common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.all[hash]; ok {
+		t.deprioritized[hash] = struct{}{}
+	}
+}
+
+// IsDeprioritized reports whether a transaction in the lookup is deprioritized.
+func (t *txLookup) IsDeprioritized(hash common.Hash) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	_, ok := t.deprioritized[hash]
+	return ok
+}
+
+// Deprioritized returns the deprioritized transactions in the lookup, in no
+// particular order.
+func (t *txLookup) Deprioritized() types.Transactions {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	txs := make(types.Transactions, 0, len(t.deprioritized))
+	for hash := range t.deprioritized {
+		txs = append(txs, t.all[hash])
+	}
+	return txs
+}
+
 // Remove removes a transaction from the lookup.
 func (t *txLookup) Remove(hash common.Hash) {
 	t.lock.Lock()
@@ -1696,4 +1970,5 @@ func (t *txLookup) Remove(hash common.Hash) {
 	}
 
 	delete(t.all, hash)
+	delete(t.deprioritized, hash)
 }
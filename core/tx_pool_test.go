@@ -17,6 +17,7 @@
 package core
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"fmt"
 	"io/ioutil"
@@ -29,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	mockEngine "github.com/ethereum/go-ethereum/consensus/consensustest"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -81,6 +83,10 @@ func (bc *testBlockChain) GetVMConfig() *vm.Config {
 	return nil
 }
 
+func (bc *testBlockChain) SystemCaller() *contract_comm.SystemCaller {
+	return nil
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
 	return pricedTransaction(nonce, gaslimit, big.NewInt(1), key)
 }
@@ -95,8 +101,48 @@ func lesTransaction(nonce uint64, gaslimit uint64, gatewayFee *big.Int, key *ecd
 	return tx
 }
 
+func currencyPricedTransaction(nonce uint64, gaslimit uint64, gasprice *big.Int, feeCurrency *common.Address, key *ecdsa.PrivateKey) *types.Transaction {
+	tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), gaslimit, gasprice, feeCurrency, nil, nil, nil), types.HomesteadSigner{}, key)
+	return tx
+}
+
+// systemTestBlockChain is a testBlockChain whose system caller runs against its
+// state, so that transactions paying fees in a token deployed there validate.
+type systemTestBlockChain struct {
+	*testBlockChain
+}
+
+func (bc *systemTestBlockChain) CurrentHeader() *types.Header {
+	return &types.Header{Number: new(big.Int)}
+}
+
+func (bc *systemTestBlockChain) GetHeaderByNumber(uint64) *types.Header {
+	return nil
+}
+
+func (bc *systemTestBlockChain) GetVMConfig() *vm.Config {
+	return &vm.Config{}
+}
+
+func (bc *systemTestBlockChain) State() (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *systemTestBlockChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (bc *systemTestBlockChain) SystemCaller() *contract_comm.SystemCaller {
+	return contract_comm.NewSystemCaller(bc)
+}
+
+// richTokenCode is the code of a token contract reporting a balance of 2^128-1
+// for every account: PUSH16 0xff..ff PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN.
+var richTokenCode = append(append([]byte{byte(vm.PUSH16)}, bytes.Repeat([]byte{0xff}, 16)...),
+	byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+
 func setupTxPool() (*TxPool, *ecdsa.PrivateKey) {
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	key, _ := crypto.GenerateKey()
@@ -181,7 +227,7 @@ func (c *testChain) State() (*state.StateDB, error) {
 	// a state change between those fetches.
 	stdb := c.statedb
 	if *c.trigger {
-		c.statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		c.statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 		// simulate that the new head block included tx0 and tx1
 		c.statedb.SetNonce(c.address, 2)
 		c.statedb.SetBalance(c.address, new(big.Int).SetUint64(params.Ether))
@@ -199,7 +245,7 @@ func TestStateChangeDuringTransactionPoolReset(t *testing.T) {
 	var (
 		key, _     = crypto.GenerateKey()
 		address    = crypto.PubkeyToAddress(key.PublicKey)
-		statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb, _ = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 		trigger    = false
 	)
 
@@ -368,7 +414,7 @@ func TestTransactionChainFork(t *testing.T) {
 
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	resetState := func() {
-		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 		statedb.AddBalance(addr, big.NewInt(100000000000000))
 
 		pool.chain = &testBlockChain{statedb, 1000000, new(event.Feed)}
@@ -377,14 +423,14 @@ func TestTransactionChainFork(t *testing.T) {
 	resetState()
 
 	tx := transaction(0, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, nil); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	pool.removeTx(tx.Hash(), true)
 
 	// reset the pool's internal state
 	resetState()
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, nil); err != nil {
 		t.Error("didn't expect error", err)
 	}
 }
@@ -397,7 +443,7 @@ func TestTransactionDoubleNonce(t *testing.T) {
 
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	resetState := func() {
-		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 		statedb.AddBalance(addr, big.NewInt(100000000000000))
 
 		pool.chain = &testBlockChain{statedb, 1000000, new(event.Feed)}
@@ -411,10 +457,10 @@ func TestTransactionDoubleNonce(t *testing.T) {
 	tx3, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 1000000, big.NewInt(1), nil, nil, nil, nil), signer, key)
 
 	// Add the first two transaction, ensure higher priced stays only
-	if replace, err := pool.add(tx1, false); err != nil || replace {
+	if replace, err := pool.add(tx1, false, nil); err != nil || replace {
 		t.Errorf("first transaction insert failed (%v) or reported replacement (%v)", err, replace)
 	}
-	if replace, err := pool.add(tx2, false); err != nil || !replace {
+	if replace, err := pool.add(tx2, false, nil); err != nil || !replace {
 		t.Errorf("second transaction insert failed (%v) or not reported replacement (%v)", err, replace)
 	}
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
@@ -426,7 +472,7 @@ func TestTransactionDoubleNonce(t *testing.T) {
 	}
 
 	// Add the third transaction and ensure it's not saved (smaller price)
-	pool.add(tx3, false)
+	pool.add(tx3, false, nil)
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
@@ -449,7 +495,7 @@ func TestTransactionMissingNonce(t *testing.T) {
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	pool.currentState.AddBalance(addr, big.NewInt(100000000000000))
 	tx := transaction(1, 100000, key)
-	if _, err := pool.add(tx, false); err != nil {
+	if _, err := pool.add(tx, false, nil); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	if len(pool.pending) != 0 {
@@ -588,7 +634,7 @@ func TestTransactionPostponing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the postponing with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -801,7 +847,7 @@ func testTransactionQueueGlobalLimiting(t *testing.T, nolocals bool) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -891,7 +937,7 @@ func testTransactionQueueTimeLimiting(t *testing.T, nolocals bool) {
 	evictionInterval = time.Second
 
 	// Create the pool to test the non-expiration enforcement
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -994,7 +1040,7 @@ func TestTransactionPendingGlobalLimiting(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1040,7 +1086,7 @@ func TestTransactionCapClearsFromAll(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1074,7 +1120,7 @@ func TestTransactionPendingMinimumAllowance(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the limit enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1122,7 +1168,7 @@ func TestTransactionPoolRepricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1243,7 +1289,7 @@ func TestTransactionPoolRepricingKeepsLocals(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1305,7 +1351,7 @@ func TestTransactionPoolUnderpricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1404,6 +1450,276 @@ func TestTransactionPoolUnderpricing(t *testing.T) {
 	}
 }
 
+// testGatewayFee is a gateway fee requirement that the transactions created by
+// pricedTransaction don't meet, as they name no gateway fee recipient.
+var testGatewayFee = GatewayFeeRequirement{Recipient: common.HexToAddress("0xfee"), Minimum: big.NewInt(1)}
+
+// Tests that light client transactions not meeting the gateway fee requirement
+// are rejected, or deprioritized if so required.
+func TestTransactionPoolGatewayFee(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(100000000))
+
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{pricedTransaction(0, 100000, big.NewInt(1), key)}, testGatewayFee); errs[0] == nil {
+		t.Fatalf("transaction not meeting the gateway fee requirement accepted")
+	}
+	paying, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 100000, big.NewInt(1), nil, &testGatewayFee.Recipient, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{paying}, testGatewayFee); errs[0] != nil {
+		t.Fatalf("failed to add transaction meeting the gateway fee requirement: %v", errs[0])
+	}
+	if pool.all.IsDeprioritized(paying.Hash()) {
+		t.Fatalf("transaction meeting the gateway fee requirement deprioritized")
+	}
+	deprioritize := testGatewayFee
+	deprioritize.Deprioritize = true
+	dtx := pricedTransaction(1, 100000, big.NewInt(1), key)
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{dtx}, deprioritize); errs[0] != nil {
+		t.Fatalf("failed to add deprioritized transaction: %v", errs[0])
+	}
+	if !pool.all.IsDeprioritized(dtx.Hash()) {
+		t.Fatalf("transaction not meeting the gateway fee requirement not deprioritized")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that deprioritized transactions can't replace transactions that aren't
+// through the price bump, but can replace deprioritized ones.
+func TestTransactionPoolDeprioritizedReplacement(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(100000000))
+
+	deprioritize := testGatewayFee
+	deprioritize.Deprioritize = true
+
+	// Add a pending and a queued transaction, and fail to replace them with deprioritized ones
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(2, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	for _, nonce := range []uint64{0, 2} {
+		if errs := pool.AddLightRemotesSync([]*types.Transaction{pricedTransaction(nonce, 100000, big.NewInt(100), key)}, deprioritize); errs[0] != ErrReplaceUnderpriced {
+			t.Fatalf("deprioritized replacement of nonce %d error mismatch: have %v, want %v", nonce, errs[0], ErrReplaceUnderpriced)
+		}
+	}
+	// Ensure that a deprioritized transaction can be replaced with a bumped deprioritized one
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{pricedTransaction(1, 100000, big.NewInt(1), key)}, deprioritize); errs[0] != nil {
+		t.Fatalf("failed to add deprioritized transaction: %v", errs[0])
+	}
+	replacement := pricedTransaction(1, 100000, big.NewInt(2), key)
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{replacement}, deprioritize); errs[0] != nil {
+		t.Fatalf("failed to replace deprioritized transaction: %v", errs[0])
+	}
+	if pool.Get(replacement.Hash()) == nil || !pool.all.IsDeprioritized(replacement.Hash()) {
+		t.Fatalf("deprioritized replacement missing")
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that when the pool reaches its global transaction limit, deprioritized
+// transactions are rejected and are the first to be dropped for new ones,
+// regardless of their price.
+func TestTransactionPoolDeprioritized(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the deprioritization with
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.GlobalSlots = 2
+	config.GlobalQueue = 2
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	// Create a number of test accounts and fund them
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		pool.currentState.AddBalance(crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(100000000))
+	}
+	deprioritize := testGatewayFee
+	deprioritize.Deprioritize = true
+
+	// Fill the pool with a well priced deprioritized transaction and cheap ones
+	dtx := pricedTransaction(0, 100000, big.NewInt(10), keys[0])
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{dtx}, deprioritize); errs[0] != nil {
+		t.Fatalf("failed to add deprioritized transaction: %v", errs[0])
+	}
+	for i := 1; i < len(keys); i++ {
+		if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), keys[i])); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if pending, _ := pool.Stats(); pending != 4 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 4)
+	}
+	// Ensure that a deprioritized transaction doesn't displace anything, however well priced
+	if errs := pool.AddLightRemotesSync([]*types.Transaction{pricedTransaction(1, 100000, big.NewInt(100), keys[0])}, deprioritize); errs[0] != ErrUnderpriced {
+		t.Fatalf("adding deprioritized transaction to a full pool error mismatch: have %v, want %v", errs[0], ErrUnderpriced)
+	}
+	// Ensure that a new transaction drops the deprioritized one, even if cheaper
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(2), keys[1])); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if pool.Get(dtx.Hash()) != nil {
+		t.Fatalf("deprioritized transaction not dropped")
+	}
+	pending, queued := pool.Stats()
+	if pending != 4 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 4)
+	}
+	if queued != 0 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 0)
+	}
+	// With no deprioritized transactions left, the pricing rules apply again
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(1), keys[2])); err != ErrUnderpriced {
+		t.Fatalf("adding underpriced transaction error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that when the pool is full, the cheapest deprioritized transactions are
+// dropped first, whatever the order they were added in.
+func TestTransactionPoolDeprioritizedCheapestFirst(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the deprioritization with
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.GlobalSlots = 2
+	config.GlobalQueue = 2
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	// Create a number of test accounts and fund them
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		pool.currentState.AddBalance(crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(100000000))
+	}
+	deprioritize := testGatewayFee
+	deprioritize.Deprioritize = true
+
+	// Fill the pool with differently priced deprioritized transactions and a regular one
+	dtxs := []*types.Transaction{
+		pricedTransaction(0, 100000, big.NewInt(5), keys[0]),
+		pricedTransaction(0, 100000, big.NewInt(2), keys[1]),
+		pricedTransaction(0, 100000, big.NewInt(8), keys[2]),
+	}
+	for i, tx := range dtxs {
+		if errs := pool.AddLightRemotesSync([]*types.Transaction{tx}, deprioritize); errs[0] != nil {
+			t.Fatalf("failed to add deprioritized transaction %d: %v", i, errs[0])
+		}
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), keys[3])); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	// Ensure that new transactions drop the deprioritized ones from the cheapest
+	order := []int{1, 0, 2}
+	for i := range order {
+		if err := pool.addRemoteSync(pricedTransaction(uint64(i+1), 100000, big.NewInt(1), keys[3])); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+		for j, idx := range order {
+			if have, want := pool.Get(dtxs[idx].Hash()) != nil, j > i; have != want {
+				t.Fatalf("addition %d: deprioritized transaction %d presence mismatch: have %v, want %v", i, idx, have, want)
+			}
+		}
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that when a fee currency has used up its slots, cheap transactions paying
+// fees in it are rejected or dropped for better priced ones, while transactions
+// in other currencies and same nonce replacements are unaffected.
+func TestTransactionPoolCurrencySlots(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the currency slots with, and a token to pay fees in
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &systemTestBlockChain{&testBlockChain{statedb, 1000000, new(event.Feed)}}
+
+	token := common.HexToAddress("0xa")
+	statedb.SetCode(token, richTokenCode)
+
+	config := testTxPoolConfig
+	config.CurrencySlots = map[common.Address]uint64{token: 2}
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	// Create a number of test accounts and fund them
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		pool.currentState.AddBalance(crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(100000000))
+	}
+	// Fill the slots of the token
+	cheap := currencyPricedTransaction(0, 200000, big.NewInt(2), &token, keys[0])
+	if err := pool.addRemoteSync(cheap); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(currencyPricedTransaction(0, 200000, big.NewInt(3), &token, keys[1])); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	// Ensure that an underpriced transaction in the token is rejected, but not in Celo Gold
+	if err := pool.addRemoteSync(currencyPricedTransaction(0, 200000, big.NewInt(1), &token, keys[2])); err != ErrUnderpriced {
+		t.Fatalf("adding underpriced transaction error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), keys[3])); err != nil {
+		t.Fatalf("failed to add transaction in Celo Gold: %v", err)
+	}
+	// Ensure that a same nonce replacement doesn't drop another transaction in the token
+	replacement := currencyPricedTransaction(0, 200000, big.NewInt(10), &token, keys[1])
+	if err := pool.addRemoteSync(replacement); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	if pool.Get(cheap.Hash()) == nil {
+		t.Fatalf("transaction dropped for a same nonce replacement")
+	}
+	// Ensure that a well priced transaction in the token drops the cheapest one
+	if err := pool.addRemoteSync(currencyPricedTransaction(0, 200000, big.NewInt(4), &token, keys[2])); err != nil {
+		t.Fatalf("failed to add well priced transaction: %v", err)
+	}
+	if pool.Get(cheap.Hash()) != nil {
+		t.Fatalf("cheapest transaction in the token not dropped")
+	}
+	if stats := pool.StatsByCurrency()[token]; stats.Pending != 2 || stats.Slots != 2 {
+		t.Fatalf("token stats mismatch: have %+v, want 2 pending of 2 slots", stats)
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that more expensive transactions push out cheap ones from the pool, but
 // without producing instability by creating gaps that start jumping transactions
 // back and forth between queued/pending.
@@ -1411,7 +1727,7 @@ func TestTransactionPoolStableUnderpricing(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1476,7 +1792,7 @@ func TestTransactionDeduplication(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1542,7 +1858,7 @@ func TestTransactionReplacement(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the pricing enforcement with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
@@ -1637,7 +1953,7 @@ func testTransactionJournaling(t *testing.T, nolocals bool) {
 	os.Remove(journal)
 
 	// Create the original pool to inject transaction into the journal
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	config := testTxPoolConfig
@@ -1735,7 +2051,7 @@ func TestTransactionStatusCheck(t *testing.T) {
 	t.Parallel()
 
 	// Create the pool to test the status retrievals with
-	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
 
 	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
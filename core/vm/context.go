@@ -103,6 +103,7 @@ func NewEVMContext(msg Message, header *types.Header, chain ChainContext, txFeeR
 		Time:              new(big.Int).SetUint64(header.Time),
 		GasPrice:          new(big.Int).Set(msg.GasPrice()),
 		Engine:            engine,
+		Chain:             chain,
 	}
 }
 
@@ -28,15 +28,21 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/blake2b"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+	"github.com/ethereum/go-ethereum/crypto/bls12377"
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
 	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/ethereum/go-ethereum/crypto/ed25519"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/blake2s"
 	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
 )
 
 // PrecompiledContract is the basic interface for native Go contracts. The implementation
@@ -47,6 +53,25 @@ type PrecompiledContract interface {
 	Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) // Run runs the precompiled contract
 }
 
+// ActivePrecompiles returns the set of pre-compiled contracts enabled by the
+// given chain rules.
+func ActivePrecompiles(rules params.Rules) map[common.Address]PrecompiledContract {
+	switch {
+	case rules.IsGingerbread:
+		return PrecompiledContractsGingerbread
+	case rules.IsEspresso:
+		return PrecompiledContractsEspresso
+	case rules.IsDonut:
+		return PrecompiledContractsDonut
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
 // PrecompiledContractsHomestead contains the default set of pre-compiled Ethereum
 // contracts used in the Frontier and Homestead releases.
 var PrecompiledContractsHomestead = map[common.Address]PrecompiledContract{
@@ -73,6 +98,35 @@ var (
 	hashHeaderAddress            = celoPrecompileAddress(9)
 	getParentSealBitmapAddress   = celoPrecompileAddress(10)
 	getVerifiedSealBitmapAddress = celoPrecompileAddress(11)
+
+	bls12377G1AddAddress      = celoPrecompileAddress(12)
+	bls12377G1MulAddress      = celoPrecompileAddress(13)
+	bls12377G1MultiExpAddress = celoPrecompileAddress(14)
+	bls12377G2AddAddress      = celoPrecompileAddress(15)
+	bls12377G2MulAddress      = celoPrecompileAddress(16)
+	bls12377G2MultiExpAddress = celoPrecompileAddress(17)
+	bls12377PairingAddress    = celoPrecompileAddress(18)
+	bls12377MapToG1Address    = celoPrecompileAddress(19)
+	bls12377MapToG2Address    = celoPrecompileAddress(20)
+
+	bls12381G1AddAddress      = celoPrecompileAddress(21)
+	bls12381G1MulAddress      = celoPrecompileAddress(22)
+	bls12381G1MultiExpAddress = celoPrecompileAddress(23)
+	bls12381G2AddAddress      = celoPrecompileAddress(24)
+	bls12381G2MulAddress      = celoPrecompileAddress(25)
+	bls12381G2MultiExpAddress = celoPrecompileAddress(26)
+	bls12381PairingAddress    = celoPrecompileAddress(27)
+	bls12381MapToG1Address    = celoPrecompileAddress(28)
+	bls12381MapToG2Address    = celoPrecompileAddress(29)
+
+	ed25519VerifyAddress = celoPrecompileAddress(30)
+	sha3256Address       = celoPrecompileAddress(31)
+	keccak512Address     = celoPrecompileAddress(32)
+	blake2sAddress       = celoPrecompileAddress(33)
+
+	getBlockRandomnessAddress    = celoPrecompileAddress(34)
+	getEpochValidatorAddress     = celoPrecompileAddress(35)
+	numberEpochValidatorsAddress = celoPrecompileAddress(36)
 )
 
 // PrecompiledContractsByzantium contains the default set of pre-compiled Ethereum
@@ -126,6 +180,60 @@ var PrecompiledContractsIstanbul = map[common.Address]PrecompiledContract{
 	getVerifiedSealBitmapAddress: &getVerifiedSealBitmap{},
 }
 
+// PrecompiledContractsDonut contains the default set of pre-compiled Ethereum
+// contracts used in the Donut release.
+var PrecompiledContractsDonut = extendPrecompiles(PrecompiledContractsIstanbul, map[common.Address]PrecompiledContract{
+	bls12377G1AddAddress:      &bls12377G1Add{},
+	bls12377G1MulAddress:      &bls12377G1Mul{},
+	bls12377G1MultiExpAddress: &bls12377G1MultiExp{},
+	bls12377G2AddAddress:      &bls12377G2Add{},
+	bls12377G2MulAddress:      &bls12377G2Mul{},
+	bls12377G2MultiExpAddress: &bls12377G2MultiExp{},
+	bls12377PairingAddress:    &bls12377Pairing{},
+	bls12377MapToG1Address:    &bls12377MapToG1{},
+	bls12377MapToG2Address:    &bls12377MapToG2{},
+
+	bls12381G1AddAddress:      &bls12381G1Add{},
+	bls12381G1MulAddress:      &bls12381G1Mul{},
+	bls12381G1MultiExpAddress: &bls12381G1MultiExp{},
+	bls12381G2AddAddress:      &bls12381G2Add{},
+	bls12381G2MulAddress:      &bls12381G2Mul{},
+	bls12381G2MultiExpAddress: &bls12381G2MultiExp{},
+	bls12381PairingAddress:    &bls12381Pairing{},
+	bls12381MapToG1Address:    &bls12381MapToG1{},
+	bls12381MapToG2Address:    &bls12381MapToG2{},
+})
+
+// PrecompiledContractsEspresso contains the default set of pre-compiled Ethereum
+// contracts used in the Espresso release.
+var PrecompiledContractsEspresso = extendPrecompiles(PrecompiledContractsDonut, map[common.Address]PrecompiledContract{
+	ed25519VerifyAddress: &ed25519Verify{},
+	sha3256Address:       &sha3256hash{},
+	keccak512Address:     &keccak512hash{},
+	blake2sAddress:       &blake2shash{},
+})
+
+// PrecompiledContractsGingerbread contains the default set of pre-compiled Ethereum
+// contracts used in the Gingerbread release.
+var PrecompiledContractsGingerbread = extendPrecompiles(PrecompiledContractsEspresso, map[common.Address]PrecompiledContract{
+	getBlockRandomnessAddress:    &getBlockRandomness{},
+	getEpochValidatorAddress:     &getEpochValidator{},
+	numberEpochValidatorsAddress: &numberEpochValidators{},
+})
+
+// extendPrecompiles returns the pre-compiled contracts of a release, made of
+// those of the previous release and the ones added or replaced by the release.
+func extendPrecompiles(previous, added map[common.Address]PrecompiledContract) map[common.Address]PrecompiledContract {
+	precompiles := make(map[common.Address]PrecompiledContract, len(previous)+len(added))
+	for addr, p := range previous {
+		precompiles[addr] = p
+	}
+	for addr, p := range added {
+		precompiles[addr] = p
+	}
+	return precompiles
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract, evm *EVM) (ret []byte, err error) {
 	log.Trace("Running precompiled contract", "codeaddr", contract.CodeAddr, "input", input, "caller", contract.CallerAddress, "gas", contract.Gas)
@@ -727,6 +835,94 @@ func (c *proofOfPossession) Run(input []byte, caller common.Address, evm *EVM, g
 	return true32Byte, gas, nil
 }
 
+// ed25519Verify verifies an Ed25519 signature as specified in RFC 8032, with the
+// acceptance rules pinned by crypto/ed25519 rather than those of the standard
+// library of the Go release the node was built with.
+type ed25519Verify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *ed25519Verify) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Ed25519VerifyPerWordGas + params.Ed25519VerifyGas
+}
+
+func (c *ed25519Verify) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of 3 arguments:
+	//   publicKey: 32 bytes, representing the Ed25519 public key
+	//   signature: 64 bytes, representing the Ed25519 signature on `message`
+	//   message:   the remaining bytes, representing the signed message
+	if len(input) < ed25519.PublicKeySize+ed25519.SignatureSize {
+		return nil, gas, ErrInputLength
+	}
+	publicKey := input[:ed25519.PublicKeySize]
+	signature := input[ed25519.PublicKeySize : ed25519.PublicKeySize+ed25519.SignatureSize]
+	message := input[ed25519.PublicKeySize+ed25519.SignatureSize:]
+
+	if ed25519.Verify(publicKey, message, signature) {
+		return true32Byte, gas, nil
+	}
+	return false32Byte, gas, nil
+}
+
+// SHA3-256 implemented as a native contract.
+type sha3256hash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *sha3256hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Sha3256PerWordGas + params.Sha3256BaseGas
+}
+
+func (c *sha3256hash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	h := sha3.Sum256(input)
+	return h[:], gas, nil
+}
+
+// Keccak-512 implemented as a native contract.
+type keccak512hash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *keccak512hash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Keccak512PerWordGas + params.Keccak512BaseGas
+}
+
+func (c *keccak512hash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	keccak := sha3.NewLegacyKeccak512()
+	keccak.Write(input)
+	return keccak.Sum(nil), gas, nil
+}
+
+// BLAKE2s-256 implemented as a native contract.
+type blake2shash struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blake2shash) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.Blake2sPerWordGas + params.Blake2sBaseGas
+}
+
+func (c *blake2shash) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	h := blake2s.Sum256(input)
+	return h[:], gas, nil
+}
+
 // bn256PairingIstanbul implements a pairing pre-compile for the bn256 curve
 // conforming to Istanbul consensus rules.
 type bn256PairingIstanbul struct{}
@@ -915,6 +1111,140 @@ func (c *numberValidators) Run(input []byte, caller common.Address, evm *EVM, ga
 	return numberValidatorsBytes, gas, nil
 }
 
+// epochValidators returns the validator set elected for the given epoch, or an error if the epoch
+// has not started by the current, possibly unsealed, block.
+func epochValidators(epochNumber *big.Int, evm *EVM) ([]istanbul.Validator, error) {
+	epochSize := evm.Context.Engine.EpochSize()
+	currentEpoch := istanbul.GetEpochNumber(evm.Context.BlockNumber.Uint64(), epochSize)
+	if epochNumber.Sign() == 0 || epochNumber.Cmp(new(big.Int).SetUint64(currentEpoch)) > 0 {
+		return nil, ErrBlockNumberOutOfBounds
+	}
+
+	// The validators elected for an epoch are those that sign its first block.
+	firstBlockNumber, err := istanbul.GetEpochFirstBlockNumber(epochNumber.Uint64(), epochSize)
+	if err != nil {
+		return nil, ErrBlockNumberOutOfBounds
+	}
+
+	// Note: Passing empty hash as here as it is an extra expense and the hash is not actually used.
+	return evm.Context.Engine.GetValidators(new(big.Int).SetUint64(firstBlockNumber-1), common.Hash{}), nil
+}
+
+type getEpochValidator struct{}
+
+func (c *getEpochValidator) RequiredGas(input []byte) uint64 {
+	return params.GetValidatorGas
+}
+
+// Return the validator at the given index in the validator set elected for the given epoch. The epoch must have
+// started by the current, possibly unsealed, block.
+// WARNING: Validator set is always constructed from the canonical chain, therefore this precompile is undefined
+// if the engine is aware of a chain with higher total difficulty.
+func (c *getEpochValidator) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of two arguments:
+	//   index: 32 byte integer representing the index of the validator to get
+	//   epochNumber: 32 byte integer representing the epoch to access
+	if len(input) < 64 {
+		return nil, gas, ErrInputLength
+	}
+
+	index := new(big.Int).SetBytes(input[0:32])
+	epochNumber := new(big.Int).SetBytes(input[32:64])
+
+	validators, err := epochValidators(epochNumber, evm)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Ensure index, which is guaranteed to be non-negative, is valid.
+	if index.Cmp(big.NewInt(int64(len(validators)))) >= 0 {
+		return nil, gas, ErrValidatorsOutOfBounds
+	}
+
+	validatorAddress := validators[index.Uint64()].Address()
+	addressBytes := common.LeftPadBytes(validatorAddress[:], 32)
+
+	return addressBytes, gas, nil
+}
+
+type numberEpochValidators struct{}
+
+func (c *numberEpochValidators) RequiredGas(input []byte) uint64 {
+	return params.GetValidatorGas
+}
+
+// Return the number of validators in the validator set elected for the given epoch. The epoch must have started
+// by the current, possibly unsealed, block.
+// WARNING: Validator set is always constructed from the canonical chain, therefore this precompile is undefined
+// if the engine is aware of a chain with higher total difficulty.
+func (c *numberEpochValidators) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single argument:
+	//   epochNumber: 32 byte integer representing the epoch to access
+	if len(input) < 32 {
+		return nil, gas, ErrInputLength
+	}
+
+	validators, err := epochValidators(new(big.Int).SetBytes(input[0:32]), evm)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	numberValidators := big.NewInt(int64(len(validators))).Bytes()
+	numberValidatorsBytes := common.LeftPadBytes(numberValidators[:], 32)
+	return numberValidatorsBytes, gas, nil
+}
+
+type getBlockRandomness struct{}
+
+func (c *getBlockRandomness) RequiredGas(input []byte) uint64 {
+	return params.GetBlockRandomnessGas
+}
+
+// Return the randomness revealed in the given, previously sealed, block. The value is read from the Random
+// contract, which only keeps a bounded history, and the gas used by that call is charged to the caller.
+func (c *getBlockRandomness) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// input is comprised of a single argument:
+	//   blockNumber: 32 byte integer representing the block number to access
+	if len(input) < 32 {
+		return nil, gas, ErrInputLength
+	}
+
+	blockNumber := new(big.Int).SetBytes(input[0:32])
+
+	// Ensure the request is for the randomness of a previously sealed block.
+	if blockNumber.Cmp(common.Big0) == 0 || blockNumber.Cmp(evm.Context.BlockNumber) >= 0 {
+		return nil, gas, ErrBlockNumberOutOfBounds
+	}
+
+	randomAddress, err := GetRegisteredAddressWithEvm(params.RandomRegistryId, evm)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	var randomness common.Hash
+	gas, err = evm.StaticCallFromSystem(*randomAddress, randomABI, "getBlockRandomness", []interface{}{blockNumber}, &randomness, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	return randomness.Bytes(), gas, nil
+}
+
 type epochSize struct{}
 
 func (c *epochSize) RequiredGas(input []byte) uint64 {
@@ -1062,3 +1392,857 @@ func (c *getVerifiedSealBitmap) Run(input []byte, caller common.Address, evm *EV
 
 	return common.LeftPadBytes(extra.AggregatedSeal.Bitmap.Bytes()[:], 32), gas, nil
 }
+
+var (
+	errBLS12InvalidFieldElementTopBytes = errors.New("invalid field element top bytes")
+	errBLS12G1PointSubgroup             = errors.New("g1 point is not on correct subgroup")
+	errBLS12G2PointSubgroup             = errors.New("g2 point is not on correct subgroup")
+)
+
+// The BLS12-377 and BLS12-381 precompiles share the EIP-2537 interface: field
+// elements are 64 byte big endian integers whose top 16 bytes are zero, G1 and
+// G2 points are the affine coordinates of the point (with the c0 coefficient of
+// an Fp2 element first) and the point at infinity is all zeroes.
+
+// decodeBLS12FieldElement decodes a BLS12-377 or BLS12-381 field element.
+// Removes top 16 bytes of 64 byte input.
+func decodeBLS12FieldElement(in []byte) ([]byte, error) {
+	if len(in) != 64 {
+		return nil, errors.New("invalid field element length")
+	}
+	// check top bytes
+	for i := 0; i < 16; i++ {
+		if in[i] != byte(0x00) {
+			return nil, errBLS12InvalidFieldElementTopBytes
+		}
+	}
+	out := make([]byte, 48)
+	copy(out[:], in[16:])
+	return out, nil
+}
+
+// bls12MultiExpGas returns the gas of a multi scalar multiplication of k
+// points, each of which costs mulGas on its own, discounted as EIP-2537 does.
+func bls12MultiExpGas(k int, mulGas uint64) uint64 {
+	if k == 0 {
+		// Return 0 gas for small input length
+		return 0
+	}
+	// Lookup discount value for point, scalar value pair length
+	var discount uint64
+	if dLen := len(params.Bls12MultiExpDiscountTable); k < dLen {
+		discount = params.Bls12MultiExpDiscountTable[k-1]
+	} else {
+		discount = params.Bls12MultiExpDiscountTable[dLen-1]
+	}
+	// Calculate gas and return the result
+	return (uint64(k) * mulGas * discount) / 1000
+}
+
+// bls12377G1Add implements G1 point addition on BLS12-377.  The input is two G1
+// points, which are not required to be in the prime order subgroup.
+type bls12377G1Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12377G1AddGas
+}
+
+func (c *bls12377G1Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 addition call expects `256` bytes as an input that is interpreted as byte concatenation of two G1 points (`128` bytes each).
+	// Output is an encoding of addition operation result - single G1 point (`128` bytes).
+	if len(input) != 256 {
+		return nil, gas, ErrInputLength
+	}
+	var p0, p1 *bls12377.PointG1
+
+	// Initialize G1
+	g := bls12377.NewG1()
+
+	// Decode G1 point p_0
+	if p0, err = g.DecodePoint(input[:128]); err != nil {
+		return nil, gas, err
+	}
+	// Decode G1 point p_1
+	if p1, err = g.DecodePoint(input[128:]); err != nil {
+		return nil, gas, err
+	}
+
+	// Compute r = p_0 + p_1
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	// Encode the G1 point result into 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377G1Mul implements G1 scalar multiplication on BLS12-377.
+type bls12377G1Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12377G1MulGas
+}
+
+func (c *bls12377G1Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 multiplication call expects `160` bytes as an input that is interpreted as byte concatenation of encoding of G1 point (`128` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiplication operation result - single G1 point (`128` bytes).
+	if len(input) != 160 {
+		return nil, gas, ErrInputLength
+	}
+	var p0 *bls12377.PointG1
+
+	// Initialize G1
+	g := bls12377.NewG1()
+
+	// Decode G1 point
+	if p0, err = g.DecodePoint(input[:128]); err != nil {
+		return nil, gas, err
+	}
+	// Decode scalar value
+	e := new(big.Int).SetBytes(input[128:])
+
+	// Compute r = e * p_0
+	r := g.New()
+	g.MulScalar(r, p0, e)
+
+	// Encode the G1 point into 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377G1MultiExp implements G1 multi scalar multiplication on BLS12-377.
+type bls12377G1MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G1MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(len(input)/160, params.Bls12377G1MulGas)
+}
+
+func (c *bls12377G1MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 multiplication call expects `160*k` bytes as an input that is interpreted as byte concatenation of `k` slices each of them being a byte concatenation of encoding of G1 point (`128` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiexponentiation operation result - single G1 point (`128` bytes).
+	k := len(input) / 160
+	if len(input) == 0 || len(input)%160 != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]*bls12377.PointG1, k)
+	scalars := make([]*big.Int, k)
+
+	// Initialize G1
+	g := bls12377.NewG1()
+
+	// Decode point scalar pairs
+	for i := 0; i < k; i++ {
+		off := 160 * i
+		t0, t1, t2 := off, off+128, off+160
+		// Decode G1 point
+		if points[i], err = g.DecodePoint(input[t0:t1]); err != nil {
+			return nil, gas, err
+		}
+		// Decode scalar value
+		scalars[i] = new(big.Int).SetBytes(input[t1:t2])
+	}
+
+	// Compute r = e_0 * p_0 + e_1 * p_1 + ... + e_(k-1) * p_(k-1)
+	r := g.New()
+	g.MultiExp(r, points, scalars)
+
+	// Encode the G1 point to 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377G2Add implements G2 point addition on BLS12-377.  The input is two G2
+// points, which are not required to be in the prime order subgroup.
+type bls12377G2Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12377G2AddGas
+}
+
+func (c *bls12377G2Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 addition call expects `512` bytes as an input that is interpreted as byte concatenation of two G2 points (`256` bytes each).
+	// Output is an encoding of addition operation result - single G2 point (`256` bytes).
+	if len(input) != 512 {
+		return nil, gas, ErrInputLength
+	}
+	var p0, p1 *bls12377.PointG2
+
+	// Initialize G2
+	g := bls12377.NewG2()
+	r := g.New()
+
+	// Decode G2 point p_0
+	if p0, err = g.DecodePoint(input[:256]); err != nil {
+		return nil, gas, err
+	}
+	// Decode G2 point p_1
+	if p1, err = g.DecodePoint(input[256:]); err != nil {
+		return nil, gas, err
+	}
+
+	// Compute r = p_0 + p_1
+	g.Add(r, p0, p1)
+
+	// Encode the G2 point into 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377G2Mul implements G2 scalar multiplication on BLS12-377.
+type bls12377G2Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12377G2MulGas
+}
+
+func (c *bls12377G2Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 multiplication call expects `288` bytes as an input that is interpreted as byte concatenation of encoding of G2 point (`256` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiplication operation result - single G2 point (`256` bytes).
+	if len(input) != 288 {
+		return nil, gas, ErrInputLength
+	}
+	var p0 *bls12377.PointG2
+
+	// Initialize G2
+	g := bls12377.NewG2()
+
+	// Decode G2 point
+	if p0, err = g.DecodePoint(input[:256]); err != nil {
+		return nil, gas, err
+	}
+	// Decode scalar value
+	e := new(big.Int).SetBytes(input[256:])
+
+	// Compute r = e * p_0
+	r := g.New()
+	g.MulScalar(r, p0, e)
+
+	// Encode the G2 point into 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377G2MultiExp implements G2 multi scalar multiplication on BLS12-377.
+type bls12377G2MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377G2MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(len(input)/288, params.Bls12377G2MulGas)
+}
+
+func (c *bls12377G2MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 multiplication call expects `288*k` bytes as an input that is interpreted as byte concatenation of `k` slices each of them being a byte concatenation of encoding of G2 point (`256` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiexponentiation operation result - single G2 point (`256` bytes).
+	k := len(input) / 288
+	if len(input) == 0 || len(input)%288 != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]*bls12377.PointG2, k)
+	scalars := make([]*big.Int, k)
+
+	// Initialize G2
+	g := bls12377.NewG2()
+
+	// Decode point scalar pairs
+	for i := 0; i < k; i++ {
+		off := 288 * i
+		t0, t1, t2 := off, off+256, off+288
+		// Decode G2 point
+		if points[i], err = g.DecodePoint(input[t0:t1]); err != nil {
+			return nil, gas, err
+		}
+		// Decode scalar value
+		scalars[i] = new(big.Int).SetBytes(input[t1:t2])
+	}
+
+	// Compute r = e_0 * p_0 + e_1 * p_1 + ... + e_(k-1) * p_(k-1)
+	r := g.New()
+	g.MultiExp(r, points, scalars)
+
+	// Encode the G2 point to 256 bytes.
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377Pairing implements a pairing check on BLS12-377.
+type bls12377Pairing struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12377PairingBaseGas + uint64(len(input)/384)*params.Bls12377PairingPerPairGas
+}
+
+func (c *bls12377Pairing) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Pairing call expects `384*k` bytes as an inputs that is interpreted as byte concatenation of `k` slices. Each slice has the following structure:
+	// - `128` bytes of G1 point encoding
+	// - `256` bytes of G2 point encoding
+	// Output is a `32` bytes where last single byte is `0x01` if pairing result is equal to multiplicative identity in a pairing target field and `0x00` otherwise.
+	k := len(input) / 384
+	if len(input) == 0 || len(input)%384 != 0 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Initialize BLS12-377 pairing engine
+	e := bls12377.NewPairingEngine()
+	g1, g2 := e.G1, e.G2
+
+	// Decode pairs
+	for i := 0; i < k; i++ {
+		off := 384 * i
+		t0, t1, t2 := off, off+128, off+384
+
+		// Decode G1 point
+		p1, err := g1.DecodePoint(input[t0:t1])
+		if err != nil {
+			return nil, gas, err
+		}
+		// Decode G2 point
+		p2, err := g2.DecodePoint(input[t1:t2])
+		if err != nil {
+			return nil, gas, err
+		}
+
+		// 'point is on curve' check already done,
+		// Here we need to apply subgroup checks.
+		if !g1.InCorrectSubgroup(p1) {
+			return nil, gas, errBLS12G1PointSubgroup
+		}
+		if !g2.InCorrectSubgroup(p2) {
+			return nil, gas, errBLS12G2PointSubgroup
+		}
+
+		// Update pairing engine with G1 and G2 points
+		e.AddPair(p1, p2)
+	}
+
+	// Compute pairing and set the result
+	if e.Check() {
+		return true32Byte, gas, nil
+	}
+	return false32Byte, gas, nil
+}
+
+// bls12377MapToG1 maps a base field element to a G1 point on BLS12-377377 by try-and-increment:
+// x is incremented until x^3+1 is a square, and the smaller square root is y.
+type bls12377MapToG1 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377MapToG1) RequiredGas(input []byte) uint64 {
+	return params.Bls12377MapG1Gas
+}
+
+func (c *bls12377MapToG1) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Field-to-curve call expects `64` bytes an an input that is interpreted as a an element of the base field.
+	// Output of this call is `128` bytes and is G1 point following respective encoding rules.
+	if len(input) != 64 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Decode input field element
+	fe, err := decodeBLS12FieldElement(input)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Initialize G1
+	g := bls12377.NewG1()
+
+	// Compute mapping
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Encode the G1 point to 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12377MapToG2 maps a quadratic extension field element to a G2 point on
+// BLS12-377377 by try-and-increment, as bls12377MapToG1 does.
+type bls12377MapToG2 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12377MapToG2) RequiredGas(input []byte) uint64 {
+	return params.Bls12377MapG2Gas
+}
+
+func (c *bls12377MapToG2) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Field-to-curve call expects `128` bytes an an input that is interpreted as a an element of the quadratic extension field.
+	// Output of this call is `256` bytes and is G2 point following respective encoding rules.
+	if len(input) != 128 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Decode input field element
+	fe := make([]byte, 96)
+	c0, err := decodeBLS12FieldElement(input[:64])
+	if err != nil {
+		return nil, gas, err
+	}
+	copy(fe[48:], c0)
+	c1, err := decodeBLS12FieldElement(input[64:])
+	if err != nil {
+		return nil, gas, err
+	}
+	copy(fe[:48], c1)
+
+	// Initialize G2
+	g := bls12377.NewG2()
+
+	// Compute mapping
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Encode the G2 point to 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G1Add implements G1 point addition on BLS12-381.  The input is two G1
+// points, which are not required to be in the prime order subgroup.
+type bls12381G1Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G1AddGas
+}
+
+func (c *bls12381G1Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 addition call expects `256` bytes as an input that is interpreted as byte concatenation of two G1 points (`128` bytes each).
+	// Output is an encoding of addition operation result - single G1 point (`128` bytes).
+	if len(input) != 256 {
+		return nil, gas, ErrInputLength
+	}
+	var p0, p1 *bls12381.PointG1
+
+	// Initialize G1
+	g := bls12381.NewG1()
+
+	// Decode G1 point p_0
+	if p0, err = g.DecodePoint(input[:128]); err != nil {
+		return nil, gas, err
+	}
+	// Decode G1 point p_1
+	if p1, err = g.DecodePoint(input[128:]); err != nil {
+		return nil, gas, err
+	}
+
+	// Compute r = p_0 + p_1
+	r := g.New()
+	g.Add(r, p0, p1)
+
+	// Encode the G1 point result into 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G1Mul implements G1 scalar multiplication on BLS12-381.
+type bls12381G1Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G1MulGas
+}
+
+func (c *bls12381G1Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 multiplication call expects `160` bytes as an input that is interpreted as byte concatenation of encoding of G1 point (`128` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiplication operation result - single G1 point (`128` bytes).
+	if len(input) != 160 {
+		return nil, gas, ErrInputLength
+	}
+	var p0 *bls12381.PointG1
+
+	// Initialize G1
+	g := bls12381.NewG1()
+
+	// Decode G1 point
+	if p0, err = g.DecodePoint(input[:128]); err != nil {
+		return nil, gas, err
+	}
+	// Decode scalar value
+	e := new(big.Int).SetBytes(input[128:])
+
+	// Compute r = e * p_0
+	r := g.New()
+	g.MulScalar(r, p0, e)
+
+	// Encode the G1 point into 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G1MultiExp implements G1 multi scalar multiplication on BLS12-381.
+type bls12381G1MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(len(input)/160, params.Bls12381G1MulGas)
+}
+
+func (c *bls12381G1MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G1 multiplication call expects `160*k` bytes as an input that is interpreted as byte concatenation of `k` slices each of them being a byte concatenation of encoding of G1 point (`128` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiexponentiation operation result - single G1 point (`128` bytes).
+	k := len(input) / 160
+	if len(input) == 0 || len(input)%160 != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]*bls12381.PointG1, k)
+	scalars := make([]*big.Int, k)
+
+	// Initialize G1
+	g := bls12381.NewG1()
+
+	// Decode point scalar pairs
+	for i := 0; i < k; i++ {
+		off := 160 * i
+		t0, t1, t2 := off, off+128, off+160
+		// Decode G1 point
+		if points[i], err = g.DecodePoint(input[t0:t1]); err != nil {
+			return nil, gas, err
+		}
+		// Decode scalar value
+		scalars[i] = new(big.Int).SetBytes(input[t1:t2])
+	}
+
+	// Compute r = e_0 * p_0 + e_1 * p_1 + ... + e_(k-1) * p_(k-1)
+	r := g.New()
+	g.MultiExp(r, points, scalars)
+
+	// Encode the G1 point to 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G2Add implements G2 point addition on BLS12-381.  The input is two G2
+// points, which are not required to be in the prime order subgroup.
+type bls12381G2Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2AddGas
+}
+
+func (c *bls12381G2Add) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 addition call expects `512` bytes as an input that is interpreted as byte concatenation of two G2 points (`256` bytes each).
+	// Output is an encoding of addition operation result - single G2 point (`256` bytes).
+	if len(input) != 512 {
+		return nil, gas, ErrInputLength
+	}
+	var p0, p1 *bls12381.PointG2
+
+	// Initialize G2
+	g := bls12381.NewG2()
+	r := g.New()
+
+	// Decode G2 point p_0
+	if p0, err = g.DecodePoint(input[:256]); err != nil {
+		return nil, gas, err
+	}
+	// Decode G2 point p_1
+	if p1, err = g.DecodePoint(input[256:]); err != nil {
+		return nil, gas, err
+	}
+
+	// Compute r = p_0 + p_1
+	g.Add(r, p0, p1)
+
+	// Encode the G2 point into 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G2Mul implements G2 scalar multiplication on BLS12-381.
+type bls12381G2Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2MulGas
+}
+
+func (c *bls12381G2Mul) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 multiplication call expects `288` bytes as an input that is interpreted as byte concatenation of encoding of G2 point (`256` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiplication operation result - single G2 point (`256` bytes).
+	if len(input) != 288 {
+		return nil, gas, ErrInputLength
+	}
+	var p0 *bls12381.PointG2
+
+	// Initialize G2
+	g := bls12381.NewG2()
+
+	// Decode G2 point
+	if p0, err = g.DecodePoint(input[:256]); err != nil {
+		return nil, gas, err
+	}
+	// Decode scalar value
+	e := new(big.Int).SetBytes(input[256:])
+
+	// Compute r = e * p_0
+	r := g.New()
+	g.MulScalar(r, p0, e)
+
+	// Encode the G2 point into 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381G2MultiExp implements G2 multi scalar multiplication on BLS12-381.
+type bls12381G2MultiExp struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2MultiExp) RequiredGas(input []byte) uint64 {
+	return bls12MultiExpGas(len(input)/288, params.Bls12381G2MulGas)
+}
+
+func (c *bls12381G2MultiExp) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// G2 multiplication call expects `288*k` bytes as an input that is interpreted as byte concatenation of `k` slices each of them being a byte concatenation of encoding of G2 point (`256` bytes) and encoding of a scalar value (`32` bytes).
+	// Output is an encoding of multiexponentiation operation result - single G2 point (`256` bytes).
+	k := len(input) / 288
+	if len(input) == 0 || len(input)%288 != 0 {
+		return nil, gas, ErrInputLength
+	}
+	points := make([]*bls12381.PointG2, k)
+	scalars := make([]*big.Int, k)
+
+	// Initialize G2
+	g := bls12381.NewG2()
+
+	// Decode point scalar pairs
+	for i := 0; i < k; i++ {
+		off := 288 * i
+		t0, t1, t2 := off, off+256, off+288
+		// Decode G2 point
+		if points[i], err = g.DecodePoint(input[t0:t1]); err != nil {
+			return nil, gas, err
+		}
+		// Decode scalar value
+		scalars[i] = new(big.Int).SetBytes(input[t1:t2])
+	}
+
+	// Compute r = e_0 * p_0 + e_1 * p_1 + ... + e_(k-1) * p_(k-1)
+	r := g.New()
+	g.MultiExp(r, points, scalars)
+
+	// Encode the G2 point to 256 bytes.
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381Pairing implements a pairing check on BLS12-381.
+type bls12381Pairing struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12381PairingBaseGas + uint64(len(input)/384)*params.Bls12381PairingPerPairGas
+}
+
+func (c *bls12381Pairing) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Pairing call expects `384*k` bytes as an inputs that is interpreted as byte concatenation of `k` slices. Each slice has the following structure:
+	// - `128` bytes of G1 point encoding
+	// - `256` bytes of G2 point encoding
+	// Output is a `32` bytes where last single byte is `0x01` if pairing result is equal to multiplicative identity in a pairing target field and `0x00` otherwise.
+	k := len(input) / 384
+	if len(input) == 0 || len(input)%384 != 0 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Initialize BLS12-381 pairing engine
+	e := bls12381.NewPairingEngine()
+	g1, g2 := e.G1, e.G2
+
+	// Decode pairs
+	for i := 0; i < k; i++ {
+		off := 384 * i
+		t0, t1, t2 := off, off+128, off+384
+
+		// Decode G1 point
+		p1, err := g1.DecodePoint(input[t0:t1])
+		if err != nil {
+			return nil, gas, err
+		}
+		// Decode G2 point
+		p2, err := g2.DecodePoint(input[t1:t2])
+		if err != nil {
+			return nil, gas, err
+		}
+
+		// 'point is on curve' check already done,
+		// Here we need to apply subgroup checks.
+		if !g1.InCorrectSubgroup(p1) {
+			return nil, gas, errBLS12G1PointSubgroup
+		}
+		if !g2.InCorrectSubgroup(p2) {
+			return nil, gas, errBLS12G2PointSubgroup
+		}
+
+		// Update pairing engine with G1 and G2 points
+		e.AddPair(p1, p2)
+	}
+
+	// Compute pairing and set the result
+	if e.Check() {
+		return true32Byte, gas, nil
+	}
+	return false32Byte, gas, nil
+}
+
+// bls12381MapToG1 maps a base field element to a G1 point on BLS12-381381 with the simplified
+// SWU map of EIP-2537.
+type bls12381MapToG1 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapToG1) RequiredGas(input []byte) uint64 {
+	return params.Bls12381MapG1Gas
+}
+
+func (c *bls12381MapToG1) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Field-to-curve call expects `64` bytes an an input that is interpreted as a an element of the base field.
+	// Output of this call is `128` bytes and is G1 point following respective encoding rules.
+	if len(input) != 64 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Decode input field element
+	fe, err := decodeBLS12FieldElement(input)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Initialize G1
+	g := bls12381.NewG1()
+
+	// Compute mapping
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Encode the G1 point to 128 bytes
+	return g.EncodePoint(r), gas, nil
+}
+
+// bls12381MapToG2 maps a quadratic extension field element to a G2 point on
+// BLS12-381381 with the simplified SWU map of EIP-2537.
+type bls12381MapToG2 struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381MapToG2) RequiredGas(input []byte) uint64 {
+	return params.Bls12381MapG2Gas
+}
+
+func (c *bls12381MapToG2) Run(input []byte, caller common.Address, evm *EVM, gas uint64) ([]byte, uint64, error) {
+	gas, err := debitRequiredGas(c, input, gas)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Field-to-curve call expects `128` bytes an an input that is interpreted as a an element of the quadratic extension field.
+	// Output of this call is `256` bytes and is G2 point following respective encoding rules.
+	if len(input) != 128 {
+		return nil, gas, ErrInputLength
+	}
+
+	// Decode input field element
+	fe := make([]byte, 96)
+	c0, err := decodeBLS12FieldElement(input[:64])
+	if err != nil {
+		return nil, gas, err
+	}
+	copy(fe[48:], c0)
+	c1, err := decodeBLS12FieldElement(input[64:])
+	if err != nil {
+		return nil, gas, err
+	}
+	copy(fe[:48], c1)
+
+	// Initialize G2
+	g := bls12381.NewG2()
+
+	// Compute mapping
+	r, err := g.MapToCurve(fe)
+	if err != nil {
+		return nil, gas, err
+	}
+
+	// Encode the G2 point to 256 bytes
+	return g.EncodePoint(r), gas, nil
+}
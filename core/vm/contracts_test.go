@@ -18,7 +18,9 @@ package vm
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"reflect"
 	"testing"
@@ -637,6 +639,114 @@ var numberValidatorsTests = []precompiledTest{
 	},
 }
 
+var getEpochValidatorTests = []precompiledTest{
+	// Input is { validator index | epoch number }. Output is validator address.
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "input_invalid_empty",
+		errorExpected: true,
+	},
+	{
+		input:         "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected:      "block number out of bounds",
+		name:          "invalid_epoch_0",
+		errorExpected: true,
+	},
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001",
+		expected: "000000000000000000000000875cd0c4b3b114f762abf222c47780f217dcd6ce",
+		name:     "correct_epoch_1_index_0x0",
+	},
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003",
+		expected: "0000000000000000000000007dc62a173f27ac23172ee5457e9f44be0f5b69b3",
+		name:     "correct_epoch_3_index_0x0",
+	},
+	{
+		input:    "000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000003",
+		expected: "000000000000000000000000e1f0ead4d20049c16b5b762ebb0394849e7c9492",
+		name:     "correct_epoch_3_index_0xa",
+	},
+	{
+		input:         "00000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000003",
+		expected:      "validator index out of bounds",
+		name:          "invalid_index_out_of_bounds",
+		errorExpected: true,
+	},
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000064",
+		expected: "00000000000000000000000004cd14eb65b241fb67eec4165feaef526673c269",
+		name:     "correct_current_epoch",
+	},
+	{
+		input:         "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000065",
+		expected:      "block number out of bounds",
+		name:          "invalid_future_epoch",
+		errorExpected: true,
+	},
+}
+
+var numberEpochValidatorsTests = []precompiledTest{
+	// Input is epoch number. Output is validator set size.
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "input_invalid_empty",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000",
+		expected:      "block number out of bounds",
+		name:          "invalid_epoch_0",
+		errorExpected: true,
+	},
+	{
+		input:    "0000000000000000000000000000000000000000000000000000000000000003",
+		expected: "0000000000000000000000000000000000000000000000000000000000000010",
+		name:     "correct_epoch_3",
+	},
+	{
+		input:    "0000000000000000000000000000000000000000000000000000000000000064",
+		expected: "0000000000000000000000000000000000000000000000000000000000000010",
+		name:     "correct_current_epoch",
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000065",
+		expected:      "block number out of bounds",
+		name:          "invalid_future_epoch",
+		errorExpected: true,
+	},
+}
+
+var getBlockRandomnessTests = []precompiledTest{
+	// Input is block number. Output is the randomness revealed in that block.
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "input_invalid_empty",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000000000",
+		expected:      "block number out of bounds",
+		name:          "invalid_genesis_block",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000002710",
+		expected:      "block number out of bounds",
+		name:          "invalid_current_block",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000000000000000000000000000000002711",
+		expected:      "block number out of bounds",
+		name:          "invalid_future_block",
+		errorExpected: true,
+	},
+}
+
 var getParentSealBitmapTests = []precompiledTest{
 	// Input is block number. Output is bitmap.
 	{
@@ -705,8 +815,341 @@ var getVerifiedSealBitmapTests = []precompiledTest{
 	},
 }
 
+// Test vectors from RFC 8032, section 7.1
+var ed25519VerifyTests = []precompiledTest{
+	{
+		input:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "rfc8032_test_1",
+	},
+	{
+		input:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0072",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "rfc8032_test_2",
+	},
+	{
+		input:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0073",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "wrong_message",
+	},
+	{
+		input:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660ce5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "wrong_public_key",
+	},
+	{
+		input:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901554c8c7872aa064e049dbb3013fbf29380d25bf5f0595bbe24655141438e7a101b",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "non_canonical_s",
+	},
+	{
+		input:    "010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "small_order_key",
+	},
+	{
+		input:    "0100000000000000000000000000000000000000000000000000000000000000eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f0000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "non_canonical_r",
+	},
+	{
+		input:    "1d75df16736e7f2de5ac08106bf65209ffded5c019b3b0ea5d86d1565fc5e740d0c1490f4e36955d1a084681c3093334eb040969585bb507c65bc1d2ec7955824bfce6c1907cec0b37b415ca0d4a4891ef7444eac0e992d5cd45c5a3b7262c0c6d6573736167652030",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "mixed_order_key_cofactored_only",
+	},
+	{
+		input:         "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a10",
+		expected:      "invalid input length",
+		name:          "input_too_short",
+		errorExpected: true,
+	},
+}
+
+var sha3256Tests = []precompiledTest{
+	{
+		input:    "",
+		expected: "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+		name:     "abc",
+	},
+}
+
+var keccak512Tests = []precompiledTest{
+	{
+		input:    "",
+		expected: "0eab42de4c3ceb9235fc91acffe746b29c29a8c366b7c60e4e67c466f36a4304c00fa9caf9d87976ba469bcbe06713b435f091ef2769fb160cdab33d3670680e",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "18587dc2ea106b9a1563e32b3312421ca164c7f1f07bc922a9c83d77cea3a1e5d0c69910739025372dc14ac9642629379540c17e2a65b19d77aa511a9d00bb96",
+		name:     "abc",
+	},
+}
+
+var blake2sTests = []precompiledTest{
+	{
+		input:    "",
+		expected: "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9",
+		name:     "empty",
+	},
+	{
+		input:    "616263",
+		expected: "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982",
+		name:     "abc",
+	},
+}
+
+var bls12377G1AddTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000ed453141939e91056edb5a4b5452ed7e61f7f3dd2a4b7ee90e97c9a2301955880661656781dc90857aed6d6a4163900000000000000000000000000000000000cfb0b9717bc8e5ae04601813171337ad99cdae42c561cae80b12f135c64479d6a23f5675ed5ca7e2dd5e8727d7c7ed",
+		expected: "0000000000000000000000000000000001252b781171f507db36291b433a1f911a46543890a20ca9712e11f66a5d216e63d817bd8d96cef715abc604dcf6ec2e00000000000000000000000000000000014a00fa77c727e8987cc438b51bbe012c823a19955ae692c54ce572a61f0ea1fe5cd981533df419fd1330d1f6e6d802",
+		name:     "g1_plus_2g1",
+	},
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6",
+		name:     "g1_plus_infinity",
+	},
+	{
+		input:         "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea700000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6",
+		expected:      "point is not on curve",
+		name:          "not_on_curve",
+		errorExpected: true,
+	},
+	{
+		input:         "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000ed453141939e91056edb5a4b5452ed7e61f7f3dd2a4b7ee90e97c9a2301955880661656781dc90857aed6d6a4163900000000000000000000000000000000000cfb0b9717bc8e5ae04601813171337ad99cdae42c561cae80b12f135c64479d6a23f5675ed5ca7e2dd5e8727d7c7",
+		expected:      "invalid input length",
+		name:          "invalid_length",
+		errorExpected: true,
+	},
+}
+
+var bls12377G1MulTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000000000000000000000000000000002",
+		expected: "0000000000000000000000000000000000ed453141939e91056edb5a4b5452ed7e61f7f3dd2a4b7ee90e97c9a2301955880661656781dc90857aed6d6a4163900000000000000000000000000000000000cfb0b9717bc8e5ae04601813171337ad99cdae42c561cae80b12f135c64479d6a23f5675ed5ca7e2dd5e8727d7c7ed",
+		name:     "g1_times_2",
+	},
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea612ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11800000000001",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "g1_times_order",
+	},
+	{
+		input:         "01000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000000000000000000000000000000002",
+		expected:      "invalid field element top bytes",
+		name:          "invalid_field_element",
+		errorExpected: true,
+	},
+}
+
+var bls12377G1MultiExpTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea600000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000ed453141939e91056edb5a4b5452ed7e61f7f3dd2a4b7ee90e97c9a2301955880661656781dc90857aed6d6a4163900000000000000000000000000000000000cfb0b9717bc8e5ae04601813171337ad99cdae42c561cae80b12f135c64479d6a23f5675ed5ca7e2dd5e8727d7c7ed0000000000000000000000000000000000000000000000000000000000000003",
+		expected: "00000000000000000000000000000000018aff632c0048f5afb5c07fd197a44a127c829be3ff6170c6cebc1154bc72633b45de2ac855e0da30cebfa33672e7f30000000000000000000000000000000000efb82d5f13565755df1445db9ed4c4969f09bb31cc610f83eb1490be76ab3817808c52d98074f1a98ff896012a78ab",
+		name:     "g1_times_2_plus_2g1_times_3",
+	},
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea60000000000000000000000000000000000000000000000000000000000000002",
+		expected: "0000000000000000000000000000000000ed453141939e91056edb5a4b5452ed7e61f7f3dd2a4b7ee90e97c9a2301955880661656781dc90857aed6d6a4163900000000000000000000000000000000000cfb0b9717bc8e5ae04601813171337ad99cdae42c561cae80b12f135c64479d6a23f5675ed5ca7e2dd5e8727d7c7ed",
+		name:     "g1_times_2",
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+	},
+}
+
+var bls12377G2AddTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c0051960000000000000000000000000000000000ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe0000000000000000000000000000000000690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf0000000000000000000000000000000000f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f9300000000000000000000000000000000016d31b9f625914e7717654ae659d1c0cfe58c83f1579a83b1f0717e9e6a41a053e6e88f7f56ec0bc2fd5b6d61713d7900000000000000000000000000000000013314397e45ef715136c17ec005c87a36157abeb1f7a56d3543b7fc8e581da2d4ac27a0ceddfa0b1f3f55a777e94d5c00000000000000000000000000000000013106bc403f57a46a1a948f33846771dcd578b8632fbd0470e947ce81c1dcd1fcba62e57360c6859b8c6f901a2f4a2a0000000000000000000000000000000000e3e0ae82a18e0e5aee91c83d30519de4d2dbfa9147c43da20d55f1dcca734e600ceb36b99def794d69de8542315202",
+		expected: "00000000000000000000000000000000010989ed742d307e93acdd3ef1a3680e0bb12886bc4ee3fca003f51b04dc60ea6ff6d482b888da1601f96e4acccdeb2a000000000000000000000000000000000069c7a695505c5042f73f3f69027e0f97c21244c0fd397209582ac6f6f58d1a8b1e6118d9550c7f3d81a461fd12b64c000000000000000000000000000000000191cd587c9c65903e2cc24a5b031bb76ac23a2968294c604aa74e4574c8b60754fdb3abc99d7dd35707109e4f98c99a00000000000000000000000000000000004ffd6a638c1b4eeba9769cee5a2ef0e87bbd284704f4ee4870771ad3dbf7510c7799240851ea08209d1ea57c8e2f5a",
+		name:     "g2_plus_2g2",
+	},
+}
+
+var bls12377G2MulTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c0051960000000000000000000000000000000000ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe0000000000000000000000000000000000690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf0000000000000000000000000000000000f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f930000000000000000000000000000000000000000000000000000000000000002",
+		expected: "00000000000000000000000000000000016d31b9f625914e7717654ae659d1c0cfe58c83f1579a83b1f0717e9e6a41a053e6e88f7f56ec0bc2fd5b6d61713d7900000000000000000000000000000000013314397e45ef715136c17ec005c87a36157abeb1f7a56d3543b7fc8e581da2d4ac27a0ceddfa0b1f3f55a777e94d5c00000000000000000000000000000000013106bc403f57a46a1a948f33846771dcd578b8632fbd0470e947ce81c1dcd1fcba62e57360c6859b8c6f901a2f4a2a0000000000000000000000000000000000e3e0ae82a18e0e5aee91c83d30519de4d2dbfa9147c43da20d55f1dcca734e600ceb36b99def794d69de8542315202",
+		name:     "g2_times_2",
+	},
+}
+
+var bls12377G2MultiExpTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c0051960000000000000000000000000000000000ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe0000000000000000000000000000000000690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf0000000000000000000000000000000000f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f93000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000016d31b9f625914e7717654ae659d1c0cfe58c83f1579a83b1f0717e9e6a41a053e6e88f7f56ec0bc2fd5b6d61713d7900000000000000000000000000000000013314397e45ef715136c17ec005c87a36157abeb1f7a56d3543b7fc8e581da2d4ac27a0ceddfa0b1f3f55a777e94d5c00000000000000000000000000000000013106bc403f57a46a1a948f33846771dcd578b8632fbd0470e947ce81c1dcd1fcba62e57360c6859b8c6f901a2f4a2a0000000000000000000000000000000000e3e0ae82a18e0e5aee91c83d30519de4d2dbfa9147c43da20d55f1dcca734e600ceb36b99def794d69de85423152020000000000000000000000000000000000000000000000000000000000000003",
+		expected: "00000000000000000000000000000000012f5e069064c44f6bbb7fcf49b8f1f7bd3fc26228250e50a9358700418a9f2f6d5edc7ef26e8f5ad2547179ca4806540000000000000000000000000000000000e84a4d39433ddf62fc22c00f5638ba238757208c08925f7f820a8b2e8a4feb1265fab17dfd0680fad4852a48136431000000000000000000000000000000000147b999363dd973ef30ea09490d512c2cfd38af634198cad2da7be80f547caa51a025a57e5bc55aba5072523da8028600000000000000000000000000000000002beb4a3f2fde9ba09104e96264bf931293f16650c5cad6f74d7ed858dc6bf797d15f3e35cbeb9e784ae913b5b219d1",
+		name:     "g2_times_2_plus_2g2_times_3",
+	},
+}
+
+var bls12377PairingTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000007600e44c57d07c59c26952cce5101995f6aad2dffa3c09e96d7e86d47b20d5081289e4155563a47baae3d7b1a158800000000000000000000000000000000000f486c66329e50ad474f9c3c1bca63e6026ebb68b5c5acbba76ebbf39bdb3fb649dbf2c21e599e7f7fbe786d832e64b00000000000000000000000000000000018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c0051960000000000000000000000000000000000ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe0000000000000000000000000000000000690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf0000000000000000000000000000000000f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f9300000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef00000000000000000000000000000000001cefdc52b4e1eba6d3b6633bf15a765ca326aa36b6c0b5b1db375b6a5124fa540d200dfb56a6e58785e1aaaa63715b00000000000000000000000000000000017ffe1677c3eab4e8931e7d5139fb536e19af2a948144afc5d32d0b3975af208b6362aa206903a349b4070fa885ac6600000000000000000000000000000000003fb8544cba1b4d7ce528e48c8674bf5e4635e1f19e78151e8f840873bdb80609cb46c86a60485a263479f6e9b00f6b00000000000000000000000000000000001528f43db4c42403a7d17d7a566b150439cada0e0ab210e7d91bf86c11025d76ce1b2ea8edc91145ca9547e5e04b1f0000000000000000000000000000000000d1c4290ee39ea6e83c25ebd3d9fa83b78f71f6ef920ba18d97fe4df90442ecf07bcddb2c7b15ef21875ad33e0f0f84",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bilinearity",
+	},
+	{
+		input:    "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea600000000000000000000000000000000018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c0051960000000000000000000000000000000000ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe0000000000000000000000000000000000690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf0000000000000000000000000000000000f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f93",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "g1_g2",
+	},
+	{
+		input:         "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000017ec7b8aafa9390ce7e93b4ae59691f23c4e04bd4480efbfc6ca6d1518764aa45bdbdf03e9bcba347f3951cd2475ad600000000000000000000000000000000009cb7e17b71e87a17785cdec275db96340f87db51d759c1bd791cda4b977e0e18bf327cc5a93cfc451bac7933cbad5c",
+		expected:      "g2 point is not on correct subgroup",
+		name:          "g2_not_in_subgroup",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+}
+
+var bls12377MapToG1Tests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001",
+		expected: "00000000000000000000000000000000008848defe740a67c8fc6225bf87ff5485951e2caa9d41bb188282c8bd37cb5cd5481512ffcd394eeab9b16eb21be9ef0000000000000000000000000000000001914a69c5102eff1f674f5d30afeec4bd7fb348ca3e52d96d182ad44fb82305c2fe3d3634a9591afd82de55559c8ea6",
+		name:     "one",
+	},
+}
+
+var bls12377MapToG2Tests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002",
+		expected: "000000000000000000000000000000000008bb51fd1cf0cdce3ba655d26cb08f1017b31ac88ad7618a5a15da7915f3169da7d8b819a964c58aa675f7204eeb420000000000000000000000000000000000af258e481699d6b13443e0a0f6ace3407a28d70e5d0586e3fdacebf6f1e9bee55967c811bdc820229a450225da2fc30000000000000000000000000000000001750158ef1bc36dbcfedfcca3d50d924886cde07b1145c66db2e0d48664d424a0d8080df5eaecae1210041f09bf62bc0000000000000000000000000000000000d1005623994560893b8bbdcb735777c8aa18fdce02d67df6af2d9f3a08922e3ca87b212531f129b36a667cddce0c27",
+		name:     "one_two",
+	},
+}
+
+var bls12381G1AddTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28",
+		expected: "0000000000000000000000000000000009ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e522400000000000000000000000000000000032b80d3a6f5b09f8a84623389c5f80ca69a0cddabc3097f9d9c27310fd43be6e745256c634af45ca3473b0590ae30d1",
+		name:     "g1_plus_2g1",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		expected: "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		name:     "g1_plus_infinity",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e00000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
+		expected:      "point is not on curve",
+		name:          "not_on_curve",
+		errorExpected: true,
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d",
+		expected:      "invalid input length",
+		name:          "invalid_length",
+		errorExpected: true,
+	},
+}
+
+var bls12381G1MulTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000002",
+		expected: "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28",
+		name:     "g1_times_2",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e173eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		name:     "g1_times_order",
+	},
+	{
+		input:         "0100000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000002",
+		expected:      "invalid field element top bytes",
+		name:          "invalid_field_element",
+		errorExpected: true,
+	},
+}
+
+var bls12381G1MultiExpTests = []precompiledTest{
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d280000000000000000000000000000000000000000000000000000000000000003",
+		expected: "00000000000000000000000000000000085ae765588126f5e860d019c0e26235f567a9c0c0b2d8ff30f3e8d436b1082596e5e7462d20f5be3764fd473e57f9cf0000000000000000000000000000000019e7dfab8a794b6abb9f84e57739de172a63415273f460d1607fa6a74f0acd97d9671b801dd1fd4f18232dd1259359a1",
+		name:     "g1_times_2_plus_2g1_times_3",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e10000000000000000000000000000000000000000000000000000000000000002",
+		expected: "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28",
+		name:     "g1_times_2",
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+	},
+}
+
+var bls12381G2AddTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf3",
+		expected: "00000000000000000000000000000000122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae0000000000000000000000000000000009380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc000000000000000000000000000000000b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd8920000000000000000000000000000000008f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e849",
+		name:     "g2_plus_2g2",
+	},
+}
+
+var bls12381G2MulTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000002",
+		expected: "000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf3",
+		name:     "g2_times_2",
+	},
+}
+
+var bls12381G2MultiExpTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf30000000000000000000000000000000000000000000000000000000000000003",
+		expected: "0000000000000000000000000000000002142a58bae275564a6d63cb6bd6266ca66bef07a6ab8ca37b9d0ba2d4effbccfd89c169649f7d0e8a3eb006846579ad0000000000000000000000000000000012be651a5fa620340d418834526d37a8c932652345400b4cd9d43c8f41c080f41a6d9558118ebeab9d4268bb73e850e10000000000000000000000000000000015f4b235c209d89ce833f8f296e4cfb748e8abce6990ce1a5a914b9416c08e0d3a26db89625915c821a5f152b7fa592e0000000000000000000000000000000006fcacb3ee6650a1044852d61c9c20bedc8ee90aad97de8e24670a9ef57483e678db11dd95428915088d76e30cb01a37",
+		name:     "g2_times_2_plus_2g2_times_3",
+	},
+}
+
+var bls12381PairingTests = []precompiledTest{
+	{
+		input:    "00000000000000000000000000000000117eccb52da252ae40a01077a0ada503c9fbcc1aacb22d83c4ee7e9cd482de4d858616decdc382811121261daee420a800000000000000000000000000000000128c102db614443efeb9a47dfa678d1de62278969afdb692542a09d3ee72fc6d6d24a84aeb36df2cef06a2bcae36b27900000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca000000000000000000000000000000000b2b0e38e1951ee1adfc8445485d4160ca74b2b958cbe2a52c987b618636b8e36d158b6ba436b27dddaef2f7ce0789ef0000000000000000000000000000000008da006ad0a34fd9fc33f744fc0eacbc584fea4795c8c4b2590005d2d4aa76a1f1bb6e1c58c9aade06144158e2708c66000000000000000000000000000000000b2136644e0c04faf1eb428f0fa6cfa62a002b427c7bbec96aba1fdc82fca680d16aa2bb3dd41608d54856f04ef5d16d000000000000000000000000000000001902255a9113b208ba50725de704b793a60d50a30ae232d6d265a166bd3548749c12fcebf0b1396683d0c6912eb72622",
+		expected: "0000000000000000000000000000000000000000000000000000000000000001",
+		name:     "bilinearity",
+	},
+	{
+		input:    "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be",
+		expected: "0000000000000000000000000000000000000000000000000000000000000000",
+		name:     "g1_g2",
+	},
+	{
+		input:         "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000018c6b864ae17dc9da64203ffefb966306425a7bc6aeb7c75247438372716284a4173830420cd476ba1a365b95bfcec3800000000000000000000000000000000172e93db764a8400a7d5071b6b6f5de0da2f0f4a063119abca014006b7c40a2cfe291a1924e65db0d6d0fcfbf3bf3d5c",
+		expected:      "g2 point is not on correct subgroup",
+		name:          "g2_not_in_subgroup",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+	{
+		input:         "",
+		expected:      "invalid input length",
+		name:          "empty_input",
+		errorExpected: true,
+		noBenchmark:   true,
+	},
+}
+
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsGingerbread[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -733,8 +1176,48 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 	})
 }
 
+// precompiledJSONTest is the layout of the reference vectors stored under
+// testdata/precompiles: the EIP-2537 vectors for BLS12-381 and the Celo vectors
+// for BLS12-377.  Failure vectors carry an ExpectedError instead of Expected.
+type precompiledJSONTest struct {
+	Input         string
+	Expected      string
+	ExpectedError string
+	Name          string
+}
+
+// loadBls12JSON loads the reference vectors of the given file.
+func loadBls12JSON(t *testing.T, name string) []precompiledTest {
+	data, err := ioutil.ReadFile(fmt.Sprintf("testdata/precompiles/%s.json", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []precompiledJSONTest
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	tests := make([]precompiledTest, len(vectors))
+	for i, v := range vectors {
+		tests[i] = precompiledTest{input: v.Input, expected: v.Expected, name: v.Name}
+		if v.ExpectedError != "" {
+			tests[i].expected, tests[i].errorExpected = v.ExpectedError, true
+		}
+	}
+	return tests
+}
+
+// testBls12JSON runs the reference vectors of the given files against the
+// precompile at addr.
+func testBls12JSON(addr string, t *testing.T, names ...string) {
+	for _, name := range names {
+		for _, test := range loadBls12JSON(t, name) {
+			testPrecompiled(addr, test, t)
+		}
+	}
+}
+
 func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsGingerbread[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("31337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -756,7 +1239,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	if test.noBenchmark {
 		return
 	}
-	p := PrecompiledContractsIstanbul[common.HexToAddress(addr)]
+	p := PrecompiledContractsGingerbread[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	reqGas := p.RequiredGas(in)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
@@ -917,6 +1400,34 @@ func TestPrecompiledProofOfPossession(t *testing.T) {
 	}
 }
 
+// Tests the sample inputs for ed25519Verify
+func TestPrecompiledEd25519Verify(t *testing.T) {
+	for _, test := range ed25519VerifyTests {
+		testPrecompiled("e1", test, t)
+	}
+}
+
+// Tests the sample inputs for sha3256hash
+func TestPrecompiledSha3256(t *testing.T) {
+	for _, test := range sha3256Tests {
+		testPrecompiled("e0", test, t)
+	}
+}
+
+// Tests the sample inputs for keccak512hash
+func TestPrecompiledKeccak512(t *testing.T) {
+	for _, test := range keccak512Tests {
+		testPrecompiled("df", test, t)
+	}
+}
+
+// Tests the sample inputs for blake2shash
+func TestPrecompiledBlake2s(t *testing.T) {
+	for _, test := range blake2sTests {
+		testPrecompiled("de", test, t)
+	}
+}
+
 // Tests sample inputs for getValidator
 func TestGetValidator(t *testing.T) {
 	for _, test := range getValidatorTests {
@@ -931,6 +1442,54 @@ func TestNumberValidators(t *testing.T) {
 	}
 }
 
+// Tests sample inputs for getEpochValidator
+func TestGetEpochValidator(t *testing.T) {
+	for _, test := range getEpochValidatorTests {
+		testPrecompiled("dc", test, t)
+	}
+}
+
+// Tests sample inputs for numberEpochValidators
+func TestNumberEpochValidators(t *testing.T) {
+	for _, test := range numberEpochValidatorsTests {
+		testPrecompiled("db", test, t)
+	}
+}
+
+// Tests sample inputs for getBlockRandomness
+func TestGetBlockRandomness(t *testing.T) {
+	for _, test := range getBlockRandomnessTests {
+		testPrecompiled("dd", test, t)
+	}
+}
+
+// Tests that each release enables the precompiles of the previous one and its own.
+func TestActivePrecompiles(t *testing.T) {
+	tests := []struct {
+		rules    params.Rules
+		enabled  []common.Address
+		disabled []common.Address
+	}{
+		{params.Rules{IsIstanbul: true}, []common.Address{transferAddress}, []common.Address{bls12377G1AddAddress, ed25519VerifyAddress, getBlockRandomnessAddress}},
+		{params.Rules{IsDonut: true}, []common.Address{transferAddress, bls12377G1AddAddress}, []common.Address{ed25519VerifyAddress, getBlockRandomnessAddress}},
+		{params.Rules{IsEspresso: true}, []common.Address{transferAddress, bls12377G1AddAddress, ed25519VerifyAddress}, []common.Address{getBlockRandomnessAddress, getEpochValidatorAddress, numberEpochValidatorsAddress}},
+		{params.Rules{IsGingerbread: true}, []common.Address{transferAddress, bls12377G1AddAddress, ed25519VerifyAddress, getBlockRandomnessAddress, getEpochValidatorAddress, numberEpochValidatorsAddress}, nil},
+	}
+	for i, test := range tests {
+		precompiles := ActivePrecompiles(test.rules)
+		for _, addr := range test.enabled {
+			if precompiles[addr] == nil {
+				t.Errorf("test %d: precompile %x not enabled", i, addr)
+			}
+		}
+		for _, addr := range test.disabled {
+			if precompiles[addr] != nil {
+				t.Errorf("test %d: precompile %x enabled", i, addr)
+			}
+		}
+	}
+}
+
 // Tests sample inputs for getBlockNumberFromHeader
 func TestGetBlockNumberFromHeader(t *testing.T) {
 	for _, test := range blockNumberFromHeaderTests {
@@ -958,3 +1517,117 @@ func TestGetVerifiedSealBitmap(t *testing.T) {
 		testPrecompiled("f4", test, t)
 	}
 }
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G1 addition precompiles.
+func TestPrecompiledBls12G1Add(t *testing.T) {
+	for _, test := range bls12377G1AddTests {
+		testPrecompiled("f3", test, t)
+	}
+	for _, test := range bls12381G1AddTests {
+		testPrecompiled("ea", test, t)
+	}
+	testBls12JSON("f3", t, "bls12377G1Add_matter", "bls12377G1Add_zexe", "fail-bls12377G1Add")
+	testBls12JSON("ea", t, "blsG1Add", "fail-blsG1Add")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G1 scalar multiplication precompiles.
+func TestPrecompiledBls12G1Mul(t *testing.T) {
+	for _, test := range bls12377G1MulTests {
+		testPrecompiled("f2", test, t)
+	}
+	for _, test := range bls12381G1MulTests {
+		testPrecompiled("e9", test, t)
+	}
+	testBls12JSON("f2", t, "bls12377G1Mul_matter", "bls12377G1Mul_zexe", "fail-bls12377G1Mul")
+	testBls12JSON("e9", t, "blsG1Mul", "fail-blsG1Mul")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G1 multi exponentiation precompiles.
+// The Celo "matter" multi exponentiation vectors are not used: their generator
+// drops the top bit of 256 bit scalars, which disagrees with scalar multiplication.
+func TestPrecompiledBls12G1MultiExp(t *testing.T) {
+	for _, test := range bls12377G1MultiExpTests {
+		testPrecompiled("f1", test, t)
+	}
+	for _, test := range bls12381G1MultiExpTests {
+		testPrecompiled("e8", test, t)
+	}
+	testBls12JSON("f1", t, "bls12377G1MultiExp_zexe", "fail-bls12377G1Multiexp")
+	testBls12JSON("e8", t, "blsG1MultiExp", "fail-blsG1MultiExp")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G2 addition precompiles.
+func TestPrecompiledBls12G2Add(t *testing.T) {
+	for _, test := range bls12377G2AddTests {
+		testPrecompiled("f0", test, t)
+	}
+	for _, test := range bls12381G2AddTests {
+		testPrecompiled("e7", test, t)
+	}
+	testBls12JSON("f0", t, "bls12377G2Add_matter", "bls12377G2Add_zexe", "fail-bls12377G2Add")
+	testBls12JSON("e7", t, "blsG2Add", "fail-blsG2Add")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G2 scalar multiplication precompiles.
+func TestPrecompiledBls12G2Mul(t *testing.T) {
+	for _, test := range bls12377G2MulTests {
+		testPrecompiled("ef", test, t)
+	}
+	for _, test := range bls12381G2MulTests {
+		testPrecompiled("e6", test, t)
+	}
+	testBls12JSON("ef", t, "bls12377G2Mul_matter", "bls12377G2Mul_zexe", "fail-bls12377G2Mul")
+	testBls12JSON("e6", t, "blsG2Mul", "fail-blsG2Mul")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 G2 multi exponentiation precompiles.
+// The Celo "matter" multi exponentiation vectors are not used: their generator
+// drops the top bit of 256 bit scalars, which disagrees with scalar multiplication.
+func TestPrecompiledBls12G2MultiExp(t *testing.T) {
+	for _, test := range bls12377G2MultiExpTests {
+		testPrecompiled("ee", test, t)
+	}
+	for _, test := range bls12381G2MultiExpTests {
+		testPrecompiled("e5", test, t)
+	}
+	testBls12JSON("ee", t, "bls12377G2MultiExp_zexe", "fail-bls12377G2Multiexp")
+	testBls12JSON("e5", t, "blsG2MultiExp", "fail-blsG2MultiExp")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 pairing check precompiles.
+func TestPrecompiledBls12Pairing(t *testing.T) {
+	for _, test := range bls12377PairingTests {
+		testPrecompiled("ed", test, t)
+	}
+	for _, test := range bls12381PairingTests {
+		testPrecompiled("e4", test, t)
+	}
+	testBls12JSON("ed", t, "bls12377Pairing_matter", "fail-bls12377Pairing")
+	testBls12JSON("e4", t, "blsPairing", "fail-blsPairing")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 map to G1 precompiles.
+func TestPrecompiledBls12MapToG1(t *testing.T) {
+	for _, test := range bls12377MapToG1Tests {
+		testPrecompiled("ec", test, t)
+	}
+	testBls12JSON("e3", t, "blsMapG1", "fail-blsMapG1")
+}
+
+// Tests the sample inputs for the BLS12-377 and BLS12-381 map to G2 precompiles.
+func TestPrecompiledBls12MapToG2(t *testing.T) {
+	for _, test := range bls12377MapToG2Tests {
+		testPrecompiled("eb", test, t)
+	}
+	testBls12JSON("e2", t, "blsMapG2", "fail-blsMapG2")
+}
+
+// Benchmarks the sample inputs for the BLS12-377 and BLS12-381 pairing check precompiles.
+func BenchmarkPrecompiledBls12Pairing(bench *testing.B) {
+	for _, test := range bls12377PairingTests {
+		benchmarkPrecompiled("ed", test, bench)
+	}
+	for _, test := range bls12381PairingTests {
+		benchmarkPrecompiled("e4", test, bench)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contract_comm/bindings"
 	"github.com/ethereum/go-ethereum/contract_comm/errors"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -31,7 +32,10 @@ const (
                              }]`
 )
 
-var getAddressForFuncABI, _ = abi.JSON(strings.NewReader(getAddressForABI))
+var (
+	getAddressForFuncABI, _ = abi.JSON(strings.NewReader(getAddressForABI))
+	randomABI, _            = abi.JSON(strings.NewReader(bindings.RandomABI))
+)
 
 // TODO(kevjue) - Re-Enable caching of the retrieved registered address
 // See this commit for the removed code for caching:  https://github.com/celo-org/geth/commit/43a275273c480d307a3d2b3c55ca3b3ee31ec7dd.
@@ -0,0 +1,13 @@
+package bls12377
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+var fuz int = 10
+
+func randScalar(max *big.Int) *big.Int {
+	a, _ := rand.Int(rand.Reader, max)
+	return a
+}
@@ -0,0 +1,286 @@
+package bls12377
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func (g *G2) one() *PointG2 {
+	one, _ := g.fromBytesUnchecked(
+		common.FromHex("" +
+			"00ea6040e700403170dc5a51b1b140d5532777ee6651cecbe7223ece0799c9de5cf89984bff76fe6b26bfefa6ea16afe" +
+			"018480be71c785fec89630a2a3841d01c565f071203e50317ea501f557db6b9b71889f52bb53540274e3e48f7c005196" +
+			"00f8169fd28355189e549da3151a70aa61ef11ac3d591bf12463b01acee304c24279b83f5e52270bd9a1cdd185eb8f93" +
+			"00690d665d446f7bd960736bcbb2efb4de03ed7274b49a58e458c282f832d204f2cf88886d8c7c2ef094094409fd4ddf",
+		),
+	)
+	return one
+}
+
+func (g *G2) rand() *PointG2 {
+	k, err := rand.Int(rand.Reader, q)
+	if err != nil {
+		panic(err)
+	}
+	return g.MulScalar(&PointG2{}, g.one(), k)
+}
+
+func TestG2Serialization(t *testing.T) {
+	g2 := NewG2()
+	for i := 0; i < fuz; i++ {
+		a := g2.rand()
+		buf := g2.ToBytes(a)
+		b, err := g2.FromBytes(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Equal(a, b) {
+			t.Fatal("bad serialization from/to")
+		}
+	}
+	for i := 0; i < fuz; i++ {
+		a := g2.rand()
+		encoded := g2.EncodePoint(a)
+		b, err := g2.DecodePoint(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Equal(a, b) {
+			t.Fatal("bad serialization encode/decode")
+		}
+	}
+}
+
+func TestG2IsOnCurve(t *testing.T) {
+	g := NewG2()
+	zero := g.Zero()
+	if !g.IsOnCurve(zero) {
+		t.Fatal("zero must be on curve")
+	}
+	one := new(fe2).one()
+	p := &PointG2{*one, *one, *one}
+	if g.IsOnCurve(p) {
+		t.Fatal("(1, 1) is not on curve")
+	}
+}
+
+func TestG2AdditiveProperties(t *testing.T) {
+	g := NewG2()
+	t0, t1 := g.New(), g.New()
+	zero := g.Zero()
+	for i := 0; i < fuz; i++ {
+		a, b := g.rand(), g.rand()
+		_, _, _ = b, t1, zero
+		g.Add(t0, a, zero)
+		if !g.Equal(t0, a) {
+			t.Fatal("a + 0 == a")
+		}
+		g.Add(t0, zero, zero)
+		if !g.Equal(t0, zero) {
+			t.Fatal("0 + 0 == 0")
+		}
+		g.Sub(t0, a, zero)
+		if !g.Equal(t0, a) {
+			t.Fatal("a - 0 == a")
+		}
+		g.Sub(t0, zero, zero)
+		if !g.Equal(t0, zero) {
+			t.Fatal("0 - 0 == 0")
+		}
+		g.Neg(t0, zero)
+		if !g.Equal(t0, zero) {
+			t.Fatal("- 0 == 0")
+		}
+		g.Sub(t0, zero, a)
+		g.Neg(t0, t0)
+		if !g.Equal(t0, a) {
+			t.Fatal(" - (0 - a) == a")
+		}
+		g.Double(t0, zero)
+		if !g.Equal(t0, zero) {
+			t.Fatal("2 * 0 == 0")
+		}
+		g.Double(t0, a)
+		g.Sub(t0, t0, a)
+		if !g.Equal(t0, a) || !g.IsOnCurve(t0) {
+			t.Fatal(" (2 * a) - a == a")
+		}
+		g.Add(t0, a, b)
+		g.Add(t1, b, a)
+		if !g.Equal(t0, t1) {
+			t.Fatal("a + b == b + a")
+		}
+		g.Sub(t0, a, b)
+		g.Sub(t1, b, a)
+		g.Neg(t1, t1)
+		if !g.Equal(t0, t1) {
+			t.Fatal("a - b == - ( b - a )")
+		}
+		c := g.rand()
+		g.Add(t0, a, b)
+		g.Add(t0, t0, c)
+		g.Add(t1, a, c)
+		g.Add(t1, t1, b)
+		if !g.Equal(t0, t1) {
+			t.Fatal("(a + b) + c == (a + c ) + b")
+		}
+		g.Sub(t0, a, b)
+		g.Sub(t0, t0, c)
+		g.Sub(t1, a, c)
+		g.Sub(t1, t1, b)
+		if !g.Equal(t0, t1) {
+			t.Fatal("(a - b) - c == (a - c) -b")
+		}
+	}
+}
+
+func TestG2MultiplicativeProperties(t *testing.T) {
+	g := NewG2()
+	t0, t1 := g.New(), g.New()
+	zero := g.Zero()
+	for i := 0; i < fuz; i++ {
+		a := g.rand()
+		s1, s2, s3 := randScalar(q), randScalar(q), randScalar(q)
+		sone := big.NewInt(1)
+		g.MulScalar(t0, zero, s1)
+		if !g.Equal(t0, zero) {
+			t.Fatal(" 0 ^ s == 0")
+		}
+		g.MulScalar(t0, a, sone)
+		if !g.Equal(t0, a) {
+			t.Fatal(" a ^ 1 == a")
+		}
+		g.MulScalar(t0, zero, s1)
+		if !g.Equal(t0, zero) {
+			t.Fatal(" 0 ^ s == a")
+		}
+		g.MulScalar(t0, a, s1)
+		g.MulScalar(t0, t0, s2)
+		s3.Mul(s1, s2)
+		g.MulScalar(t1, a, s3)
+		if !g.Equal(t0, t1) {
+			t.Errorf(" (a ^ s1) ^ s2 == a ^ (s1 * s2)")
+		}
+		g.MulScalar(t0, a, s1)
+		g.MulScalar(t1, a, s2)
+		g.Add(t0, t0, t1)
+		s3.Add(s1, s2)
+		g.MulScalar(t1, a, s3)
+		if !g.Equal(t0, t1) {
+			t.Errorf(" (a ^ s1) + (a ^ s2) == a ^ (s1 + s2)")
+		}
+	}
+}
+
+func TestG2MultiExpExpected(t *testing.T) {
+	g := NewG2()
+	one := g.one()
+	var scalars [2]*big.Int
+	var bases [2]*PointG2
+	scalars[0] = big.NewInt(2)
+	scalars[1] = big.NewInt(3)
+	bases[0], bases[1] = new(PointG2).Set(one), new(PointG2).Set(one)
+	expected, result := g.New(), g.New()
+	g.MulScalar(expected, one, big.NewInt(5))
+	_, _ = g.MultiExp(result, bases[:], scalars[:])
+	if !g.Equal(expected, result) {
+		t.Fatal("bad multi-exponentiation")
+	}
+}
+
+func TestG2MultiExpBatch(t *testing.T) {
+	g := NewG2()
+	one := g.one()
+	n := 1000
+	bases := make([]*PointG2, n)
+	scalars := make([]*big.Int, n)
+	// scalars: [s0,s1 ... s(n-1)]
+	// bases: [P0,P1,..P(n-1)] = [s(n-1)*G, s(n-2)*G ... s0*G]
+	for i, j := 0, n-1; i < n; i, j = i+1, j-1 {
+		scalars[j], _ = rand.Int(rand.Reader, big.NewInt(100000))
+		bases[i] = g.New()
+		g.MulScalar(bases[i], one, scalars[j])
+	}
+	// expected: s(n-1)*P0 + s(n-2)*P1 + s0*P(n-1)
+	expected, tmp := g.New(), g.New()
+	for i := 0; i < n; i++ {
+		g.MulScalar(tmp, bases[i], scalars[i])
+		g.Add(expected, expected, tmp)
+	}
+	result := g.New()
+	_, _ = g.MultiExp(result, bases, scalars)
+	if !g.Equal(expected, result) {
+		t.Fatal("bad multi-exponentiation")
+	}
+}
+
+func TestG2MapToCurve(t *testing.T) {
+	for i, v := range []struct {
+		u        []byte
+		expected []byte
+	}{
+		{
+			u:        make([]byte, 96),
+			expected: common.FromHex("00a272c24ca7d3429d9f07b86366d6ea24148cd0d42beba1a208c4859cf3630f4de60b0c0f56b5e10ddf884b60d27f88" + "00415abb1137c88935bb08884aee7b99049f123bf27dce03401724764cd34f4d20a7c4c78130d2d07faab58c9b87bda9" + "0121cb2668f07eaa4752e212f85ed167509504b67ef09df578536c115ac38999e66e6d2df31cbe0ceb2f80ac9c3a8051" + "0199275defaacc0db97bc0e7237bbb97f523908ed09da02f4ac4ef1a54a524d933cc6492b66b5ee576521ebbbb23ee23"),
+		},
+		{
+			u:        common.FromHex("0189d36791095926b9442175f7ee8b31e22ccaa51217de0b342e14f44d6e48c864bdac4b8112a4db2de6c09c362bd3f3" + "002b7948b90937d09f5f42e77adee1e6ff8ed760a560691aebc65c07a980740b32b538cd556b43ff42a1031028bb981b"),
+			expected: common.FromHex("0096a9d9ebb32eddae2b32e356070c254581ad816e5f719828ff5639aae3ded71ac634e986d3746a7ea4266c90f6455a" + "01817377698161e7b0e594773e8ef95ae617192bb2e75c21f7928dca47fab4497fc4b37cecf492479021c8bbeb8fc755" + "01a070834ece3bfebda2065efdf2589dcba0f0762243a395611d1a2cfa9974207037cd3a841e21e2cceac3e1102f46ad" + "0168dbdb11f6d41019ae48fada827c13a35165cc65ad6bde16a9a1a29ee0f8bff7eb0b22e2778252e79d715c5422c65a"),
+		},
+		{
+			u:        common.FromHex("011be40811e89aae00f4587e3062a9a03592bd8637d0be896c0322b4a043c2c0916d0dafe7bbbee1991e1bfd77abf2de" + "0198db7bbd2edc2ec3f08bd4e69b569c167edfe47dd68e97bdc7bec77cc9807695c7484cb936187c6c9b943677ef273f"),
+			expected: common.FromHex("01092bc98b9936a4c71aa5705b116817ec0f2aae35b546679549cbb71a55f53ef28b0e0b8bfb8a0babc021019e0c8135" + "0175b1f668f447d25695af5cf833100a180676f7fe9be5e77f086425fb82888c6dc084d36604b4e1450d88e4c9d600b6" + "0034d954981feaff2bb9ce8ca4f1e27cfe80c19f69cff329d1f2b3b2e843c0da202fce2d831cb9d5871efa28b1788472" + "0136b1ca518665cbd434a40b635b5b3d3c54f75421869c74194838dc36676b7bc77ff990feb652252259109a02dbd88d"),
+		},
+		{
+			u:        common.FromHex("010e0f68a7506882922875bb6a9f0a4584731ae17e83f5a803065434906cbee8d6001b2e4a2ed355e8acda40cd41e689" + "004cbeac328859d733209d73fe717820a8a56699993e225303592711033176c802659267ca9544b59e5289b9d6300018"),
+			expected: common.FromHex("001cde31b3ae49f7a3b799bd7c933e0d4e811b623610b01beb9035be398200f24ac049467cbf59bd5836bb9778ca16ff" + "0181dab5d4fd697c0bca2b73bf54fc5b71f6ef2c1b226720f9e2185032da4658219afed9998126e949d039df484e5b0a" + "008a13c80d3fcf519414acac9db2cd2f0535caa1f38bbc80e442e66d98e9c73f0c017304e52d9c9d591ff34e3839fc3d" + "0124407c4a9d4f046b7755ebe402ab31e3ac29493fa53e8a6e355c87c3dbe6569f3e8ebc3a73ac0c8cee6c059ba9a23e"),
+		},
+		{
+			u:        common.FromHex("013528bfc2ff49e8e9a3352fc3a7ea8161ab78afe310ed6741fa8f8fd7e5fbdd21a7cc18ee4d8b5c93f546a4523be8e6" + "01a895239a1e1f110d4a75e94e4232be6f1504dde7a165859b9465ed02a8a4e99addb5dde51652eeeb8d765d8cd7c84d"),
+			expected: common.FromHex("00699a796522e0aa1324e660dccace792760c97951eb967cdac8da62ba91506d89e8c349d05b04044eae8142ad1e2558" + "016945e30b3c58001c97a923b9575b0774b79414755d017a124045710547d486e9f73928e384ed0f319a615e4bc4403a" + "0097fcc10a078ce25ab8ac694cd4084ea09b3ecaaaa191b629fbf8543b4d59879da9cb3affd75e55fdc7ec584d9387ff" + "0187aecc0a417e0750daa825660c67783a93f1ed9528cd9195b81d8a9daa70b7e69d397a17df63685ee47ba55859524d"),
+		},
+	} {
+		g := NewG2()
+		p0, err := g.MapToCurve(v.u)
+		if err != nil {
+			t.Fatal("map to curve fails", i, err)
+		}
+		if !bytes.Equal(g.ToBytes(p0), v.expected) {
+			t.Fatal("map to curve fails", i)
+		}
+	}
+}
+
+func BenchmarkG2Add(t *testing.B) {
+	g2 := NewG2()
+	a, b, c := g2.rand(), g2.rand(), PointG2{}
+	t.ResetTimer()
+	for i := 0; i < t.N; i++ {
+		g2.Add(&c, a, b)
+	}
+}
+
+func BenchmarkG2Mul(t *testing.B) {
+	g2 := NewG2()
+	a, e, c := g2.rand(), q, PointG2{}
+	t.ResetTimer()
+	for i := 0; i < t.N; i++ {
+		g2.MulScalar(&c, a, e)
+	}
+}
+
+func BenchmarkG2MapToCurve(t *testing.B) {
+	a := make([]byte, 96)
+	g2 := NewG2()
+	t.ResetTimer()
+	for i := 0; i < t.N; i++ {
+		_, err := g2.MapToCurve(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
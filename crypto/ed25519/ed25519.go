@@ -0,0 +1,82 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ed25519 implements Ed25519 signature verification with a fixed set of
+// acceptance rules, for use where all nodes must agree on which signatures are
+// valid.
+//
+// The code is the "ref10" port of golang.org/x/crypto/ed25519 as it was before
+// being replaced by a wrapper of the standard library, whose rules are free to
+// change between Go releases.  A signature (R, S) on message M by public key A
+// is accepted if and only if:
+//
+//   - the signature is 64 bytes and the top three bits of S are zero,
+//   - S is canonical, that is S < L, the order of the prime order subgroup,
+//   - A decodes to a point of the curve.  Non canonical encodings of the y
+//     coordinate and points of small or mixed order are accepted,
+//   - R is byte for byte equal to the canonical encoding of [S]B - [k]A, with
+//     k = SHA-512(R || A || M) mod L.  This is the cofactorless equation, and it
+//     rejects non canonical encodings of R.
+package ed25519
+
+import (
+	"bytes"
+	"crypto/sha512"
+
+	"github.com/ethereum/go-ethereum/crypto/ed25519/internal/edwards25519"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of public keys as used in this package.
+	PublicKeySize = 32
+	// SignatureSize is the size, in bytes, of signatures verified by this package.
+	SignatureSize = 64
+)
+
+// Verify reports whether sig is a valid signature of message by publicKey,
+// according to the rules of the package documentation.  Keys and signatures of
+// the wrong size are rejected.
+func Verify(publicKey, message, sig []byte) bool {
+	if len(publicKey) != PublicKeySize {
+		return false
+	}
+	if len(sig) != SignatureSize || sig[63]&224 != 0 {
+		return false
+	}
+
+	var A edwards25519.ExtendedGroupElement
+	var publicKeyBytes [32]byte
+	copy(publicKeyBytes[:], publicKey)
+	if !A.FromBytes(&publicKeyBytes) {
+		return false
+	}
+	edwards25519.FeNeg(&A.X, &A.X)
+	edwards25519.FeNeg(&A.T, &A.T)
+
+	h := sha512.New()
+	h.Write(sig[:32])
+	h.Write(publicKey[:])
+	h.Write(message)
+	var digest [64]byte
+	h.Sum(digest[:0])
+
+	var hReduced [32]byte
+	edwards25519.ScReduce(&hReduced, &digest)
+
+	var R edwards25519.ProjectiveGroupElement
+	var s [32]byte
+	copy(s[:], sig[32:])
+
+	// https://tools.ietf.org/html/rfc8032#section-5.1.7 requires that s be in
+	// the range [0, order) in order to prevent signature malleability.
+	if !edwards25519.ScMinimal(&s) {
+		return false
+	}
+
+	edwards25519.GeDoubleScalarMultVartime(&R, &hReduced, &A, &s)
+
+	var checkR [32]byte
+	R.ToBytes(&checkR)
+	return bytes.Equal(sig[:32], checkR[:])
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ed25519
+
+import (
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+// verifyTests are encoded as publicKey || signature || message.
+var verifyTests = []struct {
+	name  string
+	input string
+	valid bool
+}{
+	{
+		name:  "rfc8032_test_1",
+		input: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		valid: true,
+	},
+	{
+		name:  "rfc8032_test_2",
+		input: "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c0072",
+		valid: true,
+	},
+	{
+		// rfc8032_test_1 with L added to S.
+		name:  "non_canonical_s",
+		input: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901554c8c7872aa064e049dbb3013fbf29380d25bf5f0595bbe24655141438e7a101b",
+		valid: false,
+	},
+	{
+		// rfc8032_test_1 with the top three bits of S set.
+		name:  "s_high_bits",
+		input: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511ae5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a10eb",
+		valid: false,
+	},
+	{
+		// A and R are the identity and S is zero.
+		name:  "small_order_key",
+		input: "010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		valid: true,
+	},
+	{
+		// As small_order_key, with A encoded as y = p + 1.
+		name:  "non_canonical_key",
+		input: "eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f01000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		valid: true,
+	},
+	{
+		// As small_order_key, with R encoded as y = p + 1.
+		name:  "non_canonical_r",
+		input: "0100000000000000000000000000000000000000000000000000000000000000eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f0000000000000000000000000000000000000000000000000000000000000000",
+		valid: false,
+	},
+	{
+		name:  "key_not_on_curve",
+		input: "0200000000000000000000000000000000000000000000000000000000000000e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		valid: false,
+	},
+	{
+		// A = aB + T, with T of order 2, and an odd k = H(R || A || M), so that
+		// only the cofactored equation holds.
+		name:  "mixed_order_key_odd_k",
+		input: "1d75df16736e7f2de5ac08106bf65209ffded5c019b3b0ea5d86d1565fc5e740d0c1490f4e36955d1a084681c3093334eb040969585bb507c65bc1d2ec7955824bfce6c1907cec0b37b415ca0d4a4891ef7444eac0e992d5cd45c5a3b7262c0c6d6573736167652030",
+		valid: false,
+	},
+	{
+		// As mixed_order_key_odd_k, with an even k, so that both equations hold.
+		name:  "mixed_order_key_even_k",
+		input: "1d75df16736e7f2de5ac08106bf65209ffded5c019b3b0ea5d86d1565fc5e74059b75388ed0ac748a99e51ed2a6559827ac3f52c82485cd9c14ced6b9e09b89fbb8a1e466521fe3adf9f9cdf70d520a1154af3dfbba0b31dae836be4612b5a0e6d6573736167652032",
+		valid: true,
+	},
+}
+
+func TestVerify(t *testing.T) {
+	for _, test := range verifyTests {
+		input, err := hex.DecodeString(test.input)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		publicKey := input[:PublicKeySize]
+		sig := input[PublicKeySize : PublicKeySize+SignatureSize]
+		message := input[PublicKeySize+SignatureSize:]
+		if valid := Verify(publicKey, message, sig); valid != test.valid {
+			t.Errorf("%s: have %v, want %v", test.name, valid, test.valid)
+		}
+	}
+}
+
+func TestVerifyBadLengths(t *testing.T) {
+	input, _ := hex.DecodeString(verifyTests[0].input)
+	publicKey, sig := input[:PublicKeySize], input[PublicKeySize:PublicKeySize+SignatureSize]
+	if Verify(publicKey[:PublicKeySize-1], nil, sig) {
+		t.Error("accepted a short public key")
+	}
+	if Verify(publicKey, nil, sig[:SignatureSize-1]) {
+		t.Error("accepted a short signature")
+	}
+}
+
+// TestVerifyStandardSignatures checks that signatures produced by the standard
+// library are accepted.
+func TestVerifyStandardSignatures(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		publicKey, privateKey, err := stded25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := make([]byte, i)
+		rand.Read(message)
+		sig := stded25519.Sign(privateKey, message)
+		if !Verify(publicKey, message, sig) {
+			t.Fatalf("rejected a valid signature on %x", message)
+		}
+		message = append(message, 0)
+		if Verify(publicKey, message, sig) {
+			t.Fatalf("accepted a signature on the wrong message")
+		}
+	}
+}
@@ -27,13 +27,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	mockEngine "github.com/ethereum/go-ethereum/consensus/consensustest"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	istanbulBackend "github.com/ethereum/go-ethereum/consensus/istanbul/backend"
-	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -194,6 +194,8 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 			TrieDirtyLimit:      config.TrieDirtyCache,
 			TrieDirtyDisabled:   config.NoPruning,
 			TrieTimeLimit:       config.TrieTimeout,
+			SigningHistoryIndex: config.SigningHistoryIndex,
+			SnapshotLimit:       config.SnapshotCache,
 		}
 	)
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, chainConfig, eth.engine, vmConfig, eth.shouldPreserve)
@@ -212,10 +214,6 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
 	}
 
-	// Set the blockchain for the EVMHandler singleton that geth can use to make calls to smart contracts.
-	// Note that this should NOT be used when executing smart contract calls done via end user transactions.
-	contract_comm.SetInternalEVMHandler(eth.blockchain)
-
 	eth.txPool = core.NewTxPool(config.TxPool, chainConfig, eth.blockchain)
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -236,6 +234,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 				stateRoot := eth.blockchain.GetHeaderByHash(hash).Root
 				return eth.blockchain.StateAt(stateRoot)
 			})
+		istanbul.SetSystemCaller(eth.blockchain.SystemCaller())
 
 		chainHeadCh := make(chan core.ChainHeadEvent, 10)
 		chainHeadSub := eth.blockchain.SubscribeChainHeadEvent(chainHeadCh)
@@ -532,7 +531,7 @@ func (s *Ethereum) StartMining(threads int) error {
 			return fmt.Errorf("blsbase missing: %v", err)
 		}
 
-		if istanbul, isIstanbul := s.engine.(*istanbulBackend.Backend); isIstanbul {
+		if istEngine, isIstanbul := s.engine.(*istanbulBackend.Backend); isIstanbul {
 			valAccount := accounts.Account{Address: validator}
 			wallet, err := s.accountManager.Find(valAccount)
 			if wallet == nil || err != nil {
@@ -548,7 +547,12 @@ func (s *Ethereum) StartMining(threads int) error {
 				log.Error("BLSbase account unavailable locally", "err", err)
 				return fmt.Errorf("BLS signer missing: %v", err)
 			}
-			istanbul.Authorize(validator, publicKey, wallet.Decrypt, wallet.SignData, blswallet.SignBLS)
+			signBLSFn := istanbul.BLSSignerInAnyView(blswallet.SignBLS)
+			if externalSigner, ok := blswallet.(*external.ExternalSigner); ok {
+				// Let the external signer know the views messages are signed in, to protect against double signing
+				signBLSFn = externalSigner.SignBLSInView
+			}
+			istEngine.Authorize(validator, publicKey, wallet.Decrypt, wallet.SignData, signBLSFn)
 		}
 
 		// If mining is started, we can disable the transaction rejection mechanism
@@ -66,6 +66,9 @@ type Config struct {
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
+	// Whether to index the validators that signed each block for signing history lookups
+	SigningHistoryIndex bool `toml:",omitempty"`
+
 	// Whitelist of required block number -> hash values to accept
 	Whitelist map[uint64]common.Hash `toml:"-"`
 
@@ -76,6 +79,12 @@ type Config struct {
 	LightPeers   int `toml:",omitempty"` // Maximum number of LES client peers
 	// Minimum gateway fee value to serve a transaction from a light client
 	GatewayFee *big.Int `toml:",omitempty"`
+	// Whether to relay light client transactions failing the gateway fee requirement at the lowest priority instead of rejecting them
+	GatewayFeeDeprioritize bool `toml:",omitempty"`
+	// Highest gateway fee a light client is willing to pay to relay its transactions, nil if unlimited
+	MaxGatewayFee *big.Int `toml:",omitempty"`
+	// Gateway fee recipients a light client prefers to relay its transactions through
+	GatewayFeeRecipients []common.Address `toml:",omitempty"`
 	// Validator is the address used to sign consensus messages.
 	TxFeeRecipient common.Address `toml:",omitempty"`
 	// Etherbase is the GatewayFeeRecipient light clients need to specify in order for their transactions to be accepted by this node.
@@ -98,6 +107,11 @@ type Config struct {
 	TrieDirtyCache int
 	TrieTimeout    time.Duration
 
+	// Megabytes of memory to cache the state snapshot with, 0 disables it. The state snapshot
+	// is experimental, so it's disabled by default. --snapshot enables it with the
+	// --cache.snapshot percentage of the --cache allowance.
+	SnapshotCache int
+
 	// Mining options
 	Miner miner.Config
 
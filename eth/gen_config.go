@@ -22,12 +22,16 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SyncMode                downloader.SyncMode
 		NoPruning               bool
 		NoPrefetch              bool
+		SigningHistoryIndex     bool                   `toml:",omitempty"`
 		Whitelist               map[uint64]common.Hash `toml:"-"`
 		LightServ               int                    `toml:",omitempty"`
 		LightIngress            int                    `toml:",omitempty"`
 		LightEgress             int                    `toml:",omitempty"`
 		LightPeers              int                    `toml:",omitempty"`
 		GatewayFee              *big.Int               `toml:",omitempty"`
+		GatewayFeeDeprioritize  bool                   `toml:",omitempty"`
+		MaxGatewayFee           *big.Int               `toml:",omitempty"`
+		GatewayFeeRecipients    []common.Address       `toml:",omitempty"`
 		Etherbase               common.Address         `toml:",omitempty"`
 		BLSbase                 common.Address         `toml:",omitempty"`
 		UltraLightServers       []string               `toml:",omitempty"`
@@ -40,6 +44,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TrieCleanCache          int
 		TrieDirtyCache          int
 		TrieTimeout             time.Duration
+		SnapshotCache           int
 		Miner                   miner.Config
 		TxPool                  core.TxPoolConfig
 		EnablePreimageRecording bool
@@ -58,12 +63,16 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SyncMode = c.SyncMode
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
+	enc.SigningHistoryIndex = c.SigningHistoryIndex
 	enc.Whitelist = c.Whitelist
 	enc.LightServ = c.LightServ
 	enc.LightIngress = c.LightIngress
 	enc.LightEgress = c.LightEgress
 	enc.LightPeers = c.LightPeers
 	enc.GatewayFee = c.GatewayFee
+	enc.GatewayFeeDeprioritize = c.GatewayFeeDeprioritize
+	enc.MaxGatewayFee = c.MaxGatewayFee
+	enc.GatewayFeeRecipients = c.GatewayFeeRecipients
 	enc.Etherbase = c.Etherbase
 	enc.BLSbase = c.BLSbase
 	enc.UltraLightServers = c.UltraLightServers
@@ -76,6 +85,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TrieCleanCache = c.TrieCleanCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
+	enc.SnapshotCache = c.SnapshotCache
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
@@ -98,12 +108,16 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SyncMode                *downloader.SyncMode
 		NoPruning               *bool
 		NoPrefetch              *bool
+		SigningHistoryIndex     *bool                  `toml:",omitempty"`
 		Whitelist               map[uint64]common.Hash `toml:"-"`
 		LightServ               *int                   `toml:",omitempty"`
 		LightIngress            *int                   `toml:",omitempty"`
 		LightEgress             *int                   `toml:",omitempty"`
 		LightPeers              *int                   `toml:",omitempty"`
 		GatewayFee              *big.Int               `toml:",omitempty"`
+		GatewayFeeDeprioritize  *bool                  `toml:",omitempty"`
+		MaxGatewayFee           *big.Int               `toml:",omitempty"`
+		GatewayFeeRecipients    []common.Address       `toml:",omitempty"`
 		Etherbase               *common.Address        `toml:",omitempty"`
 		BLSbase                 *common.Address        `toml:",omitempty"`
 		UltraLightServers       []string               `toml:",omitempty"`
@@ -116,6 +130,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TrieCleanCache          *int
 		TrieDirtyCache          *int
 		TrieTimeout             *time.Duration
+		SnapshotCache           *int
 		Miner                   *miner.Config
 		TxPool                  *core.TxPoolConfig
 		EnablePreimageRecording *bool
@@ -147,6 +162,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.NoPrefetch != nil {
 		c.NoPrefetch = *dec.NoPrefetch
 	}
+	if dec.SigningHistoryIndex != nil {
+		c.SigningHistoryIndex = *dec.SigningHistoryIndex
+	}
 	if dec.Whitelist != nil {
 		c.Whitelist = dec.Whitelist
 	}
@@ -165,6 +183,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.GatewayFee != nil {
 		c.GatewayFee = dec.GatewayFee
 	}
+	if dec.GatewayFeeDeprioritize != nil {
+		c.GatewayFeeDeprioritize = *dec.GatewayFeeDeprioritize
+	}
+	if dec.MaxGatewayFee != nil {
+		c.MaxGatewayFee = dec.MaxGatewayFee
+	}
+	if dec.GatewayFeeRecipients != nil {
+		c.GatewayFeeRecipients = dec.GatewayFeeRecipients
+	}
 	if dec.Etherbase != nil {
 		c.Etherbase = *dec.Etherbase
 	}
@@ -201,6 +228,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TrieTimeout != nil {
 		c.TrieTimeout = *dec.TrieTimeout
 	}
+	if dec.SnapshotCache != nil {
+		c.SnapshotCache = *dec.SnapshotCache
+	}
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
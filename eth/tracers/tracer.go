@@ -279,6 +279,8 @@ func (cw *contractWrapper) pushObject(vm *duktape.Context) {
 type Tracer struct {
 	inited bool // Flag whether the context was already inited from the EVM
 
+	activePrecompiles map[common.Address]vm.PrecompiledContract // Precompiles enabled at the traced block
+
 	vm *duktape.Context // Javascript VM instance
 
 	tracerObject int // Stack index of the tracer JavaScript object
@@ -390,7 +392,7 @@ func New(code string) (*Tracer, error) {
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("isPrecompiled", func(ctx *duktape.Context) int {
-		_, ok := vm.PrecompiledContractsIstanbul[common.BytesToAddress(popSlice(ctx))]
+		_, ok := tracer.activePrecompiles[common.BytesToAddress(popSlice(ctx))]
 		ctx.PushBoolean(ok)
 		return 1
 	})
@@ -537,6 +539,7 @@ func (jst *Tracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost
 		// Initialize the context if it wasn't done yet
 		if !jst.inited {
 			jst.ctx["block"] = env.BlockNumber.Uint64()
+			jst.activePrecompiles = vm.ActivePrecompiles(env.ChainConfig().Rules(env.BlockNumber))
 			jst.inited = true
 		}
 		// If tracing was interrupted, set the error and stop
@@ -145,3 +145,40 @@ func TestHaltBetweenSteps(t *testing.T) {
 		t.Errorf("Expected timeout error, got %v", err)
 	}
 }
+
+func TestIsPrecompiled(t *testing.T) {
+	config := *params.DefaultChainConfig
+	config.IstanbulBlock = big.NewInt(0)
+	config.DonutBlock = big.NewInt(100)
+	config.EspressoBlock = big.NewInt(200)
+	config.GingerbreadBlock = big.NewInt(300)
+
+	// The first BLS12-377 precompile is only enabled from the Donut fork on.
+	code := "{res: null, step: function() { this.res = isPrecompiled(toAddress('0x00000000000000000000000000000000000000f3')); }, fault: function() {}, result: function() { return this.res; }}"
+	for _, test := range []struct {
+		number   int64
+		expected string
+	}{
+		{50, "false"},
+		{150, "true"},
+		{250, "true"},
+	} {
+		tracer, err := New(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env := vm.NewEVM(vm.Context{BlockNumber: big.NewInt(test.number)}, &dummyStatedb{}, &config, vm.Config{Debug: true, Tracer: tracer})
+		contract := vm.NewContract(account{}, account{}, big.NewInt(0), 10000)
+		contract.Code = []byte{byte(vm.PUSH1), 0x1, 0x0}
+		if _, err := env.Interpreter().Run(contract, []byte{}, false); err != nil {
+			t.Fatal(err)
+		}
+		ret, err := tracer.GetResult()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(ret) != test.expected {
+			t.Errorf("block %d: isPrecompiled returned %s, want %s", test.number, ret, test.expected)
+		}
+	}
+}
@@ -796,7 +796,7 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		valSet = s.assembleValidatorSet(block, state)
 	}
 
-	gasLimit := core.CalcGasLimit(block, state)
+	gasLimit := core.CalcGasLimit(s.eth.BlockChain().SystemCaller(), block, state)
 
 	return &blockStats{
 		Number:      header.Number,
@@ -839,11 +839,11 @@ func (s *Service) assembleValidatorSet(block *types.Block, state vm.StateDB) val
 	)
 
 	// Add set of registered validators
-	valsRegisteredMap, _ := validators.RetrieveRegisteredValidators(s.eth.BlockChain().CurrentHeader(), state)
+	valsRegisteredMap, _ := validators.RetrieveRegisteredValidators(s.eth.BlockChain().SystemCaller(), s.eth.BlockChain().CurrentHeader(), state)
 	valsRegistered = make([]validatorInfo, 0, len(valsRegisteredMap))
 	for _, address := range valsRegisteredMap {
 		var valData validators.ValidatorContractData
-		valData, err = validators.GetValidator(s.eth.BlockChain().CurrentHeader(), state, address)
+		valData, err = validators.GetValidator(s.eth.BlockChain().SystemCaller(), s.eth.BlockChain().CurrentHeader(), state, address)
 
 		if err != nil {
 			log.Warn("Validator data not found", "address", address.Hex(), "err", err)
@@ -57,15 +57,20 @@ func NewPublicEthereumAPI(b Backend) *PublicEthereumAPI {
 	return &PublicEthereumAPI{b}
 }
 
-// GasPrice returns a suggestion for a gas price.
-func (s *PublicEthereumAPI) GasPrice(ctx context.Context, feeCurrency *common.Address) (*hexutil.Big, error) {
-	if feeCurrency == nil {
+// GasPrice returns a suggestion for a gas price. If a block is given, the suggestion
+// is computed against the state of that block rather than the chain head.
+func (s *PublicEthereumAPI) GasPrice(ctx context.Context, feeCurrency *common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	if feeCurrency == nil && blockNrOrHash == nil {
 		price, err := s.b.SuggestPrice(ctx)
 		return (*hexutil.Big)(price), err
 	}
 
-	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
-	if err != nil {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if state == nil || err != nil {
 		return nil, err
 	}
 	price, err := s.b.SuggestPriceInCurrency(ctx, feeCurrency, header, state)
@@ -147,6 +152,21 @@ func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 	}
 }
 
+// StatusByCurrency returns the number of pending and queued transactions in the pool
+// for each fee currency, along with the number of slots the currency is limited to
+// (0 if only the global limits apply). Celo Gold is reported under the zero address.
+func (s *PublicTxPoolAPI) StatusByCurrency() map[common.Address]map[string]hexutil.Uint64 {
+	status := make(map[common.Address]map[string]hexutil.Uint64)
+	for feeCurrency, stats := range s.b.StatsByCurrency() {
+		status[feeCurrency] = map[string]hexutil.Uint64{
+			"pending": hexutil.Uint64(stats.Pending),
+			"queued":  hexutil.Uint64(stats.Queued),
+			"slots":   hexutil.Uint64(stats.Slots),
+		}
+	}
+	return status
+}
+
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
@@ -901,7 +921,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args CallArgs, blockNrOrHash
 			return 0, err
 		}
 
-		hi = core.CalcGasLimit(block, statedb)
+		hi = core.CalcGasLimit(b.SystemCaller(), block, statedb)
 	}
 	if gasCap != nil && hi > gasCap.Uint64() {
 		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", gasCap)
@@ -1388,7 +1408,7 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 			// We need to cover the gas use of one 'balanceOf', one 'debitFrom', and two 'creditTo' calls.
 			state, header, err := b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 			if err != nil {
-				*(*uint64)(args.Gas) = defaultGas + blockchain_parameters.GetIntrinsicGasForAlternativeFeeCurrency(header, state)
+				*(*uint64)(args.Gas) = defaultGas + blockchain_parameters.GetIntrinsicGasForAlternativeFeeCurrency(b.SystemCaller(), header, state)
 			} else {
 				log.Warn("Cannot read intrinsic gas for alternative fee currency", "err", err)
 				*(*uint64)(args.Gas) = defaultGas + params.IntrinsicGasForAlternativeFeeCurrency
@@ -668,6 +668,18 @@ web3._extend({
 				return status;
 			}
 		}),
+		new web3._extend.Property({
+			name: 'statusByCurrency',
+			getter: 'txpool_statusByCurrency',
+			outputFormatter: function(status) {
+				for (var currency in status) {
+					status[currency].pending = web3._extend.utils.toDecimal(status[currency].pending);
+					status[currency].queued = web3._extend.utils.toDecimal(status[currency].queued);
+					status[currency].slots = web3._extend.utils.toDecimal(status[currency].slots);
+				}
+				return status;
+			}
+		}),
 	]
 });
 `
@@ -739,6 +751,29 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'getUptime',
+			call: 'istanbul_getUptime',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorSigningHistory',
+			call: 'istanbul_getValidatorSigningHistory',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getEpochRewards',
+			call: 'istanbul_getEpochRewards',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'previewEpochRewards',
+			call: 'istanbul_previewEpochRewards',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'addProxy',
 			call: 'istanbul_addProxy',
@@ -749,6 +784,30 @@ web3._extend({
 			call: 'istanbul_removeProxy',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'startAtBlock',
+			call: 'istanbul_startAtBlock',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'stopAtBlock',
+			call: 'istanbul_stopAtBlock',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getEquivocationEvidence',
+			call: 'istanbul_getEquivocationEvidence',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Property({
+			name: 'replicaState',
+			getter: 'istanbul_getReplicaState',
+		}),
+		new web3._extend.Property({
+			name: 'proxiesInfo',
+			getter: 'istanbul_getProxiesInfo',
+		}),
 		new web3._extend.Property({
 			name: 'valEnodeTableInfo',
 			getter: 'istanbul_getValEnodeTable',
@@ -847,6 +906,10 @@ web3._extend({
 		new web3._extend.Property({
 			name: 'gatewayFeeCache',
 			getter: 'les_gatewayFeeCache'
+		}),
+		new web3._extend.Property({
+			name: 'gatewayFeeEarnings',
+			getter: 'les_gatewayFeeEarnings'
 		})
 	]
 });
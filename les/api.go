@@ -89,6 +89,12 @@ func (api *PrivateLightServerAPI) GatewayFeeRecipient() (eb common.Address, err
 	return api.server.handler.etherbase, nil
 }
 
+// GatewayFeeEarnings returns the gateway fees earned from the transactions relayed for light
+// clients, totalled per client and per day
+func (api *PrivateLightServerAPI) GatewayFeeEarnings() (*GatewayFeeEarningsSummary, error) {
+	return api.server.handler.gatewayFees.summary()
+}
+
 // ServerInfo returns global server parameters
 func (api *PrivateLightServerAPI) ServerInfo() map[string]interface{} {
 	res := make(map[string]interface{})
@@ -416,8 +422,15 @@ func (api *LightClientAPI) RequestPeerGatewayFees() error {
 	return nil
 }
 
-// SuggestGatewayFee suggests the best light server to choose based on different factors. Currently only minPeerGatewayFee.
+// SuggestGatewayFee suggests the connected light server to relay transactions through, as
+// chosen by the gateway fee policy, falling back to the lowest gateway fee known.
 func (api *LightClientAPI) SuggestGatewayFee() (*GatewayFeeInformation, error) {
+	if p := api.le.gatewayFeeServer(); p != nil {
+		etherbase, _ := p.Etherbase()
+		if fee, ok := p.GatewayFee(); ok {
+			return &GatewayFeeInformation{GatewayFee: fee, Etherbase: etherbase}, nil
+		}
+	}
 	bestGatewayFeeInfo, err := api.le.handler.gatewayFeeCache.MinPeerGatewayFee()
 	if err != nil {
 		return nil, err
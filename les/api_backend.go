@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	gpm "github.com/ethereum/go-ethereum/contract_comm/gasprice_minimum"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
@@ -202,6 +203,14 @@ func (b *LesApiBackend) Stats() (pending int, queued int) {
 	return b.eth.txPool.Stats(), 0
 }
 
+func (b *LesApiBackend) StatsByCurrency() map[common.Address]core.TxPoolCurrencyStats {
+	stats := make(map[common.Address]core.TxPoolCurrencyStats)
+	for feeCurrency, pending := range b.eth.txPool.StatsByCurrency() {
+		stats[feeCurrency] = core.TxPoolCurrencyStats{Pending: pending}
+	}
+	return stats
+}
+
 func (b *LesApiBackend) TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions) {
 	return b.eth.txPool.Content()
 }
@@ -239,15 +248,19 @@ func (b *LesApiBackend) ProtocolVersion() int {
 }
 
 func (b *LesApiBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
-	return gpm.GetGasPriceSuggestion(nil, nil, nil)
+	return gpm.GetGasPriceSuggestion(b.SystemCaller(), nil, nil, nil)
 }
 
 func (b *LesApiBackend) SuggestPriceInCurrency(ctx context.Context, currencyAddress *common.Address, header *types.Header, state *state.StateDB) (*big.Int, error) {
-	return gpm.GetGasPriceSuggestion(currencyAddress, header, state)
+	return gpm.GetGasPriceSuggestion(b.SystemCaller(), currencyAddress, header, state)
 }
 
 func (b *LesApiBackend) GetGasPriceMinimum(ctx context.Context, currencyAddress *common.Address) (*big.Int, error) {
-	return gpm.GetGasPriceMinimum(currencyAddress, nil, nil)
+	return gpm.GetGasPriceMinimum(b.SystemCaller(), currencyAddress, nil, nil)
+}
+
+func (b *LesApiBackend) SystemCaller() *contract_comm.SystemCaller {
+	return b.eth.blockchain.SystemCaller()
 }
 
 func (b *LesApiBackend) ChainDb() ethdb.Database {
@@ -285,10 +298,18 @@ func (b *LesApiBackend) ServiceFilter(ctx context.Context, session *bloombits.Ma
 }
 
 func (b *LesApiBackend) GatewayFeeRecipient() common.Address {
-	return b.eth.GetRandomPeerEtherbase()
+	if p := b.eth.gatewayFeeServer(); p != nil {
+		etherbase, _ := p.Etherbase()
+		return etherbase
+	}
+	return common.Address{}
 }
 
 func (b *LesApiBackend) GatewayFee() *big.Int {
-	// TODO(nategraf): Create a method to fetch the gateway fee values of peers along with the coinbase.
+	if p := b.eth.gatewayFeeServer(); p != nil {
+		if fee, ok := p.GatewayFee(); ok {
+			return fee
+		}
+	}
 	return eth.DefaultConfig.GatewayFee
 }
@@ -27,7 +27,6 @@ import (
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/consensus"
 	istanbulBackend "github.com/ethereum/go-ethereum/consensus/istanbul/backend"
-	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -113,7 +112,7 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 		networkId:      config.NetworkId,
 		bloomRequests:  make(chan chan *bloombits.Retrieval),
 		bloomIndexer:   eth.NewBloomIndexer(chainDb, params.BloomBitsBlocksClient, params.HelperTrieConfirmations, fullChainAvailable),
-		serverPool:     newServerPool(chainDb, config.UltraLightServers),
+		serverPool:     newServerPool(chainDb, config.UltraLightServers, newGatewayFeePolicy(config.MaxGatewayFee, config.GatewayFeeRecipients)),
 	}
 
 	if syncMode == downloader.LightestSync && chainConfig.Istanbul == nil {
@@ -142,10 +141,6 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 		return nil, err
 	}
 
-	// Set the blockchain for the EVMHandler singleton that geth can use to make calls to smart contracts.
-	// Note that this should NOT be used when executing smart contract calls done via end user transactions.
-	contract_comm.SetInternalEVMHandler(leth.blockchain)
-
 	leth.chainReader = leth.blockchain
 	leth.txPool = light.NewTxPool(leth.chainConfig, leth.blockchain, leth.relay)
 
@@ -164,7 +159,7 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	leth.bloomIndexer.Start(leth.blockchain)
 
 	// TODO mcortesi (needs etherbase & gatewayFee?)
-	leth.handler = newClientHandler(syncMode, config.UltraLightServers, config.UltraLightFraction, checkpoint, leth, config.GatewayFee)
+	leth.handler = newClientHandler(syncMode, config.UltraLightServers, config.UltraLightFraction, checkpoint, leth)
 	if leth.handler.ulc != nil {
 		log.Warn("Ultra light client is enabled", "trustedNodes", len(leth.handler.ulc.keys), "minTrustedFraction", leth.handler.ulc.fraction)
 		leth.blockchain.DisableCheckFreq()
@@ -186,6 +181,7 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	// If the engine is istanbul, then inject the blockchain
 	if istanbul, isIstanbul := leth.engine.(*istanbulBackend.Backend); isIstanbul {
 		istanbul.SetChain(leth.chainreader, nil, nil)
+		istanbul.SetSystemCaller(leth.blockchain.SystemCaller())
 	}
 
 	return leth, nil
@@ -292,8 +288,10 @@ func (s *LightEthereum) Start(srvr *p2p.Server) error {
 	return nil
 }
 
-func (s *LightEthereum) GetRandomPeerEtherbase() common.Address {
-	return s.peers.randomPeerEtherbase()
+// gatewayFeeServer returns the connected server to relay transactions through, chosen by
+// the gateway fee policy of the client, or nil if there is none.
+func (s *LightEthereum) gatewayFeeServer() *peer {
+	return s.peers.gatewayFeeServer(s.serverPool.feePolicy)
 }
 
 // Stop implements node.Service, terminating all internal goroutines used by the
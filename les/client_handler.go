@@ -43,9 +43,6 @@ type clientHandler struct {
 	backend    *LightEthereum
 	syncMode   downloader.SyncMode
 
-	// TODO(nategraf) Remove this field once gateway fees can be retreived.
-	gatewayFee *big.Int
-
 	closeCh  chan struct{}
 	wg       sync.WaitGroup // WaitGroup used to track all connected peers.
 	syncDone func()         // Test hooks when syncing is done.
@@ -87,7 +84,7 @@ func (c *gatewayFeeCache) update(nodeID string, val *GatewayFeeInformation) erro
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	if val.Etherbase == common.ZeroAddress || val.GatewayFee.Cmp(big.NewInt(0)) < 0 {
+	if val.Etherbase == common.ZeroAddress || val.GatewayFee == nil || val.GatewayFee.Cmp(big.NewInt(0)) < 0 {
 		return errors.New("invalid gatewayFeeInformation object")
 	}
 	c.gatewayFeeMap[nodeID] = val
@@ -115,13 +112,12 @@ func (c *gatewayFeeCache) MinPeerGatewayFee() (*GatewayFeeInformation, error) {
 	return minGatewayFeeInformation, nil
 }
 
-func newClientHandler(syncMode downloader.SyncMode, ulcServers []string, ulcFraction int, checkpoint *params.TrustedCheckpoint, backend *LightEthereum, gatewayFee *big.Int) *clientHandler {
+func newClientHandler(syncMode downloader.SyncMode, ulcServers []string, ulcFraction int, checkpoint *params.TrustedCheckpoint, backend *LightEthereum) *clientHandler {
 	handler := &clientHandler{
 		checkpoint: checkpoint,
 		backend:    backend,
 		closeCh:    make(chan struct{}),
 		syncMode:   syncMode,
-		gatewayFee: gatewayFee,
 	}
 	if ulcServers != nil {
 		ulc, err := newULC(ulcServers, ulcFraction)
@@ -193,9 +189,6 @@ func (h *clientHandler) handle(p *peer) error {
 		return err
 	}
 
-	// TODO(nategraf) The local gateway fee is temporarily being used as the peer gateway fee.
-	p.SetGatewayFee(h.gatewayFee)
-
 	// Register the peer locally
 	if err := h.backend.peers.Register(p); err != nil {
 		p.Log().Error("Light Ethereum peer registration failed", "err", err)
@@ -217,14 +210,22 @@ func (h *clientHandler) handle(p *peer) error {
 		h.backend.serverPool.registered(p.poolEntry)
 	}
 
-	// Loop until we receive a RequestEtherbase response or timeout.
+	// Loop until we receive a RequestEtherbase response or timeout. Servers supporting
+	// lpv4 are asked for their gateway fee instead, which comes with their etherbase.
 	go func() {
 		maxRequests := 10
 		for requests := 1; requests <= maxRequests; requests++ {
-			p.Log().Trace("Requesting etherbase from new peer")
+			var err error
 			reqID := genReqID()
-			cost := p.GetRequestCost(GetEtherbaseMsg, int(1))
-			err := p.RequestEtherbase(reqID, cost)
+			if p.version >= lpv4 {
+				p.Log().Trace("Requesting gateway fee from new peer")
+				cost := p.GetRequestCost(GetGatewayFeeMsg, int(1))
+				err = p.RequestGatewayFee(reqID, cost)
+			} else {
+				p.Log().Trace("Requesting etherbase from new peer")
+				cost := p.GetRequestCost(GetEtherbaseMsg, int(1))
+				err = p.RequestEtherbase(reqID, cost)
+			}
 
 			if err != nil {
 				p.Log().Warn("Unable to request etherbase from peer", "err", err)
@@ -327,6 +328,28 @@ func (h *clientHandler) handleMsg(p *peer) error {
 				}
 			}
 		}
+	case EpochHeadersMsg:
+		p.Log().Trace("Received epoch headers response message")
+		var resp struct {
+			ReqID, BV uint64
+			Headers   []*epochHeader
+		}
+		if err := msg.Decode(&resp); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		p.fcServer.ReceivedReply(resp.ReqID, resp.BV)
+		headers := make([]*types.Header, len(resp.Headers))
+		for i, eh := range resp.Headers {
+			if eh.Header == nil {
+				return errResp(ErrInvalidResponse, "reqID = %v", resp.ReqID)
+			}
+			headers[i] = eh.Header
+			// The epoch snark data is verified when the header is inserted
+			h.backend.blockchain.AddEpochSnarkData(eh.Header.Hash(), eh.EpochSnarkData)
+		}
+		if err := h.downloader.DeliverHeaders(p.id, headers); err != nil {
+			log.Error("Failed to deliver headers", "err", err)
+		}
 	case BlockBodiesMsg:
 		p.Log().Trace("Received block bodies response")
 		var resp struct {
@@ -455,7 +478,13 @@ func (h *clientHandler) handleMsg(p *peer) error {
 		}
 
 		p.fcServer.ReceivedReply(resp.ReqID, resp.BV)
-		h.gatewayFeeCache.update(p.id, &resp.Data)
+		if err := h.gatewayFeeCache.update(p.id, &resp.Data); err != nil {
+			p.Log().Debug("Ignoring invalid gateway fee", "err", err)
+			break
+		}
+		p.SetEtherbase(resp.Data.Etherbase)
+		p.SetGatewayFee(resp.Data.GatewayFee)
+		h.backend.serverPool.adjustGatewayFee(p.poolEntry, &resp.Data)
 
 	default:
 		p.Log().Trace("Received invalid message", "code", msg.Code)
@@ -511,6 +540,11 @@ func (pc *peerConnection) RequestHeadersByHash(origin common.Hash, amount int, s
 }
 
 func (pc *peerConnection) RequestHeadersByNumber(origin uint64, amount int, skip int, reverse bool) error {
+	// Epoch headers, as fetched by a lightest sync, are requested with their epoch snark data
+	// from the servers supporting it
+	if epochSize := pc.epochSize(); pc.peer.version >= lpv4 && epochSize > 0 && uint64(skip)+1 == epochSize && origin%epochSize == 0 && !reverse {
+		return pc.requestEpochHeaders(origin/epochSize, amount)
+	}
 	rq := &distReq{
 		getCost: func(dp distPeer) uint64 {
 			peer := dp.(*peer)
@@ -534,6 +568,39 @@ func (pc *peerConnection) RequestHeadersByNumber(origin uint64, amount int, skip
 	return nil
 }
 
+// requestEpochHeaders fetches the last headers of a batch of epochs, starting with the given one
+func (pc *peerConnection) requestEpochHeaders(firstEpoch uint64, amount int) error {
+	rq := &distReq{
+		getCost: func(dp distPeer) uint64 {
+			peer := dp.(*peer)
+			return peer.GetRequestCost(GetEpochHeadersMsg, amount)
+		},
+		canSend: func(dp distPeer) bool {
+			return dp.(*peer) == pc.peer
+		},
+		request: func(dp distPeer) func() {
+			reqID := genReqID()
+			peer := dp.(*peer)
+			cost := peer.GetRequestCost(GetEpochHeadersMsg, amount)
+			peer.fcServer.QueuedRequest(reqID, cost)
+			return func() { peer.RequestEpochHeaders(reqID, cost, firstEpoch, amount) }
+		},
+	}
+	_, ok := <-pc.handler.backend.reqDist.queue(rq)
+	if !ok {
+		return light.ErrNoPeers
+	}
+	return nil
+}
+
+// epochSize returns the size of the Istanbul epochs of the chain, or 0 if it doesn't use Istanbul
+func (pc *peerConnection) epochSize() uint64 {
+	if config := pc.handler.backend.blockchain.Config().Istanbul; config != nil {
+		return config.Epoch
+	}
+	return 0
+}
+
 // downloaderPeerNotify implements peerSetNotify
 type downloaderPeerNotify clientHandler
 
@@ -45,6 +45,8 @@ var (
 		SendTxV2Msg:            {0, 450000},
 		GetTxStatusMsg:         {0, 250000},
 		GetEtherbaseMsg:        {10000, 1},
+		GetGatewayFeeMsg:       {10000, 1},
+		GetEpochHeadersMsg:     {150000, 100000},
 	}
 	// maximum incoming message size estimates
 	reqMaxInSize = requestCostTable{
@@ -57,6 +59,8 @@ var (
 		SendTxV2Msg:            {0, 16500},
 		GetTxStatusMsg:         {0, 50},
 		GetEtherbaseMsg:        {0, 10},
+		GetGatewayFeeMsg:       {0, 10},
+		GetEpochHeadersMsg:     {40, 0},
 	}
 	// maximum outgoing message size estimates
 	reqMaxOutSize = requestCostTable{
@@ -69,6 +73,8 @@ var (
 		SendTxV2Msg:            {0, 100},
 		GetTxStatusMsg:         {0, 100},
 		GetEtherbaseMsg:        {0, 100},
+		GetGatewayFeeMsg:       {0, 100},
+		GetEpochHeadersMsg:     {0, 700},
 	}
 	// request amounts that have to fit into the minimum buffer size minBufferMultiplier times
 	minBufferReqAmount = map[uint64]uint64{
@@ -81,6 +87,8 @@ var (
 		SendTxV2Msg:            8,
 		GetTxStatusMsg:         64,
 		GetEtherbaseMsg:        1,
+		GetGatewayFeeMsg:       1,
+		GetEpochHeadersMsg:     192,
 	}
 	minBufferMultiplier = 3
 )
@@ -298,6 +306,8 @@ func (ct *costTracker) gfLoop() {
 						relativeCostSendTxHistogram.Update(relCost)
 					case GetTxStatusMsg:
 						relativeCostTxStatusHistogram.Update(relCost)
+					case GetEpochHeadersMsg:
+						relativeCostEpochHistogram.Update(relCost)
 					}
 				}
 				// SendTxV2 and GetTxStatus requests are two special cases.
@@ -0,0 +1,94 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// preferredRecipientWeight is the factor the selection weight of a server whose
+	// gateway fee recipient is preferred is multiplied by
+	preferredRecipientWeight = 4
+	// excessiveFeeWeight is the factor the selection weight of a server asking more than
+	// the maximum gateway fee is multiplied by. Such servers are still dialed at a low rate,
+	// as they serve chain data even if they don't relay transactions.
+	excessiveFeeWeight = 0.05
+)
+
+// gatewayFeePolicy decides which gateway fees a light client is willing to pay, and which
+// light servers it prefers to relay its transactions through.
+type gatewayFeePolicy struct {
+	maxFee    *big.Int                // Highest acceptable gateway fee, nil if unlimited
+	preferred map[common.Address]bool // Gateway fee recipients preferred over others
+}
+
+// newGatewayFeePolicy creates a gateway fee policy from the client configuration
+func newGatewayFeePolicy(maxFee *big.Int, preferred []common.Address) *gatewayFeePolicy {
+	policy := &gatewayFeePolicy{maxFee: maxFee, preferred: make(map[common.Address]bool)}
+	for _, recipient := range preferred {
+		policy.preferred[recipient] = true
+	}
+	return policy
+}
+
+// acceptable returns whether the client is willing to pay the given gateway fee. Without a
+// maximum fee any fee is acceptable, including the unknown fee of servers that don't
+// advertise one (before lpv4). With a maximum fee, unknown fees aren't acceptable, as the
+// server may ask for any fee.
+func (p *gatewayFeePolicy) acceptable(fee *big.Int) bool {
+	if p.maxFee == nil {
+		return true
+	}
+	return fee != nil && fee.Cmp(p.maxFee) <= 0
+}
+
+// weight returns the factor the selection weight of a server advertising the given gateway
+// fee and recipient is multiplied by. Servers are favoured linearly from half weight at the
+// maximum fee to full weight when relaying for free. Without a maximum fee, or while the
+// fee of a server is unknown, only the recipient preference applies.
+func (p *gatewayFeePolicy) weight(fee *big.Int, recipient common.Address) float64 {
+	weight := 1.0
+	if fee != nil && p.maxFee != nil {
+		if !p.acceptable(fee) {
+			return excessiveFeeWeight
+		}
+		if p.maxFee.Sign() > 0 {
+			ratio, _ := new(big.Rat).SetFrac(fee, p.maxFee).Float64()
+			weight -= ratio / 2
+		}
+	}
+	if p.preferred[recipient] {
+		weight *= preferredRecipientWeight
+	}
+	return weight
+}
+
+// better returns whether a server advertising the given gateway fee and recipient is a
+// better choice to relay transactions through than one advertising the other ones:
+// preferred recipients come first, then the lowest fee, then servers with an unknown fee.
+func (p *gatewayFeePolicy) better(fee *big.Int, recipient common.Address, otherFee *big.Int, otherRecipient common.Address) bool {
+	if preferred, otherPreferred := p.preferred[recipient], p.preferred[otherRecipient]; preferred != otherPreferred {
+		return preferred
+	}
+	if fee == nil || otherFee == nil {
+		return otherFee == nil && fee != nil
+	}
+	return fee.Cmp(otherFee) < 0
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestGatewayFeePolicyWeight(t *testing.T) {
+	preferred := common.HexToAddress("deadbeef")
+	other := common.HexToAddress("badf00d")
+	policy := newGatewayFeePolicy(big.NewInt(100), []common.Address{preferred})
+
+	cases := []struct {
+		fee       *big.Int
+		recipient common.Address
+		weight    float64
+	}{
+		{nil, other, 1},
+		{big.NewInt(0), other, 1},
+		{big.NewInt(50), other, 0.75},
+		{big.NewInt(100), other, 0.5},
+		{big.NewInt(101), other, excessiveFeeWeight},
+		{big.NewInt(100), preferred, 0.5 * preferredRecipientWeight},
+		{big.NewInt(101), preferred, excessiveFeeWeight},
+	}
+	for i, c := range cases {
+		if weight := policy.weight(c.fee, c.recipient); weight != c.weight {
+			t.Errorf("case %d: weight mismatch: have %v, want %v", i, weight, c.weight)
+		}
+	}
+
+	unlimited := newGatewayFeePolicy(nil, nil)
+	if weight := unlimited.weight(big.NewInt(1000000), other); weight != 1 {
+		t.Errorf("weight without a maximum fee mismatch: have %v, want 1", weight)
+	}
+}
+
+func TestGatewayFeeServer(t *testing.T) {
+	preferred := common.HexToAddress("deadbeef")
+	cheap, expensive := common.HexToAddress("01"), common.HexToAddress("02")
+
+	ps := newPeerSet()
+	ps.peers["cheap"] = &peer{id: "cheap", etherbase: &cheap, gatewayFee: big.NewInt(10)}
+	ps.peers["expensive"] = &peer{id: "expensive", etherbase: &expensive, gatewayFee: big.NewInt(20)}
+	ps.peers["preferred"] = &peer{id: "preferred", etherbase: &preferred, gatewayFee: big.NewInt(30)}
+	ps.peers["unknown"] = &peer{id: "unknown", gatewayFee: big.NewInt(0)}
+	ps.peers["unadvertised"] = &peer{id: "unadvertised", etherbase: &preferred}
+
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(nil, nil)); p == nil || p.id != "cheap" {
+		t.Errorf("lowest fee server not selected: %v", p)
+	}
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(nil, []common.Address{preferred})); p == nil || p.id != "preferred" {
+		t.Errorf("preferred server not selected: %v", p)
+	}
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(big.NewInt(20), []common.Address{preferred})); p == nil || p.id != "cheap" {
+		t.Errorf("lowest acceptable fee server not selected: %v", p)
+	}
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(big.NewInt(5), []common.Address{preferred})); p != nil {
+		t.Errorf("server with an excessive fee selected: %v", p.id)
+	}
+}
+
+func TestGatewayFeeServerUnknownFee(t *testing.T) {
+	legacy, cheap := common.HexToAddress("01"), common.HexToAddress("02")
+
+	// Servers before lpv4 don't advertise their gateway fee
+	ps := newPeerSet()
+	ps.peers["legacy"] = &peer{id: "legacy", etherbase: &legacy}
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(nil, nil)); p == nil || p.id != "legacy" {
+		t.Errorf("server without an advertised fee not selected: %v", p)
+	}
+	if p := ps.gatewayFeeServer(newGatewayFeePolicy(big.NewInt(10), nil)); p != nil {
+		t.Errorf("server without an advertised fee selected with a maximum fee: %v", p.id)
+	}
+
+	// Servers advertising their fee are preferred
+	ps.peers["cheap"] = &peer{id: "cheap", etherbase: &cheap, gatewayFee: big.NewInt(10)}
+	for i := 0; i < 10; i++ {
+		if p := ps.gatewayFeeServer(newGatewayFeePolicy(nil, nil)); p == nil || p.id != "cheap" {
+			t.Fatalf("server with an advertised fee not selected: %v", p)
+		}
+	}
+}
+
+func TestPoolEntryGatewayFeeRLP(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := &poolEntryAddress{ip: net.IP{127, 0, 0, 1}, port: 30303}
+	entry := &poolEntry{
+		node:          enode.NewV4(&key.PublicKey, addr.ip, int(addr.port), int(addr.port)),
+		lastConnected: addr,
+	}
+
+	// Entries stored without a gateway fee decode with an unknown fee
+	enc, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		t.Fatalf("failed to encode pool entry: %v", err)
+	}
+	var decoded poolEntry
+	if err := rlp.DecodeBytes(enc, &decoded); err != nil {
+		t.Fatalf("failed to decode pool entry: %v", err)
+	}
+	if decoded.gatewayFee != nil || decoded.feeWeight != 1 {
+		t.Errorf("gateway fee of entry without fee mismatch: have %v, weight %v", decoded.gatewayFee, decoded.feeWeight)
+	}
+
+	entry.gatewayFee = &GatewayFeeInformation{GatewayFee: big.NewInt(42), Etherbase: common.HexToAddress("deadbeef")}
+	if enc, err = rlp.EncodeToBytes(entry); err != nil {
+		t.Fatalf("failed to encode pool entry: %v", err)
+	}
+	if err := rlp.DecodeBytes(enc, &decoded); err != nil {
+		t.Fatalf("failed to decode pool entry: %v", err)
+	}
+	if decoded.gatewayFee == nil || decoded.gatewayFee.GatewayFee.Cmp(big.NewInt(42)) != 0 || decoded.gatewayFee.Etherbase != entry.gatewayFee.Etherbase {
+		t.Errorf("gateway fee mismatch: have %v, want %v", decoded.gatewayFee, entry.gatewayFee)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxPendingRelayedTxs is the maximum number of relayed transactions waiting to be
+	// mined that are tracked at once
+	maxPendingRelayedTxs = 4096
+	// gatewayFeeConfirmations is the number of blocks a relayed transaction is waited to be
+	// buried under before its gateway fee is accounted, so that it isn't reorged out
+	gatewayFeeConfirmations = 5
+	// gatewayFeeDayFormat is the format of the days gateway fee earnings are totalled by
+	gatewayFeeDayFormat = "2006-01-02"
+)
+
+// relayedTxTimeout is how long a relayed transaction is waited for to be mined before its
+// gateway fee is considered unpaid
+var relayedTxTimeout = core.DefaultTxPoolConfig.Lifetime
+
+// gatewayFeeChain is the part of the blockchain the gateway fee tracker follows
+type gatewayFeeChain interface {
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// GatewayFeeTotal is the amount of gateway fees earned in a fee currency. The zero
+// address stands for the native currency.
+type GatewayFeeTotal struct {
+	FeeCurrency common.Address `json:"feeCurrency"`
+	Amount      *big.Int       `json:"amount"`
+}
+
+// GatewayFeeEarnings are the gateway fees earned from the transactions relayed for a
+// client, or mined during a day.
+type GatewayFeeEarnings struct {
+	Txs  uint64             `json:"txs"`
+	Fees []*GatewayFeeTotal `json:"fees"`
+}
+
+// add accounts the gateway fee paid by a mined transaction
+func (e *GatewayFeeEarnings) add(currency common.Address, fee *big.Int) {
+	e.Txs++
+	for _, total := range e.Fees {
+		if total.FeeCurrency == currency {
+			total.Amount = new(big.Int).Add(total.Amount, fee)
+			return
+		}
+	}
+	e.Fees = append(e.Fees, &GatewayFeeTotal{FeeCurrency: currency, Amount: new(big.Int).Set(fee)})
+}
+
+// relayedTx is a transaction relayed for a light client that pays a gateway fee to the
+// server, waiting to be mined
+type relayedTx struct {
+	client    string
+	etherbase common.Address // Gateway fee recipient of the server when the transaction was relayed
+	relayed   time.Time
+}
+
+// gatewayFeeTracker records the transactions relayed for light clients that pay a gateway
+// fee to the server, and accounts their fees per client and per day once they are mined in
+// the canonical chain and confirmed by gatewayFeeConfirmations blocks. Istanbul charges the
+// gateway fee along with the other transaction fees, so every mined transaction paid it,
+// even if its execution failed.
+type gatewayFeeTracker struct {
+	db    ethdb.Database
+	chain gatewayFeeChain
+
+	lock    sync.Mutex
+	pending map[common.Hash]*relayedTx
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newGatewayFeeTracker creates a gateway fee tracker storing its totals in the given database
+func newGatewayFeeTracker(db ethdb.Database, chain gatewayFeeChain) *gatewayFeeTracker {
+	return &gatewayFeeTracker{
+		db:      db,
+		chain:   chain,
+		pending: make(map[common.Hash]*relayedTx),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// start starts checking the pending relayed transactions with every new chain head
+func (t *gatewayFeeTracker) start() {
+	t.wg.Add(1)
+	go t.loop()
+}
+
+// stop stops the gateway fee tracker. Relayed transactions still pending are forgotten.
+func (t *gatewayFeeTracker) stop() {
+	close(t.closeCh)
+	t.wg.Wait()
+}
+
+func (t *gatewayFeeTracker) loop() {
+	defer t.wg.Done()
+
+	headCh := make(chan core.ChainHeadEvent, 10)
+	sub := t.chain.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-headCh:
+			t.checkPending(ev.Block.NumberU64(), time.Now())
+		case <-sub.Err():
+			return
+		case <-t.closeCh:
+			return
+		}
+	}
+}
+
+// relayed records a transaction relayed for a client, if it pays a gateway fee to the
+// given etherbase
+func (t *gatewayFeeTracker) relayed(client string, tx *types.Transaction, etherbase common.Address) {
+	if recipient := tx.GatewayFeeRecipient(); recipient == nil || *recipient != etherbase || etherbase == (common.Address{}) {
+		return
+	}
+	if fee := tx.GatewayFee(); fee == nil || fee.Sign() <= 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.pending) >= maxPendingRelayedTxs {
+		log.Debug("Too many relayed transactions pending, not tracking gateway fee", "hash", tx.Hash())
+		return
+	}
+	t.pending[tx.Hash()] = &relayedTx{client: client, etherbase: etherbase, relayed: time.Now()}
+	gatewayFeeRelayedMeter.Mark(1)
+	gatewayFeePendingGauge.Update(int64(len(t.pending)))
+}
+
+// checkPending accounts the gateway fees of the pending relayed transactions confirmed in
+// the canonical chain at the given head, and gives up on the ones not mined for too long.
+// Transactions mined but not yet confirmed are kept pending, as they may be reorged out.
+func (t *gatewayFeeTracker) checkPending(head uint64, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for hash, relayed := range t.pending {
+		tx, blockHash, number, _ := rawdb.ReadTransaction(t.db, hash)
+		if tx == nil {
+			if now.Sub(relayed.relayed) > relayedTxTimeout {
+				log.Debug("Relayed transaction not mined, gateway fee unpaid", "hash", hash, "client", relayed.client)
+				delete(t.pending, hash)
+				gatewayFeeUnpaidMeter.Mark(1)
+			}
+			continue
+		}
+		if number+gatewayFeeConfirmations > head {
+			continue
+		}
+		header := t.chain.GetHeaderByNumber(number)
+		if header == nil || header.Hash() != blockHash {
+			continue
+		}
+		delete(t.pending, hash)
+		if recipient := tx.GatewayFeeRecipient(); recipient == nil || *recipient != relayed.etherbase || tx.GatewayFee() == nil {
+			log.Warn("Relayed transaction mined without paying the gateway fee", "hash", hash, "client", relayed.client)
+			gatewayFeeUnpaidMeter.Mark(1)
+			continue
+		}
+		if err := t.paid(relayed.client, tx, time.Unix(int64(header.Time), 0)); err != nil {
+			log.Error("Failed to store gateway fee earnings", "hash", hash, "err", err)
+		}
+		gatewayFeePaidMeter.Mark(1)
+	}
+	gatewayFeePendingGauge.Update(int64(len(t.pending)))
+}
+
+// paid adds the gateway fee of a transaction relayed for the given client and mined at the
+// given time to the totals of the client and of the day
+func (t *gatewayFeeTracker) paid(client string, tx *types.Transaction, mined time.Time) error {
+	var currency common.Address
+	if tx.FeeCurrency() != nil {
+		currency = *tx.FeeCurrency()
+	}
+	day := mined.UTC().Format(gatewayFeeDayFormat)
+
+	clientEarnings, err := decodeEarnings(rawdb.ReadGatewayFeeClientEarnings(t.db, client))
+	if err != nil {
+		return err
+	}
+	dayEarnings, err := decodeEarnings(rawdb.ReadGatewayFeeDayEarnings(t.db, day))
+	if err != nil {
+		return err
+	}
+	clientEarnings.add(currency, tx.GatewayFee())
+	dayEarnings.add(currency, tx.GatewayFee())
+
+	clientBlob, err := rlp.EncodeToBytes(clientEarnings)
+	if err != nil {
+		return err
+	}
+	dayBlob, err := rlp.EncodeToBytes(dayEarnings)
+	if err != nil {
+		return err
+	}
+	batch := t.db.NewBatch()
+	rawdb.WriteGatewayFeeClientEarnings(batch, client, clientBlob)
+	rawdb.WriteGatewayFeeDayEarnings(batch, day, dayBlob)
+	return batch.Write()
+}
+
+// decodeEarnings decodes stored gateway fee earnings, nothing being earned yet if empty
+func decodeEarnings(blob []byte) (*GatewayFeeEarnings, error) {
+	earnings := new(GatewayFeeEarnings)
+	if len(blob) == 0 {
+		return earnings, nil
+	}
+	if err := rlp.DecodeBytes(blob, earnings); err != nil {
+		return nil, err
+	}
+	return earnings, nil
+}
+
+// GatewayFeeEarningsSummary is the gateway fee accounting reported by les_gatewayFeeEarnings
+type GatewayFeeEarningsSummary struct {
+	Clients map[string]*GatewayFeeEarnings `json:"clients"` // Earnings by client id
+	Days    map[string]*GatewayFeeEarnings `json:"days"`    // Earnings by UTC day the transactions were mined
+	Pending int                            `json:"pending"` // Relayed transactions waiting to be mined
+}
+
+// summary returns the stored gateway fee totals
+func (t *gatewayFeeTracker) summary() (*GatewayFeeEarningsSummary, error) {
+	summary := &GatewayFeeEarningsSummary{
+		Clients: make(map[string]*GatewayFeeEarnings),
+		Days:    make(map[string]*GatewayFeeEarnings),
+	}
+	for _, totals := range []struct {
+		prefix []byte
+		dest   map[string]*GatewayFeeEarnings
+	}{
+		{rawdb.GatewayFeeClientPrefix, summary.Clients},
+		{rawdb.GatewayFeeDayPrefix, summary.Days},
+	} {
+		it := t.db.NewIteratorWithPrefix(totals.prefix)
+		for it.Next() {
+			earnings := new(GatewayFeeEarnings)
+			if err := rlp.DecodeBytes(it.Value(), earnings); err != nil {
+				it.Release()
+				return nil, err
+			}
+			totals.dest[string(it.Key()[len(totals.prefix):])] = earnings
+		}
+		it.Release()
+	}
+	t.lock.Lock()
+	summary.Pending = len(t.pending)
+	t.lock.Unlock()
+	return summary, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+type testGatewayFeeChain struct {
+	feed    event.Feed
+	headers map[uint64]*types.Header
+}
+
+func (c *testGatewayFeeChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func (c *testGatewayFeeChain) GetHeaderByNumber(number uint64) *types.Header {
+	return c.headers[number]
+}
+
+func TestGatewayFeeTracker(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	chain := &testGatewayFeeChain{headers: make(map[uint64]*types.Header)}
+	tracker := newGatewayFeeTracker(db, chain)
+
+	etherbase, other := common.HexToAddress("deadbeef"), common.HexToAddress("badf00d")
+	currency := common.HexToAddress("c0ffee")
+	tx := func(nonce uint64, recipient *common.Address, feeCurrency *common.Address, fee int64) *types.Transaction {
+		return types.NewTransaction(nonce, common.Address{}, nil, 0, nil, feeCurrency, recipient, big.NewInt(fee), nil)
+	}
+	paid := []*types.Transaction{tx(0, &etherbase, nil, 10), tx(1, &etherbase, nil, 20), tx(2, &etherbase, &currency, 5)}
+	unpaid := tx(3, &etherbase, nil, 40)
+	for _, tx := range paid {
+		tracker.relayed("client", tx, etherbase)
+	}
+	tracker.relayed("other", unpaid, etherbase)
+
+	// Transactions not paying a gateway fee to the server are not tracked
+	tracker.relayed("client", tx(4, &other, nil, 10), etherbase)
+	tracker.relayed("client", tx(5, nil, nil, 10), etherbase)
+	tracker.relayed("client", tx(6, &etherbase, nil, 0), etherbase)
+	if len(tracker.pending) != 4 {
+		t.Fatalf("pending relayed transactions mismatch: have %d, want 4", len(tracker.pending))
+	}
+
+	// mine writes a canonical block at the given height containing the given transactions
+	mine := func(number uint64, txs []*types.Transaction) {
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Time: uint64(time.Date(2020, 6, 1, 12, 0, int(number), 0, time.UTC).Unix())}
+		block := types.NewBlock(header, txs, nil, nil)
+		chain.headers[number] = block.Header()
+		rawdb.WriteBody(db, block.Hash(), number, block.Body())
+		rawdb.WriteCanonicalHash(db, block.Hash(), number)
+		rawdb.WriteTxLookupEntries(db, block)
+	}
+
+	// Fees aren't accounted before the transactions are confirmed, nor once reorged out
+	mine(1, paid)
+	tracker.checkPending(gatewayFeeConfirmations, time.Now())
+	if len(tracker.pending) != 4 {
+		t.Fatalf("pending relayed transactions mismatch before confirmation: have %d, want 4", len(tracker.pending))
+	}
+	mine(1, nil)
+	tracker.checkPending(1+gatewayFeeConfirmations, time.Now())
+	if len(tracker.pending) != 4 {
+		t.Fatalf("pending relayed transactions mismatch after reorg: have %d, want 4", len(tracker.pending))
+	}
+
+	// A transaction mined without paying the gateway fee to the server isn't accounted
+	wrongRecipient := tx(7, &etherbase, nil, 10)
+	tracker.relayed("client", wrongRecipient, etherbase)
+	tracker.pending[wrongRecipient.Hash()].etherbase = other
+
+	mine(2, append(paid, wrongRecipient))
+	tracker.checkPending(2+gatewayFeeConfirmations, time.Now().Add(relayedTxTimeout+time.Second))
+	if len(tracker.pending) != 0 {
+		t.Errorf("relayed transactions still pending: %d", len(tracker.pending))
+	}
+
+	summary, err := tracker.summary()
+	if err != nil {
+		t.Fatalf("failed to summarize gateway fee earnings: %v", err)
+	}
+	for name, earnings := range map[string]*GatewayFeeEarnings{"client": summary.Clients["client"], "day": summary.Days["2020-06-01"]} {
+		if earnings == nil || earnings.Txs != 3 || len(earnings.Fees) != 2 {
+			t.Fatalf("%s earnings mismatch: have %+v", name, earnings)
+		}
+		for _, fee := range earnings.Fees {
+			want := map[common.Address]int64{{}: 30, currency: 5}[fee.FeeCurrency]
+			if fee.Amount.Cmp(big.NewInt(want)) != 0 {
+				t.Errorf("%s earnings in %x mismatch: have %v, want %d", name, fee.FeeCurrency, fee.Amount, want)
+			}
+		}
+	}
+	if len(summary.Clients) != 1 || len(summary.Days) != 1 {
+		t.Errorf("unexpected earnings: %d clients, %d days", len(summary.Clients), len(summary.Days))
+	}
+}
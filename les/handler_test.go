@@ -254,6 +254,37 @@ func testGetBlockBodies(t *testing.T, protocol int) {
 	}
 }
 
+// Tests that the last headers of epochs can be retrieved with their epoch snark data.
+func TestGetEpochHeadersLes4(t *testing.T) { testGetEpochHeaders(t, 4) }
+
+func testGetEpochHeaders(t *testing.T, protocol int) {
+	server, tearDown := newServerEnv(t, downloader.MaxBlockFetch+15, protocol, nil, false, true, 0)
+	defer tearDown()
+
+	bc := server.handler.blockchain
+	genesis := &epochHeader{Header: bc.Genesis().Header(), EpochSnarkData: &types.EpochSnarkData{Bitmap: new(big.Int)}}
+
+	// The test chain ends within the first epoch, so only the genesis header ends an epoch
+	tests := []struct {
+		query    *getEpochHeadersData
+		expected []*epochHeader
+	}{
+		{&getEpochHeadersData{FirstEpoch: 0, Amount: 1}, []*epochHeader{genesis}},
+		{&getEpochHeadersData{FirstEpoch: 0, Amount: 10}, []*epochHeader{genesis}},
+		{&getEpochHeadersData{FirstEpoch: 1, Amount: 10}, []*epochHeader{}},
+	}
+	var reqID uint64
+	for i, tt := range tests {
+		reqID++
+
+		cost := server.peer.peer.GetRequestCost(GetEpochHeadersMsg, int(tt.query.Amount))
+		sendRequest(server.peer.app, GetEpochHeadersMsg, reqID, cost, tt.query)
+		if err := expectResponse(server.peer.app, EpochHeadersMsg, reqID, testBufLimit, tt.expected); err != nil {
+			t.Errorf("test %d: epoch headers mismatch: %v", i, err)
+		}
+	}
+}
+
 // Tests that the contract codes can be retrieved based on account addresses.
 func TestGetCodeLes2(t *testing.T) { testGetCode(t, 2) }
 func TestGetCodeLes3(t *testing.T) { testGetCode(t, 3) }
@@ -705,3 +736,25 @@ func TestTransactionGatewayFeeRequirementLes2(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGatewayFeeDeprioritizeLes2(t *testing.T) {
+	server, tearDown := newServerEnv(t, 0, 2, nil, false, true, 0)
+	defer tearDown()
+
+	server.handler.addTxsSync = true
+	server.handler.etherbase = common.HexToAddress("2ad937cb878d8beefc84f3d0545750c2ff78cd0e")
+	server.handler.gatewayFee = big.NewInt(25000)
+	server.handler.deprioritizeGatewayFee = true
+
+	// Transactions failing the gateway fee requirement are accepted into the pool
+	wrongAddress := common.HexToAddress("1762042962b8759e17d2b5ac6c5565273df506fd")
+	tx, _ := types.SignTx(types.NewTransaction(0, userAddr1, big.NewInt(10000), params.TxGas, big.NewInt(100000000000), nil, &wrongAddress, nil, nil), types.HomesteadSigner{}, bankKey)
+
+	cost := server.peer.peer.GetRequestCost(SendTxV2Msg, 1)
+	if err := sendRequest(server.peer.app, SendTxV2Msg, 1, cost, types.Transactions{tx}); err != nil {
+		t.Fatalf("transaction send failed: %v", err)
+	}
+	if err := expectResponse(server.peer.app, TxStatusMsg, 1, testBufLimit, []light.TxStatus{{Status: core.TxStatusPending}}); err != nil {
+		t.Fatalf("transaction status mismatch: %v", err)
+	}
+}
@@ -42,6 +42,8 @@ var (
 	miscInTxStatusTrafficMeter   = metrics.NewRegisteredMeter("les/misc/in/traffic/txStatus", nil)
 	miscInEtherbasePacketsMeter  = metrics.NewRegisteredMeter("les/misc/in/packets/etherbase", nil)
 	miscInEtherbaseTrafficMeter  = metrics.NewRegisteredMeter("les/misc/in/traffic/etherbase", nil)
+	miscInEpochPacketsMeter      = metrics.NewRegisteredMeter("les/misc/in/packets/epoch", nil)
+	miscInEpochTrafficMeter      = metrics.NewRegisteredMeter("les/misc/in/traffic/epoch", nil)
 
 	miscOutPacketsMeter           = metrics.NewRegisteredMeter("les/misc/out/packets/total", nil)
 	miscOutTrafficMeter           = metrics.NewRegisteredMeter("les/misc/out/traffic/total", nil)
@@ -63,6 +65,8 @@ var (
 	miscOutTxStatusTrafficMeter   = metrics.NewRegisteredMeter("les/misc/out/traffic/txStatus", nil)
 	miscOutEtherbasePacketsMeter  = metrics.NewRegisteredMeter("les/misc/out/packets/etherbase", nil)
 	miscOutEtherbaseTrafficMeter  = metrics.NewRegisteredMeter("les/misc/out/traffic/etherbase", nil)
+	miscOutEpochPacketsMeter      = metrics.NewRegisteredMeter("les/misc/out/packets/epoch", nil)
+	miscOutEpochTrafficMeter      = metrics.NewRegisteredMeter("les/misc/out/traffic/epoch", nil)
 
 	miscServingTimeHeaderTimer     = metrics.NewRegisteredTimer("les/misc/serve/header", nil)
 	miscServingTimeBodyTimer       = metrics.NewRegisteredTimer("les/misc/serve/body", nil)
@@ -73,6 +77,7 @@ var (
 	miscServingTimeTxTimer         = metrics.NewRegisteredTimer("les/misc/serve/txs", nil)
 	miscServingTimeTxStatusTimer   = metrics.NewRegisteredTimer("les/misc/serve/txStatus", nil)
 	miscServingTimeEtherbaseTimer  = metrics.NewRegisteredTimer("les/misc/serve/etherbase", nil)
+	miscServingTimeEpochTimer      = metrics.NewRegisteredTimer("les/misc/serve/epoch", nil)
 
 	connectionTimer       = metrics.NewRegisteredTimer("les/connection/duration", nil)
 	serverConnectionGauge = metrics.NewRegisteredGauge("les/connection/server", nil)
@@ -96,6 +101,7 @@ var (
 	relativeCostHelperProofHistogram = metrics.NewRegisteredHistogram("les/server/req/relative/helperTrie", nil, metrics.NewExpDecaySample(1028, 0.015))
 	relativeCostSendTxHistogram      = metrics.NewRegisteredHistogram("les/server/req/relative/txs", nil, metrics.NewExpDecaySample(1028, 0.015))
 	relativeCostTxStatusHistogram    = metrics.NewRegisteredHistogram("les/server/req/relative/txStatus", nil, metrics.NewExpDecaySample(1028, 0.015))
+	relativeCostEpochHistogram       = metrics.NewRegisteredHistogram("les/server/req/relative/epoch", nil, metrics.NewExpDecaySample(1028, 0.015))
 
 	globalFactorGauge    = metrics.NewRegisteredGauge("les/server/globalFactor", nil)
 	recentServedGauge    = metrics.NewRegisteredGauge("les/server/recentRequestServed", nil)
@@ -110,6 +116,11 @@ var (
 	clientFreezeMeter       = metrics.NewRegisteredMeter("les/server/clientEvent/freeze", nil)
 	clientErrorMeter        = metrics.NewRegisteredMeter("les/server/clientEvent/error", nil)
 
+	gatewayFeeRelayedMeter = metrics.NewRegisteredMeter("les/server/gatewayFee/relayed", nil)
+	gatewayFeePaidMeter    = metrics.NewRegisteredMeter("les/server/gatewayFee/paid", nil)
+	gatewayFeeUnpaidMeter  = metrics.NewRegisteredMeter("les/server/gatewayFee/unpaid", nil)
+	gatewayFeePendingGauge = metrics.NewRegisteredGauge("les/server/gatewayFee/pending", nil)
+
 	requestRTT       = metrics.NewRegisteredTimer("les/client/req/rtt", nil)
 	requestSendDelay = metrics.NewRegisteredTimer("les/client/req/sendDelay", nil)
 )
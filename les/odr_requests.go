@@ -56,6 +56,8 @@ func LesRequest(req light.OdrRequest) LesOdrRequest {
 	switch r := req.(type) {
 	case *light.BlockRequest:
 		return (*BlockRequest)(r)
+	case *light.EpochSnarkDataRequest:
+		return (*EpochSnarkDataRequest)(r)
 	case *light.HeaderRequest:
 		return (*HeaderRequest)(r)
 	case *light.ReceiptsRequest:
@@ -128,6 +130,60 @@ func (r *BlockRequest) Validate(db ethdb.Database, msg *Msg) error {
 	return nil
 }
 
+// EpochSnarkDataRequest is the ODR request type for the epoch snark data of a block
+type EpochSnarkDataRequest light.EpochSnarkDataRequest
+
+// GetCost returns the cost of the given ODR request according to the serving
+// peer's cost table (implementation of LesOdrRequest)
+func (r *EpochSnarkDataRequest) GetCost(peer *peer) uint64 {
+	return peer.GetRequestCost(GetBlockBodiesMsg, 1)
+}
+
+// CanSend tells if a certain peer is suitable for serving the given request
+func (r *EpochSnarkDataRequest) CanSend(peer *peer) bool {
+	number := r.Header.Number.Uint64()
+	return peer.HasBlock(r.Header.Hash(), &number, false)
+}
+
+// Request sends an ODR request to the LES network (implementation of LesOdrRequest)
+func (r *EpochSnarkDataRequest) Request(reqID uint64, peer *peer) error {
+	peer.Log().Debug("Requesting epoch snark data", "number", r.Header.Number, "hash", r.Header.Hash())
+	return peer.RequestBodies(reqID, r.GetCost(peer), []common.Hash{r.Header.Hash()})
+}
+
+// Valid processes an ODR request reply message from the LES network
+// returns true and stores results in memory if the message was a valid reply
+// to the request (implementation of LesOdrRequest)
+func (r *EpochSnarkDataRequest) Validate(db ethdb.Database, msg *Msg) error {
+	log.Debug("Validating epoch snark data", "number", r.Header.Number, "hash", r.Header.Hash())
+
+	// Ensure we have a correct message with a single block body
+	if msg.MsgType != MsgBlockBodies {
+		return errInvalidMessageType
+	}
+	bodies := msg.Obj.([]*types.Body)
+	if len(bodies) != 1 {
+		return errInvalidEntryCount
+	}
+	body := bodies[0]
+
+	// The header isn't stored yet, validate the block content against the requested one
+	if r.Header.TxHash != types.DeriveSha(types.Transactions(body.Transactions)) {
+		return errTxHashMismatch
+	}
+	// The epoch snark data isn't committed to by the header, so it's verified against the
+	// validator sets of the epochs before storing anything
+	if err := r.Verify(body.EpochSnarkData); err != nil {
+		return err
+	}
+	data, err := rlp.EncodeToBytes(body)
+	if err != nil {
+		return err
+	}
+	r.EpochSnarkData, r.Rlp = body.EpochSnarkData, data
+	return nil
+}
+
 // BlockRequest is the ODR request type for block headers
 type HeaderRequest light.HeaderRequest
 
@@ -438,6 +438,12 @@ func (p *peer) ReplyBlockHeaders(reqID uint64, headers []*types.Header) *reply {
 	return &reply{p.rw, BlockHeadersMsg, reqID, data}
 }
 
+// ReplyEpochHeaders creates a reply with a batch of epoch headers.
+func (p *peer) ReplyEpochHeaders(reqID uint64, headers []*epochHeader) *reply {
+	data, _ := rlp.EncodeToBytes(headers)
+	return &reply{p.rw, EpochHeadersMsg, reqID, data}
+}
+
 // ReplyBlockBodiesRLP creates a reply with a batch of block contents from
 // an already RLP encoded format.
 func (p *peer) ReplyBlockBodiesRLP(reqID uint64, bodies []rlp.RawValue) *reply {
@@ -497,6 +503,13 @@ func (p *peer) RequestHeadersByNumber(reqID, cost, origin uint64, amount int, sk
 	return sendRequest(p.rw, GetBlockHeadersMsg, reqID, cost, &getBlockHeadersData{Origin: hashOrNumber{Number: origin}, Amount: uint64(amount), Skip: uint64(skip), Reverse: reverse})
 }
 
+// RequestEpochHeaders fetches a batch of epoch headers, with the epoch snark data of their
+// blocks, starting with the last header of the given epoch.
+func (p *peer) RequestEpochHeaders(reqID, cost, firstEpoch uint64, amount int) error {
+	p.Log().Debug("Fetching batch of epoch headers", "count", amount, "fromepoch", firstEpoch)
+	return sendRequest(p.rw, GetEpochHeadersMsg, reqID, cost, &getEpochHeadersData{FirstEpoch: firstEpoch, Amount: uint64(amount)})
+}
+
 // RequestBodies fetches a batch of blocks' bodies corresponding to the hashes
 // specified.
 func (p *peer) RequestBodies(reqID, cost uint64, hashes []common.Hash) error {
@@ -779,7 +792,8 @@ func (p *peer) Handshake(td *big.Int, head common.Hash, headNum uint64, genesis
 
 		if !p.onlyAnnounce {
 			for msgCode := range reqAvgTimeCost {
-				if p.fcCosts[msgCode] == nil {
+				// Messages introduced in later protocol versions aren't supported by the peer
+				if msgCode < ProtocolLengths[uint(p.version)] && p.fcCosts[msgCode] == nil {
 					return errResp(ErrUselessPeer, "peer does not support message %d", msgCode)
 				}
 			}
@@ -912,18 +926,33 @@ func (ps *peerSet) Register(p *peer) error {
 	return nil
 }
 
-// TODO(nategraf) Remove this function when better method for choosing a peer is available.
-func (ps *peerSet) randomPeerEtherbase() common.Address {
+// gatewayFeeServer returns the connected server to relay transactions through, chosen by
+// the given gateway fee policy among the servers with a known etherbase and an acceptable
+// gateway fee. It returns nil if there is no such server.
+func (ps *peerSet) gatewayFeeServer(policy *gatewayFeePolicy) *peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
-	// Rely on golang's random map iteration order.
+	var (
+		best          *peer
+		bestFee       *big.Int
+		bestEtherbase common.Address
+	)
+	// Rely on golang's random map iteration order to spread ties.
 	for _, p := range ps.peers {
-		if etherbase, ok := p.Etherbase(); ok {
-			return etherbase
+		etherbase, ok := p.Etherbase()
+		if !ok || etherbase == (common.Address{}) {
+			continue
+		}
+		fee, _ := p.GatewayFee()
+		if !policy.acceptable(fee) {
+			continue
+		}
+		if best == nil || policy.better(fee, etherbase, bestFee, bestEtherbase) {
+			best, bestFee, bestEtherbase = p, fee, etherbase
 		}
 	}
-	return common.Address{}
+	return best
 }
 
 // Unregister removes a remote peer from the active set, disabling any further
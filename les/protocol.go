@@ -24,6 +24,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -33,18 +34,18 @@ import (
 const (
 	lpv2 = 2
 	lpv3 = 3
-	lpv4 = 4 // Work in progress. Breaking changes expected.
+	lpv4 = 4
 )
 
 // Supported versions of the les protocol (first is primary)
 var (
-	ClientProtocolVersions    = []uint{lpv2, lpv3}
-	ServerProtocolVersions    = []uint{lpv2, lpv3}
+	ClientProtocolVersions    = []uint{lpv2, lpv3, lpv4}
+	ServerProtocolVersions    = []uint{lpv2, lpv3, lpv4}
 	AdvertiseProtocolVersions = []uint{lpv2} // clients are searching for the first advertised protocol in the list
 )
 
 // Number of implemented message corresponding to different protocol versions.
-var ProtocolLengths = map[uint]uint64{lpv2: 24, lpv3: 26, lpv4: 28}
+var ProtocolLengths = map[uint]uint64{lpv2: 24, lpv3: 26, lpv4: 30}
 
 const (
 	NetworkId          = 1
@@ -78,9 +79,11 @@ const (
 	// Protocol messages introduced in LPV3
 	StopMsg   = 0x18
 	ResumeMsg = 0x19
-	// Protocol messages to be introduced in LPV4
-	GetGatewayFeeMsg = 0x1A
-	GatewayFeeMsg    = 0x1B
+	// Protocol messages introduced in LPV4
+	GetGatewayFeeMsg   = 0x1A
+	GatewayFeeMsg      = 0x1B
+	GetEpochHeadersMsg = 0x1C
+	EpochHeadersMsg    = 0x1D
 )
 
 type requestInfo struct {
@@ -99,6 +102,7 @@ var requests = map[uint64]requestInfo{
 	GetTxStatusMsg:         {"GetTxStatus", MaxTxStatus},
 	GetEtherbaseMsg:        {"GetEtherbase", MaxEtherbase},
 	GetGatewayFeeMsg:       {"GetGatewayFee", MaxGatewayFee},
+	GetEpochHeadersMsg:     {"GetEpochHeaders", MaxEpochHeaderFetch},
 }
 
 type errCode int
@@ -204,6 +208,19 @@ type getBlockHeadersData struct {
 	Reverse bool         // Query direction (false = rising towards latest, true = falling towards genesis)
 }
 
+// getEpochHeadersData represents an epoch header query.
+type getEpochHeadersData struct {
+	FirstEpoch uint64 // Epoch whose last header is the first one to retrieve
+	Amount     uint64 // Maximum number of epoch headers to retrieve
+}
+
+// epochHeader is the last header of an epoch, which carries the validator set diff of the
+// epoch in its istanbul extra, served with the epoch snark data of its block.
+type epochHeader struct {
+	Header         *types.Header
+	EpochSnarkData *types.EpochSnarkData
+}
+
 // hashOrNumber is a combined field for specifying an origin block.
 type hashOrNumber struct {
 	Hash   common.Hash // Block hash from which to retrieve headers (excludes Number)
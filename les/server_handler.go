@@ -20,7 +20,6 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -28,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -44,6 +44,7 @@ import (
 const (
 	softResponseLimit = 2 * 1024 * 1024 // Target maximum size of returned blocks, headers or node data.
 	estHeaderRlpSize  = 500             // Approximate size of an RLP encoded block header
+	estEpochRlpSize   = 150             // Approximate size of an RLP encoded epoch snark data
 	ethVersion        = 64              // equivalent eth version for the downloader
 
 	MaxHeaderFetch           = 192 // Amount of block headers to be fetched per retrieval request
@@ -56,6 +57,7 @@ const (
 	MaxTxStatus              = 256 // Amount of transactions to queried per request
 	MaxEtherbase             = 1
 	MaxGatewayFee            = 1
+	MaxEpochHeaderFetch      = 192 // Amount of epoch headers to be fetched per retrieval request
 )
 
 var (
@@ -76,23 +78,27 @@ type serverHandler struct {
 	synced  func() bool    // Callback function used to determine whether local node is synced.
 
 	// Celo Specific
-	etherbase  common.Address
-	gatewayFee *big.Int
+	etherbase              common.Address
+	gatewayFee             *big.Int
+	deprioritizeGatewayFee bool // Whether to deprioritize rather than reject transactions with an invalid gateway fee
+	gatewayFees            *gatewayFeeTracker
 
 	// Testing fields
 	addTxsSync bool
 }
 
-func newServerHandler(server *LesServer, blockchain *core.BlockChain, chainDb ethdb.Database, txpool *core.TxPool, synced func() bool, etherbase common.Address, gatewayFee *big.Int) *serverHandler {
+func newServerHandler(server *LesServer, blockchain *core.BlockChain, chainDb ethdb.Database, txpool *core.TxPool, synced func() bool, etherbase common.Address, gatewayFee *big.Int, deprioritizeGatewayFee bool) *serverHandler {
 	handler := &serverHandler{
-		server:     server,
-		blockchain: blockchain,
-		chainDb:    chainDb,
-		txpool:     txpool,
-		closeCh:    make(chan struct{}),
-		synced:     synced,
-		etherbase:  etherbase,
-		gatewayFee: gatewayFee,
+		server:                 server,
+		blockchain:             blockchain,
+		chainDb:                chainDb,
+		txpool:                 txpool,
+		closeCh:                make(chan struct{}),
+		synced:                 synced,
+		etherbase:              etherbase,
+		gatewayFee:             gatewayFee,
+		deprioritizeGatewayFee: deprioritizeGatewayFee,
+		gatewayFees:            newGatewayFeeTracker(chainDb, blockchain),
 	}
 	return handler
 }
@@ -101,11 +107,13 @@ func newServerHandler(server *LesServer, blockchain *core.BlockChain, chainDb et
 func (h *serverHandler) start() {
 	h.wg.Add(1)
 	go h.broadcastHeaders()
+	h.gatewayFees.start()
 }
 
 // stop stops the server handler.
 func (h *serverHandler) stop() {
 	close(h.closeCh)
+	h.gatewayFees.stop()
 	h.wg.Wait()
 }
 
@@ -770,23 +778,21 @@ func (h *serverHandler) handleMsg(p *peer, wg *sync.WaitGroup) error {
 					hash := tx.Hash()
 					stats[i] = h.txStatus(hash)
 					if stats[i].Status == core.TxStatusUnknown {
-						// Only include transactions that have a valid gateway fee recipient & fee
-						if err := h.verifyGatewayFee(tx.GatewayFeeRecipient(), tx.GatewayFee()); err != nil {
-							p.Log().Trace("Rejected transaction from light peer for invalid gateway fee", "hash", hash.String(), "err", err)
-							stats[i].Error = err.Error()
-							continue
-						}
-
-						addFn := h.txpool.AddRemotes
+						addFn := h.txpool.AddLightRemotes
 						// Add transactions synchronously for testing purpose
 						if h.addTxsSync {
-							addFn = h.txpool.AddRemotesSync
+							addFn = h.txpool.AddLightRemotesSync
 						}
-						if errs := addFn([]*types.Transaction{tx}); errs[0] != nil {
+						// Only include transactions that have a valid gateway fee recipient & fee,
+						// or include them at the lowest priority if so configured
+						gatewayFee := core.GatewayFeeRequirement{Recipient: h.etherbase, Minimum: h.gatewayFee, Deprioritize: h.deprioritizeGatewayFee}
+						if errs := addFn([]*types.Transaction{tx}, gatewayFee); errs[0] != nil {
+							p.Log().Trace("Rejected transaction from light peer", "hash", hash.String(), "err", errs[0])
 							stats[i].Error = errs[0].Error()
 							continue
 						}
 						stats[i] = h.txStatus(hash)
+						h.gatewayFees.relayed(p.id, tx, h.etherbase)
 						p.Log().Trace("Added transaction from light peer to pool", "hash", hash.String(), "tx", tx)
 					}
 				}
@@ -883,6 +889,66 @@ func (h *serverHandler) handleMsg(p *peer, wg *sync.WaitGroup) error {
 			}()
 		}
 
+	case GetEpochHeadersMsg:
+		p.Log().Trace("Received epoch headers request")
+		if metrics.EnabledExpensive {
+			miscInEpochPacketsMeter.Mark(1)
+			miscInEpochTrafficMeter.Mark(int64(msg.Size))
+			defer func(start time.Time) { miscServingTimeEpochTimer.UpdateSince(start) }(time.Now())
+		}
+		var req struct {
+			ReqID uint64
+			Query getEpochHeadersData
+		}
+		if err := msg.Decode(&req); err != nil {
+			clientErrorMeter.Mark(1)
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		query := req.Query
+		if accept(req.ReqID, query.Amount, MaxEpochHeaderFetch) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var epochSize uint64
+				if config := h.blockchain.Config().Istanbul; config != nil {
+					epochSize = config.Epoch
+				}
+
+				// Gather the last headers of the epochs until the fetch or network limits is reached
+				var (
+					bytes   common.StorageSize
+					headers []*epochHeader
+				)
+				for epoch := query.FirstEpoch; epochSize > 0 && len(headers) < int(query.Amount) && bytes < softResponseLimit; epoch++ {
+					if len(headers) > 0 && !task.waitOrStop() {
+						sendResponse(req.ReqID, 0, nil, task.servingTime)
+						return
+					}
+					header := h.blockchain.GetHeaderByNumber(istanbul.GetEpochLastBlockNumber(epoch, epochSize))
+					if header == nil {
+						if len(headers) == 0 {
+							atomic.AddUint32(&p.invalidCount, 1)
+						}
+						break
+					}
+					// The validator set diff of the epoch is part of the header, the epoch
+					// snark data sealing it is part of the block body
+					epochSnarkData := &types.EpochSnarkData{Bitmap: new(big.Int)}
+					if body := h.blockchain.GetBody(header.Hash()); body != nil && body.EpochSnarkData != nil {
+						epochSnarkData = body.EpochSnarkData
+					}
+					headers = append(headers, &epochHeader{Header: header, EpochSnarkData: epochSnarkData})
+					bytes += estHeaderRlpSize + estEpochRlpSize
+				}
+				reply := p.ReplyEpochHeaders(req.ReqID, headers)
+				sendResponse(req.ReqID, query.Amount, reply, task.done())
+				if metrics.EnabledExpensive {
+					miscOutEpochPacketsMeter.Mark(1)
+					miscOutEpochTrafficMeter.Mark(int64(reply.size()))
+				}
+			}()
+		}
+
 	default:
 		p.Log().Trace("Received invalid message", "code", msg.Code)
 		clientErrorMeter.Mark(1)
@@ -1013,30 +1079,3 @@ func (h *serverHandler) broadcastHeaders() {
 		}
 	}
 }
-
-func (h *serverHandler) verifyGatewayFee(gatewayFeeRecipient *common.Address, gatewayFee *big.Int) error {
-
-	// If this node does not specify an etherbase, accept any GatewayFeeRecipient.
-	if h.etherbase == common.ZeroAddress {
-		return nil
-	}
-
-	// If this node does not specify a non-zero gateway fee accept any value.
-	if h.gatewayFee == nil || h.gatewayFee.Cmp(common.Big0) <= 0 {
-		return nil
-	}
-
-	// Otherwise, reject transactions that don't pay gas fees to this node.
-	if gatewayFeeRecipient == nil {
-		return fmt.Errorf("gateway fee recipient must be %s, got <nil>", h.etherbase.String())
-	}
-	if *gatewayFeeRecipient != h.etherbase {
-		return fmt.Errorf("gateway fee recipient must be %s, got %s", h.etherbase.String(), (*gatewayFeeRecipient).String())
-	}
-
-	// Check that the value of the supplied gateway fee is at least the minimum.
-	if gatewayFee == nil || gatewayFee.Cmp(h.gatewayFee) < 0 {
-		return fmt.Errorf("gateway fee value must be at least %s, got %s", h.gatewayFee, gatewayFee)
-	}
-	return nil
-}
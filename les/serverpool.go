@@ -124,6 +124,7 @@ type serverPool struct {
 	discLookups   chan bool
 
 	trustedNodes         map[enode.ID]*enode.Node
+	feePolicy            *gatewayFeePolicy
 	entries              map[enode.ID]*poolEntry
 	timeout, enableRetry chan *poolEntry
 	adjustStats          chan poolStatAdjust
@@ -141,7 +142,7 @@ type serverPool struct {
 }
 
 // newServerPool creates a new serverPool instance
-func newServerPool(db ethdb.Database, ulcServers []string) *serverPool {
+func newServerPool(db ethdb.Database, ulcServers []string, feePolicy *gatewayFeePolicy) *serverPool {
 	pool := &serverPool{
 		db:           db,
 		entries:      make(map[enode.ID]*poolEntry),
@@ -156,6 +157,7 @@ func newServerPool(db ethdb.Database, ulcServers []string) *serverPool {
 		newSelect:    newWeightedRandomSelect(),
 		fastDiscover: true,
 		trustedNodes: parseTrustedNodes(ulcServers),
+		feePolicy:    feePolicy,
 	}
 
 	pool.knownQueue = newPoolEntryQueue(maxKnownEntries, pool.removeEntry)
@@ -252,13 +254,16 @@ const (
 	pseBlockDelay = iota
 	pseResponseTime
 	pseResponseTimeout
+	pseGatewayFee
 )
 
 // poolStatAdjust records are sent to adjust peer block delay/response time statistics
+// or to update the gateway fee advertised by a peer
 type poolStatAdjust struct {
 	adjustType int
 	entry      *poolEntry
 	time       time.Duration
+	gatewayFee *GatewayFeeInformation
 }
 
 // adjustBlockDelay adjusts the block announce delay statistics of a node
@@ -266,7 +271,7 @@ func (pool *serverPool) adjustBlockDelay(entry *poolEntry, time time.Duration) {
 	if entry == nil {
 		return
 	}
-	pool.adjustStats <- poolStatAdjust{pseBlockDelay, entry, time}
+	pool.adjustStats <- poolStatAdjust{pseBlockDelay, entry, time, nil}
 }
 
 // adjustResponseTime adjusts the request response time statistics of a node
@@ -275,12 +280,20 @@ func (pool *serverPool) adjustResponseTime(entry *poolEntry, time time.Duration,
 		return
 	}
 	if timeout {
-		pool.adjustStats <- poolStatAdjust{pseResponseTimeout, entry, time}
+		pool.adjustStats <- poolStatAdjust{pseResponseTimeout, entry, time, nil}
 	} else {
-		pool.adjustStats <- poolStatAdjust{pseResponseTime, entry, time}
+		pool.adjustStats <- poolStatAdjust{pseResponseTime, entry, time, nil}
 	}
 }
 
+// adjustGatewayFee records the gateway fee and recipient advertised by a node
+func (pool *serverPool) adjustGatewayFee(entry *poolEntry, info *GatewayFeeInformation) {
+	if entry == nil || entry.trusted {
+		return
+	}
+	pool.adjustStats <- poolStatAdjust{pseGatewayFee, entry, 0, info}
+}
+
 // eventLoop handles pool events and mutex locking for all internal functions
 func (pool *serverPool) eventLoop() {
 	defer pool.wg.Done()
@@ -342,6 +355,8 @@ func (pool *serverPool) eventLoop() {
 				adj.entry.timeoutStats.add(0, 1)
 			case pseResponseTimeout:
 				adj.entry.timeoutStats.add(1, 1)
+			case pseGatewayFee:
+				pool.setGatewayFee(adj.entry, adj.gatewayFee)
 			}
 
 		case node := <-pool.discNodes:
@@ -448,6 +463,7 @@ func (pool *serverPool) findOrNewNode(node *enode.Node) *poolEntry {
 			addr:       make(map[string]*poolEntryAddress),
 			addrSelect: *newWeightedRandomSelect(),
 			shortRetry: shortRetryCnt,
+			feeWeight:  1,
 		}
 		pool.entries[node.ID()] = entry
 		// initialize previously unknown peers with good statistics to give a chance to prove themselves
@@ -489,6 +505,9 @@ func (pool *serverPool) loadNodes() {
 			"delay", fmt.Sprintf("%v/%v", time.Duration(e.delayStats.avg), e.delayStats.weight),
 			"response", fmt.Sprintf("%v/%v", time.Duration(e.responseStats.avg), e.responseStats.weight),
 			"timeout", fmt.Sprintf("%v/%v", e.timeoutStats.avg, e.timeoutStats.weight))
+		if e.gatewayFee != nil {
+			pool.setGatewayFee(e, e.gatewayFee)
+		}
 		pool.entries[e.node.ID()] = e
 		if pool.trustedNodes[e.node.ID()] == nil {
 			pool.knownQueue.setLatest(e)
@@ -497,6 +516,13 @@ func (pool *serverPool) loadNodes() {
 	}
 }
 
+// setGatewayFee records the gateway fee and recipient advertised by a node. The adjusted
+// selection weight applies from the next time the node can be dialed.
+func (pool *serverPool) setGatewayFee(entry *poolEntry, info *GatewayFeeInformation) {
+	entry.gatewayFee = info
+	entry.feeWeight = pool.feePolicy.weight(info.GatewayFee, info.Etherbase)
+}
+
 // connectToTrustedNodes adds trusted server nodes as static trusted peers.
 //
 // Note: trusted nodes are not handled by the server pool logic, they are not
@@ -681,6 +707,9 @@ type poolEntry struct {
 	queueIdx                      int
 	removed                       bool
 
+	gatewayFee *GatewayFeeInformation // Gateway fee and recipient last advertised, if known
+	feeWeight  float64                // Selection weight factor of the gateway fee policy
+
 	delayedRetry bool
 	shortRetry   int
 }
@@ -692,9 +721,14 @@ type poolEntryEnc struct {
 	Port                       uint16
 	Fails                      uint
 	CStat, DStat, RStat, TStat poolStats
+	GatewayFee                 []*GatewayFeeInformation `rlp:"tail"` // Empty if unknown, for compatibility with older entries
 }
 
 func (e *poolEntry) EncodeRLP(w io.Writer) error {
+	var gatewayFee []*GatewayFeeInformation
+	if e.gatewayFee != nil {
+		gatewayFee = append(gatewayFee, e.gatewayFee)
+	}
 	return rlp.Encode(w, &poolEntryEnc{
 		Pubkey: encodePubkey64(e.node.Pubkey()),
 		IP:     e.lastConnected.ip,
@@ -704,6 +738,8 @@ func (e *poolEntry) EncodeRLP(w io.Writer) error {
 		DStat:  e.delayStats,
 		RStat:  e.responseStats,
 		TStat:  e.timeoutStats,
+
+		GatewayFee: gatewayFee,
 	})
 }
 
@@ -727,6 +763,10 @@ func (e *poolEntry) DecodeRLP(s *rlp.Stream) error {
 	e.delayStats = entry.DStat
 	e.responseStats = entry.RStat
 	e.timeoutStats = entry.TStat
+	if len(entry.GatewayFee) > 0 {
+		e.gatewayFee = entry.GatewayFee[0]
+	}
+	e.feeWeight = 1
 	e.shortRetry = shortRetryCnt
 	e.known = true
 	return nil
@@ -763,7 +803,7 @@ func (e *knownEntry) Weight() int64 {
 	if e.state != psNotConnected || !e.known || e.delayedRetry {
 		return 0
 	}
-	return int64(1000000000 * e.connectStats.recentAvg() * math.Exp(-float64(e.lastConnected.fails)*failDropLn-e.responseStats.recentAvg()/float64(responseScoreTC)-e.delayStats.recentAvg()/float64(delayScoreTC)) * math.Pow(1-e.timeoutStats.recentAvg(), timeoutPow))
+	return int64(1000000000 * e.feeWeight * e.connectStats.recentAvg() * math.Exp(-float64(e.lastConnected.fails)*failDropLn-e.responseStats.recentAvg()/float64(responseScoreTC)-e.delayStats.recentAvg()/float64(delayScoreTC)) * math.Pow(1-e.timeoutStats.recentAvg(), timeoutPow))
 }
 
 // poolEntryAddress is a separate object because currently it is necessary to remember
@@ -215,7 +215,7 @@ func newTestClientHandler(syncMode downloader.SyncMode, backend *backends.Simula
 		blockchain: chain,
 		eventMux:   evmux,
 	}
-	client.handler = newClientHandler(syncMode, ulcServers, ulcFraction, nil, client, nil)
+	client.handler = newClientHandler(syncMode, ulcServers, ulcFraction, nil, client)
 
 	if client.oracle != nil {
 		client.oracle.start(backend)
@@ -281,7 +281,7 @@ func newTestServerHandler(blocks int, indexers []*core.ChainIndexer, db ethdb.Da
 	server.costTracker.testCostList = testCostList(0) // Disable flow control mechanism.
 	server.clientPool = newClientPool(db, 1, clock, nil)
 	server.clientPool.setLimits(10000, 10000) // Assign enough capacity for clientpool
-	server.handler = newServerHandler(server, simulation.Blockchain(), db, txpool, func() bool { return true }, common.ZeroAddress, eth.DefaultConfig.GatewayFee)
+	server.handler = newServerHandler(server, simulation.Blockchain(), db, txpool, func() bool { return true }, common.ZeroAddress, eth.DefaultConfig.GatewayFee, false)
 	if server.oracle != nil {
 		server.oracle.start(simulation)
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -133,6 +134,7 @@ func (self *lesTxRelay) send(txs types.Transactions) {
 			}
 			status := statuses[0]
 			if status.Error != "" {
+				log.Debug("Light server rejected transaction", "hash", hash, "err", status.Error)
 				return errors.New(status.Error)
 			}
 			if status.Status == core.TxStatusUnknown {
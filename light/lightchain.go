@@ -29,6 +29,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -43,10 +44,15 @@ import (
 )
 
 var (
-	bodyCacheLimit  = 256
-	blockCacheLimit = 256
+	bodyCacheLimit           = 256
+	blockCacheLimit          = 256
+	epochSnarkDataCacheLimit = 1024
 )
 
+// epochSnarkDataTimeout is the time allowed to retrieve and verify the epoch snark data of an
+// epoch header inserted by a lightest sync
+const epochSnarkDataTimeout = 10 * time.Second
+
 // LightChain represents a canonical chain that by default only handles block
 // headers, downloading block bodies and receipts on demand through an ODR
 // interface. It only does header validation during chain insertion.
@@ -61,11 +67,14 @@ type LightChain struct {
 	chainHeadFeed event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
+	systemCaller  *contract_comm.SystemCaller
 
 	bodyCache    *lru.Cache // Cache for the most recent block bodies
 	bodyRLPCache *lru.Cache // Cache for the most recent block bodies in RLP encoded format
 	blockCache   *lru.Cache // Cache for the most recent entire blocks
 
+	epochSnarkDataCache *lru.Cache // Cache for the epoch snark data served with epoch headers, not verified yet
+
 	chainmu sync.RWMutex // protects header inserts
 	quit    chan struct{}
 	wg      sync.WaitGroup
@@ -83,6 +92,7 @@ func NewLightChain(odr OdrBackend, config *params.ChainConfig, engine consensus.
 	bodyCache, _ := lru.New(bodyCacheLimit)
 	bodyRLPCache, _ := lru.New(bodyCacheLimit)
 	blockCache, _ := lru.New(blockCacheLimit)
+	epochSnarkDataCache, _ := lru.New(epochSnarkDataCacheLimit)
 
 	bc := &LightChain{
 		chainDb:       odr.Database(),
@@ -93,12 +103,15 @@ func NewLightChain(odr OdrBackend, config *params.ChainConfig, engine consensus.
 		bodyRLPCache:  bodyRLPCache,
 		blockCache:    blockCache,
 		engine:        engine,
+
+		epochSnarkDataCache: epochSnarkDataCache,
 	}
 	var err error
 	bc.hc, err = core.NewHeaderChain(odr.Database(), config, bc.engine, bc.getProcInterrupt)
 	if err != nil {
 		return nil, err
 	}
+	bc.systemCaller = contract_comm.NewSystemCaller(bc)
 	bc.genesisBlock, _ = bc.GetBlockByNumber(NoOdr, 0)
 	if bc.genesisBlock == nil {
 		return nil, core.ErrNoGenesis
@@ -382,6 +395,14 @@ func (lc *LightChain) InsertHeaderChain(chain []*types.Header, checkFreq int, co
 		log.Error(fmt.Sprintf("Failed to validate the header chain at %d due to \"%v\"", i, err))
 		return i, err
 	}
+	// Non contiguous headers skip the epochs in between, so the validator set transitions
+	// they carry are checked against the epoch validator set seals
+	if !contiguousHeaders {
+		if i, err := lc.verifyEpochSnarkData(chain); err != nil {
+			log.Error(fmt.Sprintf("Failed to verify the epoch snark data at %d due to \"%v\"", i, err))
+			return i, err
+		}
+	}
 
 	// Make sure only one thread manipulates the chain at once
 	lc.chainmu.Lock()
@@ -413,6 +434,56 @@ func (lc *LightChain) InsertHeaderChain(chain []*types.Header, checkFreq int, co
 	return i, err
 }
 
+// verifyEpochSnarkData checks that the yet unknown epoch headers in the chain carry an epoch
+// validator set seal from a quorum of the validators of the epoch they end. The epoch snark
+// data is retrieved from the network, unless it was served along with the header and
+// verifies. A peer serving epoch snark data that doesn't verify is dropped by the retrieval,
+// so a forged validator set transition can't be retrieved from any peer.
+func (lc *LightChain) verifyEpochSnarkData(chain []*types.Header) (int, error) {
+	engine, ok := lc.engine.(consensus.Istanbul)
+	if !ok {
+		return 0, nil
+	}
+	for i, header := range chain {
+		if header.Number.Uint64() == 0 || !engine.IsLastBlockOfEpoch(header) || lc.HasHeader(header.Hash(), header.Number.Uint64()) {
+			continue
+		}
+		header, parents := header, chain[:i]
+		verify := func(epochSnarkData *types.EpochSnarkData) error {
+			return engine.VerifyEpochSnarkData(lc.hc, header, parents, epochSnarkData)
+		}
+		// Epoch snark data served along with the header is only trusted once verified,
+		// otherwise it's retrieved from the network
+		if cached, ok := lc.epochSnarkDataCache.Get(header.Hash()); ok {
+			lc.epochSnarkDataCache.Remove(header.Hash())
+			err := verify(cached.(*types.EpochSnarkData))
+			if err == nil {
+				continue
+			}
+			log.Debug("Served epoch snark data failed verification", "number", header.Number, "hash", header.Hash(), "err", err)
+		}
+		req := &EpochSnarkDataRequest{
+			Header: header,
+			Verify: verify,
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), epochSnarkDataTimeout)
+		err := lc.odr.Retrieve(ctx, req)
+		cancel()
+		if err != nil {
+			return i, err
+		}
+	}
+	return 0, nil
+}
+
+// AddEpochSnarkData caches the epoch snark data served along with an epoch header, so that it
+// doesn't have to be retrieved when the header is inserted.
+func (lc *LightChain) AddEpochSnarkData(hash common.Hash, epochSnarkData *types.EpochSnarkData) {
+	if epochSnarkData != nil {
+		lc.epochSnarkDataCache.Add(hash, epochSnarkData)
+	}
+}
+
 // CurrentHeader retrieves the current head header of the canonical chain. The
 // header is retrieved from the HeaderChain's internal cache.
 func (lc *LightChain) CurrentHeader() *types.Header {
@@ -488,6 +559,12 @@ func (self *LightChain) GetVMConfig() *vm.Config {
 	return &vm.Config{}
 }
 
+// SystemCaller returns the caller used to make calls to the system contracts
+// against the current head of the light chain.
+func (lc *LightChain) SystemCaller() *contract_comm.SystemCaller {
+	return lc.systemCaller
+}
+
 // Config retrieves the header chain's chain configuration.
 func (lc *LightChain) Config() *params.ChainConfig { return lc.hc.Config() }
 
@@ -119,6 +119,21 @@ func (req *BlockRequest) StoreResult(db ethdb.Database) {
 	rawdb.WriteBodyRLP(db, req.Hash, req.Number, req.Rlp)
 }
 
+// EpochSnarkDataRequest is the ODR request type for retrieving the epoch snark data of the
+// last block of an epoch, which is part of the block body rather than the header
+type EpochSnarkDataRequest struct {
+	OdrRequest
+	Header         *types.Header
+	Verify         func(*types.EpochSnarkData) error // Checks the epoch snark data served by a peer
+	EpochSnarkData *types.EpochSnarkData
+	Rlp            []byte
+}
+
+// StoreResult stores the retrieved block body in local database
+func (req *EpochSnarkDataRequest) StoreResult(db ethdb.Database) {
+	rawdb.WriteBodyRLP(db, req.Header.Hash(), req.Header.Number.Uint64(), req.Rlp)
+}
+
 type blockHashOrNumber struct {
 	Hash   common.Hash
 	Number *uint64
@@ -344,6 +344,23 @@ func (pool *TxPool) Stats() (pending int) {
 	return
 }
 
+// StatsByCurrency returns the number of currently pending (locally created) transactions
+// by fee currency. Transactions paying fees in Celo Gold are keyed by the zero address.
+func (pool *TxPool) StatsByCurrency() map[common.Address]int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	pending := make(map[common.Address]int)
+	for _, tx := range pool.pending {
+		feeCurrency := common.ZeroAddress
+		if tx.FeeCurrency() != nil {
+			feeCurrency = *tx.FeeCurrency()
+		}
+		pending[feeCurrency]++
+	}
+	return pending
+}
+
 // validateTx checks whether a transaction is valid according to the consensus rules and will be broadcast.
 func (pool *TxPool) validateTx(ctx context.Context, tx *types.Transaction) error {
 	// Validate sender
@@ -371,14 +388,14 @@ func (pool *TxPool) validateTx(ctx context.Context, tx *types.Transaction) error
 	}
 
 	// Transactor should have enough funds to cover the costs
-	err = core.ValidateTransactorBalanceCoversTx(tx, from, currentState)
+	err = core.ValidateTransactorBalanceCoversTx(pool.chain.SystemCaller(), tx, from, currentState)
 	if err != nil {
 		return err
 	}
 
 	// Should supply enough intrinsic gas
 	header := pool.chain.GetHeaderByHash(pool.head)
-	gas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, header, currentState, tx.FeeCurrency(), pool.istanbul)
+	gas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, pool.chain.SystemCaller(), header, currentState, tx.FeeCurrency(), pool.istanbul)
 	if err != nil {
 		return err
 	}
@@ -389,11 +406,11 @@ func (pool *TxPool) validateTx(ctx context.Context, tx *types.Transaction) error
 	// Ensure gold transfers are whitelisted if transfers are frozen.
 	if tx.Value().Sign() > 0 {
 		to := *tx.To()
-		if isFrozen, err := freezer.IsFrozen(params.GoldTokenRegistryId, nil, nil); err != nil {
+		if isFrozen, err := freezer.IsFrozen(pool.chain.SystemCaller(), params.GoldTokenRegistryId, nil, nil); err != nil {
 			log.Warn("Error determining if transfers are frozen, will proceed as if they are not", "err", err)
 		} else if isFrozen {
 			log.Info("Transfers are frozen")
-			if !transfer_whitelist.IsWhitelisted(to, from, nil, nil) {
+			if !transfer_whitelist.IsWhitelisted(pool.chain.SystemCaller(), to, from, nil, nil) {
 				log.Debug("Attempt to transfer between non-whitelisted addresses", "hash", tx.Hash(), "to", to, "from", from)
 				return core.ErrTransfersFrozen
 			}
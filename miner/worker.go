@@ -18,6 +18,7 @@ package miner
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -83,6 +84,9 @@ const (
 var (
 	randomSeedString = []byte("Randomness seed string")
 	randomSeed       []byte
+
+	goldRate            = big.NewRat(1, 1)
+	errZeroExchangeRate = errors.New("zero exchange rate")
 )
 
 // environment is the worker's current environment and holds all of the current state information.
@@ -99,6 +103,10 @@ type environment struct {
 	txs        []*types.Transaction
 	receipts   []*types.Receipt
 	randomness *types.Randomness // The types.Randomness of the last block by mined by this worker.
+
+	parent      *types.Header               // Parent of the block being built
+	parentState *state.StateDB              // State after the parent, used to read the exchange rates
+	rates       map[common.Address]*big.Rat // Celo Gold value of a unit of each fee currency as of parent, nil if unknown
 }
 
 // task contains all information for consensus engine sealing and result submitting.
@@ -316,8 +324,53 @@ func (w *worker) close() {
 	close(w.exitCh)
 }
 
+// resolveExchangeRates reads the exchange rates of the fee currencies of the given transactions
+// as of the parent of the block being built, so that sorting them doesn't re-enter the EVM.
+func (w *worker) resolveExchangeRates(txs map[common.Address]types.Transactions) {
+	for _, accTxs := range txs {
+		for _, tx := range accTxs {
+			feeCurrency := tx.FeeCurrency()
+			if feeCurrency == nil {
+				continue
+			}
+			if _, ok := w.current.rates[*feeCurrency]; ok {
+				continue
+			}
+			var rate *big.Rat
+			numerator, denominator, err := currency.GetExchangeRate(w.chain.SystemCaller(), feeCurrency, w.current.parent, w.current.parentState)
+			if err == nil && numerator.Sign() == 0 {
+				err = errZeroExchangeRate
+			}
+			if err != nil {
+				log.Warn("Error in retrieving exchange rate. Will compare prices without exchange rate conversion.", "feeCurrency", feeCurrency.Hex(), "err", err)
+			} else {
+				rate = new(big.Rat).SetFrac(denominator, numerator)
+			}
+			w.current.rates[*feeCurrency] = rate
+		}
+	}
+}
+
+// txCmp compares the gas prices of two transactions using the exchange rates resolved by
+// resolveExchangeRates.
 func (w *worker) txCmp(tx1 *types.Transaction, tx2 *types.Transaction) int {
-	return currency.Cmp(tx1.GasPrice(), tx1.FeeCurrency(), tx2.GasPrice(), tx2.FeeCurrency())
+	currency1, currency2 := tx1.FeeCurrency(), tx2.FeeCurrency()
+	if currency1 == nil && currency2 == nil || currency1 != nil && currency2 != nil && *currency1 == *currency2 {
+		return tx1.GasPrice().Cmp(tx2.GasPrice())
+	}
+	rate1, rate2 := goldRate, goldRate
+	if currency1 != nil {
+		rate1 = w.current.rates[*currency1]
+	}
+	if currency2 != nil {
+		rate2 = w.current.rates[*currency2]
+	}
+	if rate1 == nil || rate2 == nil {
+		return tx1.GasPrice().Cmp(tx2.GasPrice())
+	}
+	price1 := new(big.Rat).Mul(new(big.Rat).SetInt(tx1.GasPrice()), rate1)
+	price2 := new(big.Rat).Mul(new(big.Rat).SetInt(tx2.GasPrice()), rate2)
+	return price1.Cmp(price2)
 }
 
 // newWorkLoop is a standalone goroutine to submit new mining work upon received events.
@@ -472,6 +525,7 @@ func (w *worker) mainLoop() {
 					txs[acc] = append(txs[acc], tx)
 				}
 
+				w.resolveExchangeRates(txs)
 				txset := types.NewTransactionsByPriceAndNonce(w.current.signer, txs, w.txCmp)
 				tcount := w.current.tcount
 				w.commitTransactions(txset, txFeeRecipient, nil)
@@ -631,11 +685,14 @@ func (w *worker) makeCurrent(parent *types.Block, header *types.Header) error {
 	}
 
 	env := &environment{
-		signer:    types.NewEIP155Signer(w.chainConfig.ChainID),
-		state:     state,
-		ancestors: mapset.NewSet(),
-		header:    header,
-		gasLimit:  core.CalcGasLimit(parent, state),
+		signer:      types.NewEIP155Signer(w.chainConfig.ChainID),
+		state:       state,
+		ancestors:   mapset.NewSet(),
+		header:      header,
+		gasLimit:    core.CalcGasLimit(w.chain.SystemCaller(), parent, state),
+		parent:      parent.Header(),
+		parentState: state.Copy(),
+		rates:       make(map[common.Address]*big.Rat),
 	}
 
 	// when 08 is processed ancestors contain 07 (quick block)
@@ -726,7 +783,7 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, txFe
 		// We will not add any more txns from the `txns` parameter if `tx`'s gasPrice is below the gas price minimum.
 		// All the other transactions after this `tx` will either also be below the gas price minimum or will have a
 		// nonce that is non sequential to the last mined txn for the account.
-		gasPriceMinimum, _ := gpm.GetGasPriceMinimum(tx.FeeCurrency(), w.current.header, w.current.state)
+		gasPriceMinimum, _ := gpm.GetGasPriceMinimum(w.chain.SystemCaller(), tx.FeeCurrency(), w.current.header, w.current.state)
 		if tx.GasPrice().Cmp(gasPriceMinimum) == -1 {
 			log.Info("Excluding transaction from block due to failure to exceed gasPriceMinimum", "gasPrice", tx.GasPrice(), "gasPriceMinimum", gasPriceMinimum)
 			break
@@ -878,7 +935,7 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	w.updateSnapshot()
 
 	// Play our part in generating the random beacon.
-	if w.isRunning() && random.IsRunning() {
+	if w.isRunning() && random.IsRunning(w.chain.SystemCaller()) {
 		if randomSeed == nil {
 			account := accounts.Account{Address: w.validator}
 			wallet, err := w.eth.AccountManager().Find(account)
@@ -892,19 +949,19 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 			}
 		}
 
-		lastRandomness, err := random.GetLastRandomness(w.validator, w.db, w.current.header, w.current.state, w.chain, randomSeed)
+		lastRandomness, err := random.GetLastRandomness(w.chain.SystemCaller(), w.validator, w.db, w.current.header, w.current.state, w.chain, randomSeed)
 		if err != nil {
 			log.Error("Failed to get last randomness", "err", err)
 			return
 		}
 
-		commitment, err := random.GenerateNewRandomnessAndCommitment(w.current.header, w.current.state, w.db, randomSeed)
+		commitment, err := random.GenerateNewRandomnessAndCommitment(w.chain.SystemCaller(), w.current.header, w.current.state, w.db, randomSeed)
 		if err != nil {
 			log.Error("Failed to generate randomness commitment", "err", err)
 			return
 		}
 
-		err = random.RevealAndCommit(lastRandomness, commitment, w.validator, w.current.header, w.current.state)
+		err = random.RevealAndCommit(w.chain.SystemCaller(), lastRandomness, commitment, w.validator, w.current.header, w.current.state)
 		if err != nil {
 			log.Error("Failed to reveal and commit randomness", "randomness", lastRandomness.Hex(), "commitment", commitment.Hex(), "err", err)
 			return
@@ -940,12 +997,14 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		}
 	}
 	if len(localTxs) > 0 {
+		w.resolveExchangeRates(localTxs)
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, localTxs, w.txCmp)
 		if w.commitTransactions(txs, w.txFeeRecipient, interrupt) {
 			return
 		}
 	}
 	if len(remoteTxs) > 0 {
+		w.resolveExchangeRates(remoteTxs)
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, remoteTxs, w.txCmp)
 		if w.commitTransactions(txs, w.txFeeRecipient, interrupt) {
 			return
@@ -39,7 +39,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
 
-	"github.com/ethereum/go-ethereum/contract_comm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -128,8 +127,6 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 	genesis := gspec.MustCommit(db)
 
 	chain, _ := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec.Config, engine, vm.Config{}, nil)
-	contract_comm.SetInternalEVMHandler(chain)
-
 	txpool := core.NewTxPool(testTxPoolConfig, chainConfig, chain)
 
 	// If istanbul engine used, set the objects in that engine
@@ -139,6 +136,9 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 			return chain.StateAt(parentStateRoot)
 		})
 	}
+	if backend, ok := engine.(*istanbulBackend.Backend); ok {
+		backend.SetSystemCaller(chain.SystemCaller())
+	}
 
 	// Generate a small n-block chain.
 	if n > 0 {
@@ -253,6 +253,8 @@ func getAuthorizedIstanbulEngine() consensus.Istanbul {
 
 	config := istanbul.DefaultConfig
 	config.RoundStateDBPath = ""
+	config.EquivocationDBPath = ""
+	config.SlashingProtectionDBPath = ""
 	config.ValidatorEnodeDBPath = ""
 	config.VersionCertificateDBPath = ""
 
@@ -523,3 +525,34 @@ func testAdjustInterval(t *testing.T, chainConfig *params.ChainConfig, engine co
 		t.Error("interval reset timeout")
 	}
 }
+
+// Tests that transaction prices in different fee currencies are compared using the
+// exchange rates resolved for the block being built.
+func TestTxCmp(t *testing.T) {
+	token, unknown := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	w := &worker{current: &environment{rates: map[common.Address]*big.Rat{
+		token:   big.NewRat(1, 4), // 4 token units are worth 1 Celo Gold unit
+		unknown: nil,
+	}}}
+	newTx := func(price int64, feeCurrency *common.Address) *types.Transaction {
+		return types.NewTransaction(0, common.Address{}, nil, 0, big.NewInt(price), feeCurrency, nil, nil, nil)
+	}
+
+	tests := []struct {
+		tx1, tx2 *types.Transaction
+		want     int
+	}{
+		{newTx(2, nil), newTx(3, nil), -1},
+		{newTx(8, &token), newTx(7, &token), 1},
+		{newTx(8, &token), newTx(2, nil), 0},
+		{newTx(8, &token), newTx(3, nil), -1},
+		{newTx(3, nil), newTx(8, &token), 1},
+		{newTx(8, &unknown), newTx(3, nil), 1},
+		{newTx(8, &unknown), newTx(8, &token), 0},
+	}
+	for i, tt := range tests {
+		if have := w.txCmp(tt.tx1, tt.tx2); have != tt.want {
+			t.Errorf("test %d: comparison mismatch: have %d, want %d", i, have, tt.want)
+		}
+	}
+}
@@ -199,6 +199,8 @@ func NewNode(datadir string, config *NodeConfig) (stack *Node, _ error) {
 		ethConf.Istanbul.VersionCertificateDBPath = ""
 		// Use an in memory DB for roundState table
 		ethConf.Istanbul.RoundStateDBPath = ""
+		ethConf.Istanbul.EquivocationDBPath = ""
+		ethConf.Istanbul.SlashingProtectionDBPath = ""
 		if err := rawStack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
 			return les.New(ctx, &ethConf)
 		}); err != nil {
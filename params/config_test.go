@@ -70,6 +70,23 @@ func TestCheckCompatible(t *testing.T) {
 				RewindTo:     9,
 			},
 		},
+		{
+			stored:  &ChainConfig{Istanbul: &IstanbulConfig{StakeWeightedProposerBlock: big.NewInt(10)}},
+			new:     &ChainConfig{Istanbul: &IstanbulConfig{StakeWeightedProposerBlock: big.NewInt(20)}},
+			head:    9,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{Istanbul: &IstanbulConfig{StakeWeightedProposerBlock: big.NewInt(10)}},
+			new:    &ChainConfig{Istanbul: &IstanbulConfig{}},
+			head:   15,
+			wantErr: &ConfigCompatError{
+				What:         "Stake weighted proposer fork block",
+				StoredConfig: big.NewInt(10),
+				NewConfig:    nil,
+				RewindTo:     9,
+			},
+		},
 	}
 
 	for _, test := range tests {
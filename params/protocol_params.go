@@ -136,15 +136,48 @@ const (
 	// Celo precompiled contracts
 	FractionMulExpGas           uint64 = 50     // Cost of performing multiplication and exponentiation of fractions to an exponent of up to 10^3.
 	ProofOfPossessionGas        uint64 = 350000 // Cost of verifying a BLS proof of possession.
+	Ed25519VerifyGas            uint64 = 2000   // Base cost of verifying an Ed25519 signature.
+	Ed25519VerifyPerWordGas     uint64 = 12     // Per-word cost of hashing the message of an Ed25519 signature.
+	Sha3256BaseGas              uint64 = 60     // Base price for a SHA3-256 operation.
+	Sha3256PerWordGas           uint64 = 12     // Per-word price for a SHA3-256 operation.
+	Keccak512BaseGas            uint64 = 60     // Base price for a Keccak-512 operation.
+	Keccak512PerWordGas         uint64 = 12     // Per-word price for a Keccak-512 operation.
+	Blake2sBaseGas              uint64 = 60     // Base price for a BLAKE2s operation.
+	Blake2sPerWordGas           uint64 = 12     // Per-word price for a BLAKE2s operation.
 	GetValidatorGas             uint64 = 1000   // Cost of reading a validator's address.
+	GetBlockRandomnessGas       uint64 = 1000   // Base cost of reading past block randomness, excluding the call to the Random contract.
 	GetEpochSizeGas             uint64 = 10     // Cost of querying the number of blocks in an epoch.
 	GetBlockNumberFromHeaderGas uint64 = 10     // Cost of decoding a block header.
 	HashHeaderGas               uint64 = 10     // Cost of hashing a block header.
 	GetParentSealBitmapGas      uint64 = 100    // Cost of reading the parent seal bitmap from the chain.
 	// May take a bit more time with 100 validators, need to bench that
 	GetVerifiedSealBitmapGas uint64 = 350000 // Cost of verifying the seal on a given RLP encoded header.
+
+	// BLS12-381 operations are priced as in EIP-2537 and BLS12-377 operations as
+	// in EIP-2539.  EIP-2539 has no map-to-curve operations: the BLS12-377 G2 map
+	// is priced as the BLS12-381 one, and the G1 map at 1.75 times the BLS12-381
+	// price, the ratio of the BenchmarkG1MapToCurve times of the two packages.
+	Bls12377G1AddGas          uint64 = 600    // Price for BLS12-377 elliptic curve G1 point addition
+	Bls12377G1MulGas          uint64 = 12000  // Price for BLS12-377 elliptic curve G1 point scalar multiplication
+	Bls12377G2AddGas          uint64 = 4500   // Price for BLS12-377 elliptic curve G2 point addition
+	Bls12377G2MulGas          uint64 = 55000  // Price for BLS12-377 elliptic curve G2 point scalar multiplication
+	Bls12377PairingBaseGas    uint64 = 65000  // Base gas price for BLS12-377 elliptic curve pairing check
+	Bls12377PairingPerPairGas uint64 = 55000  // Per-point pair gas price for BLS12-377 elliptic curve pairing check
+	Bls12377MapG1Gas          uint64 = 9625   // Gas price for BLS12-377 mapping field element to G1 operation
+	Bls12377MapG2Gas          uint64 = 110000 // Gas price for BLS12-377 mapping field element to G2 operation
+	Bls12381G1AddGas          uint64 = 600    // Price for BLS12-381 elliptic curve G1 point addition
+	Bls12381G1MulGas          uint64 = 12000  // Price for BLS12-381 elliptic curve G1 point scalar multiplication
+	Bls12381G2AddGas          uint64 = 4500   // Price for BLS12-381 elliptic curve G2 point addition
+	Bls12381G2MulGas          uint64 = 55000  // Price for BLS12-381 elliptic curve G2 point scalar multiplication
+	Bls12381PairingBaseGas    uint64 = 115000 // Base gas price for BLS12-381 elliptic curve pairing check
+	Bls12381PairingPerPairGas uint64 = 23000  // Per-point pair gas price for BLS12-381 elliptic curve pairing check
+	Bls12381MapG1Gas          uint64 = 5500   // Gas price for BLS12-381 mapping field element to G1 operation
+	Bls12381MapG2Gas          uint64 = 110000 // Gas price for BLS12-381 mapping field element to G2 operation
 )
 
+// Gas discount table for BLS12-377 and BLS12-381 G1 and G2 multi exponentiation operations
+var Bls12MultiExpDiscountTable = [128]uint64{1200, 888, 764, 641, 594, 547, 500, 453, 438, 423, 408, 394, 379, 364, 349, 334, 330, 326, 322, 318, 314, 310, 306, 302, 298, 294, 289, 285, 281, 277, 273, 269, 268, 266, 265, 263, 262, 260, 259, 257, 256, 254, 253, 251, 250, 248, 247, 245, 244, 242, 241, 239, 238, 236, 235, 233, 232, 231, 229, 228, 226, 225, 223, 222, 221, 220, 219, 219, 218, 217, 216, 216, 215, 214, 213, 213, 212, 211, 211, 210, 209, 208, 208, 207, 206, 205, 205, 204, 203, 202, 202, 201, 200, 199, 199, 198, 197, 196, 196, 195, 194, 193, 193, 192, 191, 191, 190, 189, 188, 188, 187, 186, 185, 185, 184, 183, 182, 182, 181, 180, 179, 179, 178, 177, 176, 176, 175, 174}
+
 var (
 	RegistrySmartContractAddress = common.HexToAddress("0x000000000000000000000000000000000000ce10")
 
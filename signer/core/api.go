@@ -41,9 +41,9 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.0.0"
+	ExternalAPIVersion = "6.1.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.0"
+	InternalAPIVersion = "7.1.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -58,6 +58,10 @@ type ExternalAPI interface {
 	SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error)
 	// SignTypedData - request to sign the given structured data (plus prefix)
 	SignTypedData(ctx context.Context, addr common.MixedcaseAddress, data TypedData) (hexutil.Bytes, error)
+	// SignBLS - request to BLS sign the given message and extra data, in the given consensus view
+	SignBLS(ctx context.Context, addr common.MixedcaseAddress, msg hexutil.Bytes, extraData hexutil.Bytes, useComposite bool, view *BLSView) (hexutil.Bytes, error)
+	// GenerateProofOfPossessionBLS - request to generate the BLS proof of possession of an account for the given address
+	GenerateProofOfPossessionBLS(ctx context.Context, addr common.MixedcaseAddress, address common.Address) (*ProofOfPossessionBLS, error)
 	// EcRecover - recover public key from given message and signature
 	EcRecover(ctx context.Context, data hexutil.Bytes, sig hexutil.Bytes) (common.Address, error)
 	// Version info about the APIs
@@ -71,6 +75,10 @@ type UIClientAPI interface {
 	ApproveTx(request *SignTxRequest) (SignTxResponse, error)
 	// ApproveSignData prompt the user for confirmation to request to sign data
 	ApproveSignData(request *SignDataRequest) (SignDataResponse, error)
+	// ApproveSignBLS prompt the user for confirmation to request to BLS sign a message
+	ApproveSignBLS(request *SignBLSRequest) (SignBLSResponse, error)
+	// ApproveProofOfPossessionBLS prompt the user for confirmation to request to generate a BLS proof of possession
+	ApproveProofOfPossessionBLS(request *ProofOfPossessionBLSRequest) (ProofOfPossessionBLSResponse, error)
 	// ApproveListing prompt the user for confirmation to list accounts
 	// the list of accounts to list can be modified by the UI
 	ApproveListing(request *ListRequest) (ListResponse, error)
@@ -240,6 +248,27 @@ type (
 	SignDataResponse struct {
 		Approved bool `json:"approved"`
 	}
+	// SignBLSRequest contains info about a message to BLS sign
+	SignBLSRequest struct {
+		Address      common.MixedcaseAddress `json:"address"`
+		Message      hexutil.Bytes           `json:"message"`
+		ExtraData    hexutil.Bytes           `json:"extra_data"`
+		UseComposite bool                    `json:"use_composite"`
+		View         *BLSView                `json:"view"`
+		Meta         Metadata                `json:"meta"`
+	}
+	SignBLSResponse struct {
+		Approved bool `json:"approved"`
+	}
+	// ProofOfPossessionBLSRequest contains info about a BLS proof of possession to generate
+	ProofOfPossessionBLSRequest struct {
+		Address      common.MixedcaseAddress `json:"address"`
+		ProofAddress common.Address          `json:"proof_address"`
+		Meta         Metadata                `json:"meta"`
+	}
+	ProofOfPossessionBLSResponse struct {
+		Approved bool `json:"approved"`
+	}
 	NewAccountRequest struct {
 		Meta Metadata `json:"meta"`
 	}
@@ -75,6 +75,16 @@ func (ui *headlessUi) ApproveSignData(request *core.SignDataRequest) (core.SignD
 	return core.SignDataResponse{approved}, nil
 }
 
+func (ui *headlessUi) ApproveSignBLS(request *core.SignBLSRequest) (core.SignBLSResponse, error) {
+	approved := (<-ui.approveCh == "Y")
+	return core.SignBLSResponse{approved}, nil
+}
+
+func (ui *headlessUi) ApproveProofOfPossessionBLS(request *core.ProofOfPossessionBLSRequest) (core.ProofOfPossessionBLSResponse, error) {
+	approved := (<-ui.approveCh == "Y")
+	return core.ProofOfPossessionBLSResponse{approved}, nil
+}
+
 func (ui *headlessUi) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
 	approval := <-ui.approveCh
 	//fmt.Printf("approval %s\n", approval)
@@ -76,6 +76,26 @@ func (l *AuditLogger) SignTypedData(ctx context.Context, addr common.MixedcaseAd
 	return b, e
 }
 
+func (l *AuditLogger) SignBLS(ctx context.Context, addr common.MixedcaseAddress, msg hexutil.Bytes, extraData hexutil.Bytes, useComposite bool, view *BLSView) (hexutil.Bytes, error) {
+	l.log.Info("SignBLS", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "msg", common.Bytes2Hex(msg), "extraData", common.Bytes2Hex(extraData), "composite", useComposite, "view", view)
+	b, e := l.api.SignBLS(ctx, addr, msg, extraData, useComposite, view)
+	l.log.Info("SignBLS", "type", "response", "data", common.Bytes2Hex(b), "error", e)
+	return b, e
+}
+
+func (l *AuditLogger) GenerateProofOfPossessionBLS(ctx context.Context, addr common.MixedcaseAddress, address common.Address) (*ProofOfPossessionBLS, error) {
+	l.log.Info("GenerateProofOfPossessionBLS", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "address", address.String())
+	res, e := l.api.GenerateProofOfPossessionBLS(ctx, addr, address)
+	if res != nil {
+		l.log.Info("GenerateProofOfPossessionBLS", "type", "response", "publicKey", common.Bytes2Hex(res.PublicKey), "signature", common.Bytes2Hex(res.Signature), "error", e)
+	} else {
+		l.log.Info("GenerateProofOfPossessionBLS", "type", "response", "error", e)
+	}
+	return res, e
+}
+
 func (l *AuditLogger) EcRecover(ctx context.Context, data hexutil.Bytes, sig hexutil.Bytes) (common.Address, error) {
 	l.log.Info("EcRecover", "type", "request", "metadata", MetadataFromContext(ctx).String(),
 		"data", common.Bytes2Hex(data), "sig", common.Bytes2Hex(sig))
@@ -155,6 +155,43 @@ func (ui *CommandlineUI) ApproveSignData(request *SignDataRequest) (SignDataResp
 	return SignDataResponse{true}, nil
 }
 
+// ApproveSignBLS prompt the user for confirmation to request to BLS sign a message
+func (ui *CommandlineUI) ApproveSignBLS(request *SignBLSRequest) (SignBLSResponse, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	fmt.Printf("-------- Sign BLS request--------------\n")
+	fmt.Printf("Account:  %s\n", request.Address.String())
+	fmt.Printf("message:  %v\n", request.Message)
+	fmt.Printf("extra data:  %v\n", request.ExtraData)
+	fmt.Printf("composite:  %v\n", request.UseComposite)
+	if request.View != nil {
+		fmt.Printf("view:  %v\n", request.View)
+	}
+	fmt.Printf("-------------------------------------------\n")
+	showMetadata(request.Meta)
+	if !ui.confirm() {
+		return SignBLSResponse{false}, nil
+	}
+	return SignBLSResponse{true}, nil
+}
+
+// ApproveProofOfPossessionBLS prompt the user for confirmation to request to generate a BLS proof of possession
+func (ui *CommandlineUI) ApproveProofOfPossessionBLS(request *ProofOfPossessionBLSRequest) (ProofOfPossessionBLSResponse, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	fmt.Printf("-------- BLS proof of possession request--------------\n")
+	fmt.Printf("Account:  %s\n", request.Address.String())
+	fmt.Printf("proof address:  %s\n", request.ProofAddress.Hex())
+	fmt.Printf("-------------------------------------------\n")
+	showMetadata(request.Meta)
+	if !ui.confirm() {
+		return ProofOfPossessionBLSResponse{false}, nil
+	}
+	return ProofOfPossessionBLSResponse{true}, nil
+}
+
 // ApproveListing prompt the user for confirmation to list accounts
 // the list of accounts to list can be modified by the UI
 func (ui *CommandlineUI) ApproveListing(request *ListRequest) (ListResponse, error) {
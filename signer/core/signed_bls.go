@@ -0,0 +1,146 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	blscrypto "github.com/ethereum/go-ethereum/crypto/bls"
+)
+
+// BLSView is the consensus view (sequence and round) a BLS signature is requested in.
+// It allows rules to refuse signing conflicting messages for the same view.
+type BLSView struct {
+	Sequence *hexutil.Big `json:"sequence"`
+	Round    *hexutil.Big `json:"round"`
+}
+
+// Cmp compares v and w and returns -1 if v < w, 0 if v == w and +1 if v > w.
+// Sequences are compared first, then rounds.
+func (v *BLSView) Cmp(w *BLSView) int {
+	if c := v.Sequence.ToInt().Cmp(w.Sequence.ToInt()); c != 0 {
+		return c
+	}
+	return v.Round.ToInt().Cmp(w.Round.ToInt())
+}
+
+func (v *BLSView) String() string {
+	return fmt.Sprintf("{Sequence: %v, Round: %v}", v.Sequence.ToInt(), v.Round.ToInt())
+}
+
+// ProofOfPossessionBLS is the BLS public key of an account, and the proof of possession of its
+// private key for an address.
+type ProofOfPossessionBLS struct {
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// blsPassphraseWallet is implemented by the wallets able to BLS sign with a passphrase, like
+// the keystore wallets.
+type blsPassphraseWallet interface {
+	SignBLSWithPassphrase(account accounts.Account, passphrase string, msg []byte, extraData []byte, useComposite bool) (blscrypto.SerializedSignature, error)
+	GenerateProofOfPossessionBLSWithPassphrase(account accounts.Account, passphrase string, address common.Address) ([]byte, []byte, error)
+}
+
+// findBLSWallet looks up the wallet containing the account, and the password to unlock it.
+func (api *SignerAPI) findBLSWallet(account accounts.Account) (blsPassphraseWallet, string, error) {
+	wallet, err := api.am.Find(account)
+	if err != nil {
+		return nil, "", err
+	}
+	blsWallet, ok := wallet.(blsPassphraseWallet)
+	if !ok {
+		return nil, "", accounts.ErrNotSupported
+	}
+	pw, err := api.lookupOrQueryPassword(account.Address,
+		"Password for BLS signing",
+		fmt.Sprintf("Please enter password for BLS signing with account %s", account.Address.Hex()))
+	if err != nil {
+		return nil, "", err
+	}
+	return blsWallet, pw, nil
+}
+
+// SignBLS signs the message and extra data with the BLS key of the account. The view is the
+// consensus view the message is signed in, if any.
+func (api *SignerAPI) SignBLS(ctx context.Context, addr common.MixedcaseAddress, msg hexutil.Bytes, extraData hexutil.Bytes, useComposite bool, view *BLSView) (hexutil.Bytes, error) {
+	if view != nil && (view.Sequence == nil || view.Round == nil) {
+		return nil, fmt.Errorf("invalid view %v: sequence and round are required", view)
+	}
+	req := &SignBLSRequest{
+		Address:      addr,
+		Message:      msg,
+		ExtraData:    extraData,
+		UseComposite: useComposite,
+		View:         view,
+		Meta:         MetadataFromContext(ctx),
+	}
+	// We make the request prior to looking up if we actually have the account, to prevent
+	// account-enumeration via the API
+	res, err := api.UI.ApproveSignBLS(req)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Approved {
+		return nil, ErrRequestDenied
+	}
+	account := accounts.Account{Address: addr.Address()}
+	wallet, pw, err := api.findBLSWallet(account)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	signature, err := wallet.SignBLSWithPassphrase(account, pw, msg, extraData, useComposite)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	return signature[:], nil
+}
+
+// GenerateProofOfPossessionBLS returns the BLS public key of the account, and the proof of
+// possession of its private key for the given address.
+func (api *SignerAPI) GenerateProofOfPossessionBLS(ctx context.Context, addr common.MixedcaseAddress, address common.Address) (*ProofOfPossessionBLS, error) {
+	req := &ProofOfPossessionBLSRequest{
+		Address:      addr,
+		ProofAddress: address,
+		Meta:         MetadataFromContext(ctx),
+	}
+	res, err := api.UI.ApproveProofOfPossessionBLS(req)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Approved {
+		return nil, ErrRequestDenied
+	}
+	account := accounts.Account{Address: addr.Address()}
+	wallet, pw, err := api.findBLSWallet(account)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	publicKey, signature, err := wallet.GenerateProofOfPossessionBLSWithPassphrase(account, pw, address)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	return &ProofOfPossessionBLS{PublicKey: publicKey, Signature: signature}, nil
+}
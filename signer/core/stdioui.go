@@ -72,6 +72,18 @@ func (ui *StdIOUI) ApproveSignData(request *SignDataRequest) (SignDataResponse,
 	return result, err
 }
 
+func (ui *StdIOUI) ApproveSignBLS(request *SignBLSRequest) (SignBLSResponse, error) {
+	var result SignBLSResponse
+	err := ui.dispatch("ui_approveSignBLS", request, &result)
+	return result, err
+}
+
+func (ui *StdIOUI) ApproveProofOfPossessionBLS(request *ProofOfPossessionBLSRequest) (ProofOfPossessionBLSResponse, error) {
+	var result ProofOfPossessionBLSResponse
+	err := ui.dispatch("ui_approveProofOfPossessionBLS", request, &result)
+	return result, err
+}
+
 func (ui *StdIOUI) ApproveListing(request *ListRequest) (ListResponse, error) {
 	var result ListResponse
 	err := ui.dispatch("ui_approveListing", request, &result)
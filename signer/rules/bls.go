@@ -0,0 +1,95 @@
+// Copyright 2020 The celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+var (
+	// errBLSDoubleSign is returned if a different message was already BLS signed in the requested view
+	errBLSDoubleSign = errors.New("a different message was already signed in this view")
+	// errBLSOldView is returned if a message was already BLS signed in a view after the requested view
+	errBLSOldView = errors.New("a message was already signed in a later view")
+)
+
+// signedBLSView is the last consensus view an account BLS signed a message in, stored in the
+// rules storage.
+type signedBLSView struct {
+	View core.BLSView `json:"view"`
+	Hash common.Hash  `json:"hash"`
+}
+
+// blsViewKey returns the storage key of the last view the account of the request signed in.
+// Composite and direct signatures (epoch and commit seals) are tracked separately, as both
+// are signed in the same view.
+func blsViewKey(request *core.SignBLSRequest) string {
+	kind := "direct"
+	if request.UseComposite {
+		kind = "composite"
+	}
+	return fmt.Sprintf("bls-view-%s-%s", request.Address.Address().Hex(), kind)
+}
+
+// blsMessageHash returns the hash identifying the message and extra data of the request.
+func blsMessageHash(request *core.SignBLSRequest) common.Hash {
+	data, _ := rlp.EncodeToBytes([][]byte{request.Message, request.ExtraData})
+	return crypto.Keccak256Hash(data)
+}
+
+// checkBLSView checks that the request doesn't conflict with the last view its account
+// signed in: only the same message can be signed again in that view, and none in an
+// older view.
+func (r *rulesetUI) checkBLSView(request *core.SignBLSRequest) error {
+	val, err := r.storage.Get(blsViewKey(request))
+	if err == storage.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var last signedBLSView
+	if err := json.Unmarshal([]byte(val), &last); err != nil {
+		return err
+	}
+	switch request.View.Cmp(&last.View) {
+	case -1:
+		return errBLSOldView
+	case 0:
+		if last.Hash != blsMessageHash(request) {
+			return errBLSDoubleSign
+		}
+	}
+	return nil
+}
+
+// recordBLSView records the view of an approved request as the last view its account signed in.
+func (r *rulesetUI) recordBLSView(request *core.SignBLSRequest) error {
+	val, err := json.Marshal(&signedBLSView{View: *request.View, Hash: blsMessageHash(request)})
+	if err != nil {
+		return err
+	}
+	r.storage.Put(blsViewKey(request), string(val))
+	return nil
+}
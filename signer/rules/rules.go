@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
@@ -51,6 +52,8 @@ type rulesetUI struct {
 	next    core.UIClientAPI // The next handler, for manual processing
 	storage storage.Storage
 	jsRules string // The rules to use
+
+	blsMu sync.Mutex // Serializes the BLS signing approvals, to check and record the signed views atomically
 }
 
 func NewRuleEvaluator(next core.UIClientAPI, jsbackend storage.Storage) (*rulesetUI, error) {
@@ -185,6 +188,54 @@ func (r *rulesetUI) ApproveSignData(request *core.SignDataRequest) (core.SignDat
 	return core.SignDataResponse{Approved: false}, err
 }
 
+// ApproveSignBLS refuses to BLS sign conflicting messages in the same consensus view, or in
+// a view older than the last one signed in, before evaluating the ApproveSignBLS rule.
+func (r *rulesetUI) ApproveSignBLS(request *core.SignBLSRequest) (core.SignBLSResponse, error) {
+	r.blsMu.Lock()
+	defer r.blsMu.Unlock()
+
+	if request.View != nil {
+		if err := r.checkBLSView(request); err != nil {
+			log.Warn("BLS signing rejected", "address", request.Address.String(), "view", request.View, "err", err)
+			return core.SignBLSResponse{Approved: false}, nil
+		}
+	}
+	jsonreq, err := json.Marshal(request)
+	approved, err := r.checkApproval("ApproveSignBLS", jsonreq, err)
+	if err != nil {
+		log.Info("Rule-based approval error, going to manual", "error", err)
+		res, err := r.next.ApproveSignBLS(request)
+		if err != nil || !res.Approved {
+			return res, err
+		}
+		approved = true
+	}
+	if approved {
+		// Signing is only approved once its view is recorded
+		if request.View != nil {
+			if err := r.recordBLSView(request); err != nil {
+				log.Warn("Failed to record BLS signing view", "view", request.View, "err", err)
+				return core.SignBLSResponse{Approved: false}, nil
+			}
+		}
+		return core.SignBLSResponse{Approved: true}, nil
+	}
+	return core.SignBLSResponse{Approved: false}, nil
+}
+
+func (r *rulesetUI) ApproveProofOfPossessionBLS(request *core.ProofOfPossessionBLSRequest) (core.ProofOfPossessionBLSResponse, error) {
+	jsonreq, err := json.Marshal(request)
+	approved, err := r.checkApproval("ApproveProofOfPossessionBLS", jsonreq, err)
+	if err != nil {
+		log.Info("Rule-based approval error, going to manual", "error", err)
+		return r.next.ApproveProofOfPossessionBLS(request)
+	}
+	if approved {
+		return core.ProofOfPossessionBLSResponse{Approved: true}, nil
+	}
+	return core.ProofOfPossessionBLSResponse{Approved: false}, err
+}
+
 // OnInputRequired not handled by rules
 func (r *rulesetUI) OnInputRequired(info core.UserInputRequest) (core.UserInputResponse, error) {
 	return r.next.OnInputRequired(info)
@@ -91,6 +91,14 @@ func (alwaysDenyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDat
 	return core.SignDataResponse{Approved: false}, nil
 }
 
+func (alwaysDenyUI) ApproveSignBLS(request *core.SignBLSRequest) (core.SignBLSResponse, error) {
+	return core.SignBLSResponse{Approved: false}, nil
+}
+
+func (alwaysDenyUI) ApproveProofOfPossessionBLS(request *core.ProofOfPossessionBLSRequest) (core.ProofOfPossessionBLSResponse, error) {
+	return core.ProofOfPossessionBLSResponse{Approved: false}, nil
+}
+
 func (alwaysDenyUI) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
 	return core.ListResponse{Accounts: nil}, nil
 }
@@ -217,6 +225,16 @@ func (d *dummyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataR
 	return core.SignDataResponse{}, core.ErrRequestDenied
 }
 
+func (d *dummyUI) ApproveSignBLS(request *core.SignBLSRequest) (core.SignBLSResponse, error) {
+	d.calls = append(d.calls, "ApproveSignBLS")
+	return core.SignBLSResponse{}, core.ErrRequestDenied
+}
+
+func (d *dummyUI) ApproveProofOfPossessionBLS(request *core.ProofOfPossessionBLSRequest) (core.ProofOfPossessionBLSResponse, error) {
+	d.calls = append(d.calls, "ApproveProofOfPossessionBLS")
+	return core.ProofOfPossessionBLSResponse{}, core.ErrRequestDenied
+}
+
 func (d *dummyUI) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
 	d.calls = append(d.calls, "ApproveListing")
 	return core.ListResponse{}, core.ErrRequestDenied
@@ -522,6 +540,16 @@ func (d *dontCallMe) ApproveSignData(request *core.SignDataRequest) (core.SignDa
 	return core.SignDataResponse{}, core.ErrRequestDenied
 }
 
+func (d *dontCallMe) ApproveSignBLS(request *core.SignBLSRequest) (core.SignBLSResponse, error) {
+	d.t.Fatalf("Did not expect next-handler to be called")
+	return core.SignBLSResponse{}, core.ErrRequestDenied
+}
+
+func (d *dontCallMe) ApproveProofOfPossessionBLS(request *core.ProofOfPossessionBLSRequest) (core.ProofOfPossessionBLSResponse, error) {
+	d.t.Fatalf("Did not expect next-handler to be called")
+	return core.ProofOfPossessionBLSResponse{}, core.ErrRequestDenied
+}
+
 func (d *dontCallMe) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
 	d.t.Fatalf("Did not expect next-handler to be called")
 	return core.ListResponse{}, core.ErrRequestDenied
@@ -626,3 +654,75 @@ function ApproveSignData(r){
 		t.Fatalf("Expected approved")
 	}
 }
+
+func blsRequest(addr *common.MixedcaseAddress, msg string, composite bool, sequence, round int64) *core.SignBLSRequest {
+	return &core.SignBLSRequest{
+		Address:      *addr,
+		Message:      []byte(msg),
+		ExtraData:    []byte{},
+		UseComposite: composite,
+		View:         &core.BLSView{Sequence: (*hexutil.Big)(big.NewInt(sequence)), Round: (*hexutil.Big)(big.NewInt(round))},
+		Meta:         core.Metadata{Remote: "remoteip", Local: "localip", Scheme: "inproc"},
+	}
+}
+
+// TestSignBLS tests that the rule-engine refuses to BLS sign conflicting messages in a view
+func TestSignBLS(t *testing.T) {
+
+	js := `function ApproveSignBLS(r){
+    if( r.address.toLowerCase() == "0x694267f14675d7e1b9494fd8d72fefe1755710fa")
+    {
+        return "Approve"
+    }
+    return "Reject"
+}`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Errorf("Couldn't create evaluator %v", err)
+		return
+	}
+	addr, _ := mixAddr("0x694267f14675d7e1b9494fd8d72fefe1755710fa")
+	other, _ := mixAddr("0x000000000000000000000000000000000000dead")
+
+	tests := []struct {
+		request  *core.SignBLSRequest
+		approved bool
+	}{
+		{blsRequest(other, "seal", false, 10, 0), false},
+		{blsRequest(addr, "seal", false, 10, 0), true},
+		// The same message can be signed again in the same view
+		{blsRequest(addr, "seal", false, 10, 0), true},
+		// A different message can't
+		{blsRequest(addr, "other seal", false, 10, 0), false},
+		// Composite signatures are tracked separately
+		{blsRequest(addr, "epoch seal", true, 10, 0), true},
+		{blsRequest(addr, "other epoch seal", true, 10, 0), false},
+		// Nothing can be signed in older views
+		{blsRequest(addr, "seal", false, 9, 3), false},
+		{blsRequest(addr, "other seal", false, 10, 1), true},
+		{blsRequest(addr, "seal", false, 10, 0), false},
+		{blsRequest(addr, "seal", false, 11, 0), true},
+	}
+	for i, test := range tests {
+		resp, err := r.ApproveSignBLS(test.request)
+		if err != nil {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+		if resp.Approved != test.approved {
+			t.Errorf("test %d: approval mismatch: have %v, want %v", i, resp.Approved, test.approved)
+		}
+	}
+
+	// Messages signed outside of a view aren't checked
+	request := blsRequest(addr, "message", false, 0, 0)
+	request.View = nil
+	if resp, err := r.ApproveSignBLS(request); err != nil || !resp.Approved {
+		t.Errorf("expected message without view to be approved: %v %v", resp.Approved, err)
+	}
+
+	// The signed views are kept in the storage
+	r2 := &rulesetUI{next: &dontCallMe{t}, storage: r.storage, jsRules: js}
+	if resp, _ := r2.ApproveSignBLS(blsRequest(addr, "other seal", false, 11, 0)); resp.Approved {
+		t.Errorf("expected double signing to be rejected with the same storage")
+	}
+}
@@ -56,7 +56,7 @@ func (tt *TransactionTest) Run(config *params.ChainConfig) error {
 			return nil, nil, err
 		}
 		// Intrinsic gas
-		requiredGas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, nil, nil, nil, false)
+		requiredGas, err := core.IntrinsicGas(tx.Data(), tx.To() == nil, nil, nil, nil, nil, false)
 		if err != nil {
 			return nil, nil, err
 		}